@@ -15,9 +15,197 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/rewards/schedule": {
+            "get": {
+                "description": "Get the PCSP reward for each risk score, as set on-chain",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the reward schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RewardScheduleResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Checks a schedule against the TEE risk levels, where a higher risk must earn at least as much, and compares it with the current one. With apply, the service wallet sets it through the Controller.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Propose a reward schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Proposed schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RewardScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rewards.ScheduleChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Opens a session for the registered wallet that signed a Sign-In with Ethereum (EIP-4361) message with personal_sign, checked as\nfor registration. The token is sent as \"Authorization: Bearer {token}\" until it expires, after SIWE_SESSION_TTL (24 hours by default) or with the message.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Sign in",
+                "parameters": [
+                    {
+                        "description": "Signed sign-in message",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.SignedMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth.Token"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature, nonce or validity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not registered",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/nonce": {
+            "get": {
+                "description": "Hands out a nonce for one Sign-In with Ethereum (EIP-4361) message, with the domain and chain ID the message must be for.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get a sign-in nonce",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth.Challenge"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/register": {
             "post": {
-                "description": "Register a new user with their public key",
+                "description": "Registers the wallet that signed a Sign-In with Ethereum (EIP-4361) message with personal_sign, which proves it holds the key.\nThe message must be for this domain and chain, carry a nonce from GET /auth/nonce, which it uses up, and be currently valid.",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,12 +218,12 @@ const docTemplate = `{
                 "summary": "Register a new user",
                 "parameters": [
                     {
-                        "description": "User registration details",
+                        "description": "Signed sign-in message",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.RegisterRequest"
+                            "$ref": "#/definitions/handler.SignedMessageRequest"
                         }
                     }
                 ],
@@ -52,6 +240,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Invalid signature, nonce or validity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already registered",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -61,25 +261,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/pcsp/balance": {
+        "/docs/{docId}": {
             "get": {
-                "description": "Get the balance of PCSP tokens for a user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Combines the on-chain doc and its confirmation with the local record, with a verdict on whether they agree",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pcsp"
+                    "records"
                 ],
-                "summary": "Get PCSP balance",
+                "summary": "Get document",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User's address",
-                        "name": "address",
-                        "in": "query",
+                        "description": "Doc ID",
+                        "name": "docId",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -87,7 +284,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.PCSPBalanceResponse"
+                            "$ref": "#/definitions/records.UploadView"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -99,25 +302,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/retrieve": {
+        "/files/{fileId}/proof": {
             "get": {
-                "description": "Retrieves genomic data from the blockchain",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the Merkle proof that a file's content hash is in a root anchored on the Controller. Anyone can check it with the Controller's verifyAnchored(root, proof, docId, contentHash), or with OpenZeppelin's MerkleProof against the root.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "genomic"
+                    "records"
                 ],
-                "summary": "Retrieve genomic data",
+                "summary": "Get content hash inclusion proof",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "File ID of the genomic data",
-                        "name": "fileID",
-                        "in": "query",
+                        "description": "File ID",
+                        "name": "fileId",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -125,7 +325,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.GenomicDataResponse"
+                            "$ref": "#/definitions/anchor.Proof"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -137,40 +349,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/upload": {
+        "/governance/delegate": {
             "post": {
-                "description": "Processes genomic data in TEE, encrypts it, calculates risk score, and stores on blockchain",
+                "description": "Relays a holder-signed delegation. PCSP balances only count as votes once delegated, to the holder itself or another address.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "genomic"
+                    "governance"
                 ],
-                "summary": "Upload genomic data for processing",
+                "summary": "Delegate votes",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Raw genomic data to be processed",
-                        "name": "genomicData",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User's public key for authentication",
-                        "name": "pubkey",
-                        "in": "formData",
-                        "required": true
+                        "description": "Signed delegation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.DelegationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UploadResponse"
+                            "$ref": "#/definitions/onchain.TxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -178,28 +391,2116 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "description": "Error message",
-                    "type": "string",
-                    "example": "invalid request format"
-                }
-            }
         },
-        "handler.GenomicDataResponse": {
-            "type": "object",
-            "properties": {
-                "genomicData": {
-                    "type": "string",
-                    "example": "ATCG..."
+        "/governance/domain": {
+            "get": {
+                "description": "Get the EIP-712 domain for Ballot and Proposal payloads. Delegations are signed under the PCSP domain.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "governance"
+                ],
+                "summary": "Get governance signing domain",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/onchain.EIP712Domain"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/governance/proposals": {
+            "get": {
+                "description": "List every proposal, newest first, with its state and tally",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "governance"
+                ],
+                "summary": "List proposals",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ProposalListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Submits a signalling proposal for an author holding at least the author threshold in delegated votes. Only the service wallet proposes to the Governor, so it submits the proposal for the author. Votes are weighed at the proposal block.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "governance"
+                ],
+                "summary": "Create a proposal",
+                "parameters": [
+                    {
+                        "description": "Signed proposal",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ProposalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/governance.Proposal"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/governance/proposals/{id}": {
+            "get": {
+                "description": "Get a proposal with its state, tally and quorum",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "governance"
+                ],
+                "summary": "Get a proposal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Proposal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/governance.Proposal"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/governance/proposals/{id}/votes": {
+            "post": {
+                "description": "Relays a voter-signed Ballot, weighted by the votes delegated to the voter at the proposal snapshot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "governance"
+                ],
+                "summary": "Cast a vote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Proposal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signed ballot",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.VoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/onchain.TxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/governance/votes": {
+            "get": {
+                "description": "Get the votes delegated to an address, its own delegate and the nonce for its next delegation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "governance"
+                ],
+                "summary": "Get voting power",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Holder address",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/governance.VotingPower"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/marketplace/consents/{tokenId}": {
+            "put": {
+                "description": "The holder signs \"GenomicDAO research consent\\nToken ID: {tokenId}\\nGranted: {true|false}\\nTimestamp: {timestamp}\" with personal_sign. Consent lapses when the GeneNFT changes hands.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Grant or withdraw research consent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GeneNFT token ID",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signed consent",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ConsentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/marketplace.Consent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/marketplace/domain": {
+            "get": {
+                "description": "Get the EIP-712 domain for AnalysisRequest and AnalysisResultRequest payloads, and the researcher's request nonce",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Get research escrow signing domain",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Researcher address to include the nonce for",
+                        "name": "researcher",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/marketplace.Domain"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/marketplace/requests": {
+            "post": {
+                "description": "Locks the researcher's PCSP in escrow, which must be approved for the amount beforehand, and runs the analysis in the TEE over the GeneNFTs whose holders consented. The payment is split equally between the contributing tokens' owners, or refunded if the cohort is too small or the analysis fails.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Request a paid analysis",
+                "parameters": [
+                    {
+                        "description": "Signed analysis request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnalysisRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/marketplace.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/marketplace/requests/{id}": {
+            "get": {
+                "description": "Get the escrow status of an analysis and, once its contributors are paid, the released aggregate. The caller signs AnalysisResultRequest(uint256 requestId,uint256 deadline) under the escrow domain; the aggregate is only released to the researcher who paid for it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Get a paid analysis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Escrow request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Researcher address",
+                        "name": "researcher",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature deadline (unix seconds)",
+                        "name": "deadline",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "EIP-712 signature of the AnalysisResultRequest",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/marketplace.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/nft/{tokenId}": {
+            "get": {
+                "description": "Returns the ERC-721 metadata JSON of a GeneNFT token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nft"
+                ],
+                "summary": "Get GeneNFT metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GeneNFT token ID",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/nft.Metadata"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pcsp/allowance": {
+            "get": {
+                "description": "Get the amount of PCSP a spender may move on behalf of an owner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pcsp"
+                ],
+                "summary": "Get PCSP allowance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token owner address",
+                        "name": "owner",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Spender address",
+                        "name": "spender",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PCSPAllowanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pcsp/approve": {
+            "post": {
+                "description": "Relays an owner-signed EIP-2612 Permit and waits for it to be mined",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pcsp"
+                ],
+                "summary": "Approve a PCSP spender",
+                "parameters": [
+                    {
+                        "description": "Signed permit",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PCSPApproveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/onchain.TxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pcsp/balance": {
+            "get": {
+                "description": "Get the balance of PCSP tokens for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pcsp"
+                ],
+                "summary": "Get PCSP balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's address",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PCSPBalanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pcsp/burn": {
+            "post": {
+                "description": "Relays a holder-signed BurnWithAuthorization of a positive value and waits for it to be mined",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pcsp"
+                ],
+                "summary": "Burn PCSP",
+                "parameters": [
+                    {
+                        "description": "Signed burn",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PCSPBurnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/onchain.TxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pcsp/domain": {
+            "get": {
+                "description": "Get the EIP-712 domain for approve, transfer and burn payloads, and the owner's permit nonce",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pcsp"
+                ],
+                "summary": "Get PCSP signing domain",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner address to include the permit nonce for",
+                        "name": "owner",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PCSPDomainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pcsp/supply": {
+            "get": {
+                "description": "Get the total supply of PCSP tokens",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pcsp"
+                ],
+                "summary": "Get PCSP total supply",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PCSPSupplyResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pcsp/transfer": {
+            "post": {
+                "description": "Relays a holder-signed TransferWithAuthorization of a positive value and waits for it to be mined",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pcsp"
+                ],
+                "summary": "Transfer PCSP",
+                "parameters": [
+                    {
+                        "description": "Signed transfer",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PCSPTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/onchain.TxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pcsp/transfers": {
+            "get": {
+                "description": "Lists PCSP transfers to or from an address, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pcsp"
+                ],
+                "summary": "Get PCSP transfer history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's address",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transfers per page, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PCSPTransferHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/relay": {
+            "post": {
+                "description": "Verifies a user-signed ForwardRequest, simulates it and submits it through the trusted forwarder with the service paying gas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relay"
+                ],
+                "summary": "Relay a meta-transaction",
+                "parameters": [
+                    {
+                        "description": "Signed forward request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RelayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/onchain.RelayRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/relay/nonce": {
+            "get": {
+                "description": "Get the nonce the next ForwardRequest from an address must be signed with",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relay"
+                ],
+                "summary": "Get forwarder nonce",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's address",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RelayNonceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/relay/{txHash}": {
+            "get": {
+                "description": "Track a relayed meta-transaction by its transaction hash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relay"
+                ],
+                "summary": "Get relay status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Relay transaction hash",
+                        "name": "txHash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/onchain.RelayRecord"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/retrieve": {
+            "get": {
+                "description": "Decrypts genomic data for the current GeneNFT owner or an approved operator. Every retrieval, allowed or\ndenied, is recorded in the owner's access log, and no data is returned unless it was recorded.\nThe wallet signs \"GenomicDAO retrieve request\\nDomain: {domain}\\nChain ID: {chainId}\\nFile ID: {fileID}\\nTimestamp: {timestamp}\"\nwith personal_sign, with \"Purpose: {purpose}\\n\" before the timestamp when it states a purpose. The domain is the\nsign-in domain and the chain the one of /auth/nonce. A signature is accepted only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genomic"
+                ],
+                "summary": "Retrieve genomic data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID of the genomic data",
+                        "name": "fileID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Requesting wallet address",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix time the request was signed at",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "personal_sign signature of the retrieve message",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purpose of the retrieval, recorded in the access log",
+                        "name": "purpose",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenomicDataResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}": {
+            "get": {
+                "description": "Combines the on-chain upload session with the local record, with a verdict on whether they agree",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "records"
+                ],
+                "summary": "Get upload session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/records.UploadView"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/upload": {
+            "post": {
+                "description": "Processes genomic data in TEE, encrypts it, calculates risk score, and stores on blockchain. While the blockchain is unreachable, the encrypted data is stored and the upload answers 202, its on-chain steps queued until the chain is back.\nThe data is uploaded for the wallet signed in with POST /auth/login.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genomic"
+                ],
+                "summary": "Upload genomic data for processing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Raw genomic data to be processed",
+                        "name": "genomicData",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer token from POST /auth/login",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Show the risk tier in the public GeneNFT metadata",
+                        "name": "shareRiskTier",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UploadResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/access-log": {
+            "get": {
+                "description": "Lists who retrieved, analysed or exported the files of the signing wallet, when, for what purpose and with\nwhat outcome, newest first. Each entry has its hash in the audit log, whose heads the service wallet signs.\nThe wallet signs \"GenomicDAO access log request\\nDomain: {domain}\\nChain ID: {chainId}\\nTimestamp: {timestamp}\"\nwith personal_sign, with \"Cursor: {cursor}\\n\" before the timestamp for any page but the first. The domain is the\nsign-in domain and the chain the one of /auth/nonce. A signature is accepted only once, so each page is signed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genomic"
+                ],
+                "summary": "List accesses to my data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Requesting wallet address",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix time the request was signed at",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "personal_sign signature of the access log message",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries per page, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/audit.Page"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/export": {
+            "get": {
+                "description": "Exports the completed uploads of the signing wallet as a zip bundle signed by the service wallet: a manifest\nwith the reports, consents and on-chain references of each file, and the files sealed under keys wrapped\nfor the recipient. The wallet signs \"GenomicDAO export request\\nDomain: {domain}\\nChain ID: {chainId}\\nRecipient: {recipient}\\nTimestamp: {timestamp}\"\nwith personal_sign, the recipient left empty when it is not given. The domain is the sign-in domain and the\nchain the one of /auth/nonce. A signature is accepted only once. Files whose GeneNFT the wallet no longer\nholds are left out. The bundle is streamed; an export failing after it started is cut short and reported in\nthe X-Export-Error trailer.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "genomic"
+                ],
+                "summary": "Export my files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Requesting wallet address",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix time the request was signed at",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "personal_sign signature of the export message",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Uncompressed secp256k1 public key of the deployment to import into, hex; this deployment by default",
+                        "name": "recipient",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export bundle",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/files": {
+            "get": {
+                "description": "Lists the files uploaded by the signing wallet, with their upload status and on-chain references.\nThe wallet signs \"GenomicDAO list files request\\nDomain: {domain}\\nChain ID: {chainId}\\nTimestamp: {timestamp}\"\nwith personal_sign, with \"Cursor: {cursor}\\n\" before the timestamp for any page but the first. The domain is the\nsign-in domain and the chain the one of /auth/nonce. A signature is accepted only once, so each page is signed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genomic"
+                ],
+                "summary": "List my files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Requesting wallet address",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix time the request was signed at",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "personal_sign signature of the list message",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "completed",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Upload status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data format, e.g. markers-u64le",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "uploaded",
+                            "size"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, by upload time by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction, desc by default",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Files per page, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/files.Page"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/files/{fileId}": {
+            "delete": {
+                "description": "Asks for the raw data of a file the signing wallet uploaded to be deleted under the retention policy. It is\nno longer used for research, and is soft-deleted at the next evaluation of the policy, then purged after the\ngrace period. The report, hashes and on-chain references are kept.\nThe wallet signs \"GenomicDAO withdraw request\\nDomain: {domain}\\nChain ID: {chainId}\\nFile ID: {fileId}\\nTimestamp: {timestamp}\"\nwith personal_sign. The domain is the sign-in domain and the chain the one of /auth/nonce. A signature is\naccepted only once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genomic"
+                ],
+                "summary": "Withdraw my file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "fileId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Requesting wallet address",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix time the request was signed at",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "personal_sign signature of the withdraw message",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/files.File"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "anchor.Proof": {
+            "type": "object",
+            "properties": {
+                "blockNumber": {
+                    "type": "integer"
+                },
+                "contentHash": {
+                    "type": "string",
+                    "example": "0x8f3c..."
+                },
+                "controller": {
+                    "type": "string"
+                },
+                "docId": {
+                    "type": "string",
+                    "example": "0b1c2d3e-..."
+                },
+                "fileId": {
+                    "type": "string"
+                },
+                "leaf": {
+                    "type": "string",
+                    "example": "0x51a2..."
+                },
+                "leafCount": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "leafIndex": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "proof": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "root": {
+                    "type": "string",
+                    "example": "0x9d7e..."
+                },
+                "status": {
+                    "description": "pending until the root is mined",
+                    "type": "string",
+                    "example": "anchored"
+                },
+                "txHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "audit.Entry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "retrieve"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "0x2222222222222222222222222222222222222222"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "fileId": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "hash": {
+                    "type": "string",
+                    "example": "0x5f7c..."
+                },
+                "outcome": {
+                    "type": "string",
+                    "example": "allowed"
+                },
+                "purpose": {
+                    "type": "string",
+                    "example": "clinical review"
+                },
+                "seq": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "audit.Page": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/audit.Entry"
+                    }
+                },
+                "nextCursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.Challenge": {
+            "type": "object",
+            "properties": {
+                "chainId": {
+                    "type": "integer",
+                    "example": 9999
+                },
+                "domain": {
+                    "type": "string",
+                    "example": "localhost:8080"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "string",
+                    "example": "q3vR8xKp2LmN7aTz"
+                }
+            }
+        },
+        "auth.Token": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "5c1f..."
+                }
+            }
+        },
+        "files.File": {
+            "type": "object",
+            "properties": {
+                "docId": {
+                    "type": "string",
+                    "example": "0b1c2d3e-..."
+                },
+                "fileId": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "markers-u64le"
+                },
+                "modelVersion": {
+                    "description": "Set once the upload is recorded",
+                    "type": "string",
+                    "example": "g-stroke-v1"
+                },
+                "purgedAt": {
+                    "type": "string"
+                },
+                "riskTier": {
+                    "description": "Only shown when the user opted in to share it",
+                    "type": "integer",
+                    "example": 2
+                },
+                "sessionId": {
+                    "type": "string",
+                    "example": "14"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 40960
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                },
+                "tokenId": {
+                    "type": "string",
+                    "example": "14"
+                },
+                "uploadedAt": {
+                    "type": "string"
+                },
+                "withdrawnAt": {
+                    "description": "Set when the user withdrew the file, and once its raw data is deleted",
+                    "type": "string"
+                }
+            }
+        },
+        "files.Page": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/files.File"
+                    }
+                },
+                "nextCursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "governance.Proposal": {
+            "type": "object",
+            "properties": {
+                "deadline": {
+                    "type": "integer",
+                    "example": 303600
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Prioritise the post-covid stroke cohort"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5873014512847061873..."
+                },
+                "proposer": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "quorum": {
+                    "type": "string",
+                    "example": "40000000000000000000000000"
+                },
+                "snapshot": {
+                    "description": "Snapshot is the block whose delegated balances weigh the votes",
+                    "type": "integer",
+                    "example": 1200
+                },
+                "state": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "tally": {
+                    "$ref": "#/definitions/governance.Tally"
+                },
+                "txHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "governance.Tally": {
+            "type": "object",
+            "properties": {
+                "abstain": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "against": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "for": {
+                    "type": "string",
+                    "example": "60000000000000000000000000"
+                }
+            }
+        },
+        "governance.VotingPower": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "delegate": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "description": "Nonce to sign the next delegation with",
+                    "type": "string"
+                },
+                "votes": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AnalysisRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "analysis",
+                "deadline",
+                "researcher",
+                "signature"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "100000000000000000000"
+                },
+                "analysis": {
+                    "type": "string",
+                    "example": "risk-summary"
+                },
+                "deadline": {
+                    "type": "string",
+                    "example": "1734280000"
+                },
+                "researcher": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x..."
+                }
+            }
+        },
+        "handler.ConsentRequest": {
+            "type": "object",
+            "required": [
+                "granted",
+                "owner",
+                "signature",
+                "timestamp"
+            ],
+            "properties": {
+                "granted": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "owner": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x..."
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1734280000
+                }
+            }
+        },
+        "handler.DelegationRequest": {
+            "type": "object",
+            "required": [
+                "delegatee",
+                "delegator",
+                "expiry",
+                "signature"
+            ],
+            "properties": {
+                "delegatee": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "delegator": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "expiry": {
+                    "type": "string",
+                    "example": "1734280000"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x..."
+                }
+            }
+        },
+        "handler.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error message",
+                    "type": "string",
+                    "example": "invalid request format"
+                }
+            }
+        },
+        "handler.GenomicDataResponse": {
+            "type": "object",
+            "properties": {
+                "genomicData": {
+                    "type": "string",
+                    "example": "ATCG..."
+                }
+            }
+        },
+        "handler.PCSPAllowanceResponse": {
+            "type": "object",
+            "properties": {
+                "allowance": {
+                    "type": "string",
+                    "example": "1000000000000000000"
+                },
+                "formatted": {
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "handler.PCSPApproveRequest": {
+            "type": "object",
+            "required": [
+                "deadline",
+                "owner",
+                "signature",
+                "spender",
+                "value"
+            ],
+            "properties": {
+                "deadline": {
+                    "type": "string",
+                    "example": "1734280000"
+                },
+                "owner": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x..."
+                },
+                "spender": {
+                    "type": "string",
+                    "example": "0x6491414173c71986Ee031307Af447cE1DbDf2ED0"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "1000000000000000000"
                 }
             }
         },
@@ -207,27 +2508,299 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "balance": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "225000000000000000000"
+                },
+                "decimals": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "formatted": {
+                    "type": "string",
+                    "example": "225"
+                }
+            }
+        },
+        "handler.PCSPBurnRequest": {
+            "type": "object",
+            "required": [
+                "from",
+                "nonce",
+                "signature",
+                "validAfter",
+                "validBefore",
+                "value"
+            ],
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "nonce": {
+                    "type": "string",
+                    "example": "0x6b3c...32 bytes"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x..."
+                },
+                "validAfter": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "validBefore": {
+                    "type": "string",
+                    "example": "1734280000"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "1000000000000000000"
+                }
+            }
+        },
+        "handler.PCSPDomainResponse": {
+            "type": "object",
+            "properties": {
+                "domain": {
+                    "$ref": "#/definitions/onchain.EIP712Domain"
+                },
+                "permitNonce": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "handler.PCSPSupplyResponse": {
+            "type": "object",
+            "properties": {
+                "decimals": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "formatted": {
+                    "type": "string",
+                    "example": "1000000000"
+                },
+                "totalSupply": {
+                    "type": "string",
+                    "example": "1000000000000000000000000000"
+                }
+            }
+        },
+        "handler.PCSPTransferHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "transfers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/onchain.TransferRecord"
+                    }
+                }
+            }
+        },
+        "handler.PCSPTransferRequest": {
+            "type": "object",
+            "required": [
+                "from",
+                "nonce",
+                "signature",
+                "to",
+                "validAfter",
+                "validBefore",
+                "value"
+            ],
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "nonce": {
+                    "type": "string",
+                    "example": "0x6b3c...32 bytes"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x..."
+                },
+                "to": {
+                    "type": "string",
+                    "example": "0x6491414173c71986Ee031307Af447cE1DbDf2ED0"
+                },
+                "validAfter": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "validBefore": {
+                    "type": "string",
+                    "example": "1734280000"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "1000000000000000000"
+                }
+            }
+        },
+        "handler.ProposalListResponse": {
+            "type": "object",
+            "properties": {
+                "proposals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/governance.Proposal"
+                    }
+                }
+            }
+        },
+        "handler.ProposalRequest": {
+            "type": "object",
+            "required": [
+                "deadline",
+                "description",
+                "proposer",
+                "signature"
+            ],
+            "properties": {
+                "deadline": {
+                    "type": "string",
+                    "example": "1734280000"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Prioritise the post-covid stroke cohort"
+                },
+                "proposer": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x..."
+                }
+            }
+        },
+        "handler.RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "userID": {
+                    "description": "Unique identifier for the registered user",
+                    "type": "string",
+                    "example": "user123"
+                }
+            }
+        },
+        "handler.RelayNonceResponse": {
+            "type": "object",
+            "properties": {
+                "nonce": {
+                    "type": "string",
+                    "example": "0"
                 }
             }
         },
-        "handler.RegisterRequest": {
+        "handler.RelayRequest": {
             "type": "object",
+            "required": [
+                "data",
+                "from",
+                "gas",
+                "nonce",
+                "signature",
+                "to"
+            ],
             "properties": {
-                "address": {
-                    "description": "Public key in bytes",
+                "data": {
+                    "type": "string",
+                    "example": "0x..."
+                },
+                "from": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "gas": {
+                    "type": "string",
+                    "example": "300000"
+                },
+                "nonce": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x..."
+                },
+                "to": {
                     "type": "string",
                     "example": "0x6491414173c71986Ee031307Af447cE1DbDf2ED0"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "0"
                 }
             }
         },
-        "handler.RegisterResponse": {
+        "handler.RewardScheduleRequest": {
             "type": "object",
+            "required": [
+                "amounts"
+            ],
             "properties": {
-                "userID": {
-                    "description": "Unique identifier for the registered user",
+                "amounts": {
+                    "description": "Amounts in PCSP wei, one per risk score",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "30000000000000000000",
+                        "225000000000000000000",
+                        "3000000000000000000000",
+                        "15000000000000000000000"
+                    ]
+                },
+                "apply": {
+                    "description": "Set the schedule on-chain; otherwise it is only checked and compared with the current one",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "handler.RewardScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rewards.Tier"
+                    }
+                }
+            }
+        },
+        "handler.SignedMessageRequest": {
+            "type": "object",
+            "required": [
+                "message",
+                "signature"
+            ],
+            "properties": {
+                "message": {
                     "type": "string",
-                    "example": "user123"
+                    "example": "localhost:8080 wants you to sign in with your Ethereum account:\n0x62f563A2e09c7987dECBFF61fdcC89cd74717721\n\nSign in to GenomicDAO\n\nURI: http://localhost:8080\nVersion: 1\nChain ID: 9999\nNonce: q3vR8xKp2LmN7aTz\nIssued At: 2024-12-15T16:38:43Z"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "0x..."
                 }
             }
         },
@@ -242,9 +2815,413 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Upload successful"
                 },
+                "queued": {
+                    "description": "Stored while the blockchain is unreachable; the GeneNFT is minted once it is back",
+                    "type": "boolean",
+                    "example": false
+                },
                 "sessionId": {
                     "type": "string",
                     "example": "sess_123"
+                },
+                "tokenId": {
+                    "type": "string",
+                    "example": "14"
+                }
+            }
+        },
+        "handler.VoteRequest": {
+            "type": "object",
+            "required": [
+                "signature",
+                "support",
+                "voter"
+            ],
+            "properties": {
+                "signature": {
+                    "type": "string",
+                    "example": "0x..."
+                },
+                "support": {
+                    "description": "0 against, 1 for, 2 abstain",
+                    "type": "integer",
+                    "example": 1
+                },
+                "voter": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                }
+            }
+        },
+        "marketplace.Consent": {
+            "type": "object",
+            "properties": {
+                "granted": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "owner": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "tokenId": {
+                    "type": "string",
+                    "example": "14"
+                }
+            }
+        },
+        "marketplace.Domain": {
+            "type": "object",
+            "properties": {
+                "domain": {
+                    "$ref": "#/definitions/onchain.EIP712Domain"
+                },
+                "nonce": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "marketplace.Job": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "100000000000000000000"
+                },
+                "analysis": {
+                    "type": "string",
+                    "example": "risk-summary"
+                },
+                "contributors": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "cohort is smaller than 5 members"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "requestTxHash": {
+                    "type": "string"
+                },
+                "researcher": {
+                    "type": "string",
+                    "example": "0x62f563A2e09c7987dECBFF61fdcC89cd74717721"
+                },
+                "result": {
+                    "$ref": "#/definitions/tee.AnalysisResult"
+                },
+                "settleTxHash": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "locked, settled or refunded",
+                    "type": "string",
+                    "example": "settled"
+                }
+            }
+        },
+        "nft.Attribute": {
+            "type": "object",
+            "properties": {
+                "display_type": {
+                    "type": "string"
+                },
+                "trait_type": {
+                    "type": "string"
+                },
+                "value": {}
+            }
+        },
+        "nft.Metadata": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/nft.Attribute"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "onchain.EIP712Domain": {
+            "type": "object",
+            "properties": {
+                "chainId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "verifyingContract": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "onchain.RelayRecord": {
+            "type": "object",
+            "properties": {
+                "blockNumber": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "submittedAt": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "txHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "onchain.TransferRecord": {
+            "type": "object",
+            "properties": {
+                "blockNumber": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "logIndex": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "txHash": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "onchain.TxResult": {
+            "type": "object",
+            "properties": {
+                "blockNumber": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "txHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "records.ChainState": {
+            "type": "object",
+            "properties": {
+                "confirmTxHash": {
+                    "type": "string"
+                },
+                "confirmed": {
+                    "type": "boolean"
+                },
+                "contentHash": {
+                    "type": "string"
+                },
+                "docFound": {
+                    "type": "boolean"
+                },
+                "proof": {
+                    "type": "string"
+                },
+                "rewardAmount": {
+                    "type": "string"
+                },
+                "sessionFound": {
+                    "type": "boolean"
+                },
+                "tokenId": {
+                    "type": "string"
+                },
+                "uploadDocId": {
+                    "type": "string",
+                    "example": "c29814719d660d3f"
+                },
+                "uploadTxHash": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "records.Consistency": {
+            "type": "object",
+            "properties": {
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "consistent"
+                }
+            }
+        },
+        "records.LocalRecord": {
+            "type": "object",
+            "properties": {
+                "contentHash": {
+                    "description": "ContentHash is recomputed from the stored ciphertext",
+                    "type": "string"
+                },
+                "docId": {
+                    "type": "string"
+                },
+                "fileId": {
+                    "type": "string"
+                },
+                "legacyFileId": {
+                    "description": "The ID the file was opened under on-chain, before it was re-keyed",
+                    "type": "string"
+                },
+                "riskScore": {
+                    "type": "integer"
+                },
+                "sessionId": {
+                    "type": "string"
+                },
+                "tokenId": {
+                    "type": "string"
+                },
+                "uploadStatus": {
+                    "type": "string"
+                }
+            }
+        },
+        "records.UploadView": {
+            "type": "object",
+            "properties": {
+                "chain": {
+                    "$ref": "#/definitions/records.ChainState"
+                },
+                "consistency": {
+                    "$ref": "#/definitions/records.Consistency"
+                },
+                "docId": {
+                    "type": "string",
+                    "example": "0b1c2d3e-..."
+                },
+                "local": {
+                    "$ref": "#/definitions/records.LocalRecord"
+                },
+                "sessionId": {
+                    "type": "string",
+                    "example": "14"
+                }
+            }
+        },
+        "rewards.ScheduleChange": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "current": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rewards.Tier"
+                    }
+                },
+                "proposed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rewards.Tier"
+                    }
+                },
+                "txHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "rewards.Tier": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "15000000000000000000000"
+                },
+                "formatted": {
+                    "type": "string",
+                    "example": "15000"
+                },
+                "risk": {
+                    "type": "string",
+                    "example": "extremely high"
+                },
+                "riskScore": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "tee.AnalysisResult": {
+            "type": "object",
+            "properties": {
+                "analysis": {
+                    "type": "string",
+                    "example": "risk-summary"
+                },
+                "cohortSize": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "meanScore": {
+                    "type": "number",
+                    "example": 0.4213
+                },
+                "modelVersion": {
+                    "type": "string",
+                    "example": "g-stroke-v1"
+                },
+                "riskTiers": {
+                    "description": "Members per risk tier, keyed \"1\" to \"4\"; tiers under MinCellSize are withheld",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "suppressed": {
+                    "description": "Members of the withheld tiers",
+                    "type": "integer",
+                    "example": 2
                 }
             }
         }
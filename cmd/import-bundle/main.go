@@ -0,0 +1,121 @@
+// Command import-bundle restores a bundle exported with GET /users/me/export into this deployment.
+// The bundle must be wrapped for the PRIVATE_KEY wallet, exported on the NETWORK chain, and signed
+// by one of the -trust wallets. Every signature and hash in it is verified, and every file opened
+// inside the TEE, before anything is restored.
+//
+//	import-bundle -trust 0xSourceServiceWallet genomicdao-export.zip
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/bundle"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database"
+	"github.com/TropicalDog17/genomic-dao-service/internal/migrate"
+	"github.com/TropicalDog17/genomic-dao-service/internal/network"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
+	genomicCrypto "github.com/TropicalDog17/genomic-dao-service/pkg/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	trustFlag := flag.String("trust", "", "comma-separated service wallets whose bundles are trusted")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: import-bundle -trust <address>[,<address>...] <bundle.zip>")
+	}
+	var trusted []common.Address
+	for _, address := range strings.Split(*trustFlag, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			if !common.IsHexAddress(address) {
+				log.Fatalf("invalid trusted address %q", address)
+			}
+			trusted = append(trusted, common.HexToAddress(address))
+		}
+	}
+	if len(trusted) == 0 {
+		log.Fatal("-trust is required")
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		panic("Error loading .env file")
+	}
+
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		log.Fatal(err)
+	}
+	opened, err := bundle.Open(file, info.Size())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	name := os.Getenv("NETWORK")
+	if name == "" {
+		name = network.DefaultProfile
+	}
+	profile, err := network.Load(name, os.Getenv("NETWORK_PROFILES"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	privKey, _, _, err := genomicCrypto.DeriveEcdsaKeyPairAndEthAddress(os.Getenv("PRIVATE_KEY"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fingerprintKey, err := tee.ParseFingerprintKey(os.Getenv("TEE_FINGERPRINT_KEY"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	codec, err := tee.ParseCodec(os.Getenv("TEE_COMPRESSION"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := database.OpenFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	if err := migrate.NewMigrator(db).Check(); err != nil {
+		log.Fatalf("database schema: %v", err)
+	}
+	blobs, err := blobstore.NewFromEnv()
+	if err != nil {
+		log.Fatalf("failed to open blob store: %v", err)
+	}
+
+	importService := bundle.NewImportService(
+		tee.NewTeeService(fingerprintKey, codec),
+		auth.NewAuthService(auth.NewUserRepository(db)),
+		storage.NewGeneDataStorageService(db, blobs),
+		storage.NewMarketplaceRepository(db, blobs),
+		privKey,
+		profile.ChainID,
+	)
+	result, err := importService.Import(opened, trusted)
+	if err != nil {
+		if result != nil {
+			log.Fatalf("restored %d files for %s before failing: %v", len(result.Restored), result.User, err)
+		}
+		log.Fatal(err)
+	}
+	for source, fileID := range result.Restored {
+		log.Printf("restored %s as %s", source, fileID)
+	}
+	for _, source := range result.Skipped {
+		log.Printf("skipped %s, already stored", source)
+	}
+	log.Printf("restored %d files and %d consents for %s, signed by %s", len(result.Restored), result.Consents, result.User, opened.Signer.Hex())
+}
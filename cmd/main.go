@@ -1,11 +1,12 @@
 package main
 
 import (
+	"os"
+
 	_ "github.com/TropicalDog17/genomic-dao-service/api"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database"
 	"github.com/TropicalDog17/genomic-dao-service/internal/server"
 	"github.com/joho/godotenv"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
 )
 
 func main() {
@@ -13,10 +14,14 @@ func main() {
 	if err != nil {
 		panic("Error loading .env file")
 	}
-	db, err := gorm.Open(sqlite.Open("gorm.db"), &gorm.Config{})
+	db, err := database.OpenFromEnv()
 
 	if err != nil {
-		panic("failed to connect database")
+		panic(err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(db, os.Args[2:])
+		return
 	}
 
 	server.StartService(db)
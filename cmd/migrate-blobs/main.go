@@ -0,0 +1,38 @@
+// Command migrate-blobs moves the encrypted gene data still kept in the SQLite database to the blob
+// store selected by BLOB_STORE. Run it once, with the service stopped, when `migrate up` stops at
+// 1735400000-align-schema-with-models.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	batchSize := flag.Int("batch", 100, "rows read from the database at a time")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		panic("Error loading .env file")
+	}
+	db, err := database.OpenFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	blobs, err := blobstore.NewFromEnv()
+	if err != nil {
+		log.Fatalf("failed to open blob store: %v", err)
+	}
+
+	moved, err := storage.MoveBlobs(db, blobs, *batchSize)
+	if err != nil {
+		log.Fatalf("moved %d rows before failing: %v", moved, err)
+	}
+	log.Printf("moved %d rows to the blob store", moved)
+}
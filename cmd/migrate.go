@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/migrate"
+	"gorm.io/gorm"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status | baseline <version>"
+
+// runMigrate handles `migrate <command>`, the only way the schema of gorm.db is changed.
+func runMigrate(db *gorm.DB, args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+	migrator := migrate.NewMigrator(db)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			log.Printf("applied %d-%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("migrate up: %v", err)
+		}
+		log.Printf("%d migrations applied", len(applied))
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				log.Fatal(migrateUsage)
+			}
+			steps = n
+		}
+		reverted, err := migrator.Down(steps)
+		for _, migration := range reverted {
+			log.Printf("reverted %d-%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("migrate down: %v", err)
+		}
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("migrate status: %v", err)
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%d-%s\t%s\n", status.Version, status.Name, state)
+		}
+	case "baseline":
+		if len(args) < 2 {
+			log.Fatal(migrateUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			log.Fatal(migrateUsage)
+		}
+		if err := migrator.Baseline(version); err != nil {
+			log.Fatalf("migrate baseline: %v", err)
+		}
+		log.Printf("recorded migrations up to %d as applied", version)
+	default:
+		log.Fatal(migrateUsage)
+	}
+}
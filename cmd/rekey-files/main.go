@@ -0,0 +1,29 @@
+// Command rekey-files gives the files in the SQLite database still under a legacy 16-character ID
+// their content-derived ID. Run it after cmd/migrate-blobs, before `migrate up` applies
+// 1735400000-align-schema-with-models; the legacy IDs keep resolving.
+package main
+
+import (
+	"log"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/database"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	err := godotenv.Load()
+	if err != nil {
+		panic("Error loading .env file")
+	}
+	db, err := database.OpenFromEnv()
+	if err != nil {
+		panic(err)
+	}
+
+	rekeyed, left, err := storage.RekeyFileIDs(db)
+	if err != nil {
+		log.Fatalf("re-keyed %d files before failing: %v", rekeyed, err)
+	}
+	log.Printf("re-keyed %d files, %d left under a legacy ID (pending uploads, or not in the blob store yet)", rekeyed, left)
+}
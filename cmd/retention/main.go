@@ -0,0 +1,53 @@
+// Command retention evaluates the RETENTION_POLICY once, as the service does every
+// RETENTION_INTERVAL. Run it with -dry-run to see what a policy would delete before turning it on.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database"
+	"github.com/TropicalDog17/genomic-dao-service/internal/migrate"
+	"github.com/TropicalDog17/genomic-dao-service/internal/retention"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "only log what would be deleted")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		panic("Error loading .env file")
+	}
+	policy, err := retention.ParsePolicy(os.Getenv("RETENTION_POLICY"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if grace, err := time.ParseDuration(os.Getenv("RETENTION_GRACE")); err == nil && grace >= 0 {
+		policy.Grace = grace
+	}
+	policy.DryRun = *dryRun
+
+	db, err := database.OpenFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	if err := migrate.NewMigrator(db).Check(); err != nil {
+		log.Fatalf("database schema: %v", err)
+	}
+	blobs, err := blobstore.NewFromEnv()
+	if err != nil {
+		log.Fatalf("failed to open blob store: %v", err)
+	}
+
+	result, err := retention.NewRetentionService(storage.NewRetentionRepository(db, blobs), policy).Evaluate()
+	if err != nil {
+		log.Fatalf("%d actions before failing: %v", len(result.Actions), err)
+	}
+	log.Printf("%d actions under %s (dry run: %v)", len(result.Actions), policy, policy.DryRun)
+}
@@ -0,0 +1,69 @@
+// Command verify-audit walks the whole audit log and checks that no event is missing, edited or out
+// of the chain, and that every signed head is still in the log, signed by the -signer wallet (the
+// PRIVATE_KEY wallet by default) and, when anchored, queued with the same hash. It lists every
+// problem found and exits with status 1 if there is any.
+//
+//	verify-audit -signer 0xServiceWallet
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/audit"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database"
+	"github.com/TropicalDog17/genomic-dao-service/internal/migrate"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	genomicCrypto "github.com/TropicalDog17/genomic-dao-service/pkg/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	signerFlag := flag.String("signer", "", "wallet expected to sign the heads of the log, the PRIVATE_KEY wallet by default")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		panic("Error loading .env file")
+	}
+
+	var signer common.Address
+	switch {
+	case *signerFlag != "":
+		if !common.IsHexAddress(*signerFlag) {
+			log.Fatalf("invalid signer address %q", *signerFlag)
+		}
+		signer = common.HexToAddress(*signerFlag)
+	default:
+		var err error
+		_, _, signer, err = genomicCrypto.DeriveEcdsaKeyPairAndEthAddress(os.Getenv("PRIVATE_KEY"))
+		if err != nil {
+			log.Fatalf("-signer is not set and PRIVATE_KEY is invalid: %v", err)
+		}
+	}
+
+	db, err := database.OpenFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	if err := migrate.NewMigrator(db).Check(); err != nil {
+		log.Fatalf("database schema: %v", err)
+	}
+
+	// Only verifying, so nothing is signed or anchored
+	service := audit.NewAuditService(storage.NewAuditRepository(db), nil, nil, storage.NewAnchorRepository(db))
+	report, err := service.Verify(signer)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, problem := range report.Problems {
+		fmt.Printf("event %d: %s\n", problem.Seq, problem.Issue)
+	}
+	log.Printf("%d events and %d signed heads verified against %s, %d problems", report.Events, report.Heads, signer.Hex(), len(report.Problems))
+	if len(report.Problems) > 0 {
+		os.Exit(1)
+	}
+}
@@ -1,10 +1,13 @@
 pragma solidity ^0.8.9;
 
+import "@openzeppelin/contracts/access/Ownable.sol";
+import "@openzeppelin/contracts/metatx/ERC2771Context.sol";
 import "@openzeppelin/contracts/utils/Counters.sol";
+import "@openzeppelin/contracts/utils/cryptography/MerkleProof.sol";
 import "./NFT.sol";
 import "./Token.sol";
 
-contract Controller {
+contract Controller is ERC2771Context, Ownable {
     using Counters for Counters.Counter;
 
     //
@@ -26,11 +29,28 @@ contract Controller {
         string hashContent;
     }
 
+    struct ConfirmItem {
+        string docId;
+        string contentHash;
+        string proof;
+        uint256 sessionId;
+        uint256 riskScore;
+    }
+
+    // Largest batch accepted, so a batch stays well under the block gas limit
+    uint256 public constant MAX_BATCH_SIZE = 50;
+
+    // Marks a call that is not part of a batch
+    uint256 private constant NOT_BATCHED = type(uint256).max;
+
     mapping(uint256 => UploadSession) sessions;
     mapping(string => DataDoc) docs;
     mapping(string => bool) docSubmits;
     mapping(uint256 => string) nftDocs;
 
+    // Merkle roots of batches of content hashes, with the block each was anchored in
+    mapping(bytes32 => uint256) public anchoredRoots;
+
     //
     // EVENTS
     //
@@ -38,16 +58,57 @@ contract Controller {
     event GeneDataSubmitted(string indexed docId, address indexed user, string hashContent);
     event GeneNFTMinted(address indexed owner, uint256 indexed tokenId, string docId);
     event PCSPRewarded(address indexed user, uint256 amount, uint256 riskScore);
+    event UploadConfirmed(string docId, uint256 indexed sessionId, uint256 indexed tokenId, uint256 rewardAmount);
+    event RootAnchored(bytes32 indexed root, uint256 leafCount);
+
+    //
+    // ERRORS
+    //
+    // A batch is reverted as a whole; index is the position of the first item that failed
+    error BatchItemFailed(uint256 index, string reason);
 
-    constructor(address nftAddress, address pcspAddress) {
+    constructor(address nftAddress, address pcspAddress, address trustedForwarder) ERC2771Context(trustedForwarder) {
         geneNFT = GeneNFT(nftAddress);
         pcspToken = PostCovidStrokePrevention(pcspAddress);
         _sessionIdCounter = Counters.Counter(0);
     }
 
     function uploadData(string memory docId) public returns (uint256) {
+        return _uploadData(docId, NOT_BATCHED);
+    }
+
+    function uploadDataBatch(string[] memory docIds) public returns (uint256[] memory) {
+        require(docIds.length > 0 && docIds.length <= MAX_BATCH_SIZE, "Invalid batch size");
+
+        uint256[] memory sessionIds = new uint256[](docIds.length);
+        for (uint256 i = 0; i < docIds.length; i++) {
+            sessionIds[i] = _uploadData(docIds[i], i);
+        }
+        return sessionIds;
+    }
+
+    // Only the service wallet, which ran the analysis, vouches for the risk score the reward follows
+    function confirm(
+        string memory docId,
+        string memory contentHash,
+        string memory proof,
+        uint256 sessionId,
+        uint256 riskScore
+    ) public onlyOwner {
+        _confirm(ConfirmItem(docId, contentHash, proof, sessionId, riskScore), NOT_BATCHED);
+    }
+
+    function confirmBatch(ConfirmItem[] memory items) public onlyOwner {
+        require(items.length > 0 && items.length <= MAX_BATCH_SIZE, "Invalid batch size");
+
+        for (uint256 i = 0; i < items.length; i++) {
+            _confirm(items[i], i);
+        }
+    }
+
+    function _uploadData(string memory docId, uint256 index) internal returns (uint256) {
         // Check if doc has been submitted before
-        require(!docSubmits[docId], "Doc already been submitted");
+        if (docSubmits[docId]) _fail(index, "Doc already been submitted");
         
         uint256 sessionId = _sessionIdCounter.current();
         // Increment session counter
@@ -56,7 +117,7 @@ contract Controller {
         // Create new upload session
         sessions[sessionId] = UploadSession({
             id: sessionId,
-            user: msg.sender,
+            user: _msgSender(),
             proof: "",
             confirmed: false
         });
@@ -70,44 +131,53 @@ contract Controller {
         return sessionId;
     }
 
-    function confirm(
-        string memory docId,
-        string memory contentHash,
-        string memory proof,
-        uint256 sessionId,
-        uint256 riskScore
-    ) public {
+    function _confirm(ConfirmItem memory item, uint256 index) internal {
         // Verify session exists and is not confirmed
-        require(bytes(docs[docId].id).length == 0, "Doc already been submitted");
-        require(sessions[sessionId].id == sessionId, "Invalid session ID");
-        require(!sessions[sessionId].confirmed, "Session is ended");
-        require(sessions[sessionId].user == msg.sender, "Invalid session owner");
+        if (bytes(docs[item.docId].id).length != 0) _fail(index, "Doc already been submitted");
+        if (sessions[item.sessionId].id != item.sessionId) _fail(index, "Invalid session ID");
+        if (sessions[item.sessionId].confirmed) _fail(index, "Session is ended");
+
+        // The GeneNFT and reward go to whoever opened the session
+        address user = sessions[item.sessionId].user;
 
         // Update doc content
-        docs[docId] = DataDoc({
-            id: docId,
-            hashContent: contentHash
+        docs[item.docId] = DataDoc({
+            id: item.docId,
+            hashContent: item.contentHash
         });
 
         // Emit gene data submission event
-        emit GeneDataSubmitted(docId, msg.sender, contentHash);
+        emit GeneDataSubmitted(item.docId, user, item.contentHash);
 
         // Mint NFT
-        uint256 tokenId = geneNFT.safeMint(msg.sender);
-        nftDocs[tokenId] = docId;
+        uint256 tokenId = geneNFT.safeMint(user);
+        nftDocs[tokenId] = item.docId;
 
         // Emit NFT minting event
-        emit GeneNFTMinted(msg.sender, tokenId, docId);
+        emit GeneNFTMinted(user, tokenId, item.docId);
 
         // Reward PCSP tokens based on risk score
-        uint256 rewardAmount = pcspToken.reward(msg.sender, riskScore);
+        uint256 rewardAmount;
+        try pcspToken.reward(user, item.riskScore) returns (uint256 amount) {
+            rewardAmount = amount;
+        } catch Error(string memory reason) {
+            _fail(index, reason);
+        }
 
         // Emit PCSP reward event
-        emit PCSPRewarded(msg.sender, rewardAmount, riskScore);
+        emit PCSPRewarded(user, rewardAmount, item.riskScore);
 
         // Close session
-        sessions[sessionId].proof = proof;
-        sessions[sessionId].confirmed = true;
+        sessions[item.sessionId].proof = item.proof;
+        sessions[item.sessionId].confirmed = true;
+
+        emit UploadConfirmed(item.docId, item.sessionId, tokenId, rewardAmount);
+    }
+
+    // Single calls revert with the plain reason, batches name the failing item
+    function _fail(uint256 index, string memory reason) private pure {
+        if (index == NOT_BATCHED) revert(reason);
+        revert BatchItemFailed(index, reason);
     }
 
     function getSession(uint256 sessionId) public view returns(UploadSession memory) {
@@ -117,4 +187,48 @@ contract Controller {
     function getDoc(string memory docId) public view returns(DataDoc memory) {
         return docs[docId];
     }
+
+    function getTokenDoc(uint256 tokenId) public view returns(string memory) {
+        return nftDocs[tokenId];
+    }
+
+    function setNFTBaseURI(string memory baseURI) public onlyOwner {
+        geneNFT.setBaseURI(baseURI);
+    }
+
+    // The Controller owns PCSP, so its owner changes the reward per risk score through it
+    function setRewardSchedule(uint256[4] memory amounts) public onlyOwner {
+        pcspToken.setRewardSchedule(amounts);
+    }
+
+    // Anchors the content hashes of docs confirmed without one, at the cost of a single slot
+    function anchorRoot(bytes32 root, uint256 leafCount) public onlyOwner {
+        require(root != bytes32(0), "Invalid root");
+        require(anchoredRoots[root] == 0, "Root already anchored");
+        anchoredRoots[root] = block.number;
+
+        emit RootAnchored(root, leafCount);
+    }
+
+    // Leaves are keccak256(keccak256(abi.encode(docId, contentHash))), paired in sorted order
+    function verifyAnchored(bytes32 root, bytes32[] calldata proof, string calldata docId, bytes32 contentHash) public view returns (bool) {
+        if (anchoredRoots[root] == 0) return false;
+        bytes32 leaf = keccak256(bytes.concat(keccak256(abi.encode(docId, contentHash))));
+        return MerkleProof.verifyCalldata(proof, root, leaf);
+    }
+
+    //
+    // ERC-2771: calls relayed by the trusted forwarder act for the signer
+    //
+    function _msgSender() internal view override(Context, ERC2771Context) returns (address) {
+        return ERC2771Context._msgSender();
+    }
+
+    function _msgData() internal view override(Context, ERC2771Context) returns (bytes calldata) {
+        return ERC2771Context._msgData();
+    }
+
+    function _contextSuffixLength() internal view override(Context, ERC2771Context) returns (uint256) {
+        return ERC2771Context._contextSuffixLength();
+    }
 }
\ No newline at end of file
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.9;
+
+import "@openzeppelin/contracts/access/Ownable.sol";
+import "@openzeppelin/contracts/token/ERC20/IERC20.sol";
+import "@openzeppelin/contracts/token/ERC20/utils/SafeERC20.sol";
+import "@openzeppelin/contracts/token/ERC721/IERC721.sol";
+import "@openzeppelin/contracts/utils/cryptography/ECDSA.sol";
+import "@openzeppelin/contracts/utils/cryptography/EIP712.sol";
+
+// Holds a researcher's PCSP while the service runs a consented analysis in the TEE. On success the
+// payment is split equally between the owners of the contributing GeneNFTs, otherwise it is refunded.
+// A researcher can reclaim a payment the service has not settled within the settlement window.
+contract ResearchEscrow is Ownable, EIP712 {
+    using SafeERC20 for IERC20;
+
+    enum Status {
+        None,
+        Locked,
+        Settled,
+        Refunded
+    }
+
+    struct AnalysisRequest {
+        address researcher;
+        string analysis;
+        uint256 amount;
+        uint256 expiresAt;
+        Status status;
+    }
+
+    bytes32 private constant ANALYSIS_REQUEST_TYPEHASH =
+        keccak256("AnalysisRequest(address researcher,string analysis,uint256 amount,uint256 nonce,uint256 deadline)");
+
+    // Most GeneNFTs paid by one settlement, so it stays well under the block gas limit
+    uint256 public constant MAX_CONTRIBUTORS = 200;
+
+    IERC20 public immutable pcspToken;
+    IERC721 public immutable geneNFT;
+    uint256 public immutable settlementWindow;
+
+    uint256 private _nextRequestId = 1;
+    mapping(uint256 => AnalysisRequest) private _requests;
+    mapping(address => uint256) public nonces;
+
+    event AnalysisRequested(uint256 indexed requestId, address indexed researcher, string analysis, uint256 amount, uint256 expiresAt);
+    event PayoutSent(uint256 indexed requestId, address indexed owner, uint256 indexed tokenId, uint256 amount);
+    event AnalysisSettled(uint256 indexed requestId, uint256 contributors, uint256 amount);
+    event AnalysisRefunded(uint256 indexed requestId, address indexed researcher, uint256 amount);
+
+    constructor(address pcspAddress, address nftAddress, uint256 window) EIP712("ResearchEscrow", "1") {
+        pcspToken = IERC20(pcspAddress);
+        geneNFT = IERC721(nftAddress);
+        settlementWindow = window;
+    }
+
+    // Locks amount from the researcher, who signed the request and approved the escrow beforehand
+    function requestAnalysis(
+        address researcher,
+        string memory analysis,
+        uint256 amount,
+        uint256 deadline,
+        uint8 v,
+        bytes32 r,
+        bytes32 s
+    ) public onlyOwner returns (uint256) {
+        require(block.timestamp <= deadline, "Signature expired");
+        require(amount > 0, "Amount must be positive");
+
+        bytes32 structHash = keccak256(
+            abi.encode(ANALYSIS_REQUEST_TYPEHASH, researcher, keccak256(bytes(analysis)), amount, nonces[researcher], deadline)
+        );
+        require(ECDSA.recover(_hashTypedDataV4(structHash), v, r, s) == researcher, "Invalid signature");
+        nonces[researcher]++;
+
+        uint256 requestId = _nextRequestId++;
+        uint256 expiresAt = block.timestamp + settlementWindow;
+        _requests[requestId] = AnalysisRequest(researcher, analysis, amount, expiresAt, Status.Locked);
+        pcspToken.safeTransferFrom(researcher, address(this), amount);
+
+        emit AnalysisRequested(requestId, researcher, analysis, amount, expiresAt);
+        return requestId;
+    }
+
+    // Pays an equal share to the current owner of each contributing token. Token IDs must be strictly
+    // increasing, so no token is paid twice; the rounding remainder goes back to the researcher.
+    function settle(uint256 requestId, uint256[] memory tokenIds) public onlyOwner {
+        AnalysisRequest storage request = _requests[requestId];
+        require(request.status == Status.Locked, "Request not locked");
+        require(tokenIds.length > 0 && tokenIds.length <= MAX_CONTRIBUTORS, "Invalid contributor count");
+
+        request.status = Status.Settled;
+        uint256 share = request.amount / tokenIds.length;
+        for (uint256 i = 0; i < tokenIds.length; i++) {
+            require(i == 0 || tokenIds[i] > tokenIds[i - 1], "Token IDs not strictly increasing");
+            address owner = geneNFT.ownerOf(tokenIds[i]);
+            pcspToken.safeTransfer(owner, share);
+            emit PayoutSent(requestId, owner, tokenIds[i], share);
+        }
+
+        uint256 paid = share * tokenIds.length;
+        if (request.amount > paid) {
+            pcspToken.safeTransfer(request.researcher, request.amount - paid);
+        }
+        emit AnalysisSettled(requestId, tokenIds.length, paid);
+    }
+
+    function refund(uint256 requestId) public onlyOwner {
+        _refund(requestId);
+    }
+
+    // Lets the researcher recover a payment the service failed to settle or refund in time
+    function reclaim(uint256 requestId) public {
+        AnalysisRequest storage request = _requests[requestId];
+        require(msg.sender == request.researcher, "Not the researcher");
+        require(block.timestamp > request.expiresAt, "Settlement window still open");
+        _refund(requestId);
+    }
+
+    function getRequest(uint256 requestId) public view returns (AnalysisRequest memory) {
+        return _requests[requestId];
+    }
+
+    function _refund(uint256 requestId) internal {
+        AnalysisRequest storage request = _requests[requestId];
+        require(request.status == Status.Locked, "Request not locked");
+
+        request.status = Status.Refunded;
+        pcspToken.safeTransfer(request.researcher, request.amount);
+        emit AnalysisRefunded(requestId, request.researcher, request.amount);
+    }
+}
@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.9;
+
+import "@openzeppelin/contracts/metatx/MinimalForwarder.sol";
+
+// Trusted forwarder for gasless calls: users sign a ForwardRequest, the gateway relayer pays the gas
+contract Forwarder is MinimalForwarder {}
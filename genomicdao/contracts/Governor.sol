@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.9;
+
+import "@openzeppelin/contracts/governance/Governor.sol";
+import "@openzeppelin/contracts/governance/extensions/GovernorSettings.sol";
+import "@openzeppelin/contracts/governance/extensions/GovernorCountingSimple.sol";
+import "@openzeppelin/contracts/governance/extensions/GovernorVotes.sol";
+import "@openzeppelin/contracts/governance/extensions/GovernorVotesQuorumFraction.sol";
+
+// PCSP holders vote on drug-discovery priorities with the votes delegated to them
+// at the proposal snapshot. Delays and periods are counted in blocks.
+// Only the deployer, the service wallet, proposes: it submits proposals for their authors once
+// they hold the author threshold in delegated votes, and needs no votes itself.
+contract PCSPGovernor is Governor, GovernorSettings, GovernorCountingSimple, GovernorVotes, GovernorVotesQuorumFraction {
+    address public immutable proposer;
+
+    constructor(
+        IVotes token,
+        uint256 initialVotingDelay,
+        uint256 initialVotingPeriod,
+        uint256 initialProposalThreshold,
+        uint256 quorumPercent
+    )
+        Governor("GenomicDAO Governor")
+        GovernorSettings(initialVotingDelay, initialVotingPeriod, initialProposalThreshold)
+        GovernorVotes(token)
+        GovernorVotesQuorumFraction(quorumPercent)
+    {
+        proposer = _msgSender();
+    }
+
+    function propose(
+        address[] memory targets,
+        uint256[] memory values,
+        bytes[] memory calldatas,
+        string memory description
+    ) public override returns (uint256) {
+        require(_msgSender() == proposer, "PCSPGovernor: only the service wallet proposes");
+        return super.propose(targets, values, calldatas, description);
+    }
+
+    // The votes an author needs for the service wallet to propose on their behalf, set by governance
+    function authorThreshold() public view returns (uint256) {
+        return GovernorSettings.proposalThreshold();
+    }
+
+    function votingDelay() public view override(IGovernor, GovernorSettings) returns (uint256) {
+        return super.votingDelay();
+    }
+
+    function votingPeriod() public view override(IGovernor, GovernorSettings) returns (uint256) {
+        return super.votingPeriod();
+    }
+
+    function quorum(uint256 blockNumber) public view override(IGovernor, GovernorVotesQuorumFraction) returns (uint256) {
+        return super.quorum(blockNumber);
+    }
+
+    // The service wallet is the only proposer, and is not held to the author threshold
+    function proposalThreshold() public pure override(Governor, GovernorSettings) returns (uint256) {
+        return 0;
+    }
+}
@@ -10,9 +10,17 @@ contract GeneNFT is ERC721, ERC721Burnable, Ownable {
     using Counters for Counters.Counter;
 
     Counters.Counter private _tokenIdCounter;
+    string private _baseTokenURI;
+
+    event BaseURIUpdated(string baseURI);
 
     constructor() ERC721("GeneNFT", "GNFT") {}
 
+    function setBaseURI(string memory baseURI) public onlyOwner {
+        _baseTokenURI = baseURI;
+        emit BaseURIUpdated(baseURI);
+    }
+
     function safeMint(address to) public onlyOwner returns(uint256) {
         uint256 tokenId = _tokenIdCounter.current();
         _tokenIdCounter.increment();
@@ -20,4 +28,8 @@ contract GeneNFT is ERC721, ERC721Burnable, Ownable {
 
         return tokenId;
     }
+
+    function _baseURI() internal view override returns (string memory) {
+        return _baseTokenURI;
+    }
 }
\ No newline at end of file
@@ -3,18 +3,37 @@ pragma solidity ^0.8.9;
 
 import "@openzeppelin/contracts/token/ERC20/ERC20.sol";
 import "@openzeppelin/contracts/token/ERC20/extensions/ERC20Burnable.sol";
+import "@openzeppelin/contracts/token/ERC20/extensions/ERC20Permit.sol";
+import "@openzeppelin/contracts/token/ERC20/extensions/ERC20Votes.sol";
 import "@openzeppelin/contracts/access/Ownable.sol";
+import "@openzeppelin/contracts/utils/cryptography/ECDSA.sol";
 
-contract PostCovidStrokePrevention is ERC20, ERC20Burnable, Ownable {
+contract PostCovidStrokePrevention is ERC20, ERC20Burnable, ERC20Permit, ERC20Votes, Ownable {
 
-    mapping(uint256 => uint256) riskScoreToAward;
-    constructor() ERC20("Post-Covid Stroke Prevention", "PCSP") {
+    // EIP-3009 style signed transfers, so a relayer can move tokens on the holder's behalf
+    bytes32 public constant TRANSFER_WITH_AUTHORIZATION_TYPEHASH = keccak256(
+        "TransferWithAuthorization(address from,address to,uint256 value,uint256 validAfter,uint256 validBefore,bytes32 nonce)"
+    );
+    bytes32 public constant BURN_WITH_AUTHORIZATION_TYPEHASH = keccak256(
+        "BurnWithAuthorization(address from,uint256 value,uint256 validAfter,uint256 validBefore,bytes32 nonce)"
+    );
+
+    // Reward per risk score, in the TEE's order: 1 is low risk and 4 extremely high risk
+    uint256[4] private _rewardSchedule;
+    mapping(address => mapping(bytes32 => bool)) private _authorizationStates;
+
+    event AuthorizationUsed(address indexed authorizer, bytes32 indexed nonce);
+    event RewardScheduleUpdated(uint256[4] amounts);
+
+    constructor() ERC20("Post-Covid Stroke Prevention", "PCSP") ERC20Permit("Post-Covid Stroke Prevention") {
         _mint(msg.sender, 1000000000 * 10 ** decimals());
 
-        riskScoreToAward[1] = 15000 * 10 ** decimals();
-        riskScoreToAward[2] = 3000 * 10 ** decimals();
-        riskScoreToAward[3] = 225 * 10 ** decimals();
-        riskScoreToAward[4] = 30 * 10 ** decimals();
+        _setRewardSchedule([
+            30 * 10 ** decimals(),
+            225 * 10 ** decimals(),
+            3000 * 10 ** decimals(),
+            15000 * 10 ** decimals()
+        ]);
     }
 
     function mint(address to, uint256 amount) public onlyOwner {
@@ -23,8 +42,96 @@ contract PostCovidStrokePrevention is ERC20, ERC20Burnable, Ownable {
 
     function reward(address to, uint256 riskScore) public onlyOwner returns(uint256) {
         require(riskScore >= 1 && riskScore <= 4, "No reward for the risk score");
-        uint256 amount = riskScoreToAward[riskScore];
+        uint256 amount = _rewardSchedule[riskScore - 1];
         _mint(to, amount);
         return amount;
     }
-}
\ No newline at end of file
+
+    function rewardSchedule() public view returns(uint256[4] memory) {
+        return _rewardSchedule;
+    }
+
+    function setRewardSchedule(uint256[4] memory amounts) public onlyOwner {
+        _setRewardSchedule(amounts);
+    }
+
+    function _setRewardSchedule(uint256[4] memory amounts) internal {
+        _rewardSchedule = amounts;
+        emit RewardScheduleUpdated(amounts);
+    }
+
+    function authorizationState(address authorizer, bytes32 nonce) public view returns(bool) {
+        return _authorizationStates[authorizer][nonce];
+    }
+
+    function transferWithAuthorization(
+        address from,
+        address to,
+        uint256 value,
+        uint256 validAfter,
+        uint256 validBefore,
+        bytes32 nonce,
+        uint8 v,
+        bytes32 r,
+        bytes32 s
+    ) public {
+        bytes32 structHash = keccak256(
+            abi.encode(TRANSFER_WITH_AUTHORIZATION_TYPEHASH, from, to, value, validAfter, validBefore, nonce)
+        );
+        _useAuthorization(from, structHash, validAfter, validBefore, nonce, v, r, s);
+        _transfer(from, to, value);
+    }
+
+    function burnWithAuthorization(
+        address from,
+        uint256 value,
+        uint256 validAfter,
+        uint256 validBefore,
+        bytes32 nonce,
+        uint8 v,
+        bytes32 r,
+        bytes32 s
+    ) public {
+        bytes32 structHash = keccak256(
+            abi.encode(BURN_WITH_AUTHORIZATION_TYPEHASH, from, value, validAfter, validBefore, nonce)
+        );
+        _useAuthorization(from, structHash, validAfter, validBefore, nonce, v, r, s);
+        _burn(from, value);
+    }
+
+    function _useAuthorization(
+        address authorizer,
+        bytes32 structHash,
+        uint256 validAfter,
+        uint256 validBefore,
+        bytes32 nonce,
+        uint8 v,
+        bytes32 r,
+        bytes32 s
+    ) internal {
+        require(block.timestamp > validAfter, "Authorization is not yet valid");
+        require(block.timestamp < validBefore, "Authorization is expired");
+        require(!_authorizationStates[authorizer][nonce], "Authorization is used");
+
+        address signer = ECDSA.recover(_hashTypedDataV4(structHash), v, r, s);
+        require(signer == authorizer, "Invalid signature");
+
+        _authorizationStates[authorizer][nonce] = true;
+        emit AuthorizationUsed(authorizer, nonce);
+    }
+
+    //
+    // ERC20Votes: holders delegate to checkpoint their voting power for governance
+    //
+    function _afterTokenTransfer(address from, address to, uint256 amount) internal override(ERC20, ERC20Votes) {
+        super._afterTokenTransfer(from, to, amount);
+    }
+
+    function _mint(address to, uint256 amount) internal override(ERC20, ERC20Votes) {
+        super._mint(to, amount);
+    }
+
+    function _burn(address account, uint256 amount) internal override(ERC20, ERC20Votes) {
+        super._burn(account, amount);
+    }
+}
@@ -18,12 +18,50 @@ async function main() {
     await pcspToken.waitForDeployment();
     console.log("PCSP Token deployed at address:", pcspToken.target);
 
+    // Deploy PCSP governance; the vote snapshot is the proposal block, and a vote lasts
+    // about a week of 2s blocks with 4% of the supply as quorum. The deployer, the service
+    // wallet, is the only proposer, and GOVERNOR_PROPOSAL_THRESHOLD the votes authors need
+    const Governor = await ethers.getContractFactory("PCSPGovernor");
+    const governor = await Governor.deploy(
+        pcspToken.target,
+        process.env.GOVERNOR_VOTING_DELAY || 0,
+        process.env.GOVERNOR_VOTING_PERIOD || 302400,
+        process.env.GOVERNOR_PROPOSAL_THRESHOLD || 0,
+        process.env.GOVERNOR_QUORUM_PERCENT || 4
+    );
+    await governor.waitForDeployment();
+    console.log("Governor deployed at address:", governor.target);
+
+    // Deploy the research escrow; the service wallet settles payments, and researchers may
+    // reclaim a payment left unsettled for a week
+    const Escrow = await ethers.getContractFactory("ResearchEscrow");
+    const escrow = await Escrow.deploy(
+        pcspToken.target,
+        geneNftToken.target,
+        process.env.ESCROW_SETTLEMENT_WINDOW || 7 * 24 * 60 * 60
+    );
+    await escrow.waitForDeployment();
+    console.log("ResearchEscrow deployed at address:", escrow.target);
+
+    // Deploy trusted forwarder for gasless meta-transactions
+    const Forwarder = await ethers.getContractFactory("Forwarder");
+    const forwarder = await Forwarder.deploy();
+    await forwarder.waitForDeployment();
+    console.log("Forwarder deployed at address:", forwarder.target);
+
     // Deploy Controller
     const Controller = await ethers.getContractFactory("Controller");
-    const controller = await Controller.deploy(geneNftToken.target, pcspToken.target);
+    const controller = await Controller.deploy(geneNftToken.target, pcspToken.target, forwarder.target);
     await controller.waitForDeployment();
     console.log("Controller deployed at address:", controller.target);
 
+    // Point token URIs at the gateway metadata endpoint, e.g. https://gateway.example/nft/
+    if (process.env.NFT_BASE_URI) {
+        const setBaseURITx = await geneNftToken.setBaseURI(process.env.NFT_BASE_URI);
+        await setBaseURITx.wait();
+        console.log("GeneNFT base URI set to:", process.env.NFT_BASE_URI);
+    }
+
     // Transfer ownership of tokens to Controller
     const transferNFTTx = await geneNftToken.transferOwnership(controller.target);
     await transferNFTTx.wait();
@@ -0,0 +1,138 @@
+const {
+  loadFixture,
+  time,
+} = require("@nomicfoundation/hardhat-toolbox/network-helpers");
+const { expect } = require("chai");
+
+describe("ResearchEscrow", function () {
+  const settlementWindow = 3600
+  const tokens = (amount) => BigInt(amount) * BigInt("10") ** BigInt("18")
+
+  async function deployEscrowFixture() {
+    const [service, researcher, owner1, owner2] = await ethers.getSigners();
+
+    const pcspToken = await ethers.deployContract("PostCovidStrokePrevention");
+    const geneNFT = await ethers.deployContract("GeneNFT");
+    const escrow = await ethers.deployContract("ResearchEscrow", [pcspToken.target, geneNFT.target, settlementWindow]);
+
+    // Tokens 0 and 1 belong to owner1, token 2 to owner2
+    await geneNFT.safeMint(owner1.address)
+    await geneNFT.safeMint(owner1.address)
+    await geneNFT.safeMint(owner2.address)
+
+    await pcspToken.transfer(researcher.address, tokens(1000))
+    await pcspToken.connect(researcher).approve(escrow.target, tokens(1000))
+
+    return { escrow, pcspToken, geneNFT, service, researcher, owner1, owner2 }
+  }
+
+  async function signRequest(escrow, researcher, analysis, amount, deadline) {
+    const domain = {
+      name: "ResearchEscrow",
+      version: "1",
+      chainId: (await ethers.provider.getNetwork()).chainId,
+      verifyingContract: escrow.target,
+    }
+    const types = {
+      AnalysisRequest: [
+        { name: "researcher", type: "address" },
+        { name: "analysis", type: "string" },
+        { name: "amount", type: "uint256" },
+        { name: "nonce", type: "uint256" },
+        { name: "deadline", type: "uint256" },
+      ],
+    }
+    const nonce = await escrow.nonces(researcher.address)
+    return ethers.Signature.from(await researcher.signTypedData(domain, types, {
+      researcher: researcher.address, analysis, amount, nonce, deadline,
+    }))
+  }
+
+  async function lock(escrow, researcher, amount) {
+    const deadline = (await time.latest()) + 600
+    const signature = await signRequest(escrow, researcher, "risk-summary", amount, deadline)
+    await escrow.requestAnalysis(researcher.address, "risk-summary", amount, deadline, signature.v, signature.r, signature.s)
+    return 1n
+  }
+
+  it("Should lock the payment of a signed request", async function () {
+    const { escrow, pcspToken, researcher } = await loadFixture(deployEscrowFixture);
+
+    const deadline = (await time.latest()) + 600
+    const signature = await signRequest(escrow, researcher, "risk-summary", tokens(100), deadline)
+    await expect(
+      escrow.requestAnalysis(researcher.address, "risk-summary", tokens(100), deadline, signature.v, signature.r, signature.s)
+    ).to.emit(escrow, "AnalysisRequested")
+
+    expect(await pcspToken.balanceOf(escrow.target)).to.equal(tokens(100))
+    expect(await escrow.nonces(researcher.address)).to.equal(1)
+    const request = await escrow.getRequest(1)
+    expect(request.researcher).to.equal(researcher.address)
+    expect(request.status).to.equal(1)
+  })
+
+  it("Should reject a request the researcher did not sign", async function () {
+    const { escrow, researcher, owner1 } = await loadFixture(deployEscrowFixture);
+
+    const deadline = (await time.latest()) + 600
+    const signature = await signRequest(escrow, owner1, "risk-summary", tokens(100), deadline)
+    await expect(
+      escrow.requestAnalysis(researcher.address, "risk-summary", tokens(100), deadline, signature.v, signature.r, signature.s)
+    ).to.be.revertedWith("Invalid signature")
+  })
+
+  it("Should only let the service lock payments", async function () {
+    const { escrow, researcher } = await loadFixture(deployEscrowFixture);
+
+    const deadline = (await time.latest()) + 600
+    const signature = await signRequest(escrow, researcher, "risk-summary", tokens(100), deadline)
+    await expect(
+      escrow.connect(researcher).requestAnalysis(researcher.address, "risk-summary", tokens(100), deadline, signature.v, signature.r, signature.s)
+    ).to.be.revertedWith("Ownable: caller is not the owner")
+  })
+
+  it("Should pay each contributing token an equal share", async function () {
+    const { escrow, pcspToken, researcher, owner1, owner2 } = await loadFixture(deployEscrowFixture);
+
+    const requestId = await lock(escrow, researcher, tokens(100))
+    await expect(escrow.settle(requestId, [0, 1, 2]))
+      .to.emit(escrow, "AnalysisSettled")
+
+    const share = tokens(100) / 3n
+    expect(await pcspToken.balanceOf(owner1.address)).to.equal(share * 2n)
+    expect(await pcspToken.balanceOf(owner2.address)).to.equal(share)
+    // The rounding remainder goes back to the researcher
+    expect(await pcspToken.balanceOf(researcher.address)).to.equal(tokens(900) + tokens(100) - share * 3n)
+    expect(await pcspToken.balanceOf(escrow.target)).to.equal(0)
+  })
+
+  it("Should refuse to pay a token twice", async function () {
+    const { escrow, researcher } = await loadFixture(deployEscrowFixture);
+
+    const requestId = await lock(escrow, researcher, tokens(100))
+    await expect(escrow.settle(requestId, [1, 1])).to.be.revertedWith("Token IDs not strictly increasing")
+  })
+
+  it("Should refund a failed analysis once", async function () {
+    const { escrow, pcspToken, researcher } = await loadFixture(deployEscrowFixture);
+
+    const requestId = await lock(escrow, researcher, tokens(100))
+    await expect(escrow.refund(requestId))
+      .to.emit(escrow, "AnalysisRefunded")
+      .withArgs(requestId, researcher.address, tokens(100))
+
+    expect(await pcspToken.balanceOf(researcher.address)).to.equal(tokens(1000))
+    await expect(escrow.settle(requestId, [0])).to.be.revertedWith("Request not locked")
+  })
+
+  it("Should let the researcher reclaim after the settlement window", async function () {
+    const { escrow, pcspToken, researcher } = await loadFixture(deployEscrowFixture);
+
+    const requestId = await lock(escrow, researcher, tokens(100))
+    await expect(escrow.connect(researcher).reclaim(requestId)).to.be.revertedWith("Settlement window still open")
+
+    await time.increase(settlementWindow + 1)
+    await escrow.connect(researcher).reclaim(requestId)
+    expect(await pcspToken.balanceOf(researcher.address)).to.equal(tokens(1000))
+  })
+});
@@ -0,0 +1,99 @@
+const {
+  loadFixture,
+} = require("@nomicfoundation/hardhat-toolbox/network-helpers");
+const { expect } = require("chai");
+
+describe("Forwarder", function () {
+  async function deployForwarderFixture() {
+    const [owner, relayer, user] = await ethers.getSigners();
+
+    const nft = await ethers.deployContract("GeneNFT");
+    const pcspToken = await ethers.deployContract("PostCovidStrokePrevention");
+    const forwarder = await ethers.deployContract("Forwarder");
+
+    const controller = await ethers.deployContract("Controller", [nft.target, pcspToken.target, forwarder.target]);
+
+    await nft.transferOwnership(controller.target)
+    await pcspToken.transferOwnership(controller.target)
+
+    return { controller, forwarder, nft, pcspToken, owner, relayer, user }
+  }
+
+  const forwardRequestTypes = {
+    ForwardRequest: [
+      { name: "from", type: "address" },
+      { name: "to", type: "address" },
+      { name: "value", type: "uint256" },
+      { name: "gas", type: "uint256" },
+      { name: "nonce", type: "uint256" },
+      { name: "data", type: "bytes" },
+    ],
+  }
+
+  async function signRequest(forwarder, signer, to, data) {
+    const request = {
+      from: signer.address,
+      to,
+      value: 0,
+      gas: 1000000,
+      nonce: await forwarder.getNonce(signer.address),
+      data,
+    }
+    const domain = {
+      name: "MinimalForwarder",
+      version: "0.0.1",
+      chainId: (await ethers.provider.getNetwork()).chainId,
+      verifyingContract: forwarder.target,
+    }
+    const signature = await signer.signTypedData(domain, forwardRequestTypes, request)
+    return { request, signature }
+  }
+
+  it("Should trust the forwarder", async function () {
+    const { controller, forwarder } = await loadFixture(deployForwarderFixture);
+
+    expect(await controller.isTrustedForwarder(forwarder.target)).to.equal(true)
+  })
+
+  it("Should open the session for the signer", async function () {
+    const { controller, forwarder, relayer, user } = await loadFixture(deployForwarderFixture);
+
+    const data = controller.interface.encodeFunctionData("uploadData", ["doc1"])
+    const { request, signature } = await signRequest(forwarder, user, controller.target, data)
+
+    expect(await forwarder.verify(request, signature)).to.equal(true)
+    await forwarder.connect(relayer).execute(request, signature)
+
+    const session = await controller.getSession(0)
+    expect(session.user).to.equal(user.address)
+    expect(await forwarder.getNonce(user.address)).to.equal(1)
+  })
+
+  it("Should not let the signer confirm its own upload", async function () {
+    const { controller, forwarder, nft, pcspToken, relayer, user } = await loadFixture(deployForwarderFixture);
+
+    const upload = await signRequest(forwarder, user, controller.target,
+      controller.interface.encodeFunctionData("uploadData", ["doc1"]))
+    await forwarder.connect(relayer).execute(upload.request, upload.signature)
+
+    const confirm = await signRequest(forwarder, user, controller.target,
+      controller.interface.encodeFunctionData("confirm", ["doc1", "dochash", "success", 0, 4]))
+    await forwarder.connect(relayer).execute(confirm.request, confirm.signature)
+
+    expect(await nft.balanceOf(user.address)).to.equal(0)
+    expect(await pcspToken.balanceOf(user.address)).to.equal(0)
+    expect((await controller.getSession(0)).confirmed).to.equal(false)
+  })
+
+  it("Should reject a replayed request", async function () {
+    const { controller, forwarder, relayer, user } = await loadFixture(deployForwarderFixture);
+
+    const { request, signature } = await signRequest(forwarder, user, controller.target,
+      controller.interface.encodeFunctionData("uploadData", ["doc1"]))
+    await forwarder.connect(relayer).execute(request, signature)
+
+    await expect(
+      forwarder.connect(relayer).execute(request, signature)
+    ).to.be.revertedWith("MinimalForwarder: signature does not match request")
+  })
+})
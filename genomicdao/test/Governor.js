@@ -0,0 +1,124 @@
+const {
+  loadFixture,
+  mine,
+} = require("@nomicfoundation/hardhat-toolbox/network-helpers");
+const { expect } = require("chai");
+
+describe("PCSPGovernor", function () {
+  const votingPeriod = 10
+  const tokens = (amount) => BigInt(amount) * BigInt("10") ** BigInt("18")
+
+  async function deployGovernorFixture() {
+    const [owner, voter1, voter2] = await ethers.getSigners();
+
+    const pcspToken = await ethers.deployContract("PostCovidStrokePrevention");
+    const governor = await ethers.deployContract("PCSPGovernor", [pcspToken.target, 0, votingPeriod, 0, 4]);
+
+    await pcspToken.transfer(voter1.address, tokens(60000000))
+    await pcspToken.transfer(voter2.address, tokens(10000000))
+    await pcspToken.connect(voter1).delegate(voter1.address)
+    await pcspToken.connect(voter2).delegate(voter2.address)
+
+    return { governor, pcspToken, owner, voter1, voter2 }
+  }
+
+  async function propose(governor, description) {
+    const tx = await governor.propose([governor.target], [0], ["0x"], description)
+    const receipt = await tx.wait()
+    const event = receipt.logs.map((log) => governor.interface.parseLog(log)).find((log) => log && log.name === "ProposalCreated")
+    return event.args.proposalId
+  }
+
+  it("Should only take proposals from the service wallet", async function () {
+    const { governor, owner, voter1 } = await loadFixture(deployGovernorFixture);
+
+    await expect(
+      governor.connect(voter1).propose([governor.target], [0], ["0x"], "Fund genome sequencing of a new cohort")
+    ).to.be.revertedWith("PCSPGovernor: only the service wallet proposes")
+
+    // The service wallet proposes for authors without votes of its own
+    expect(await governor.proposer()).to.equal(owner.address)
+    await propose(governor, "Fund genome sequencing of a new cohort")
+  })
+
+  it("Should hold authors, not the service wallet, to the threshold", async function () {
+    const pcspToken = await ethers.deployContract("PostCovidStrokePrevention");
+    const governor = await ethers.deployContract("PCSPGovernor", [pcspToken.target, 0, votingPeriod, tokens(1000), 4]);
+
+    expect(await governor.authorThreshold()).to.equal(tokens(1000))
+    expect(await governor.proposalThreshold()).to.equal(0)
+    await propose(governor, "Prioritise post-covid stroke cohort")
+  })
+
+  it("Should checkpoint delegated votes", async function () {
+    const { pcspToken, owner, voter1 } = await loadFixture(deployGovernorFixture);
+
+    expect(await pcspToken.getVotes(voter1.address)).to.equal(tokens(60000000))
+    // Undelegated balances carry no votes
+    expect(await pcspToken.getVotes(owner.address)).to.equal(0)
+  })
+
+  it("Should weigh votes by the balance at the proposal block", async function () {
+    const { governor, pcspToken, voter1, voter2 } = await loadFixture(deployGovernorFixture);
+
+    const proposalId = await propose(governor, "Prioritise post-covid stroke cohort")
+    await pcspToken.connect(voter1).transfer(voter2.address, tokens(50000000))
+    await mine(1)
+
+    await governor.connect(voter1).castVote(proposalId, 1)
+    await governor.connect(voter2).castVote(proposalId, 0)
+
+    const votes = await governor.proposalVotes(proposalId)
+    expect(votes.forVotes).to.equal(tokens(60000000))
+    expect(votes.againstVotes).to.equal(tokens(10000000))
+  })
+
+  it("Should count a vote signed by the holder", async function () {
+    const { governor, owner, voter1 } = await loadFixture(deployGovernorFixture);
+
+    const proposalId = await propose(governor, "Fund genome sequencing of a new cohort")
+    await mine(1)
+
+    const domain = {
+      name: "GenomicDAO Governor",
+      version: "1",
+      chainId: (await ethers.provider.getNetwork()).chainId,
+      verifyingContract: governor.target,
+    }
+    const types = { Ballot: [{ name: "proposalId", type: "uint256" }, { name: "support", type: "uint8" }] }
+    const signature = ethers.Signature.from(await voter1.signTypedData(domain, types, { proposalId, support: 1 }))
+
+    await expect(
+      governor.connect(owner).castVoteBySig(proposalId, 1, signature.v, signature.r, signature.s)
+    )
+      .to.emit(governor, "VoteCast")
+      .withArgs(voter1.address, proposalId, 1, tokens(60000000), "")
+
+    expect(await governor.hasVoted(proposalId, voter1.address)).to.equal(true)
+  })
+
+  it("Should succeed once quorum is reached", async function () {
+    const { governor, voter1 } = await loadFixture(deployGovernorFixture);
+
+    const proposalId = await propose(governor, "Prioritise post-covid stroke cohort")
+    await mine(1)
+    await governor.connect(voter1).castVote(proposalId, 1)
+    await mine(votingPeriod)
+
+    // Succeeded
+    expect(await governor.state(proposalId)).to.equal(4)
+  })
+
+  it("Should be defeated without quorum", async function () {
+    const { governor, pcspToken, voter2 } = await loadFixture(deployGovernorFixture);
+
+    await pcspToken.connect(voter2).transfer(governor.target, tokens(9000000))
+    const proposalId = await propose(governor, "Prioritise post-covid stroke cohort")
+    await mine(1)
+    await governor.connect(voter2).castVote(proposalId, 1)
+    await mine(votingPeriod)
+
+    // 4% of the supply is 40M PCSP; Defeated
+    expect(await governor.state(proposalId)).to.equal(3)
+  })
+})
@@ -41,4 +41,36 @@ describe("GeneNFT", function () {
     })
   })
 
-})
\ No newline at end of file
+
+  describe("Metadata", function () {
+    it("Should return empty token URI when base URI is unset", async function () {
+      const { nft, addr1 } = await loadFixture(deployNftFixture);
+
+      await nft.safeMint(addr1)
+
+      expect(await nft.tokenURI(0)).to.equal("")
+    })
+
+    it("Should build token URI from base URI", async function () {
+      const { nft, addr1 } = await loadFixture(deployNftFixture);
+
+      await nft.safeMint(addr1)
+
+      await expect(
+        nft.setBaseURI("https://gateway.example/nft/")
+      )
+        .to.emit(nft, "BaseURIUpdated")
+        .withArgs("https://gateway.example/nft/")
+
+      expect(await nft.tokenURI(0)).to.equal("https://gateway.example/nft/0")
+    })
+
+    it("Should fail if base URI is set by non-owner", async function () {
+      const { nft, addr1 } = await loadFixture(deployNftFixture);
+
+      await expect(
+        nft.connect(addr1).setBaseURI("https://gateway.example/nft/")
+      ).to.be.revertedWith("Ownable: caller is not the owner")
+    })
+  })
+})
@@ -12,12 +12,14 @@ describe("Controller", function () {
     const nft = await ethers.deployContract("GeneNFT");
     const pcspToken = await ethers.deployContract("PostCovidStrokePrevention");
 
-    const controller = await ethers.deployContract("Controller", [nft.target, pcspToken.target]);
+    const forwarder = await ethers.deployContract("Forwarder");
+
+    const controller = await ethers.deployContract("Controller", [nft.target, pcspToken.target, forwarder.target]);
 
     await nft.transferOwnership(controller.target)
     await pcspToken.transferOwnership(controller.target)
 
-    return { controller, nft, pcspToken, owner, addr1, addr2 }
+    return { controller, forwarder, nft, pcspToken, owner, addr1, addr2 }
   }
 
   describe("Upload Data", function () {
@@ -41,7 +43,7 @@ describe("Controller", function () {
       const sessionId = 0
 
       await controller.connect(addr1).uploadData(docId)
-      await controller.connect(addr1).confirm(docId, contentHash, proof, sessionId, riskScore)
+      await controller.confirm(docId, contentHash, proof, sessionId, riskScore)
 
       await expect(
         controller.connect(addr2).uploadData(docId)
@@ -71,13 +73,13 @@ describe("Controller", function () {
       const docId = "doc1"
       const contentHash = "dochash"
       const proof = "success"
-      const riskScore = 1
+      const riskScore = 4
       const sessionId = 0
 
       const awardAmount = BigInt("15000") * BigInt("10") ** BigInt("18")
 
       await controller.connect(addr1).uploadData(docId)
-      await controller.connect(addr1).confirm(docId, contentHash, proof, sessionId, riskScore)
+      await controller.confirm(docId, contentHash, proof, sessionId, riskScore)
 
       const ownerBalance = await pcspToken.balanceOf(addr1.address)
 
@@ -94,7 +96,7 @@ describe("Controller", function () {
       const sessionId = 0
 
       await controller.connect(addr1).uploadData(docId)
-      await controller.connect(addr1).confirm(docId, contentHash, proof, sessionId, riskScore)
+      await controller.confirm(docId, contentHash, proof, sessionId, riskScore)
 
       const session = await controller.getSession(sessionId)
 
@@ -112,7 +114,7 @@ describe("Controller", function () {
       const sessionId = 0
 
       await controller.connect(addr1).uploadData(docId)
-      await controller.connect(addr1).confirm(docId, contentHash, proof, sessionId, riskScore)
+      await controller.confirm(docId, contentHash, proof, sessionId, riskScore)
 
       const doc = await controller.getDoc(docId)
 
@@ -129,27 +131,40 @@ describe("Controller", function () {
       const sessionId = 0
 
       await controller.connect(addr1).uploadData(docId)
-      await controller.connect(addr1).confirm(docId, contentHash, proof, sessionId, riskScore)
+      await controller.confirm(docId, contentHash, proof, sessionId, riskScore)
 
       await expect(
-        controller.connect(addr1).confirm(docId, contentHash, proof, sessionId, riskScore)
+        controller.confirm(docId, contentHash, proof, sessionId, riskScore)
       ).to.be.revertedWith("Doc already been submitted")
     })
 
-    it("Should fail if the session owner is invalid", async function () {
+    it("Should mint and reward the user who opened the session", async function () {
+      const { controller, nft, pcspToken, owner, addr1 } = await loadFixture(deployControllerFixture);
+
+      await controller.connect(addr1).uploadData("doc1")
+      await controller.confirm("doc1", "dochash", "success", 0, 4)
+
+      expect(await nft.ownerOf(0)).to.equal(addr1.address)
+      expect(await pcspToken.balanceOf(owner.address)).to.equal(0)
+    })
+
+    it("Should fail if confirmed by anyone but the owner", async function () {
       const { controller, addr1, addr2 } = await loadFixture(deployControllerFixture);
 
       const docId = "doc1"
       const contentHash = "dochash"
       const proof = "success"
-      const riskScore = 1
+      const riskScore = 4
       const sessionId = 0
 
       await controller.connect(addr1).uploadData(docId)
 
       await expect(
-        controller.connect(addr2).confirm(docId, contentHash, proof, sessionId, riskScore)
-      ).to.be.revertedWith("Invalid session owner")
+        controller.connect(addr1).confirm(docId, contentHash, proof, sessionId, riskScore)
+      ).to.be.revertedWith("Ownable: caller is not the owner")
+      await expect(
+        controller.connect(addr2).confirmBatch([[docId, contentHash, proof, sessionId, riskScore]])
+      ).to.be.revertedWith("Ownable: caller is not the owner")
     })
 
     it("Should fail if the session is end", async function () {
@@ -163,11 +178,175 @@ describe("Controller", function () {
       const sessionId = 0
 
       await controller.connect(addr1).uploadData(docId)
-      await controller.connect(addr1).confirm(docId, contentHash, proof, sessionId, riskScore)
+      await controller.confirm(docId, contentHash, proof, sessionId, riskScore)
 
       await expect(
-        controller.connect(addr1).confirm(docId2, contentHash, proof, sessionId, riskScore)
+        controller.confirm(docId2, contentHash, proof, sessionId, riskScore)
       ).to.be.revertedWith("Session is ended")
     })
   })
-})
\ No newline at end of file
+
+  describe("Batches", function () {
+    it("Should open a session for every doc", async function () {
+      const { controller, addr1 } = await loadFixture(deployControllerFixture);
+
+      await expect(
+        controller.connect(addr1).uploadDataBatch(["doc1", "doc2"])
+      )
+        .to.emit(controller, "UploadData").withArgs("doc1", 0)
+        .and.to.emit(controller, "UploadData").withArgs("doc2", 1)
+    })
+
+    it("Should confirm every upload in one transaction", async function () {
+      const { controller, nft, pcspToken, addr1 } = await loadFixture(deployControllerFixture);
+
+      await controller.connect(addr1).uploadDataBatch(["doc1", "doc2"])
+
+      await expect(
+        controller.confirmBatch([
+          ["doc1", "hash1", "success", 0, 1],
+          ["doc2", "hash2", "success", 1, 4],
+        ])
+      )
+        .to.emit(controller, "UploadConfirmed").withArgs("doc1", 0, 0, BigInt("30") * BigInt("10") ** BigInt("18"))
+        .and.to.emit(controller, "UploadConfirmed").withArgs("doc2", 1, 1, BigInt("15000") * BigInt("10") ** BigInt("18"))
+
+      expect(await nft.ownerOf(1)).to.equal(addr1.address)
+      expect(await controller.getTokenDoc(1)).to.equal("doc2")
+      expect((await controller.getDoc("doc2")).hashContent).to.equal("hash2")
+      expect(await pcspToken.balanceOf(addr1.address)).to.equal(BigInt("15030") * BigInt("10") ** BigInt("18"))
+    })
+
+    it("Should revert the whole batch naming the failing item", async function () {
+      const { controller, addr1 } = await loadFixture(deployControllerFixture);
+
+      await controller.connect(addr1).uploadDataBatch(["doc1", "doc2"])
+
+      await expect(
+        controller.confirmBatch([
+          ["doc1", "hash1", "success", 0, 1],
+          ["doc2", "hash2", "success", 1, 7],
+        ])
+      )
+        .to.be.revertedWithCustomError(controller, "BatchItemFailed")
+        .withArgs(1, "No reward for the risk score")
+
+      expect((await controller.getSession(0)).confirmed).to.equal(false)
+    })
+
+    it("Should fail on a doc repeated in the batch", async function () {
+      const { controller, addr1 } = await loadFixture(deployControllerFixture);
+
+      await expect(
+        controller.connect(addr1).uploadDataBatch(["doc1", "doc1"])
+      )
+        .to.be.revertedWithCustomError(controller, "BatchItemFailed")
+        .withArgs(1, "Doc already been submitted")
+    })
+
+    it("Should fail on an empty batch", async function () {
+      const { controller } = await loadFixture(deployControllerFixture);
+
+      await expect(
+        controller.confirmBatch([])
+      ).to.be.revertedWith("Invalid batch size")
+    })
+  })
+
+  describe("Token metadata", function () {
+    it("Should link token to doc", async function () {
+      const { controller, addr1 } = await loadFixture(deployControllerFixture);
+
+      const docId = "doc1"
+      const contentHash = "dochash"
+      const proof = "success"
+      const riskScore = 1
+      const sessionId = 0
+
+      await controller.connect(addr1).uploadData(docId)
+      await controller.confirm(docId, contentHash, proof, sessionId, riskScore)
+
+      expect(await controller.getTokenDoc(0)).to.equal(docId)
+    })
+
+    it("Should set nft base URI", async function () {
+      const { controller, nft, addr1 } = await loadFixture(deployControllerFixture);
+
+      const docId = "doc1"
+      const contentHash = "dochash"
+      const proof = "success"
+      const riskScore = 1
+      const sessionId = 0
+
+      await controller.connect(addr1).uploadData(docId)
+      await controller.confirm(docId, contentHash, proof, sessionId, riskScore)
+      await controller.setNFTBaseURI("https://gateway.example/nft/")
+
+      expect(await nft.tokenURI(0)).to.equal("https://gateway.example/nft/0")
+    })
+
+    it("Should fail if base URI is set by non-owner", async function () {
+      const { controller, addr1 } = await loadFixture(deployControllerFixture);
+
+      await expect(
+        controller.connect(addr1).setNFTBaseURI("https://gateway.example/nft/")
+      ).to.be.revertedWith("Ownable: caller is not the owner")
+    })
+
+    it("Should set the reward schedule through the token owner", async function () {
+      const { controller, pcspToken, addr1 } = await loadFixture(deployControllerFixture);
+
+      const amounts = [10, 20, 40, 80].map((amount) => BigInt(amount) * BigInt("10") ** BigInt("18"))
+      await controller.setRewardSchedule(amounts)
+      expect(await pcspToken.rewardSchedule()).to.deep.equal(amounts)
+
+      await expect(
+        controller.connect(addr1).setRewardSchedule(amounts)
+      ).to.be.revertedWith("Ownable: caller is not the owner")
+    })
+  })
+
+  describe("Anchor content hashes", function () {
+    const coder = ethers.AbiCoder.defaultAbiCoder()
+
+    function leaf(docId, contentHash) {
+      return ethers.keccak256(ethers.keccak256(coder.encode(["string", "bytes32"], [docId, contentHash])))
+    }
+
+    function hashPair(a, b) {
+      return BigInt(a) < BigInt(b) ? ethers.keccak256(ethers.concat([a, b])) : ethers.keccak256(ethers.concat([b, a]))
+    }
+
+    it("Should verify a doc against an anchored root", async function () {
+      const { controller } = await loadFixture(deployControllerFixture);
+
+      const hash1 = ethers.id("ciphertext1")
+      const hash2 = ethers.id("ciphertext2")
+      const root = hashPair(leaf("doc1", hash1), leaf("doc2", hash2))
+
+      expect(await controller.verifyAnchored(root, [leaf("doc2", hash2)], "doc1", hash1)).to.equal(false)
+
+      await expect(controller.anchorRoot(root, 2))
+        .to.emit(controller, "RootAnchored")
+        .withArgs(root, 2)
+
+      expect(await controller.anchoredRoots(root)).to.be.greaterThan(0)
+      expect(await controller.verifyAnchored(root, [leaf("doc2", hash2)], "doc1", hash1)).to.equal(true)
+      expect(await controller.verifyAnchored(root, [leaf("doc1", hash1)], "doc2", hash2)).to.equal(true)
+      expect(await controller.verifyAnchored(root, [leaf("doc2", hash2)], "doc1", hash2)).to.equal(false)
+    })
+
+    it("Should anchor a root once, by the owner only", async function () {
+      const { controller, addr1 } = await loadFixture(deployControllerFixture);
+
+      const root = leaf("doc1", ethers.id("ciphertext1"))
+      await expect(
+        controller.connect(addr1).anchorRoot(root, 1)
+      ).to.be.revertedWith("Ownable: caller is not the owner")
+
+      await controller.anchorRoot(root, 1)
+      await expect(controller.anchorRoot(root, 1)).to.be.revertedWith("Root already anchored")
+      await expect(controller.anchorRoot(ethers.ZeroHash, 0)).to.be.revertedWith("Invalid root")
+    })
+  })
+})
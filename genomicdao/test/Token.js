@@ -62,7 +62,7 @@ describe("Token", function () {
 
       const awardAmount = BigInt("15000") * BigInt("10") ** BigInt("18")
 
-      await pcspToken.reward(addr1, 1)
+      await pcspToken.reward(addr1, 4)
 
       const ownerBalance = await pcspToken.balanceOf(addr1.address)
 
@@ -74,7 +74,7 @@ describe("Token", function () {
 
       const awardAmount = BigInt("3000") * BigInt("10") ** BigInt("18")
 
-      await pcspToken.reward(addr1, 2)
+      await pcspToken.reward(addr1, 3)
 
       const ownerBalance = await pcspToken.balanceOf(addr1.address)
 
@@ -86,7 +86,7 @@ describe("Token", function () {
 
       const awardAmount = BigInt("225") * BigInt("10") ** BigInt("18")
 
-      await pcspToken.reward(addr1, 3)
+      await pcspToken.reward(addr1, 2)
 
       const ownerBalance = await pcspToken.balanceOf(addr1.address)
 
@@ -98,7 +98,7 @@ describe("Token", function () {
 
       const awardAmount = BigInt("30") * BigInt("10") ** BigInt("18")
 
-      await pcspToken.reward(addr1, 4)
+      await pcspToken.reward(addr1, 1)
 
       const ownerBalance = await pcspToken.balanceOf(addr1.address)
 
@@ -110,7 +110,184 @@ describe("Token", function () {
 
       await expect(pcspToken.reward(addr1, 5)).to.be.revertedWith("No reward for the risk score")
     })
+
+    it("Should pay more for a higher risk score", async function () {
+      const { pcspToken } = await loadFixture(deployTokenFixture);
+
+      const schedule = await pcspToken.rewardSchedule()
+      for (let i = 1; i < schedule.length; i++) {
+        expect(schedule[i]).to.be.greaterThan(schedule[i - 1])
+      }
+    })
+
+    it("Should award from an updated schedule", async function () {
+      const { pcspToken, addr1 } = await loadFixture(deployTokenFixture);
+
+      const amounts = [10, 20, 40, 80].map((amount) => BigInt(amount) * BigInt("10") ** BigInt("18"))
+      await expect(pcspToken.setRewardSchedule(amounts))
+        .to.emit(pcspToken, "RewardScheduleUpdated")
+        .withArgs(amounts)
+
+      await pcspToken.reward(addr1, 3)
+      expect(await pcspToken.balanceOf(addr1.address)).to.equal(amounts[2])
+    })
+
+    it("Should fail if the schedule is set by non-owner", async function () {
+      const { pcspToken, addr1 } = await loadFixture(deployTokenFixture);
+
+      await expect(
+        pcspToken.connect(addr1).setRewardSchedule([1, 2, 3, 4])
+      ).to.be.revertedWith("Ownable: caller is not the owner")
+    })
   })
 
+  describe("Signed authorizations", function () {
+    async function domain(pcspToken) {
+      return {
+        name: "Post-Covid Stroke Prevention",
+        version: "1",
+        chainId: (await ethers.provider.getNetwork()).chainId,
+        verifyingContract: pcspToken.target,
+      }
+    }
+
+    const transferTypes = {
+      TransferWithAuthorization: [
+        { name: "from", type: "address" },
+        { name: "to", type: "address" },
+        { name: "value", type: "uint256" },
+        { name: "validAfter", type: "uint256" },
+        { name: "validBefore", type: "uint256" },
+        { name: "nonce", type: "bytes32" },
+      ],
+    }
+
+    const burnTypes = {
+      BurnWithAuthorization: [
+        { name: "from", type: "address" },
+        { name: "value", type: "uint256" },
+        { name: "validAfter", type: "uint256" },
+        { name: "validBefore", type: "uint256" },
+        { name: "nonce", type: "bytes32" },
+      ],
+    }
+
+    it("Should approve with permit", async function () {
+      const { pcspToken, owner, addr1, addr2 } = await loadFixture(deployTokenFixture);
+
+      const deadline = (await time.latest()) + 3600
+      const value = BigInt("1000")
+      const permitTypes = {
+        Permit: [
+          { name: "owner", type: "address" },
+          { name: "spender", type: "address" },
+          { name: "value", type: "uint256" },
+          { name: "nonce", type: "uint256" },
+          { name: "deadline", type: "uint256" },
+        ],
+      }
+      const signature = await owner.signTypedData(await domain(pcspToken), permitTypes, {
+        owner: owner.address, spender: addr1.address, value, nonce: 0, deadline,
+      })
+      const { v, r, s } = ethers.Signature.from(signature)
+
+      await pcspToken.connect(addr2).permit(owner.address, addr1.address, value, deadline, v, r, s)
+
+      expect(await pcspToken.allowance(owner.address, addr1.address)).to.equal(value)
+    })
+
+    it("Should transfer with authorization", async function () {
+      const { pcspToken, owner, addr1, addr2 } = await loadFixture(deployTokenFixture);
+
+      const message = {
+        from: owner.address,
+        to: addr1.address,
+        value: BigInt("1000"),
+        validAfter: 0,
+        validBefore: (await time.latest()) + 3600,
+        nonce: ethers.hexlify(ethers.randomBytes(32)),
+      }
+      const signature = await owner.signTypedData(await domain(pcspToken), transferTypes, message)
+      const { v, r, s } = ethers.Signature.from(signature)
+
+      await expect(
+        pcspToken.connect(addr2).transferWithAuthorization(
+          message.from, message.to, message.value, message.validAfter, message.validBefore, message.nonce, v, r, s
+        )
+      )
+        .to.emit(pcspToken, "AuthorizationUsed")
+        .withArgs(owner.address, message.nonce)
+
+      expect(await pcspToken.balanceOf(addr1.address)).to.equal(message.value)
+      expect(await pcspToken.authorizationState(owner.address, message.nonce)).to.equal(true)
+
+      await expect(
+        pcspToken.connect(addr2).transferWithAuthorization(
+          message.from, message.to, message.value, message.validAfter, message.validBefore, message.nonce, v, r, s
+        )
+      ).to.be.revertedWith("Authorization is used")
+    })
+
+    it("Should fail transfer signed by another account", async function () {
+      const { pcspToken, owner, addr1 } = await loadFixture(deployTokenFixture);
+
+      const message = {
+        from: owner.address,
+        to: addr1.address,
+        value: BigInt("1000"),
+        validAfter: 0,
+        validBefore: (await time.latest()) + 3600,
+        nonce: ethers.hexlify(ethers.randomBytes(32)),
+      }
+      const signature = await addr1.signTypedData(await domain(pcspToken), transferTypes, message)
+      const { v, r, s } = ethers.Signature.from(signature)
+
+      await expect(
+        pcspToken.transferWithAuthorization(
+          message.from, message.to, message.value, message.validAfter, message.validBefore, message.nonce, v, r, s
+        )
+      ).to.be.revertedWith("Invalid signature")
+    })
+
+    it("Should burn with authorization", async function () {
+      const { pcspToken, owner, addr2 } = await loadFixture(deployTokenFixture);
+
+      const supply = await pcspToken.totalSupply()
+      const message = {
+        from: owner.address,
+        value: BigInt("1000"),
+        validAfter: 0,
+        validBefore: (await time.latest()) + 3600,
+        nonce: ethers.hexlify(ethers.randomBytes(32)),
+      }
+      const signature = await owner.signTypedData(await domain(pcspToken), burnTypes, message)
+      const { v, r, s } = ethers.Signature.from(signature)
+
+      await pcspToken.connect(addr2).burnWithAuthorization(
+        message.from, message.value, message.validAfter, message.validBefore, message.nonce, v, r, s
+      )
+
+      expect(await pcspToken.totalSupply()).to.equal(supply - message.value)
+    })
+
+    it("Should fail expired authorization", async function () {
+      const { pcspToken, owner } = await loadFixture(deployTokenFixture);
 
+      const message = {
+        from: owner.address,
+        value: BigInt("1000"),
+        validAfter: 0,
+        validBefore: (await time.latest()) - 1,
+        nonce: ethers.hexlify(ethers.randomBytes(32)),
+      }
+      const signature = await owner.signTypedData(await domain(pcspToken), burnTypes, message)
+      const { v, r, s } = ethers.Signature.from(signature)
+
+      await expect(
+        pcspToken.burnWithAuthorization(
+          message.from, message.value, message.validAfter, message.validBefore, message.nonce, v, r, s
+        )
+      ).to.be.revertedWith("Authorization is expired")
+    })
+  })
 })
\ No newline at end of file
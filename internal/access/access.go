@@ -0,0 +1,246 @@
+package access
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	genomicCrypto "github.com/TropicalDog17/genomic-dao-service/pkg/crypto"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrInvalidRequest  = errors.New("invalid signed request")
+	ErrExpiredRequest  = errors.New("signed request expired")
+	ErrReplayedRequest = errors.New("signed request already used")
+	ErrAccessDenied    = errors.New("requester does not hold the GeneNFT")
+)
+
+const (
+	// DefaultCacheTTL bounds how long an ownership decision is reused, and so how long
+	// access may lag behind a GeneNFT transfer.
+	DefaultCacheTTL = 15 * time.Second
+	// RequestValidity is the accepted clock skew for a signed request timestamp.
+	RequestValidity = 5 * time.Minute
+
+	maxCacheEntries = 1024
+)
+
+// RetrieveMessage is the text a wallet signs with personal_sign to retrieve a file from the service at
+// domain, on chainID. A purpose, when given, is signed with it, as it goes into the audit log.
+func RetrieveMessage(domain string, chainID uint64, fileID string, purpose string, timestamp int64) string {
+	if purpose != "" {
+		return fmt.Sprintf("GenomicDAO retrieve request\nDomain: %s\nChain ID: %d\nFile ID: %s\nPurpose: %s\nTimestamp: %d", domain, chainID, fileID, purpose, timestamp)
+	}
+	return fmt.Sprintf("GenomicDAO retrieve request\nDomain: %s\nChain ID: %d\nFile ID: %s\nTimestamp: %d", domain, chainID, fileID, timestamp)
+}
+
+// ListFilesMessage is the text a wallet signs with personal_sign to list the files it uploaded to the service
+// at domain, on chainID. Each page is signed for, with the cursor it starts at, empty for the first.
+func ListFilesMessage(domain string, chainID uint64, cursor string, timestamp int64) string {
+	if cursor != "" {
+		return fmt.Sprintf("GenomicDAO list files request\nDomain: %s\nChain ID: %d\nCursor: %s\nTimestamp: %d", domain, chainID, cursor, timestamp)
+	}
+	return fmt.Sprintf("GenomicDAO list files request\nDomain: %s\nChain ID: %d\nTimestamp: %d", domain, chainID, timestamp)
+}
+
+// WithdrawMessage is the text a wallet signs with personal_sign to have the raw data of a file it
+// uploaded to the service at domain, on chainID, deleted.
+func WithdrawMessage(domain string, chainID uint64, fileID string, timestamp int64) string {
+	return fmt.Sprintf("GenomicDAO withdraw request\nDomain: %s\nChain ID: %d\nFile ID: %s\nTimestamp: %d", domain, chainID, fileID, timestamp)
+}
+
+// ExportMessage is the text a wallet signs with personal_sign to export its files from the service at domain,
+// on chainID, wrapped for recipient. The recipient is the hex public key given with the request, empty for
+// the service's own.
+func ExportMessage(domain string, chainID uint64, recipient string, timestamp int64) string {
+	return fmt.Sprintf("GenomicDAO export request\nDomain: %s\nChain ID: %d\nRecipient: %s\nTimestamp: %d", domain, chainID, recipient, timestamp)
+}
+
+// AccessLogMessage is the text a wallet signs with personal_sign to read who accessed its data on the service
+// at domain, on chainID. Each page is signed for, with the cursor it starts at, empty for the first.
+func AccessLogMessage(domain string, chainID uint64, cursor string, timestamp int64) string {
+	if cursor != "" {
+		return fmt.Sprintf("GenomicDAO access log request\nDomain: %s\nChain ID: %d\nCursor: %s\nTimestamp: %d", domain, chainID, cursor, timestamp)
+	}
+	return fmt.Sprintf("GenomicDAO access log request\nDomain: %s\nChain ID: %d\nTimestamp: %d", domain, chainID, timestamp)
+}
+
+type AccessService interface {
+	VerifyRetrieveRequest(fileID string, purpose string, address string, timestamp int64, signature string) (common.Address, error)
+	VerifyListFilesRequest(address string, cursor string, timestamp int64, signature string) (common.Address, error)
+	VerifyExportRequest(address string, recipient string, timestamp int64, signature string) (common.Address, error)
+	VerifyWithdrawRequest(fileID string, address string, timestamp int64, signature string) (common.Address, error)
+	VerifyAccessLogRequest(address string, cursor string, timestamp int64, signature string) (common.Address, error)
+	CheckTokenAccess(tokenID string, requester common.Address) error
+}
+
+type cacheEntry struct {
+	allowed   bool
+	expiresAt time.Time
+}
+
+type accessService struct {
+	onchainService onchain.OnchainService
+	cacheTTL       time.Duration
+	domain         string
+	chainID        uint64
+	now            func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+	// Signed requests already served, until their timestamp is out of RequestValidity
+	seen map[string]time.Time
+}
+
+// NewAccessService verifies requests signed for the service at domain, on chainID.
+func NewAccessService(onchainService onchain.OnchainService, cacheTTL time.Duration, domain string, chainID uint64) AccessService {
+	return &accessService{
+		onchainService: onchainService,
+		cacheTTL:       cacheTTL,
+		domain:         domain,
+		chainID:        chainID,
+		now:            time.Now,
+		cache:          make(map[string]cacheEntry),
+		seen:           make(map[string]time.Time),
+	}
+}
+
+// VerifyRetrieveRequest checks that the request was signed by address recently, and not used before,
+// and returns the signer.
+func (s *accessService) VerifyRetrieveRequest(fileID string, purpose string, address string, timestamp int64, signature string) (common.Address, error) {
+	if fileID == "" {
+		return common.Address{}, ErrInvalidRequest
+	}
+	return s.verifySigned(RetrieveMessage(s.domain, s.chainID, fileID, purpose, timestamp), address, timestamp, signature)
+}
+
+// VerifyListFilesRequest checks that the file listing from cursor was signed by address recently, and not
+// used before, and returns the signer.
+func (s *accessService) VerifyListFilesRequest(address string, cursor string, timestamp int64, signature string) (common.Address, error) {
+	return s.verifySigned(ListFilesMessage(s.domain, s.chainID, cursor, timestamp), address, timestamp, signature)
+}
+
+// VerifyExportRequest checks that the export to recipient was signed by address recently, and not used
+// before, and returns the signer.
+func (s *accessService) VerifyExportRequest(address string, recipient string, timestamp int64, signature string) (common.Address, error) {
+	return s.verifySigned(ExportMessage(s.domain, s.chainID, recipient, timestamp), address, timestamp, signature)
+}
+
+// VerifyWithdrawRequest checks that the withdrawal was signed by address recently, and not used before,
+// and returns the signer.
+func (s *accessService) VerifyWithdrawRequest(fileID string, address string, timestamp int64, signature string) (common.Address, error) {
+	if fileID == "" {
+		return common.Address{}, ErrInvalidRequest
+	}
+	return s.verifySigned(WithdrawMessage(s.domain, s.chainID, fileID, timestamp), address, timestamp, signature)
+}
+
+// VerifyAccessLogRequest checks that the access log from cursor was signed by address recently, and not
+// used before, and returns the signer.
+func (s *accessService) VerifyAccessLogRequest(address string, cursor string, timestamp int64, signature string) (common.Address, error) {
+	return s.verifySigned(AccessLogMessage(s.domain, s.chainID, cursor, timestamp), address, timestamp, signature)
+}
+
+// verifySigned checks that message was signed by address recently, and accepts each signed message once.
+func (s *accessService) verifySigned(message string, address string, timestamp int64, signature string) (common.Address, error) {
+	if !common.IsHexAddress(address) {
+		return common.Address{}, ErrInvalidRequest
+	}
+
+	age := s.now().Sub(time.Unix(timestamp, 0))
+	if age > RequestValidity || age < -RequestValidity {
+		return common.Address{}, ErrExpiredRequest
+	}
+
+	requester := common.HexToAddress(address)
+	if !genomicCrypto.VerifyPersonalSign(requester, []byte(message), signature) {
+		return common.Address{}, ErrInvalidRequest
+	}
+	if !s.markSeen(requester, message, timestamp) {
+		return common.Address{}, ErrReplayedRequest
+	}
+
+	return requester, nil
+}
+
+// markSeen records the signed message of requester, and reports false if it was already recorded.
+// The message, not the signature, is the key, as a signature can be made malleable.
+func (s *accessService) markSeen(requester common.Address, message string, timestamp int64) bool {
+	key := strings.ToLower(requester.Hex()) + "\n" + message
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Past RequestValidity the timestamp alone rejects the message
+	now := s.now()
+	for seenKey, expiresAt := range s.seen {
+		if now.After(expiresAt) {
+			delete(s.seen, seenKey)
+		}
+	}
+	if _, ok := s.seen[key]; ok {
+		return false
+	}
+	s.seen[key] = time.Unix(timestamp, 0).Add(RequestValidity)
+	return true
+}
+
+// CheckTokenAccess allows the current GeneNFT owner and its approved operators.
+func (s *accessService) CheckTokenAccess(tokenID string, requester common.Address) error {
+	key := tokenID + ":" + strings.ToLower(requester.Hex())
+
+	s.mu.Lock()
+	entry, ok := s.cache[key]
+	s.mu.Unlock()
+
+	if !ok || s.now().After(entry.expiresAt) {
+		allowed, err := s.isOwnerOrOperator(tokenID, requester)
+		if err != nil {
+			return err
+		}
+		entry = cacheEntry{allowed: allowed, expiresAt: s.now().Add(s.cacheTTL)}
+
+		s.mu.Lock()
+		s.pruneLocked()
+		s.cache[key] = entry
+		s.mu.Unlock()
+	}
+
+	if !entry.allowed {
+		return ErrAccessDenied
+	}
+	return nil
+}
+
+// pruneLocked drops expired decisions once the cache grows past maxCacheEntries.
+func (s *accessService) pruneLocked() {
+	if len(s.cache) < maxCacheEntries {
+		return
+	}
+	now := s.now()
+	for key, entry := range s.cache {
+		if now.After(entry.expiresAt) {
+			delete(s.cache, key)
+		}
+	}
+}
+
+func (s *accessService) isOwnerOrOperator(tokenID string, requester common.Address) (bool, error) {
+	owner, err := s.onchainService.GetTokenOwner(tokenID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check token owner: %w", err)
+	}
+	if owner == requester {
+		return true, nil
+	}
+
+	approved, err := s.onchainService.IsApprovedOperator(tokenID, owner, requester)
+	if err != nil {
+		return false, fmt.Errorf("failed to check token approval: %w", err)
+	}
+	return approved, nil
+}
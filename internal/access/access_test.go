@@ -0,0 +1,379 @@
+package access
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	testDomain  = "genomicdao.example"
+	testChainID = 9876
+)
+
+// Mock onchain service exposing only the GeneNFT ownership lookups
+type mockOnchainService struct {
+	onchain.OnchainService
+	owner      common.Address
+	operator   common.Address
+	err        error
+	ownerCalls int
+}
+
+func (m *mockOnchainService) GetTokenOwner(tokenID string) (common.Address, error) {
+	m.ownerCalls++
+	return m.owner, m.err
+}
+
+func (m *mockOnchainService) IsApprovedOperator(tokenID string, owner common.Address, operator common.Address) (bool, error) {
+	return operator == m.operator, m.err
+}
+
+func TestAccessService_VerifyRetrieveRequest(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	address := crypto.PubkeyToAddress(privKey.PublicKey)
+	now := time.Unix(1734280000, 0)
+
+	sign := func(fileID string, purpose string, timestamp int64) string {
+		sig, err := crypto.Sign(accounts.TextHash([]byte(RetrieveMessage(testDomain, testChainID, fileID, purpose, timestamp))), privKey)
+		if err != nil {
+			t.Fatalf("Failed to sign: %v", err)
+		}
+		// Wallets return V as 27/28
+		sig[crypto.RecoveryIDOffset] += 27
+		return hexutil.Encode(sig)
+	}
+
+	tests := []struct {
+		name      string
+		fileID    string
+		purpose   string
+		address   string
+		timestamp int64
+		signature string
+		wantErr   error
+	}{
+		{
+			name:      "Valid request",
+			fileID:    "file1",
+			address:   address.Hex(),
+			timestamp: now.Unix(),
+			signature: sign("file1", "", now.Unix()),
+		},
+		{
+			name:      "Valid request with a purpose",
+			fileID:    "file1",
+			purpose:   "clinical review",
+			address:   address.Hex(),
+			timestamp: now.Unix(),
+			signature: sign("file1", "clinical review", now.Unix()),
+		},
+		{
+			name:      "Purpose not signed",
+			fileID:    "file1",
+			purpose:   "clinical review",
+			address:   address.Hex(),
+			timestamp: now.Unix(),
+			signature: sign("file1", "", now.Unix()),
+			wantErr:   ErrInvalidRequest,
+		},
+		{
+			name:      "Signed for another file",
+			fileID:    "file1",
+			address:   address.Hex(),
+			timestamp: now.Unix(),
+			signature: sign("file2", "", now.Unix()),
+			wantErr:   ErrInvalidRequest,
+		},
+		{
+			name:      "Claimed address is not the signer",
+			fileID:    "file1",
+			address:   "0x1234567890123456789012345678901234567890",
+			timestamp: now.Unix(),
+			signature: sign("file1", "", now.Unix()),
+			wantErr:   ErrInvalidRequest,
+		},
+		{
+			name:      "Expired timestamp",
+			fileID:    "file1",
+			address:   address.Hex(),
+			timestamp: now.Add(-time.Hour).Unix(),
+			signature: sign("file1", "", now.Add(-time.Hour).Unix()),
+			wantErr:   ErrExpiredRequest,
+		},
+		{
+			name:      "Malformed signature",
+			fileID:    "file1",
+			address:   address.Hex(),
+			timestamp: now.Unix(),
+			signature: "0x1234",
+			wantErr:   ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewAccessService(&mockOnchainService{}, DefaultCacheTTL, testDomain, testChainID).(*accessService)
+			service.now = func() time.Time { return now }
+
+			requester, err := service.VerifyRetrieveRequest(tt.fileID, tt.purpose, tt.address, tt.timestamp, tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyRetrieveRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && requester != address {
+				t.Errorf("Expected requester %s, got %s", address.Hex(), requester.Hex())
+			}
+		})
+	}
+}
+
+func TestAccessService_VerifyRetrieveRequestReplay(t *testing.T) {
+	privKey, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(privKey.PublicKey)
+	now := time.Unix(1734280000, 0)
+	service := NewAccessService(&mockOnchainService{}, DefaultCacheTTL, testDomain, testChainID).(*accessService)
+	service.now = func() time.Time { return now }
+
+	sign := func(message string) string {
+		sig, _ := crypto.Sign(accounts.TextHash([]byte(message)), privKey)
+		sig[crypto.RecoveryIDOffset] += 27
+		return hexutil.Encode(sig)
+	}
+
+	signature := sign(RetrieveMessage(testDomain, testChainID, "file1", "", now.Unix()))
+	if _, err := service.VerifyRetrieveRequest("file1", "", address.Hex(), now.Unix(), signature); err != nil {
+		t.Fatalf("VerifyRetrieveRequest() error = %v", err)
+	}
+	// The same signature is refused while its timestamp is still valid
+	now = now.Add(RequestValidity / 2)
+	if _, err := service.VerifyRetrieveRequest("file1", "", address.Hex(), now.Add(-RequestValidity/2).Unix(), signature); !errors.Is(err, ErrReplayedRequest) {
+		t.Errorf("VerifyRetrieveRequest() error = %v, want %v", err, ErrReplayedRequest)
+	}
+	// and expired afterwards, when it is no longer remembered
+	now = now.Add(RequestValidity)
+	if _, err := service.VerifyRetrieveRequest("file1", "", address.Hex(), now.Add(-3*RequestValidity/2).Unix(), signature); !errors.Is(err, ErrExpiredRequest) {
+		t.Errorf("VerifyRetrieveRequest() error = %v, want %v", err, ErrExpiredRequest)
+	}
+	if _, err := service.VerifyRetrieveRequest("file1", "", address.Hex(), now.Unix(), sign(RetrieveMessage(testDomain, testChainID, "file1", "", now.Unix()))); err != nil {
+		t.Fatalf("VerifyRetrieveRequest() error = %v", err)
+	}
+	if len(service.seen) != 1 {
+		t.Errorf("Expected expired requests to be forgotten, %d remembered", len(service.seen))
+	}
+
+	// A request signed for another deployment or chain is refused
+	for _, message := range []string{
+		RetrieveMessage("other.example", testChainID, "file1", "", now.Unix()),
+		RetrieveMessage(testDomain, testChainID+1, "file1", "", now.Unix()),
+	} {
+		if _, err := service.VerifyRetrieveRequest("file1", "", address.Hex(), now.Unix(), sign(message)); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("VerifyRetrieveRequest() error = %v, want %v", err, ErrInvalidRequest)
+		}
+	}
+}
+
+func TestAccessService_VerifyListFilesRequest(t *testing.T) {
+	privKey, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(privKey.PublicKey)
+	now := time.Unix(1734280000, 0)
+	service := NewAccessService(&mockOnchainService{}, DefaultCacheTTL, testDomain, testChainID).(*accessService)
+	service.now = func() time.Time { return now }
+
+	sign := func(message string) string {
+		sig, _ := crypto.Sign(accounts.TextHash([]byte(message)), privKey)
+		sig[crypto.RecoveryIDOffset] += 27
+		return hexutil.Encode(sig)
+	}
+
+	signature := sign(ListFilesMessage(testDomain, testChainID, "", now.Unix()))
+	requester, err := service.VerifyListFilesRequest(address.Hex(), "", now.Unix(), signature)
+	if err != nil || requester != address {
+		t.Errorf("VerifyListFilesRequest() = %s, %v, want %s", requester.Hex(), err, address.Hex())
+	}
+	if _, err := service.VerifyListFilesRequest(address.Hex(), "", now.Unix(), signature); !errors.Is(err, ErrReplayedRequest) {
+		t.Errorf("VerifyListFilesRequest() error = %v, want %v", err, ErrReplayedRequest)
+	}
+	// Each page is signed with its cursor
+	if _, err := service.VerifyListFilesRequest(address.Hex(), "next", now.Unix(), signature); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("VerifyListFilesRequest() error = %v, want %v", err, ErrInvalidRequest)
+	}
+	if _, err := service.VerifyListFilesRequest(address.Hex(), "next", now.Unix(), sign(ListFilesMessage(testDomain, testChainID, "next", now.Unix()))); err != nil {
+		t.Errorf("VerifyListFilesRequest() error = %v", err)
+	}
+	// A retrieve signature, or one for another deployment or chain, does not list files
+	for _, message := range []string{
+		RetrieveMessage(testDomain, testChainID, "file1", "", now.Unix()),
+		ListFilesMessage("other.example", testChainID, "", now.Unix()),
+		ListFilesMessage(testDomain, testChainID+1, "", now.Unix()),
+	} {
+		if _, err := service.VerifyListFilesRequest(address.Hex(), "", now.Unix(), sign(message)); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("VerifyListFilesRequest() error = %v, want %v", err, ErrInvalidRequest)
+		}
+	}
+}
+
+func TestAccessService_VerifyExportRequest(t *testing.T) {
+	privKey, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(privKey.PublicKey)
+	now := time.Unix(1734280000, 0)
+	service := NewAccessService(&mockOnchainService{}, DefaultCacheTTL, testDomain, testChainID).(*accessService)
+	service.now = func() time.Time { return now }
+	recipient := hexutil.Encode(crypto.FromECDSAPub(&privKey.PublicKey))
+
+	sign := func(message string) string {
+		sig, _ := crypto.Sign(accounts.TextHash([]byte(message)), privKey)
+		sig[crypto.RecoveryIDOffset] += 27
+		return hexutil.Encode(sig)
+	}
+
+	signature := sign(ExportMessage(testDomain, testChainID, recipient, now.Unix()))
+	requester, err := service.VerifyExportRequest(address.Hex(), recipient, now.Unix(), signature)
+	if err != nil || requester != address {
+		t.Errorf("VerifyExportRequest() = %s, %v, want %s", requester.Hex(), err, address.Hex())
+	}
+	// The export is not repeated with the same signature
+	if _, err := service.VerifyExportRequest(address.Hex(), recipient, now.Unix(), signature); !errors.Is(err, ErrReplayedRequest) {
+		t.Errorf("VerifyExportRequest() error = %v, want %v", err, ErrReplayedRequest)
+	}
+	// The signature does not export to another recipient
+	if _, err := service.VerifyExportRequest(address.Hex(), "", now.Unix(), signature); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("VerifyExportRequest() error = %v, want %v", err, ErrInvalidRequest)
+	}
+	for _, message := range []string{
+		ListFilesMessage(testDomain, testChainID, "", now.Unix()),
+		ExportMessage("other.example", testChainID, "", now.Unix()),
+		ExportMessage(testDomain, testChainID+1, "", now.Unix()),
+	} {
+		if _, err := service.VerifyExportRequest(address.Hex(), "", now.Unix(), sign(message)); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("VerifyExportRequest() error = %v, want %v", err, ErrInvalidRequest)
+		}
+	}
+}
+
+func TestAccessService_VerifyWithdrawRequest(t *testing.T) {
+	privKey, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(privKey.PublicKey)
+	now := time.Unix(1734280000, 0)
+	service := NewAccessService(&mockOnchainService{}, DefaultCacheTTL, testDomain, testChainID).(*accessService)
+	service.now = func() time.Time { return now }
+
+	sign := func(message string) string {
+		sig, _ := crypto.Sign(accounts.TextHash([]byte(message)), privKey)
+		sig[crypto.RecoveryIDOffset] += 27
+		return hexutil.Encode(sig)
+	}
+
+	signature := sign(WithdrawMessage(testDomain, testChainID, "file1", now.Unix()))
+	requester, err := service.VerifyWithdrawRequest("file1", address.Hex(), now.Unix(), signature)
+	if err != nil || requester != address {
+		t.Errorf("VerifyWithdrawRequest() = %s, %v, want %s", requester.Hex(), err, address.Hex())
+	}
+	// The withdrawal is not repeated with the same signature
+	if _, err := service.VerifyWithdrawRequest("file1", address.Hex(), now.Unix(), signature); !errors.Is(err, ErrReplayedRequest) {
+		t.Errorf("VerifyWithdrawRequest() error = %v, want %v", err, ErrReplayedRequest)
+	}
+	if _, err := service.VerifyWithdrawRequest("file2", address.Hex(), now.Unix(), signature); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("VerifyWithdrawRequest() error = %v, want %v", err, ErrInvalidRequest)
+	}
+	// A retrieve signature, or one for another deployment or chain, does not withdraw the file
+	for _, message := range []string{
+		RetrieveMessage(testDomain, testChainID, "file2", "", now.Unix()),
+		WithdrawMessage("other.example", testChainID, "file2", now.Unix()),
+		WithdrawMessage(testDomain, testChainID+1, "file2", now.Unix()),
+	} {
+		if _, err := service.VerifyWithdrawRequest("file2", address.Hex(), now.Unix(), sign(message)); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("VerifyWithdrawRequest() error = %v, want %v", err, ErrInvalidRequest)
+		}
+	}
+}
+
+func TestAccessService_VerifyAccessLogRequest(t *testing.T) {
+	privKey, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(privKey.PublicKey)
+	now := time.Unix(1734280000, 0)
+	service := NewAccessService(&mockOnchainService{}, DefaultCacheTTL, testDomain, testChainID).(*accessService)
+	service.now = func() time.Time { return now }
+
+	sign := func(message string) string {
+		sig, _ := crypto.Sign(accounts.TextHash([]byte(message)), privKey)
+		sig[crypto.RecoveryIDOffset] += 27
+		return hexutil.Encode(sig)
+	}
+
+	signature := sign(AccessLogMessage(testDomain, testChainID, "", now.Unix()))
+	requester, err := service.VerifyAccessLogRequest(address.Hex(), "", now.Unix(), signature)
+	if err != nil || requester != address {
+		t.Errorf("VerifyAccessLogRequest() = %s, %v, want %s", requester.Hex(), err, address.Hex())
+	}
+	if _, err := service.VerifyAccessLogRequest(address.Hex(), "", now.Unix(), signature); !errors.Is(err, ErrReplayedRequest) {
+		t.Errorf("VerifyAccessLogRequest() error = %v, want %v", err, ErrReplayedRequest)
+	}
+	if _, err := service.VerifyAccessLogRequest(address.Hex(), "next", now.Unix(), sign(AccessLogMessage(testDomain, testChainID, "next", now.Unix()))); err != nil {
+		t.Errorf("VerifyAccessLogRequest() error = %v", err)
+	}
+	// A file listing signature, or one for another deployment or chain, does not read the access log
+	for _, message := range []string{
+		ListFilesMessage(testDomain, testChainID, "", now.Unix()),
+		AccessLogMessage("other.example", testChainID, "", now.Unix()),
+		AccessLogMessage(testDomain, testChainID+1, "", now.Unix()),
+	} {
+		if _, err := service.VerifyAccessLogRequest(address.Hex(), "", now.Unix(), sign(message)); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("VerifyAccessLogRequest() error = %v, want %v", err, ErrInvalidRequest)
+		}
+	}
+}
+
+func TestAccessService_CheckTokenAccess(t *testing.T) {
+	owner := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	operator := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	stranger := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	mock := &mockOnchainService{owner: owner, operator: operator}
+	now := time.Unix(1734280000, 0)
+	service := NewAccessService(mock, DefaultCacheTTL, testDomain, testChainID).(*accessService)
+	service.now = func() time.Time { return now }
+
+	if err := service.CheckTokenAccess("1", owner); err != nil {
+		t.Errorf("Owner denied: %v", err)
+	}
+	if err := service.CheckTokenAccess("1", operator); err != nil {
+		t.Errorf("Approved operator denied: %v", err)
+	}
+	if err := service.CheckTokenAccess("1", stranger); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Expected ErrAccessDenied for stranger, got %v", err)
+	}
+
+	// Decisions are cached within the TTL
+	calls := mock.ownerCalls
+	if err := service.CheckTokenAccess("1", owner); err != nil {
+		t.Errorf("Owner denied: %v", err)
+	}
+	if mock.ownerCalls != calls {
+		t.Errorf("Expected cached decision, got %d extra owner lookups", mock.ownerCalls-calls)
+	}
+
+	// Access follows the token once the cache expires
+	mock.owner = stranger
+	now = now.Add(DefaultCacheTTL + time.Second)
+	if err := service.CheckTokenAccess("1", owner); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Expected ErrAccessDenied for previous owner, got %v", err)
+	}
+	if err := service.CheckTokenAccess("1", stranger); err != nil {
+		t.Errorf("New owner denied: %v", err)
+	}
+
+	// Lookup failures are not cached as denials
+	mock.err = errors.New("rpc down")
+	if err := service.CheckTokenAccess("2", owner); err == nil || errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Expected lookup error, got %v", err)
+	}
+}
@@ -0,0 +1,315 @@
+package anchor
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/TropicalDog17/genomic-dao-service/pkg/merkle"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrFileNotFound   = errors.New("file not found")
+	ErrNotAnchored    = errors.New("file content hash is not anchored in a Merkle root")
+	ErrAwaitingAnchor = errors.New("file content hash is waiting for the next anchor")
+	ErrInvalidHash    = errors.New("content hash must be 32 bytes of hex")
+	ErrRootMismatch   = errors.New("anchor leaves do not rebuild the recorded root")
+
+	errNothingToAnchor = errors.New("no content hashes to anchor")
+)
+
+// DefaultWindow is how often queued content hashes are anchored when no window is configured.
+const DefaultWindow = time.Hour
+
+// Leaves encode as abi.encode(string docId, bytes32 contentHash)
+var leafArguments = abi.Arguments{{Type: mustType("string")}, {Type: mustType("bytes32")}}
+
+// Proof shows that a file's content hash is in a Merkle root anchored on the Controller. It can be
+// checked with the Controller's verifyAnchored, or with OpenZeppelin's MerkleProof against Root.
+type Proof struct {
+	FileID      string   `json:"fileId"`
+	DocID       string   `json:"docId" example:"0b1c2d3e-..."`
+	ContentHash string   `json:"contentHash" example:"0x8f3c..."`
+	Leaf        string   `json:"leaf" example:"0x51a2..."`
+	LeafIndex   int      `json:"leafIndex" example:"3"`
+	Proof       []string `json:"proof"`
+	Root        string   `json:"root" example:"0x9d7e..."`
+	LeafCount   int      `json:"leafCount" example:"120"`
+	// pending until the root is mined
+	Status      string `json:"status" example:"anchored"`
+	TxHash      string `json:"txHash,omitempty"`
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+	Controller  string `json:"controller"`
+}
+
+type AnchorService interface {
+	AddLeaf(docID string, contentHash string) error
+	AnchorPending() (*storage.ContentAnchor, error)
+	GetProof(fileID string) (*Proof, error)
+	Run(ctx context.Context, window time.Duration)
+}
+
+type anchorService struct {
+	transactor             *onchain.Transactor
+	controllerAddr         common.Address
+	controller             *contracts.Controller
+	geneDataStorageService storage.GeneDataStorageService
+	repository             storage.AnchorRepository
+}
+
+func NewAnchorService(transactor *onchain.Transactor, controllerAddr common.Address, geneDataStorageService storage.GeneDataStorageService, repository storage.AnchorRepository) AnchorService {
+	controller, err := contracts.NewController(controllerAddr, transactor.Backend())
+	if err != nil {
+		panic(err)
+	}
+
+	return &anchorService{
+		transactor:             transactor,
+		controllerAddr:         controllerAddr,
+		controller:             controller,
+		geneDataStorageService: geneDataStorageService,
+		repository:             repository,
+	}
+}
+
+// Leaf hashes a doc's content hash the way the Controller's verifyAnchored does:
+// keccak256(keccak256(abi.encode(docId, contentHash))), hashed twice so no leaf is an inner node.
+func Leaf(docID string, contentHash common.Hash) common.Hash {
+	encoded, err := leafArguments.Pack(docID, contentHash)
+	if err != nil {
+		panic(err)
+	}
+	return crypto.Keccak256Hash(crypto.Keccak256(encoded))
+}
+
+// AddLeaf queues the content hash of a doc for the next anchor.
+func (s *anchorService) AddLeaf(docID string, contentHash string) error {
+	if _, err := parseHash(contentHash); err != nil {
+		return err
+	}
+	if err := s.repository.AddLeaf(&storage.AnchorLeaf{DocID: docID, ContentHash: contentHash}); err != nil {
+		return fmt.Errorf("failed to queue content hash: %w", err)
+	}
+	return nil
+}
+
+// AnchorPending first retries the anchors left unmined, then anchors the root of every queued
+// content hash in one transaction. It returns the new anchor, or nil when nothing was queued.
+func (s *anchorService) AnchorPending() (*storage.ContentAnchor, error) {
+	anchors, err := s.repository.FindPendingAnchors()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending anchors: %w", err)
+	}
+	for i := range anchors {
+		if err := s.submit(&anchors[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	leaves, err := s.repository.FindPendingLeaves()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find queued content hashes: %w", err)
+	}
+	tree, err := buildTree(leaves)
+	if errors.Is(err, errNothingToAnchor) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The tree is recorded before it is sent, so a crash leaves a pending anchor to retry
+	anchor := &storage.ContentAnchor{
+		Root:      tree.Root().Hex(),
+		LeafCount: tree.Len(),
+		Status:    storage.AnchorPending,
+	}
+	if err := s.repository.CreateAnchor(anchor, leaves); err != nil {
+		return nil, fmt.Errorf("failed to record anchor: %w", err)
+	}
+	if err := s.submit(anchor); err != nil {
+		return nil, err
+	}
+	return anchor, nil
+}
+
+// submit anchors the root on-chain, or records the anchor mined before the last shutdown.
+func (s *anchorService) submit(anchor *storage.ContentAnchor) error {
+	root := common.HexToHash(anchor.Root)
+	anchoredAt, err := s.controller.AnchoredRoots(nil, root)
+	if err != nil {
+		return fmt.Errorf("failed to look up anchored root: %v", err)
+	}
+
+	if anchoredAt.Sign() == 0 {
+		opts, err := s.transactor.Opts()
+		if err != nil {
+			return err
+		}
+		tx, err := s.controller.AnchorRoot(opts, root, big.NewInt(int64(anchor.LeafCount)))
+		if err != nil {
+			return fmt.Errorf("failed to anchor root: %v", err)
+		}
+		result, err := s.transactor.WaitForTx(tx)
+		if err != nil {
+			return err
+		}
+		if result.Status != onchain.TxStatusSuccess {
+			return fmt.Errorf("anchoring root %s reverted: %s", anchor.Root, result.TxHash)
+		}
+		anchor.TxHash = result.TxHash
+		anchor.BlockNumber = result.BlockNumber
+	} else {
+		block := anchoredAt.Uint64()
+		events, err := s.controller.FilterRootAnchored(&bind.FilterOpts{Start: block, End: &block}, [][32]byte{root})
+		if err != nil {
+			return fmt.Errorf("failed to find anchor transaction: %v", err)
+		}
+		for events.Next() {
+			anchor.TxHash = events.Event.Raw.TxHash.Hex()
+		}
+		events.Close()
+		anchor.BlockNumber = block
+	}
+
+	anchor.Status = storage.AnchorAnchored
+	if err := s.repository.SaveAnchor(anchor); err != nil {
+		return fmt.Errorf("failed to save anchor: %w", err)
+	}
+	log.Printf("anchored %d content hashes in root %s", anchor.LeafCount, anchor.Root)
+	return nil
+}
+
+// GetProof rebuilds the tree of the anchor holding the file's content hash and returns its inclusion proof.
+func (s *anchorService) GetProof(fileID string) (*Proof, error) {
+	record, err := s.geneDataStorageService.FindByFileID(fileID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrFileNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find gene data: %w", err)
+	}
+	if record.DocID == "" {
+		return nil, ErrNotAnchored
+	}
+
+	leaf, err := s.repository.FindLeaf(record.DocID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotAnchored
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find content hash: %w", err)
+	}
+	if leaf.AnchorID == nil {
+		return nil, ErrAwaitingAnchor
+	}
+
+	anchor, err := s.repository.FindAnchor(*leaf.AnchorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find anchor: %w", err)
+	}
+	leaves, err := s.repository.FindAnchorLeaves(anchor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find anchor leaves: %w", err)
+	}
+	tree, err := buildTree(leaves)
+	if err != nil {
+		return nil, err
+	}
+	if tree.Root().Hex() != anchor.Root {
+		return nil, ErrRootMismatch
+	}
+
+	path, err := tree.Proof(leaf.LeafIndex)
+	if err != nil {
+		return nil, err
+	}
+	proof := make([]string, len(path))
+	for i, hash := range path {
+		proof[i] = hash.Hex()
+	}
+
+	contentHash, _ := parseHash(leaf.ContentHash)
+	return &Proof{
+		FileID:      record.FileID,
+		DocID:       leaf.DocID,
+		ContentHash: contentHash.Hex(),
+		Leaf:        Leaf(leaf.DocID, contentHash).Hex(),
+		LeafIndex:   leaf.LeafIndex,
+		Proof:       proof,
+		Root:        anchor.Root,
+		LeafCount:   anchor.LeafCount,
+		Status:      anchor.Status,
+		TxHash:      anchor.TxHash,
+		BlockNumber: anchor.BlockNumber,
+		Controller:  s.controllerAddr.Hex(),
+	}, nil
+}
+
+// Run anchors the queued content hashes once per window until ctx is done.
+func (s *anchorService) Run(ctx context.Context, window time.Duration) {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	ticker := time.NewTicker(window)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.AnchorPending(); err != nil {
+			log.Printf("content anchoring failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func buildTree(leaves []storage.AnchorLeaf) (*merkle.Tree, error) {
+	if len(leaves) == 0 {
+		return nil, errNothingToAnchor
+	}
+
+	hashes := make([]common.Hash, len(leaves))
+	for i, leaf := range leaves {
+		contentHash, err := parseHash(leaf.ContentHash)
+		if err != nil {
+			return nil, fmt.Errorf("doc %s: %w", leaf.DocID, err)
+		}
+		hashes[i] = Leaf(leaf.DocID, contentHash)
+	}
+	return merkle.New(hashes)
+}
+
+// parseHash reads a content hash as stored, hex with or without 0x.
+func parseHash(contentHash string) (common.Hash, error) {
+	if len(contentHash) >= 2 && contentHash[:2] == "0x" {
+		contentHash = contentHash[2:]
+	}
+	decoded, err := hex.DecodeString(contentHash)
+	if err != nil || len(decoded) != common.HashLength {
+		return common.Hash{}, ErrInvalidHash
+	}
+	return common.BytesToHash(decoded), nil
+}
+
+func mustType(name string) abi.Type {
+	t, err := abi.NewType(name, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
@@ -0,0 +1,142 @@
+package anchor
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/TropicalDog17/genomic-dao-service/pkg/merkle"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+// Mock onchain service recording the confirmations it is sent
+type mockOnchainService struct {
+	onchain.OnchainService
+	confirmed []onchain.ConfirmRequest
+}
+
+func (m *mockOnchainService) ConfirmUpload(docID string, contentHash string, proof string, sessionID string, riskScore int) (*onchain.ConfirmResult, error) {
+	m.confirmed = append(m.confirmed, onchain.ConfirmRequest{DocID: docID, ContentHash: contentHash})
+	return &onchain.ConfirmResult{TokenID: "1"}, nil
+}
+
+func newTestService(t *testing.T, db *gorm.DB) *anchorService {
+	blobs, err := blobstore.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to open blob store: %v", err)
+	}
+	return &anchorService{
+		geneDataStorageService: storage.NewGeneDataStorageService(db, blobs),
+		repository:             storage.NewAnchorRepository(db),
+	}
+}
+
+func contentHash(i int) string {
+	return crypto.Keccak256Hash([]byte(fmt.Sprintf("ciphertext%d", i))).Hex()[2:]
+}
+
+func TestLeaf(t *testing.T) {
+	hash := crypto.Keccak256Hash([]byte("ciphertext"))
+
+	// abi.encode(string, bytes32): string offset, the hash, then the length-prefixed, padded string
+	encoded := append(math.U256Bytes(big.NewInt(64)), hash.Bytes()...)
+	encoded = append(encoded, math.U256Bytes(big.NewInt(4))...)
+	encoded = append(encoded, common.RightPadBytes([]byte("doc1"), 32)...)
+	want := crypto.Keccak256Hash(crypto.Keccak256(encoded))
+
+	if got := Leaf("doc1", hash); got != want {
+		t.Errorf("Leaf() = %s, want %s", got, want)
+	}
+}
+
+func TestWithAnchoring(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		service := newTestService(t, db)
+		chain := &mockOnchainService{}
+		anchoring := WithAnchoring(chain, service)
+
+		if _, err := anchoring.ConfirmUpload("doc1", contentHash(1), "0x1234", "1", 2); err != nil {
+			t.Fatalf("ConfirmUpload() error = %v", err)
+		}
+		if _, err := anchoring.ConfirmUpload("doc2", "not a hash", "0x1234", "2", 2); !errors.Is(err, ErrInvalidHash) {
+			t.Errorf("ConfirmUpload() error = %v, want %v", err, ErrInvalidHash)
+		}
+
+		if len(chain.confirmed) != 1 || chain.confirmed[0].ContentHash != "" {
+			t.Errorf("confirmed %+v, want doc1 without a content hash", chain.confirmed)
+		}
+		leaves, _ := service.repository.FindPendingLeaves()
+		if len(leaves) != 1 || leaves[0].DocID != "doc1" || leaves[0].ContentHash != contentHash(1) {
+			t.Errorf("queued %+v, want the content hash of doc1", leaves)
+		}
+	})
+}
+
+func TestAnchorService_GetProof(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		service := newTestService(t, db)
+
+		// Five files were anchored together; f6 is queued and f7 kept its hash on-chain
+		for i := 1; i <= 7; i++ {
+			db.Create(&storage.GeneData{FileID: fmt.Sprintf("f%d", i), DocID: fmt.Sprintf("doc%d", i)})
+			if i <= 6 {
+				service.AddLeaf(fmt.Sprintf("doc%d", i), contentHash(i))
+			}
+			if i == 5 {
+				leaves, _ := service.repository.FindPendingLeaves()
+				tree, _ := buildTree(leaves)
+				anchor := &storage.ContentAnchor{Root: tree.Root().Hex(), LeafCount: tree.Len(), Status: storage.AnchorAnchored, TxHash: "0xabc"}
+				if err := service.repository.CreateAnchor(anchor, leaves); err != nil {
+					t.Fatalf("CreateAnchor() error = %v", err)
+				}
+			}
+		}
+		db.Create(&storage.GeneData{FileID: "unconfirmed"})
+
+		tests := []struct {
+			name      string
+			fileID    string
+			wantIndex int
+			wantErr   error
+		}{
+			{name: "First leaf", fileID: "f1", wantIndex: 0},
+			{name: "Leaf without a sibling", fileID: "f5", wantIndex: 4},
+			{name: "Waiting for the next anchor", fileID: "f6", wantErr: ErrAwaitingAnchor},
+			{name: "Content hash stored on-chain", fileID: "f7", wantErr: ErrNotAnchored},
+			{name: "Upload not confirmed", fileID: "unconfirmed", wantErr: ErrNotAnchored},
+			{name: "Unknown file", fileID: "missing", wantErr: ErrFileNotFound},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				proof, err := service.GetProof(tt.fileID)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetProof() error = %v, want %v", err, tt.wantErr)
+				}
+				if err != nil {
+					return
+				}
+
+				if proof.LeafIndex != tt.wantIndex || proof.LeafCount != 5 || proof.TxHash != "0xabc" {
+					t.Errorf("GetProof() = %+v", proof)
+				}
+				path := make([]common.Hash, len(proof.Proof))
+				for i, hash := range proof.Proof {
+					path[i] = common.HexToHash(hash)
+				}
+				leaf := Leaf(proof.DocID, common.HexToHash(proof.ContentHash))
+				if leaf.Hex() != proof.Leaf || !merkle.Verify(common.HexToHash(proof.Root), leaf, path) {
+					t.Errorf("GetProof() returned a proof that does not verify: %+v", proof)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package anchor
+
+import (
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+)
+
+// WithAnchoring returns service with confirmations sent without their content hash, which is
+// queued for the next anchored Merkle root instead of being stored on the Controller. The hash is
+// queued before the confirmation, so a doc confirmed before a crash is never left unanchored.
+func WithAnchoring(service onchain.OnchainService, anchorService AnchorService) onchain.OnchainService {
+	return &anchoringService{OnchainService: service, anchorService: anchorService}
+}
+
+type anchoringService struct {
+	onchain.OnchainService
+	anchorService AnchorService
+}
+
+func (s *anchoringService) ConfirmUpload(docID string, contentHash string, proof string, sessionID string, riskScore int) (*onchain.ConfirmResult, error) {
+	if err := s.anchorService.AddLeaf(docID, contentHash); err != nil {
+		return nil, err
+	}
+	return s.OnchainService.ConfirmUpload(docID, "", proof, sessionID, riskScore)
+}
+
+func (s *anchoringService) ConfirmBatch(requests []onchain.ConfirmRequest) ([]onchain.ConfirmBatchResult, error) {
+	unhashed := make([]onchain.ConfirmRequest, len(requests))
+	for i, request := range requests {
+		if err := s.anchorService.AddLeaf(request.DocID, request.ContentHash); err != nil {
+			return nil, err
+		}
+		request.ContentHash = ""
+		unhashed[i] = request
+	}
+	return s.OnchainService.ConfirmBatch(unhashed)
+}
@@ -0,0 +1,309 @@
+package audit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	genomicCrypto "github.com/TropicalDog17/genomic-dao-service/pkg/crypto"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultSignInterval is how often the head of the log is signed when no interval is configured.
+	DefaultSignInterval = 10 * time.Minute
+	// MaxPageSize bounds the events of one page of an access log.
+	MaxPageSize = 100
+
+	batchSize = 500
+)
+
+// HeadMessage is the text the service wallet signs with personal_sign for the head of the log.
+func HeadMessage(seq uint64, hash string) string {
+	return fmt.Sprintf("GenomicDAO audit head\nSeq: %d\nHash: %s", seq, hash)
+}
+
+// AnchorDocID is the doc ID a signed head is anchored under.
+func AnchorDocID(seq uint64) string {
+	return fmt.Sprintf("audit:%d", seq)
+}
+
+// Entry is one access to the data of a user, as shown to the user.
+type Entry struct {
+	Seq     uint64    `json:"seq" example:"42"`
+	At      time.Time `json:"at"`
+	Actor   string    `json:"actor" example:"0x2222222222222222222222222222222222222222"`
+	Action  string    `json:"action" example:"retrieve"`
+	FileID  string    `json:"fileId" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Purpose string    `json:"purpose,omitempty" example:"clinical review"`
+	Outcome string    `json:"outcome" example:"allowed"`
+	Hash    string    `json:"hash" example:"0x5f7c..."`
+}
+
+// Page is one page of an access log, newest first; NextCursor is empty on the last one.
+type Page struct {
+	Events     []Entry `json:"events"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+}
+
+// Problem is a break in the log found by Verify, at the event or signed head Seq.
+type Problem struct {
+	Seq   uint64 `json:"seq"`
+	Issue string `json:"issue"`
+}
+
+// Report is the result of verifying the log.
+type Report struct {
+	Events   int       `json:"events"`
+	Heads    int       `json:"heads"`
+	Problems []Problem `json:"problems"`
+}
+
+type AuditService interface {
+	Record(event *storage.AuditEvent) error
+	ListForUser(address string, cursor string, limit int) (*Page, error)
+	SignHead() (*storage.AuditHead, error)
+	Verify(signer common.Address) (*Report, error)
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type auditService struct {
+	repository  storage.AuditRepository
+	anchors     storage.AnchorRepository
+	authService auth.AuthService
+	privKey     *ecdsa.PrivateKey
+	now         func() time.Time
+}
+
+// NewAuditService creates the service keeping the audit log, whose heads privKey signs. With
+// anchors set, every signed head is also queued for the next content anchor, and Verify checks the
+// queued hashes.
+func NewAuditService(repository storage.AuditRepository, authService auth.AuthService, privKey *ecdsa.PrivateKey, anchors storage.AnchorRepository) AuditService {
+	return &auditService{
+		repository:  repository,
+		anchors:     anchors,
+		authService: authService,
+		privKey:     privKey,
+		now:         time.Now,
+	}
+}
+
+// Record appends an access to the log. A caller about to release data must not release it when
+// Record fails, so that no access goes unrecorded.
+func (s *auditService) Record(event *storage.AuditEvent) error {
+	event.CreatedAt = s.now()
+	if err := s.repository.Append(event); err != nil {
+		log.Printf("audit: failed to record %s of file %s by %s: %v", event.Action, event.FileID, event.Actor, err)
+		return err
+	}
+	return nil
+}
+
+// ListForUser returns one page of the accesses to the data of the user registered with address,
+// newest first. The cursor is the NextCursor of the previous page.
+func (s *auditService) ListForUser(address string, cursor string, limit int) (*Page, error) {
+	user, err := s.authService.Authenticate(address)
+	if err != nil {
+		return nil, err
+	}
+	var beforeSeq uint64
+	if cursor != "" {
+		beforeSeq, err = strconv.ParseUint(cursor, 10, 64)
+		if err != nil || beforeSeq == 0 {
+			return nil, storage.ErrInvalidCursor
+		}
+	}
+	if limit < 1 || limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	// One more than asked for tells whether a next page exists
+	events, err := s.repository.FindUserEvents(user.ID, beforeSeq, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access log: %w", err)
+	}
+	page := &Page{Events: make([]Entry, 0, len(events))}
+	if len(events) > limit {
+		events = events[:limit]
+		page.NextCursor = strconv.FormatUint(events[limit-1].Seq, 10)
+	}
+	for _, event := range events {
+		page.Events = append(page.Events, Entry{
+			Seq:     event.Seq,
+			At:      event.CreatedAt,
+			Actor:   event.Actor,
+			Action:  event.Action,
+			FileID:  event.FileID,
+			Purpose: event.Purpose,
+			Outcome: event.Outcome,
+			Hash:    event.Hash,
+		})
+	}
+	return page, nil
+}
+
+// SignHead signs the last event of the log, and queues it for anchoring when anchors are set. It
+// returns nil when no event was appended since the last signed head.
+func (s *auditService) SignHead() (*storage.AuditHead, error) {
+	last, err := s.repository.FindLastEvent()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find audit log head: %w", err)
+	}
+	signed, err := s.repository.FindLastHead()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find signed head: %w", err)
+	}
+	if signed != nil && signed.Seq >= last.Seq {
+		return nil, nil
+	}
+
+	signature, err := crypto.Sign(accounts.TextHash([]byte(HeadMessage(last.Seq, last.Hash))), s.privKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign audit log head: %w", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	head := &storage.AuditHead{
+		Seq:       last.Seq,
+		Hash:      last.Hash,
+		Signer:    crypto.PubkeyToAddress(s.privKey.PublicKey).Hex(),
+		Signature: hexutil.Encode(signature),
+		SignedAt:  s.now().UTC(),
+	}
+
+	if s.anchors != nil {
+		head.AnchorDocID = AnchorDocID(head.Seq)
+		if err := s.anchors.AddLeaf(&storage.AnchorLeaf{DocID: head.AnchorDocID, ContentHash: head.Hash}); err != nil {
+			return nil, fmt.Errorf("failed to queue audit log head: %w", err)
+		}
+	}
+	if err := s.repository.SaveHead(head); err != nil {
+		return nil, fmt.Errorf("failed to store signed head: %w", err)
+	}
+	return head, nil
+}
+
+// Verify walks the whole log and reports every break in it: a missing event, an event that does not
+// follow the one before, an event whose hash no longer matches its fields, and a signed head that
+// the log no longer holds or that signer did not sign. A log rewritten from an event onwards, hashes
+// and all, no longer matches the heads signed after that event.
+func (s *auditService) Verify(signer common.Address) (*Report, error) {
+	report := &Report{Problems: []Problem{}}
+	problem := func(seq uint64, format string, args ...interface{}) {
+		report.Problems = append(report.Problems, Problem{Seq: seq, Issue: fmt.Sprintf(format, args...)})
+	}
+
+	heads := make(map[uint64]storage.AuditHead)
+	for afterSeq := uint64(0); ; {
+		batch, err := s.repository.FindHeads(afterSeq, batchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signed heads: %w", err)
+		}
+		for _, head := range batch {
+			heads[head.Seq] = head
+			afterSeq = head.Seq
+			s.verifyHead(head, signer, problem)
+		}
+		report.Heads += len(batch)
+		if len(batch) < batchSize {
+			break
+		}
+	}
+
+	var last *storage.AuditEvent
+	for afterSeq := uint64(0); ; {
+		batch, err := s.repository.FindEvents(afterSeq, batchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit log: %w", err)
+		}
+		for i := range batch {
+			event := &batch[i]
+			wantSeq, wantPrev := uint64(1), storage.GenesisHash
+			if last != nil {
+				wantSeq, wantPrev = last.Seq+1, last.Hash
+			}
+			if event.Seq != wantSeq {
+				problem(wantSeq, "events %d to %d are missing", wantSeq, event.Seq-1)
+			}
+			if event.PrevHash != wantPrev {
+				problem(event.Seq, "does not follow event %d: previous hash %s, want %s", wantSeq-1, event.PrevHash, wantPrev)
+			}
+			if hash := event.ComputeHash(); event.Hash != hash {
+				problem(event.Seq, "was edited: hash %s, recomputed %s", event.Hash, hash)
+			}
+			if head, ok := heads[event.Seq]; ok && head.Hash != event.Hash {
+				problem(event.Seq, "signed head hash %s, log has %s", head.Hash, event.Hash)
+			}
+			last = event
+			afterSeq = event.Seq
+		}
+		report.Events += len(batch)
+		if len(batch) < batchSize {
+			break
+		}
+	}
+
+	var end uint64
+	if last != nil {
+		end = last.Seq
+	}
+	for seq := range heads {
+		if seq > end {
+			problem(seq, "signed head is past the end of the log at event %d: events were removed", end)
+		}
+	}
+	return report, nil
+}
+
+func (s *auditService) verifyHead(head storage.AuditHead, signer common.Address, problem func(uint64, string, ...interface{})) {
+	if !genomicCrypto.VerifyPersonalSign(signer, []byte(HeadMessage(head.Seq, head.Hash)), head.Signature) {
+		problem(head.Seq, "signed head is not signed by %s", signer.Hex())
+	}
+	if head.AnchorDocID == "" || s.anchors == nil {
+		return
+	}
+	leaf, err := s.anchors.FindLeaf(head.AnchorDocID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		problem(head.Seq, "signed head is not queued for anchoring as %s", head.AnchorDocID)
+	case err != nil:
+		problem(head.Seq, "failed to find anchored head: %v", err)
+	case leaf.ContentHash != head.Hash:
+		problem(head.Seq, "anchored hash %s, signed head has %s", leaf.ContentHash, head.Hash)
+	}
+}
+
+// Run signs the head of the log at start and then every interval, until ctx is done.
+func (s *auditService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultSignInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		head, err := s.SignHead()
+		if err != nil {
+			log.Printf("audit: %v", err)
+		} else if head != nil {
+			log.Printf("audit: signed head at event %d, %s", head.Seq, head.Hash)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+const userAddress = "0x2222222222222222222222222222222222222222"
+
+func newTestService(t *testing.T, db *gorm.DB) (*auditService, uint32) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	authService := auth.NewAuthService(auth.NewUserRepository(db))
+	userID, err := authService.Register(userAddress)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	service := NewAuditService(storage.NewAuditRepository(db), authService, key, storage.NewAnchorRepository(db)).(*auditService)
+	return service, userID
+}
+
+// record appends n retrievals of the files of userID.
+func record(t *testing.T, service *auditService, userID uint32, n int) {
+	for i := 0; i < n; i++ {
+		err := service.Record(&storage.AuditEvent{
+			Actor:   userAddress,
+			Action:  storage.AuditRetrieve,
+			FileID:  fmt.Sprintf("file%d", i),
+			UserID:  userID,
+			Outcome: storage.AuditAllowed,
+		})
+		if err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+}
+
+func TestAuditService_Verify(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(t *testing.T, db *gorm.DB, service *auditService)
+		// Seq of every problem found
+		want []uint64
+	}{
+		{
+			name:   "Untouched",
+			tamper: func(t *testing.T, db *gorm.DB, service *auditService) {},
+		},
+		{
+			name: "Event edited",
+			tamper: func(t *testing.T, db *gorm.DB, service *auditService) {
+				db.Model(&storage.AuditEvent{}).Where("seq = ?", 2).Update("outcome", storage.AuditDenied)
+			},
+			want: []uint64{2},
+		},
+		{
+			name: "Event removed",
+			tamper: func(t *testing.T, db *gorm.DB, service *auditService) {
+				db.Where("seq = ?", 2).Delete(&storage.AuditEvent{})
+			},
+			// The gap, and the event that no longer follows
+			want: []uint64{2, 3},
+		},
+		{
+			name: "Signed events removed from the end",
+			tamper: func(t *testing.T, db *gorm.DB, service *auditService) {
+				db.Where("seq >= ?", 3).Delete(&storage.AuditEvent{})
+			},
+			want: []uint64{4},
+		},
+		{
+			name: "Event edited and the chain rehashed",
+			tamper: func(t *testing.T, db *gorm.DB, service *auditService) {
+				var events []storage.AuditEvent
+				db.Order("seq").Find(&events)
+				events[1].Outcome = storage.AuditDenied
+				for i := 1; i < len(events); i++ {
+					events[i].PrevHash = events[i-1].Hash
+					events[i].Hash = events[i].ComputeHash()
+					db.Save(&events[i])
+				}
+			},
+			want: []uint64{4},
+		},
+		{
+			name: "Head signed by another wallet",
+			tamper: func(t *testing.T, db *gorm.DB, service *auditService) {
+				service.privKey, _ = crypto.GenerateKey()
+				record(t, service, 1, 1)
+				if _, err := service.SignHead(); err != nil {
+					t.Fatalf("SignHead() error = %v", err)
+				}
+			},
+			want: []uint64{5},
+		},
+		{
+			name: "Anchored hash changed",
+			tamper: func(t *testing.T, db *gorm.DB, service *auditService) {
+				db.Model(&storage.AnchorLeaf{}).Where("doc_id = ?", AnchorDocID(4)).Update("content_hash", storage.GenesisHash)
+			},
+			want: []uint64{4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+				service, userID := newTestService(t, db)
+				signer := crypto.PubkeyToAddress(service.privKey.PublicKey)
+				record(t, service, userID, 4)
+				head, err := service.SignHead()
+				if err != nil || head == nil || head.Seq != 4 {
+					t.Fatalf("SignHead() = %+v, %v, want the head at event 4", head, err)
+				}
+				if head, err := service.SignHead(); err != nil || head != nil {
+					t.Fatalf("SignHead() again = %+v, %v, want nothing to sign", head, err)
+				}
+
+				tt.tamper(t, db, service)
+				report, err := service.Verify(signer)
+				if err != nil {
+					t.Fatalf("Verify() error = %v", err)
+				}
+				if len(report.Problems) != len(tt.want) {
+					t.Fatalf("Verify() problems = %+v, want them at %v", report.Problems, tt.want)
+				}
+				for i, seq := range tt.want {
+					if report.Problems[i].Seq != seq {
+						t.Errorf("Verify() problem %d = %+v, want it at %d", i, report.Problems[i], seq)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestAuditService_ListForUser(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		service, userID := newTestService(t, db)
+		record(t, service, userID, 3)
+		// Accesses to the data of another user are not listed
+		record(t, service, userID+1, 1)
+
+		page, err := service.ListForUser(userAddress, "", 2)
+		if err != nil {
+			t.Fatalf("ListForUser() error = %v", err)
+		}
+		if len(page.Events) != 2 || page.Events[0].Seq != 3 || page.Events[1].Seq != 2 || page.NextCursor == "" {
+			t.Fatalf("ListForUser() = %+v, want events 3 and 2 and a cursor", page)
+		}
+		page, err = service.ListForUser(userAddress, page.NextCursor, 2)
+		if err != nil {
+			t.Fatalf("ListForUser() error = %v", err)
+		}
+		if len(page.Events) != 1 || page.Events[0].Seq != 1 || page.NextCursor != "" {
+			t.Errorf("ListForUser() next page = %+v, want event 1 alone", page)
+		}
+	})
+}
@@ -7,4 +7,12 @@ var (
 	ErrInvalidAddress = errors.New("invalid address")
 	ErrUnauthorized   = errors.New("unauthorized")
 	ErrUserExists     = errors.New("user exists")
+
+	ErrInvalidMessage   = errors.New("invalid sign-in message")
+	ErrDomainMismatch   = errors.New("sign-in message is for another domain")
+	ErrChainMismatch    = errors.New("sign-in message is for another chain")
+	ErrMessageExpired   = errors.New("sign-in message is expired or not valid yet")
+	ErrInvalidNonce     = errors.New("nonce is unknown, expired or already used")
+	ErrInvalidSignature = errors.New("sign-in message is not signed by its address")
+	ErrInvalidSession   = errors.New("invalid or expired session")
 )
@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	genomicCrypto "github.com/TropicalDog17/genomic-dao-service/pkg/crypto"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// DefaultNonceTTL is how long a nonce can be signed in with.
+	DefaultNonceTTL = 5 * time.Minute
+	// DefaultSessionTTL is how long a session lasts, unless the message expires sooner.
+	DefaultSessionTTL = 24 * time.Hour
+	// ClockSkew is how far in the future a message may have been issued.
+	ClockSkew = time.Minute
+
+	nonceLength    = 16
+	nonceAlphabet  = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	sessionByteLen = 32
+)
+
+// LoginConfig is what a Sign-In with Ethereum message must be for.
+type LoginConfig struct {
+	// Host, and port if any, serving the sign-in
+	Domain     string
+	ChainID    uint64
+	NonceTTL   time.Duration
+	SessionTTL time.Duration
+}
+
+// Challenge is a nonce for a Sign-In with Ethereum message, with what the message must be for.
+type Challenge struct {
+	Nonce     string    `json:"nonce" example:"q3vR8xKp2LmN7aTz"`
+	Domain    string    `json:"domain" example:"localhost:8080"`
+	ChainID   uint64    `json:"chainId" example:"9999"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Token is a session token, sent as "Authorization: Bearer {token}".
+type Token struct {
+	Token     string    `json:"token" example:"5c1f..."`
+	Address   string    `json:"address" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type LoginService interface {
+	Challenge() (*Challenge, error)
+	Register(message string, signature string) (uint32, error)
+	Login(message string, signature string) (*Token, error)
+	Authenticate(token string) (*User, error)
+}
+
+type loginService struct {
+	authService AuthService
+	repository  SessionRepository
+	config      LoginConfig
+	now         func() time.Time
+}
+
+// NewLoginService creates the service signing wallets in with EIP-4361 messages for config.
+func NewLoginService(authService AuthService, repository SessionRepository, config LoginConfig) LoginService {
+	if config.NonceTTL <= 0 {
+		config.NonceTTL = DefaultNonceTTL
+	}
+	if config.SessionTTL <= 0 {
+		config.SessionTTL = DefaultSessionTTL
+	}
+	return &loginService{
+		authService: authService,
+		repository:  repository,
+		config:      config,
+		now:         time.Now,
+	}
+}
+
+// Challenge hands out a new nonce.
+func (s *loginService) Challenge() (*Challenge, error) {
+	nonce, err := randomNonce()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	record := &LoginNonce{Nonce: nonce, CreatedAt: now, ExpiresAt: now.Add(s.config.NonceTTL)}
+	if err := s.repository.CreateNonce(record); err != nil {
+		return nil, fmt.Errorf("failed to store nonce: %w", err)
+	}
+	return &Challenge{
+		Nonce:     nonce,
+		Domain:    s.config.Domain,
+		ChainID:   s.config.ChainID,
+		ExpiresAt: record.ExpiresAt,
+	}, nil
+}
+
+// Register registers the wallet that signed message, proving it holds the key of the address.
+func (s *loginService) Register(message string, signature string) (uint32, error) {
+	m, err := s.verify(message, signature)
+	if err != nil {
+		return 0, err
+	}
+	return s.authService.Register(m.Address)
+}
+
+// Login opens a session for the registered wallet that signed message.
+func (s *loginService) Login(message string, signature string) (*Token, error) {
+	m, err := s.verify(message, signature)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.authService.Authenticate(m.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	token := make([]byte, sessionByteLen)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate session token: %w", err)
+	}
+	now := s.now().UTC()
+	expiresAt := now.Add(s.config.SessionTTL)
+	if m.ExpirationTime != nil && m.ExpirationTime.Before(expiresAt) {
+		expiresAt = m.ExpirationTime.UTC()
+	}
+	session := &LoginSession{
+		TokenHash: hashToken(hex.EncodeToString(token)),
+		UserID:    user.ID,
+		Address:   user.Pubkey,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.repository.CreateSession(session); err != nil {
+		return nil, fmt.Errorf("failed to store session: %w", err)
+	}
+	return &Token{Token: hex.EncodeToString(token), Address: user.Pubkey, ExpiresAt: expiresAt}, nil
+}
+
+// Authenticate returns the user of an open session.
+func (s *loginService) Authenticate(token string) (*User, error) {
+	if token == "" {
+		return nil, ErrInvalidSession
+	}
+	session, err := s.repository.FindSession(hashToken(token), s.now())
+	if err != nil {
+		return nil, err
+	}
+	return s.authService.Authenticate(session.Address)
+}
+
+// verify checks that message is for this domain and chain, currently valid, signed by its address
+// and carries a nonce handed out by Challenge, which it uses up.
+func (s *loginService) verify(message string, signature string) (*Message, error) {
+	m, err := ParseMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	if m.Domain != s.config.Domain {
+		return nil, ErrDomainMismatch
+	}
+	if m.ChainID != s.config.ChainID {
+		return nil, ErrChainMismatch
+	}
+
+	now := s.now()
+	if m.IssuedAt.After(now.Add(ClockSkew)) {
+		return nil, ErrMessageExpired
+	}
+	if m.ExpirationTime != nil && !now.Before(*m.ExpirationTime) {
+		return nil, ErrMessageExpired
+	}
+	if m.NotBefore != nil && now.Before(*m.NotBefore) {
+		return nil, ErrMessageExpired
+	}
+	if !genomicCrypto.VerifyPersonalSign(common.HexToAddress(m.Address), []byte(message), signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	// Last, so a message refused for any other reason does not use up its nonce
+	if err := s.repository.UseNonce(m.Nonce, now); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func randomNonce() (string, error) {
+	nonce := make([]byte, nonceLength)
+	max := big.NewInt(int64(len(nonceAlphabet)))
+	for i := range nonce {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		nonce[i] = nonceAlphabet[n.Int64()]
+	}
+	return string(nonce), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+const signInMessage = `localhost:8080 wants you to sign in with your Ethereum account:
+0x62f563A2e09c7987dECBFF61fdcC89cd74717721
+
+Sign in to GenomicDAO
+
+URI: http://localhost:8080
+Version: 1
+Chain ID: 9999
+Nonce: q3vR8xKp2LmN7aTz
+Issued At: 2024-12-15T16:38:43Z
+Expiration Time: 2024-12-15T16:48:43Z
+Resources:
+- http://localhost:8080/upload`
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		wantErr bool
+	}{
+		{name: "Full message", message: signInMessage},
+		{name: "Without statement", message: strings.Replace(signInMessage, "Sign in to GenomicDAO\n", "", 1)},
+		{name: "With scheme", message: "https://" + signInMessage},
+		{name: "Address not checksummed", message: strings.Replace(signInMessage, "0x62f563A2e09c7987dECBFF61fdcC89cd74717721", "0x62f563a2e09c7987decbff61fdcc89cd74717721", 1), wantErr: true},
+		{name: "Short nonce", message: strings.Replace(signInMessage, "q3vR8xKp2LmN7aTz", "q3vR8", 1), wantErr: true},
+		{name: "Missing chain ID", message: strings.Replace(signInMessage, "Chain ID: 9999\n", "", 1), wantErr: true},
+		{name: "Unknown version", message: strings.Replace(signInMessage, "Version: 1", "Version: 2", 1), wantErr: true},
+		{name: "Trailing line", message: signInMessage + "\nextra", wantErr: true},
+		{name: "Not a sign-in message", message: "GenomicDAO list files request\nTimestamp: 1734280000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMessage(tt.message)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMessage) {
+					t.Errorf("ParseMessage() error = %v, want %v", err, ErrInvalidMessage)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMessage() error = %v", err)
+			}
+			if m.Domain != "localhost:8080" || m.ChainID != 9999 || m.Nonce != "q3vR8xKp2LmN7aTz" || m.ExpirationTime == nil {
+				t.Errorf("ParseMessage() = %+v", m)
+			}
+			// Formatting the message gives back the text that was signed
+			if got := m.String(); got != tt.message {
+				t.Errorf("String() = %q, want %q", got, tt.message)
+			}
+		})
+	}
+}
+
+func TestLoginService(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("Failed to generate key: %v", err)
+		}
+		address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+		now := time.Date(2024, 12, 15, 16, 38, 43, 0, time.UTC)
+		service := NewLoginService(NewAuthService(NewUserRepository(db)), NewSessionRepository(db), LoginConfig{
+			Domain:  "localhost:8080",
+			ChainID: 9999,
+		}).(*loginService)
+		service.now = func() time.Time { return now }
+
+		// sign signs a message for a new nonce, edited by edit
+		sign := func(edit func(m *Message)) (string, string) {
+			challenge, err := service.Challenge()
+			if err != nil {
+				t.Fatalf("Challenge() error = %v", err)
+			}
+			m := &Message{
+				Domain:   challenge.Domain,
+				Address:  address,
+				URI:      "http://localhost:8080",
+				Version:  "1",
+				ChainID:  challenge.ChainID,
+				Nonce:    challenge.Nonce,
+				IssuedAt: now,
+			}
+			edit(m)
+			signature, _ := crypto.Sign(accounts.TextHash([]byte(m.String())), key)
+			signature[crypto.RecoveryIDOffset] += 27
+			return m.String(), hexutil.Encode(signature)
+		}
+		unchanged := func(m *Message) {}
+
+		if _, err := service.Login(sign(unchanged)); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("Login() before registering error = %v, want %v", err, ErrUserNotFound)
+		}
+		if _, err := service.Register(sign(unchanged)); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+
+		expired := now.Add(-time.Second)
+		other, _ := crypto.GenerateKey()
+		tests := []struct {
+			name    string
+			edit    func(m *Message)
+			wantErr error
+		}{
+			{name: "Another domain", edit: func(m *Message) { m.Domain = "evil.example" }, wantErr: ErrDomainMismatch},
+			{name: "Another chain", edit: func(m *Message) { m.ChainID = 1 }, wantErr: ErrChainMismatch},
+			{name: "Expired", edit: func(m *Message) { m.ExpirationTime = &expired }, wantErr: ErrMessageExpired},
+			{name: "Unknown nonce", edit: func(m *Message) { m.Nonce = "unknownNonce1" }, wantErr: ErrInvalidNonce},
+			{name: "Another wallet's address", edit: func(m *Message) { m.Address = crypto.PubkeyToAddress(other.PublicKey).Hex() }, wantErr: ErrInvalidSignature},
+		}
+		for _, tt := range tests {
+			if _, err := service.Login(sign(tt.edit)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Login() %s error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+		}
+
+		message, signature := sign(unchanged)
+		token, err := service.Login(message, signature)
+		if err != nil {
+			t.Fatalf("Login() error = %v", err)
+		}
+		if !token.ExpiresAt.Equal(now.Add(DefaultSessionTTL)) {
+			t.Errorf("Login() expires at %s, want %s", token.ExpiresAt, now.Add(DefaultSessionTTL))
+		}
+		// A nonce is only accepted once
+		if _, err := service.Login(message, signature); !errors.Is(err, ErrInvalidNonce) {
+			t.Errorf("Login() with a used nonce error = %v, want %v", err, ErrInvalidNonce)
+		}
+
+		user, err := service.Authenticate(token.Token)
+		if err != nil || user.Pubkey != address {
+			t.Errorf("Authenticate() = %+v, %v, want %s", user, err, address)
+		}
+		if _, err := service.Authenticate("not a token"); !errors.Is(err, ErrInvalidSession) {
+			t.Errorf("Authenticate() of an unknown token error = %v, want %v", err, ErrInvalidSession)
+		}
+		now = now.Add(DefaultSessionTTL)
+		if _, err := service.Authenticate(token.Token); !errors.Is(err, ErrInvalidSession) {
+			t.Errorf("Authenticate() of an expired session error = %v, want %v", err, ErrInvalidSession)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LoginNonce is a nonce handed out for a Sign-In with Ethereum message. It is accepted once, before
+// ExpiresAt.
+type LoginNonce struct {
+	ID        uint   `gorm:"primarykey"`
+	Nonce     string `gorm:"uniqueIndex"`
+	CreatedAt time.Time
+	ExpiresAt time.Time `gorm:"index"`
+	UsedAt    *time.Time
+}
+
+// TableName matches the table created by the sign-in migration.
+func (LoginNonce) TableName() string {
+	return "auth_nonces"
+}
+
+// LoginSession is a session opened by signing in. Only the SHA-256 of its token is stored.
+type LoginSession struct {
+	ID        uint   `gorm:"primarykey"`
+	TokenHash string `gorm:"uniqueIndex"`
+	UserID    uint32 `gorm:"index"`
+	Address   string
+	CreatedAt time.Time
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+// TableName matches the table created by the sign-in migration.
+func (LoginSession) TableName() string {
+	return "auth_sessions"
+}
+
+type SessionRepository interface {
+	CreateNonce(nonce *LoginNonce) error
+	UseNonce(nonce string, now time.Time) error
+	CreateSession(session *LoginSession) error
+	FindSession(tokenHash string, now time.Time) (*LoginSession, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db}
+}
+
+// CreateNonce stores a new nonce, and drops the nonces and sessions already expired.
+func (r *sessionRepository) CreateNonce(nonce *LoginNonce) error {
+	if err := r.db.Where("expires_at < ?", nonce.CreatedAt).Delete(&LoginNonce{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Where("expires_at < ?", nonce.CreatedAt).Delete(&LoginSession{}).Error; err != nil {
+		return err
+	}
+	return r.db.Create(nonce).Error
+}
+
+// UseNonce marks a nonce used, or fails with ErrInvalidNonce when it is unknown, expired or already
+// used. Two requests with the same nonce cannot both use it.
+func (r *sessionRepository) UseNonce(nonce string, now time.Time) error {
+	result := r.db.Model(&LoginNonce{}).
+		Where("nonce = ? AND used_at IS NULL AND expires_at > ?", nonce, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return ErrInvalidNonce
+	}
+	return nil
+}
+
+func (r *sessionRepository) CreateSession(session *LoginSession) error {
+	return r.db.Create(session).Error
+}
+
+// FindSession returns the session of a token hash, or ErrInvalidSession when it is unknown or expired.
+func (r *sessionRepository) FindSession(tokenHash string, now time.Time) (*LoginSession, error) {
+	var session LoginSession
+	err := r.db.Where("token_hash = ? AND expires_at > ?", tokenHash, now).First(&session).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrInvalidSession
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
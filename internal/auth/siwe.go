@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	siweHeader  = " wants you to sign in with your Ethereum account:"
+	siweVersion = "1"
+)
+
+var nonceRegexp = regexp.MustCompile("^[a-zA-Z0-9]{8,}$")
+
+// Message is a Sign-In with Ethereum message, as specified by EIP-4361.
+type Message struct {
+	Scheme         string
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        uint64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// String formats the message as the text the wallet signs.
+func (m *Message) String() string {
+	var b strings.Builder
+	if m.Scheme != "" {
+		b.WriteString(m.Scheme + "://")
+	}
+	b.WriteString(m.Domain + siweHeader + "\n")
+	b.WriteString(m.Address + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n")
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "URI: %s\nVersion: %s\nChain ID: %d\nNonce: %s\nIssued At: %s", m.URI, m.Version, m.ChainID, m.Nonce, m.IssuedAt.Format(time.RFC3339))
+	if m.ExpirationTime != nil {
+		b.WriteString("\nExpiration Time: " + m.ExpirationTime.Format(time.RFC3339))
+	}
+	if m.NotBefore != nil {
+		b.WriteString("\nNot Before: " + m.NotBefore.Format(time.RFC3339))
+	}
+	if m.RequestID != "" {
+		b.WriteString("\nRequest ID: " + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\nResources:")
+		for _, resource := range m.Resources {
+			b.WriteString("\n- " + resource)
+		}
+	}
+	return b.String()
+}
+
+// ParseMessage reads a Sign-In with Ethereum message. The address must be EIP-55 checksummed and the
+// nonce at least 8 alphanumeric characters; it does not check the domain, chain or times.
+func ParseMessage(text string) (*Message, error) {
+	lines := strings.Split(text, "\n")
+	p := &messageParser{lines: lines}
+	m := &Message{}
+
+	header, ok := strings.CutSuffix(p.next(), siweHeader)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidMessage)
+	}
+	if scheme, domain, ok := strings.Cut(header, "://"); ok {
+		m.Scheme, header = scheme, domain
+	}
+	m.Domain = header
+	if m.Domain == "" {
+		return nil, fmt.Errorf("%w: missing domain", ErrInvalidMessage)
+	}
+
+	m.Address = p.next()
+	if !common.IsHexAddress(m.Address) || common.HexToAddress(m.Address).Hex() != m.Address {
+		return nil, fmt.Errorf("%w: address must be EIP-55 checksummed", ErrInvalidMessage)
+	}
+	if p.next() != "" {
+		return nil, fmt.Errorf("%w: missing blank line after the address", ErrInvalidMessage)
+	}
+	// An optional statement, followed by a blank line
+	if line := p.peek(); line != "" && !strings.HasPrefix(line, "URI: ") {
+		m.Statement = p.next()
+	}
+	if p.peek() == "" {
+		p.next()
+	}
+
+	var err error
+	m.URI = p.field("URI")
+	m.Version = p.field("Version")
+	chainID := p.field("Chain ID")
+	m.Nonce = p.field("Nonce")
+	issuedAt := p.field("Issued At")
+	if p.err != nil {
+		return nil, p.err
+	}
+	if m.URI == "" {
+		return nil, fmt.Errorf("%w: missing URI", ErrInvalidMessage)
+	}
+	if m.Version != siweVersion {
+		return nil, fmt.Errorf("%w: unsupported version %q", ErrInvalidMessage, m.Version)
+	}
+	if m.ChainID, err = strconv.ParseUint(chainID, 10, 64); err != nil {
+		return nil, fmt.Errorf("%w: invalid chain ID", ErrInvalidMessage)
+	}
+	if !nonceRegexp.MatchString(m.Nonce) {
+		return nil, fmt.Errorf("%w: invalid nonce", ErrInvalidMessage)
+	}
+	if m.IssuedAt, err = time.Parse(time.RFC3339, issuedAt); err != nil {
+		return nil, fmt.Errorf("%w: invalid issued at time", ErrInvalidMessage)
+	}
+
+	if value, ok := p.optional("Expiration Time"); ok {
+		if m.ExpirationTime, err = parseTime(value); err != nil {
+			return nil, fmt.Errorf("%w: invalid expiration time", ErrInvalidMessage)
+		}
+	}
+	if value, ok := p.optional("Not Before"); ok {
+		if m.NotBefore, err = parseTime(value); err != nil {
+			return nil, fmt.Errorf("%w: invalid not before time", ErrInvalidMessage)
+		}
+	}
+	m.RequestID, _ = p.optional("Request ID")
+	if p.peek() == "Resources:" {
+		p.next()
+		for p.more() {
+			resource, ok := strings.CutPrefix(p.next(), "- ")
+			if !ok {
+				return nil, fmt.Errorf("%w: invalid resource", ErrInvalidMessage)
+			}
+			m.Resources = append(m.Resources, resource)
+		}
+	}
+	if p.more() {
+		return nil, fmt.Errorf("%w: unexpected line %q", ErrInvalidMessage, p.peek())
+	}
+	return m, nil
+}
+
+func parseTime(value string) (*time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// messageParser reads the lines of a message in order.
+type messageParser struct {
+	lines []string
+	err   error
+}
+
+func (p *messageParser) more() bool {
+	return len(p.lines) > 0
+}
+
+func (p *messageParser) peek() string {
+	if len(p.lines) == 0 {
+		return ""
+	}
+	return p.lines[0]
+}
+
+func (p *messageParser) next() string {
+	line := p.peek()
+	if len(p.lines) > 0 {
+		p.lines = p.lines[1:]
+	}
+	return line
+}
+
+// field reads the required "name: value" line, recording the first one missing.
+func (p *messageParser) field(name string) string {
+	value, ok := p.optional(name)
+	if !ok && p.err == nil {
+		p.err = fmt.Errorf("%w: missing %s", ErrInvalidMessage, name)
+	}
+	return value
+}
+
+// optional reads the "name: value" line when it comes next.
+func (p *messageParser) optional(name string) (string, bool) {
+	value, ok := strings.CutPrefix(p.peek(), name+": ")
+	if !ok {
+		return "", false
+	}
+	p.next()
+	return value, true
+}
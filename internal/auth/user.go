@@ -51,7 +51,8 @@ func (r *userRepository) Create(user *User) error {
 
 func (r *userRepository) FindByPubkey(p string) (*User, error) {
 	var user User
-	if err := r.db.Where("pubkey = ?", p).First(&user).Error; err != nil {
+	// Addresses are compared whatever their checksum case
+	if err := r.db.Where("LOWER(pubkey) = LOWER(?)", p).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
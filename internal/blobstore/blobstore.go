@@ -0,0 +1,75 @@
+package blobstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	ErrNotFound         = errors.New("blob not found")
+	ErrChecksumMismatch = errors.New("blob does not match its checksum")
+)
+
+// Blob locates stored content. Blobs are content-addressed: storing the same bytes twice gives the same key.
+type Blob struct {
+	Key      string
+	Size     int64
+	Checksum string
+}
+
+type BlobStore interface {
+	Put(data []byte) (*Blob, error)
+	// Get fails with ErrChecksumMismatch when the stored bytes no longer hash to the key
+	Get(key string) ([]byte, error)
+	Delete(key string) error
+}
+
+// Checksum is the hex SHA-256 of data, which is also its key.
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func newBlob(data []byte) *Blob {
+	checksum := Checksum(data)
+	return &Blob{Key: checksum, Size: int64(len(data)), Checksum: checksum}
+}
+
+func validKey(key string) bool {
+	decoded, err := hex.DecodeString(key)
+	return err == nil && len(decoded) == sha256.Size
+}
+
+func verify(key string, data []byte) ([]byte, error) {
+	if Checksum(data) != key {
+		return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, key)
+	}
+	return data, nil
+}
+
+// NewFromEnv opens the store selected by BLOB_STORE: "fs" (the default) keeps blobs under BLOB_DIR
+// (default "blobs"), "s3" in S3_BUCKET of the S3-compatible S3_ENDPOINT.
+func NewFromEnv() (BlobStore, error) {
+	switch backend := os.Getenv("BLOB_STORE"); backend {
+	case "", "fs":
+		dir := os.Getenv("BLOB_DIR")
+		if dir == "" {
+			dir = "blobs"
+		}
+		return NewFileStore(dir)
+	case "s3":
+		return NewS3Store(S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			Prefix:    os.Getenv("S3_PREFIX"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		}, nil)
+	default:
+		return nil, fmt.Errorf("unknown BLOB_STORE %q", backend)
+	}
+}
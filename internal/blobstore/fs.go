@@ -0,0 +1,87 @@
+package blobstore
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type fileStore struct {
+	root string
+}
+
+// NewFileStore keeps blobs under root, sharded by the first two bytes of their key so no
+// directory grows too large: root/ab/cd/abcd....
+func NewFileStore(root string) (BlobStore, error) {
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %v", err)
+	}
+	return &fileStore{root: root}, nil
+}
+
+func (s *fileStore) path(key string) string {
+	return filepath.Join(s.root, key[0:2], key[2:4], key)
+}
+
+// Put writes the blob through a temporary file, so a crash never leaves a partial blob under its key.
+func (s *fileStore) Put(data []byte) (*Blob, error) {
+	blob := newBlob(data)
+	path := s.path(blob.Key)
+	if _, err := os.Stat(path); err == nil {
+		return blob, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %v", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), blob.Key+".tmp-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("failed to write blob: %v", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("failed to write blob: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write blob: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to store blob: %v", err)
+	}
+	return blob, nil
+}
+
+func (s *fileStore) Get(key string) ([]byte, error) {
+	if !validKey(key) {
+		return nil, ErrNotFound
+	}
+
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %v", err)
+	}
+	return verify(key, data)
+}
+
+func (s *fileStore) Delete(key string) error {
+	if !validKey(key) {
+		return ErrNotFound
+	}
+
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
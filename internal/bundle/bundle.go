@@ -0,0 +1,344 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+)
+
+// FormatVersion is the bundle layout written by Write and read by Open.
+const FormatVersion = 1
+
+// A bundle is a zip archive of the sealed files, named after their source file ID, and of the
+// manifest describing them, signed by the service wallet of the exporting deployment. The manifest
+// comes after the blobs, so a bundle can be written without holding them all:
+//
+//	blobs/<fileID>    each file sealed under its own key, wrapped in the manifest
+//	manifest.json
+//	manifest.sig      personal_sign of manifest.json, hex
+const (
+	ManifestEntry  = "manifest.json"
+	SignatureEntry = "manifest.sig"
+	blobDir        = "blobs/"
+
+	maxManifestSize = 16 << 20
+	// Sealing adds a header, hash, nonce and tag to data the TEE bounds, when it does not compress
+	maxBlobSize = tee.MaxDataSize + 1024
+)
+
+var (
+	ErrInvalidBundle   = errors.New("invalid export bundle")
+	ErrUntrustedSigner = errors.New("bundle is not signed by a trusted deployment")
+)
+
+// Manifest lists everything a bundle holds about one user's files. It is what the bundle
+// signature covers; the blobs are covered by their hashes in it.
+type Manifest struct {
+	Version int `json:"version"`
+	// The address the user registered with
+	User string `json:"user"`
+	// The service wallet of the exporting deployment, which signed the manifest
+	Source     string    `json:"source"`
+	ChainID    uint64    `json:"chainId"`
+	ExportedAt time.Time `json:"exportedAt"`
+	// The public key the file keys are wrapped for, hex
+	Recipient string    `json:"recipient"`
+	Files     []File    `json:"files"`
+	Consents  []Consent `json:"consents"`
+}
+
+// File is one completed upload in a bundle.
+type File struct {
+	FileID string `json:"fileId"`
+	// The ID the file was opened under on-chain, when it differs from FileID
+	LegacyFileID string    `json:"legacyFileId,omitempty"`
+	Format       string    `json:"format"`
+	UploadedAt   time.Time `json:"uploadedAt"`
+	Blob         string    `json:"blob"`
+	BlobSize     int64     `json:"blobSize"`
+	BlobSHA256   string    `json:"blobSha256"`
+	// The key the blob is sealed under, ECIES-encrypted for the recipient
+	WrappedKey string `json:"wrappedKey"`
+	// Keccak-256 of the ciphertext stored by the source, and its signature by the source wallet
+	DataHash  string    `json:"dataHash"`
+	Signature string    `json:"signature"`
+	Report    Report    `json:"report"`
+	Chain     ChainRefs `json:"chain"`
+}
+
+// Report is the report metadata the TEE produced for a file.
+type Report struct {
+	RiskScore     int    `json:"riskScore"`
+	ModelVersion  string `json:"modelVersion"`
+	ShareRiskTier bool   `json:"shareRiskTier"`
+	// When the report was delivered, which the retention policy counts from
+	ReportedAt *time.Time `json:"reportedAt,omitempty"`
+}
+
+// ChainRefs are the on-chain records of a file.
+type ChainRefs struct {
+	SessionID     string `json:"sessionId"`
+	DocID         string `json:"docId"`
+	TokenID       string `json:"tokenId"`
+	UploadTxHash  string `json:"uploadTxHash,omitempty"`
+	ConfirmTxHash string `json:"confirmTxHash,omitempty"`
+}
+
+// Consent is the research consent recorded for one of the user's GeneNFTs.
+type Consent struct {
+	TokenID  string `json:"tokenId"`
+	Owner    string `json:"owner"`
+	Granted  bool   `json:"granted"`
+	SignedAt int64  `json:"signedAt"`
+}
+
+// Bundle is an opened bundle whose signature and hashes all checked out.
+type Bundle struct {
+	Manifest Manifest
+	// The deployment wallet that signed the manifest, and every file signature
+	Signer common.Address
+	blobs  map[string][]byte
+}
+
+// Blob returns the sealed data of a file.
+func (b *Bundle) Blob(file File) []byte {
+	return b.blobs[file.Blob]
+}
+
+// NewFileKey generates the key a file is sealed under in a bundle.
+func NewFileKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generating file key: %w", err)
+	}
+	return key, nil
+}
+
+// WrapKey encrypts a file key for recipient with ECIES.
+func WrapKey(key []byte, recipient *ecdsa.PublicKey) (string, error) {
+	wrapped, err := ecies.Encrypt(rand.Reader, ecies.ImportECDSAPublic(recipient), key, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("wrapping file key: %w", err)
+	}
+	return hexutil.Encode(wrapped), nil
+}
+
+// UnwrapKey decrypts a file key wrapped for privKey.
+func UnwrapKey(wrappedKey string, privKey *ecdsa.PrivateKey) ([]byte, error) {
+	wrapped, err := hexutil.Decode(wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: wrapped key: %v", ErrInvalidBundle, err)
+	}
+	key, err := ecies.ImportECDSA(privKey).Decrypt(wrapped, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping file key: %w", err)
+	}
+	return key, nil
+}
+
+// Writer streams a bundle: each sealed blob is written as soon as it is added, and the manifest,
+// which follows them, once the bundle is closed.
+type Writer struct {
+	archive  *zip.Writer
+	manifest *Manifest
+	key      *ecdsa.PrivateKey
+}
+
+// NewWriter starts a bundle of manifest, whose files are added with Add, signed by key on Close.
+func NewWriter(w io.Writer, manifest *Manifest, key *ecdsa.PrivateKey) *Writer {
+	return &Writer{archive: zip.NewWriter(w), manifest: manifest, key: key}
+}
+
+// Add writes the sealed blob of file and lists the file in the manifest with the blob's size and hash.
+func (bw *Writer) Add(file File, blob []byte) error {
+	hash := sha256.Sum256(blob)
+	file.BlobSize = int64(len(blob))
+	file.BlobSHA256 = hex.EncodeToString(hash[:])
+	if err := writeEntry(bw.archive, file.Blob, blob); err != nil {
+		return err
+	}
+	bw.manifest.Files = append(bw.manifest.Files, file)
+	return nil
+}
+
+// Close signs the manifest and writes it last, completing the bundle.
+func (bw *Writer) Close() error {
+	bw.manifest.Version = FormatVersion
+	bw.manifest.Source = crypto.PubkeyToAddress(bw.key.PublicKey).Hex()
+	data, err := json.MarshalIndent(bw.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	signature, err := crypto.Sign(accounts.TextHash(data), bw.key)
+	if err != nil {
+		return fmt.Errorf("signing manifest: %w", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	if err := writeEntry(bw.archive, ManifestEntry, data); err != nil {
+		return err
+	}
+	if err := writeEntry(bw.archive, SignatureEntry, []byte(hexutil.Encode(signature))); err != nil {
+		return err
+	}
+	return bw.archive.Close()
+}
+
+func writeEntry(archive *zip.Writer, name string, data []byte) error {
+	// Sealed data does not compress
+	entry, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	_, err = entry.Write(data)
+	return err
+}
+
+// BlobName is the entry a file's sealed data is written to.
+func BlobName(fileID string) string {
+	return blobDir + fileID
+}
+
+// Open reads a bundle and verifies it before anything in it is used: the manifest signature, the
+// hash and size of every blob, and the signature of every file's data hash, all by the manifest's
+// source. Entries the manifest does not list are refused.
+func Open(r io.ReaderAt, size int64) (*Bundle, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	entries := make(map[string]*zip.File, len(archive.File))
+	for _, entry := range archive.File {
+		if _, ok := entries[entry.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicate entry %s", ErrInvalidBundle, entry.Name)
+		}
+		entries[entry.Name] = entry
+	}
+
+	data, err := readEntry(entries, ManifestEntry, maxManifestSize)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := readEntry(entries, SignatureEntry, 256)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: manifest: %v", ErrInvalidBundle, err)
+	}
+	if manifest.Version != FormatVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidBundle, manifest.Version)
+	}
+	if !common.IsHexAddress(manifest.Source) {
+		return nil, fmt.Errorf("%w: invalid source %q", ErrInvalidBundle, manifest.Source)
+	}
+	signer := common.HexToAddress(manifest.Source)
+	if err := verifyManifest(data, strings.TrimSpace(string(signature)), signer); err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{Manifest: manifest, Signer: signer, blobs: make(map[string][]byte, len(manifest.Files))}
+	for _, file := range manifest.Files {
+		if file.Blob != BlobName(file.FileID) {
+			return nil, fmt.Errorf("%w: file %s is in %q", ErrInvalidBundle, file.FileID, file.Blob)
+		}
+		if _, ok := bundle.blobs[file.Blob]; ok {
+			return nil, fmt.Errorf("%w: file %s listed twice", ErrInvalidBundle, file.FileID)
+		}
+		blob, err := readEntry(entries, file.Blob, maxBlobSize)
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256(blob)
+		if int64(len(blob)) != file.BlobSize || hex.EncodeToString(hash[:]) != file.BlobSHA256 {
+			return nil, fmt.Errorf("%w: file %s does not match its hash", ErrInvalidBundle, file.FileID)
+		}
+		if err := verifyFile(file, signer); err != nil {
+			return nil, err
+		}
+		bundle.blobs[file.Blob] = blob
+	}
+
+	for name := range entries {
+		if name != ManifestEntry && name != SignatureEntry {
+			if _, ok := bundle.blobs[name]; !ok {
+				return nil, fmt.Errorf("%w: unlisted entry %s", ErrInvalidBundle, name)
+			}
+		}
+	}
+	return bundle, nil
+}
+
+func readEntry(entries map[string]*zip.File, name string, limit int64) ([]byte, error) {
+	entry, ok := entries[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidBundle, name)
+	}
+	if entry.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("%w: %s is too large", ErrInvalidBundle, name)
+	}
+	reader, err := entry.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidBundle, name, err)
+	}
+	defer reader.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(reader, limit+1)); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidBundle, name, err)
+	}
+	if int64(buf.Len()) > limit {
+		return nil, fmt.Errorf("%w: %s is too large", ErrInvalidBundle, name)
+	}
+	return buf.Bytes(), nil
+}
+
+func verifyManifest(data []byte, signature string, signer common.Address) error {
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("%w: malformed manifest signature", ErrInvalidBundle)
+	}
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(accounts.TextHash(data), sig)
+	if err != nil || crypto.PubkeyToAddress(*pubKey) != signer {
+		return fmt.Errorf("%w: manifest is not signed by %s", ErrInvalidBundle, signer.Hex())
+	}
+	return nil
+}
+
+// verifyFile checks that the source signed the data hash of the file, as it does on upload.
+func verifyFile(file File, signer common.Address) error {
+	hash, err := hex.DecodeString(file.DataHash)
+	if err != nil || len(hash) != common.HashLength {
+		return fmt.Errorf("%w: file %s has a malformed data hash", ErrInvalidBundle, file.FileID)
+	}
+	sig, err := hex.DecodeString(file.Signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("%w: file %s has a malformed signature", ErrInvalidBundle, file.FileID)
+	}
+	pubKey, err := crypto.SigToPub(hash, sig)
+	if err != nil || crypto.PubkeyToAddress(*pubKey) != signer {
+		return fmt.Errorf("%w: file %s is not signed by %s", ErrInvalidBundle, file.FileID, signer.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/access"
+	"github.com/TropicalDog17/genomic-dao-service/internal/audit"
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+const (
+	userAddress = "0x2222222222222222222222222222222222222222"
+	chainID     = 9999
+)
+
+type mockOnchainService struct {
+	onchain.OnchainService
+	owners map[string]common.Address
+}
+
+func (m *mockOnchainService) GetTokenOwner(tokenID string) (common.Address, error) {
+	return m.owners[tokenID], nil
+}
+
+func (m *mockOnchainService) IsApprovedOperator(tokenID string, owner common.Address, operator common.Address) (bool, error) {
+	return false, nil
+}
+
+func (m *mockOnchainService) FindUploadTx(sessionID string) (*onchain.UploadTx, error) {
+	return &onchain.UploadTx{TxHash: "0xupload" + sessionID}, nil
+}
+
+func (m *mockOnchainService) FindConfirmTx(docID string) (*onchain.ConfirmResult, error) {
+	return &onchain.ConfirmResult{TxHash: "0xconfirm" + docID}, nil
+}
+
+// deployment is one gateway with its own service wallet, TEE secret and database.
+type deployment struct {
+	key         *ecdsa.PrivateKey
+	authService auth.AuthService
+	storage     storage.GeneDataStorageService
+	consents    storage.MarketplaceRepository
+	audit       audit.AuditService
+	tee         tee.TeeService
+	chain       *mockOnchainService
+}
+
+func newDeployment(t *testing.T, db *gorm.DB, secret string) *deployment {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	blobs, err := blobstore.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to open blob store: %v", err)
+	}
+	authService := auth.NewAuthService(auth.NewUserRepository(db))
+	return &deployment{
+		key:         key,
+		authService: authService,
+		storage:     storage.NewGeneDataStorageService(db, blobs),
+		consents:    storage.NewMarketplaceRepository(db, blobs),
+		audit:       audit.NewAuditService(storage.NewAuditRepository(db), authService, key, nil),
+		tee:         tee.NewTeeService([]byte(secret), tee.DefaultCodec),
+		chain:       &mockOnchainService{owners: make(map[string]common.Address)},
+	}
+}
+
+func (d *deployment) exporter() ExportService {
+	return NewExportService(d.tee, d.authService, d.storage, d.consents, d.chain, access.NewAccessService(d.chain, 0, "", chainID), d.audit, d.key, chainID)
+}
+
+func (d *deployment) importer() ImportService {
+	return NewImportService(d.tee, d.authService, d.storage, d.consents, d.key, chainID)
+}
+
+// upload stores a completed upload of genome, minted as tokenID to the user.
+func (d *deployment) upload(t *testing.T, userID uint32, genome []byte, tokenID string) string {
+	sealed, riskScore, err := d.tee.ProcessAndEncrypt(genome, &d.key.PublicKey)
+	if err != nil {
+		t.Fatalf("ProcessAndEncrypt() error = %v", err)
+	}
+	hash := crypto.Keccak256(sealed)
+	signature, _ := crypto.Sign(hash, d.key)
+	fileID, err := d.storage.StoreGeneData(userID, sealed, signature, hash)
+	if err != nil {
+		t.Fatalf("StoreGeneData() error = %v", err)
+	}
+	err = d.storage.UpdateUploadRecord(fileID, storage.UploadRecord{
+		SessionID:    tokenID,
+		DocID:        "doc-" + tokenID,
+		TokenID:      tokenID,
+		RiskScore:    riskScore,
+		ModelVersion: tee.ModelVersion,
+	})
+	if err != nil {
+		t.Fatalf("UpdateUploadRecord() error = %v", err)
+	}
+	d.chain.owners[tokenID] = common.HexToAddress(userAddress)
+	return fileID
+}
+
+func genome(value uint64) []byte {
+	data := make([]byte, 5*8)
+	for i := 0; i < 5; i++ {
+		binary.LittleEndian.PutUint64(data[i*8:], value+uint64(i))
+	}
+	return data
+}
+
+func TestExportImport(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		source := newDeployment(t, db, "source secret")
+		destination := newDeployment(t, databasetest.SQLite(t), "destination secret")
+
+		userID, err := source.authService.Register(userAddress)
+		if err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+		first := source.upload(t, userID, genome(1<<60), "14")
+		source.upload(t, userID, genome(1<<61), "15")
+		if err := source.consents.SaveConsent(&storage.ResearchConsent{TokenID: "14", Owner: userAddress, Granted: true, SignedAt: 100}); err != nil {
+			t.Fatalf("SaveConsent() error = %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := source.exporter().Export(userAddress, &destination.key.PublicKey, &buf); err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+		bundle, err := Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		if len(bundle.Manifest.Files) != 2 || len(bundle.Manifest.Consents) != 1 {
+			t.Fatalf("Bundle has %d files and %d consents, want 2 and 1", len(bundle.Manifest.Files), len(bundle.Manifest.Consents))
+		}
+		if log, err := source.audit.ListForUser(userAddress, "", 10); err != nil || len(log.Events) != 2 || log.Events[0].Action != storage.AuditExport {
+			t.Errorf("ListForUser() = %+v, %v, want both exports recorded", log, err)
+		}
+		if refs := bundle.Manifest.Files[0].Chain; refs.TokenID != "14" || refs.UploadTxHash != "0xupload14" || refs.ConfirmTxHash != "0xconfirmdoc-14" {
+			t.Errorf("Bundle chain references = %+v", refs)
+		}
+
+		sourceAddress := crypto.PubkeyToAddress(source.key.PublicKey)
+		otherAddress := common.HexToAddress("0x3333333333333333333333333333333333333333")
+		if _, err := destination.importer().Import(bundle, []common.Address{otherAddress}); !errors.Is(err, ErrUntrustedSigner) {
+			t.Errorf("Import() error = %v, want %v", err, ErrUntrustedSigner)
+		}
+		// Wrapped for the destination, so the source cannot open it, and nothing is restored
+		if _, err := source.importer().Import(bundle, []common.Address{sourceAddress}); err == nil {
+			t.Error("Import() with another recipient's bundle succeeded")
+		}
+
+		result, err := destination.importer().Import(bundle, []common.Address{sourceAddress})
+		if err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+		if len(result.Restored) != 2 || len(result.Skipped) != 0 || result.Consents != 1 {
+			t.Errorf("Import() = %+v, want 2 files and 1 consent restored", result)
+		}
+
+		restored, err := destination.storage.FindByFileID(result.Restored[first])
+		if err != nil {
+			t.Fatalf("FindByFileID() error = %v", err)
+		}
+		if restored.LegacyFileID != first || restored.TokenID != "14" || restored.ModelVersion != tee.ModelVersion {
+			t.Errorf("Restored record = %+v", restored)
+		}
+		sealed, err := destination.storage.RetrieveGeneData(restored.FileID)
+		if err != nil {
+			t.Fatalf("RetrieveGeneData() error = %v", err)
+		}
+		data, err := destination.tee.DecryptData(sealed, destination.key)
+		if err != nil || !bytes.Equal(data, genome(1<<60)) {
+			t.Errorf("Restored data = %x, %v, want the uploaded genome", data, err)
+		}
+		if consent, err := destination.consents.FindConsent("14"); err != nil || !consent.Granted {
+			t.Errorf("FindConsent() = %+v, %v, want granted", consent, err)
+		}
+
+		// Importing again restores nothing
+		result, err = destination.importer().Import(bundle, []common.Address{sourceAddress})
+		if err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+		if len(result.Restored) != 0 || len(result.Skipped) != 2 || result.Consents != 0 {
+			t.Errorf("Import() again = %+v, want every file skipped", result)
+		}
+	})
+}
+
+func TestExport_SkipsTransferredTokens(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		source := newDeployment(t, db, "source secret")
+		userID, err := source.authService.Register(userAddress)
+		if err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+		kept := source.upload(t, userID, genome(1<<60), "14")
+		sold := source.upload(t, userID, genome(1<<61), "15")
+
+		// The user sells GeneNFT 15
+		source.chain.owners["15"] = common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+		var buf bytes.Buffer
+		if err := source.exporter().Export(userAddress, nil, &buf); err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+		bundle, err := Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		if len(bundle.Manifest.Files) != 1 || bundle.Manifest.Files[0].FileID != kept {
+			t.Fatalf("Bundle files = %+v, want only %s", bundle.Manifest.Files, kept)
+		}
+		if _, ok := readEntries(t, buf.Bytes())[BlobName(sold)]; ok {
+			t.Errorf("Bundle holds the data of the sold GeneNFT")
+		}
+
+		log, err := source.audit.ListForUser(userAddress, "", 10)
+		if err != nil {
+			t.Fatalf("ListForUser() error = %v", err)
+		}
+		outcomes := make(map[string]string)
+		for _, event := range log.Events {
+			outcomes[event.FileID] = event.Outcome
+		}
+		if outcomes[kept] != storage.AuditAllowed || outcomes[sold] != storage.AuditDenied {
+			t.Errorf("Audit outcomes = %v, want %s allowed and %s denied", outcomes, kept, sold)
+		}
+	})
+}
+
+func TestOpen(t *testing.T) {
+	source := newDeployment(t, databasetest.SQLite(t), "source secret")
+	userID, err := source.authService.Register(userAddress)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	fileID := source.upload(t, userID, genome(1<<60), "14")
+
+	var buf bytes.Buffer
+	if err := source.exporter().Export(userAddress, nil, &buf); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	other, _ := crypto.GenerateKey()
+
+	tests := []struct {
+		name    string
+		edit    func(entries map[string][]byte)
+		wantErr bool
+	}{
+		{
+			name: "As exported",
+			edit: func(entries map[string][]byte) {},
+		},
+		{
+			name: "Blob changed",
+			edit: func(entries map[string][]byte) {
+				entries[BlobName(fileID)][0] ^= 1
+			},
+			wantErr: true,
+		},
+		{
+			name: "Manifest changed",
+			edit: func(entries map[string][]byte) {
+				entries[ManifestEntry] = bytes.Replace(entries[ManifestEntry], []byte(`"tokenId": "14"`), []byte(`"tokenId": "15"`), 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Manifest signed by another wallet",
+			edit: func(entries map[string][]byte) {
+				sig, _ := crypto.Sign(accounts.TextHash(entries[ManifestEntry]), other)
+				entries[SignatureEntry] = []byte(hexutil.Encode(sig))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Blob missing",
+			edit: func(entries map[string][]byte) {
+				delete(entries, BlobName(fileID))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unlisted entry",
+			edit: func(entries map[string][]byte) {
+				entries["blobs/extra"] = []byte("extra")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := readEntries(t, buf.Bytes())
+			tt.edit(entries)
+			data := writeEntries(t, entries)
+
+			bundle, err := Open(bytes.NewReader(data), int64(len(data)))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBundle) {
+					t.Errorf("Open() error = %v, want %v", err, ErrInvalidBundle)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			if bundle.Signer != crypto.PubkeyToAddress(source.key.PublicKey) {
+				t.Errorf("Open() signer = %s, want the source wallet", bundle.Signer.Hex())
+			}
+		})
+	}
+}
+
+func readEntries(t *testing.T, data []byte) map[string][]byte {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Failed to read bundle: %v", err)
+	}
+	entries := make(map[string][]byte)
+	for _, entry := range archive.File {
+		reader, _ := entry.Open()
+		entries[entry.Name], _ = io.ReadAll(reader)
+		reader.Close()
+	}
+	return entries
+}
+
+func writeEntries(t *testing.T, entries map[string][]byte) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, data := range entries {
+		if err := writeEntry(archive, name, data); err != nil {
+			t.Fatalf("Failed to write bundle: %v", err)
+		}
+	}
+	archive.Close()
+	return buf.Bytes()
+}
@@ -0,0 +1,233 @@
+package bundle
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/access"
+	"github.com/TropicalDog17/genomic-dao-service/internal/audit"
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+type ExportService interface {
+	Export(address string, recipient *ecdsa.PublicKey, w io.Writer) error
+}
+
+type exportService struct {
+	teeService             tee.TeeService
+	authService            auth.AuthService
+	geneDataStorageService storage.GeneDataStorageService
+	marketplaceRepository  storage.MarketplaceRepository
+	onchainService         onchain.OnchainService
+	accessService          access.AccessService
+	auditService           audit.AuditService
+	privKey                *ecdsa.PrivateKey
+	chainID                uint64
+}
+
+// NewExportService creates the service exporting bundles signed by privKey, the service wallet,
+// which also holds the key the stored data is sealed for. Every file exported, or held back because the
+// user no longer holds its GeneNFT, is recorded in the audit log.
+func NewExportService(
+	teeService tee.TeeService,
+	authService auth.AuthService,
+	geneDataStorageService storage.GeneDataStorageService,
+	marketplaceRepository storage.MarketplaceRepository,
+	onchainService onchain.OnchainService,
+	accessService access.AccessService,
+	auditService audit.AuditService,
+	privKey *ecdsa.PrivateKey,
+	chainID uint64,
+) ExportService {
+	return &exportService{
+		teeService:             teeService,
+		authService:            authService,
+		geneDataStorageService: geneDataStorageService,
+		marketplaceRepository:  marketplaceRepository,
+		onchainService:         onchainService,
+		accessService:          accessService,
+		auditService:           auditService,
+		privKey:                privKey,
+		chainID:                chainID,
+	}
+}
+
+// Export writes a bundle of the completed uploads of the user registered with address, but for those
+// whose raw data was purged and those whose GeneNFT the user no longer holds or operates. Each file is
+// sealed again inside the TEE under a new key, wrapped for recipient; a nil recipient is this
+// deployment, for an offline copy. The bundle is streamed to w, one file at a time.
+func (s *exportService) Export(address string, recipient *ecdsa.PublicKey, w io.Writer) error {
+	user, err := s.authService.Authenticate(address)
+	if err != nil {
+		return err
+	}
+	if recipient == nil {
+		recipient = &s.privKey.PublicKey
+	}
+	requester := common.HexToAddress(user.Pubkey)
+
+	manifest := &Manifest{
+		User:       user.Pubkey,
+		ChainID:    s.chainID,
+		ExportedAt: time.Now().UTC(),
+		Recipient:  hexutil.Encode(crypto.FromECDSAPub(recipient)),
+		Files:      []File{},
+		Consents:   []Consent{},
+	}
+	bundle := NewWriter(w, manifest, s.privKey)
+
+	query := storage.FileQuery{Status: storage.UploadCompleted, Sort: storage.SortUploaded, Ascending: true, Limit: storage.MaxFilePageSize}
+	for {
+		page, err := s.geneDataStorageService.ListFiles(user.ID, query)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+		for i := range page.Files {
+			record := &page.Files[i]
+			// Nothing is left to export of a file the retention policy purged
+			if record.PurgedAt != nil {
+				continue
+			}
+			event := &storage.AuditEvent{
+				Actor:   user.Pubkey,
+				Action:  storage.AuditExport,
+				FileID:  record.FileID,
+				UserID:  user.ID,
+				Purpose: "bundle for " + crypto.PubkeyToAddress(*recipient).Hex(),
+			}
+
+			// A file only goes with its GeneNFT, so one sold or transferred is left out
+			err = access.ErrAccessDenied
+			if record.TokenID != "" {
+				err = s.accessService.CheckTokenAccess(record.TokenID, requester)
+			}
+			if errors.Is(err, access.ErrAccessDenied) {
+				event.Outcome = storage.AuditDenied
+				if err := s.auditService.Record(event); err != nil {
+					return fmt.Errorf("failed to record export of file %s: %w", record.FileID, err)
+				}
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to check access to file %s: %w", record.FileID, err)
+			}
+
+			file, blob, err := s.exportFile(record, recipient)
+			if err != nil {
+				return fmt.Errorf("failed to export file %s: %w", record.FileID, err)
+			}
+			event.Outcome = storage.AuditAllowed
+			if err := s.auditService.Record(event); err != nil {
+				return fmt.Errorf("failed to record export of file %s: %w", file.FileID, err)
+			}
+			if err := bundle.Add(*file, blob); err != nil {
+				return fmt.Errorf("failed to write file %s: %w", file.FileID, err)
+			}
+
+			consent, err := s.findConsent(file.Chain.TokenID)
+			if err != nil {
+				return err
+			}
+			if consent != nil {
+				manifest.Consents = append(manifest.Consents, *consent)
+			}
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	return bundle.Close()
+}
+
+func (s *exportService) exportFile(record *storage.GeneData, recipient *ecdsa.PublicKey) (*File, []byte, error) {
+	encryptedData, err := s.geneDataStorageService.RetrieveGeneData(record.FileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := NewFileKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	blob, err := s.teeService.ExportData(encryptedData, s.privKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	wrappedKey, err := WrapKey(key, recipient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file := &File{
+		FileID:       record.FileID,
+		LegacyFileID: record.LegacyFileID,
+		Format:       record.Format,
+		UploadedAt:   record.CreatedAt,
+		Blob:         BlobName(record.FileID),
+		WrappedKey:   wrappedKey,
+		DataHash:     hex.EncodeToString(record.DataHash),
+		Signature:    hex.EncodeToString(record.Signature),
+		Report: Report{
+			RiskScore:     record.RiskScore,
+			ModelVersion:  record.ModelVersion,
+			ShareRiskTier: record.ShareRiskTier,
+			ReportedAt:    record.ReportedAt,
+		},
+		Chain: ChainRefs{
+			SessionID: record.SessionID,
+			DocID:     record.DocID,
+			TokenID:   record.TokenID,
+		},
+	}
+
+	if record.SessionID != "" {
+		uploadTx, err := s.onchainService.FindUploadTx(record.SessionID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if uploadTx != nil {
+			file.Chain.UploadTxHash = uploadTx.TxHash
+		}
+	}
+	if record.DocID != "" {
+		confirmTx, err := s.onchainService.FindConfirmTx(record.DocID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if confirmTx != nil {
+			file.Chain.ConfirmTxHash = confirmTx.TxHash
+		}
+	}
+	return file, blob, nil
+}
+
+func (s *exportService) findConsent(tokenID string) (*Consent, error) {
+	if tokenID == "" {
+		return nil, nil
+	}
+	consent, err := s.marketplaceRepository.FindConsent(tokenID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find consent: %w", err)
+	}
+	return &Consent{
+		TokenID:  consent.TokenID,
+		Owner:    consent.Owner,
+		Granted:  consent.Granted,
+		SignedAt: consent.SignedAt,
+	}, nil
+}
@@ -0,0 +1,209 @@
+package bundle
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+var ErrChainMismatch = errors.New("bundle was exported from another chain")
+
+// ImportResult reports what an import restored.
+type ImportResult struct {
+	User string
+	// The new file IDs of the restored files, by their file ID in the bundle
+	Restored map[string]string
+	// The files in the bundle whose genotype was already stored
+	Skipped  []string
+	Consents int
+}
+
+type ImportService interface {
+	Import(bundle *Bundle, trusted []common.Address) (*ImportResult, error)
+}
+
+type importService struct {
+	teeService             tee.TeeService
+	authService            auth.AuthService
+	geneDataStorageService storage.GeneDataStorageService
+	marketplaceRepository  storage.MarketplaceRepository
+	privKey                *ecdsa.PrivateKey
+	chainID                uint64
+}
+
+// NewImportService creates the service restoring bundles wrapped for privKey, the service wallet.
+func NewImportService(
+	teeService tee.TeeService,
+	authService auth.AuthService,
+	geneDataStorageService storage.GeneDataStorageService,
+	marketplaceRepository storage.MarketplaceRepository,
+	privKey *ecdsa.PrivateKey,
+	chainID uint64,
+) ImportService {
+	return &importService{
+		teeService:             teeService,
+		authService:            authService,
+		geneDataStorageService: geneDataStorageService,
+		marketplaceRepository:  marketplaceRepository,
+		privKey:                privKey,
+		chainID:                chainID,
+	}
+}
+
+type importedFile struct {
+	source        File
+	encryptedData []byte
+	geneData      storage.GeneData
+}
+
+// Import restores a bundle opened with Open, signed by one of the trusted deployments. Every file is
+// unwrapped and opened inside the TEE, which checks its hash, before anything is stored. Files whose
+// genotype is already stored are skipped, and a consent is only restored over an older one.
+func (s *importService) Import(bundle *Bundle, trusted []common.Address) (*ImportResult, error) {
+	if !isTrusted(bundle.Signer, trusted) {
+		return nil, fmt.Errorf("%w: %s", ErrUntrustedSigner, bundle.Signer.Hex())
+	}
+	manifest := bundle.Manifest
+	if manifest.ChainID != s.chainID {
+		return nil, fmt.Errorf("%w: chain %d, not %d", ErrChainMismatch, manifest.ChainID, s.chainID)
+	}
+	if !auth.ValidateAddress(manifest.User) {
+		return nil, fmt.Errorf("%w: invalid user %q", ErrInvalidBundle, manifest.User)
+	}
+
+	files := make([]importedFile, 0, len(manifest.Files))
+	for _, file := range manifest.Files {
+		imported, err := s.importFile(bundle, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import file %s: %w", file.FileID, err)
+		}
+		files = append(files, *imported)
+	}
+
+	user, err := s.findOrRegister(manifest.User)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{User: user.Pubkey, Restored: make(map[string]string)}
+	for _, file := range files {
+		file.geneData.UserID = user.ID
+		err := s.geneDataStorageService.RestoreGeneData(&file.geneData, file.encryptedData)
+		if errors.Is(err, storage.ErrDuplicateGeneData) {
+			result.Skipped = append(result.Skipped, file.source.FileID)
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to restore file %s: %w", file.source.FileID, err)
+		}
+		result.Restored[file.source.FileID] = file.geneData.FileID
+	}
+
+	for _, consent := range manifest.Consents {
+		restored, err := s.restoreConsent(consent)
+		if err != nil {
+			return result, err
+		}
+		if restored {
+			result.Consents++
+		}
+	}
+	return result, nil
+}
+
+// importFile seals a file of the bundle for this deployment and signs it, as an upload would be.
+func (s *importService) importFile(bundle *Bundle, file File) (*importedFile, error) {
+	key, err := UnwrapKey(file.WrappedKey, s.privKey)
+	if err != nil {
+		return nil, err
+	}
+	encryptedData, fingerprint, err := s.teeService.ImportData(bundle.Blob(file), key, &s.privKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	hash := crypto.Keccak256(encryptedData)
+	signature, err := crypto.Sign(hash, s.privKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign data: %w", err)
+	}
+
+	// The session was opened under the file ID the source had then
+	legacyFileID := file.LegacyFileID
+	if legacyFileID == "" {
+		legacyFileID = file.FileID
+	}
+	// The retention clock keeps running from the source's report; older bundles only have the upload time
+	reportedAt := file.Report.ReportedAt
+	if reportedAt == nil {
+		reportedAt = &file.UploadedAt
+	}
+	return &importedFile{
+		source:        file,
+		encryptedData: encryptedData,
+		geneData: storage.GeneData{
+			LegacyFileID:  legacyFileID,
+			Format:        file.Format,
+			DataHash:      hash,
+			Signature:     signature,
+			SessionID:     file.Chain.SessionID,
+			DocID:         file.Chain.DocID,
+			TokenID:       file.Chain.TokenID,
+			RiskScore:     file.Report.RiskScore,
+			ModelVersion:  file.Report.ModelVersion,
+			ShareRiskTier: file.Report.ShareRiskTier,
+			ReportedAt:    reportedAt,
+			Fingerprint:   &fingerprint,
+		},
+	}, nil
+}
+
+func (s *importService) findOrRegister(address string) (*auth.User, error) {
+	user, err := s.authService.Authenticate(address)
+	if errors.Is(err, auth.ErrUserNotFound) {
+		if _, err := s.authService.Register(address); err != nil {
+			return nil, fmt.Errorf("failed to register user: %w", err)
+		}
+		user, err = s.authService.Authenticate(address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	return user, nil
+}
+
+func (s *importService) restoreConsent(consent Consent) (bool, error) {
+	existing, err := s.marketplaceRepository.FindConsent(consent.TokenID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, fmt.Errorf("failed to find consent: %w", err)
+	}
+	if err == nil && existing.SignedAt >= consent.SignedAt {
+		return false, nil
+	}
+
+	err = s.marketplaceRepository.SaveConsent(&storage.ResearchConsent{
+		TokenID:  consent.TokenID,
+		Owner:    consent.Owner,
+		Granted:  consent.Granted,
+		SignedAt: consent.SignedAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to restore consent: %w", err)
+	}
+	return true, nil
+}
+
+func isTrusted(signer common.Address, trusted []common.Address) bool {
+	for _, address := range trusted {
+		if address == signer {
+			return true
+		}
+	}
+	return false
+}
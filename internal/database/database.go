@@ -0,0 +1,55 @@
+// Package database opens the gateway database named by a DSN.
+package database
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// DefaultDSN is the SQLite file used when DATABASE_URL is not set.
+const DefaultDSN = "gorm.db"
+
+// Dialector picks the driver of dsn:
+//   - postgres://… or postgresql://… opens PostgreSQL;
+//   - sqlite://<path>, or a bare path, opens the SQLite file at path.
+func Dialector(dsn string) (gorm.Dialector, error) {
+	switch {
+	case strings.HasPrefix(dsn, "postgres://"), strings.HasPrefix(dsn, "postgresql://"):
+		return postgres.Open(dsn), nil
+	case strings.HasPrefix(dsn, "sqlite://"):
+		return sqlite.Open(strings.TrimPrefix(dsn, "sqlite://")), nil
+	case strings.Contains(dsn, "://"):
+		return nil, fmt.Errorf("unsupported database %q", dsn[:strings.Index(dsn, "://")])
+	case dsn == "":
+		return nil, fmt.Errorf("empty database DSN")
+	default:
+		return sqlite.Open(dsn), nil
+	}
+}
+
+// Open connects to the database named by dsn.
+func Open(dsn string) (*gorm.DB, error) {
+	dialector, err := Dialector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect database: %v", err)
+	}
+	return db, nil
+}
+
+// OpenFromEnv connects to the database named by DATABASE_URL, by default the SQLite file gorm.db.
+func OpenFromEnv() (*gorm.DB, error) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		dsn = DefaultDSN
+	}
+	return Open(dsn)
+}
@@ -0,0 +1,30 @@
+package database
+
+import "testing"
+
+func TestDialector(t *testing.T) {
+	tests := []struct {
+		name    string
+		dsn     string
+		want    string
+		wantErr bool
+	}{
+		{name: "SQLite path", dsn: "gorm.db", want: "sqlite"},
+		{name: "SQLite URL", dsn: "sqlite:///var/lib/gateway/gorm.db", want: "sqlite"},
+		{name: "PostgreSQL", dsn: "postgres://gateway:secret@db:5432/gateway?sslmode=disable", want: "postgres"},
+		{name: "PostgreSQL scheme alias", dsn: "postgresql://db/gateway", want: "postgres"},
+		{name: "Unsupported driver", dsn: "mysql://db/gateway", wantErr: true},
+		{name: "Empty", dsn: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialector, err := Dialector(tt.dsn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Dialector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && dialector.Name() != tt.want {
+				t.Errorf("Dialector() = %s, want %s", dialector.Name(), tt.want)
+			}
+		})
+	}
+}
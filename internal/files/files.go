@@ -0,0 +1,125 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"gorm.io/gorm"
+)
+
+// File describes one upload to its uploader.
+type File struct {
+	FileID     string    `json:"fileId" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Size       int64     `json:"size" example:"40960"`
+	UploadedAt time.Time `json:"uploadedAt"`
+	Format     string    `json:"format" example:"markers-u64le"`
+	// Set once the upload is recorded
+	ModelVersion string `json:"modelVersion,omitempty" example:"g-stroke-v1"`
+	// Only shown when the user opted in to share it
+	RiskTier  int    `json:"riskTier,omitempty" example:"2"`
+	SessionID string `json:"sessionId,omitempty" example:"14"`
+	DocID     string `json:"docId,omitempty" example:"0b1c2d3e-..."`
+	TokenID   string `json:"tokenId,omitempty" example:"14"`
+	Status    string `json:"status" example:"completed"`
+	// Set when the user withdrew the file, and once its raw data is deleted
+	WithdrawnAt *time.Time `json:"withdrawnAt,omitempty"`
+	PurgedAt    *time.Time `json:"purgedAt,omitempty"`
+}
+
+// Page is one page of files; NextCursor is empty on the last one.
+type Page struct {
+	Files      []File `json:"files"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+var ErrFileNotFound = errors.New("file not found")
+
+type FileService interface {
+	ListFiles(address string, query storage.FileQuery) (*Page, error)
+	Withdraw(address string, fileID string) (*File, error)
+}
+
+type fileService struct {
+	authService            auth.AuthService
+	geneDataStorageService storage.GeneDataStorageService
+}
+
+func NewFileService(authService auth.AuthService, geneDataStorageService storage.GeneDataStorageService) FileService {
+	return &fileService{
+		authService:            authService,
+		geneDataStorageService: geneDataStorageService,
+	}
+}
+
+// ListFiles returns one page of the files uploaded by the user registered with address.
+func (s *fileService) ListFiles(address string, query storage.FileQuery) (*Page, error) {
+	user, err := s.authService.Authenticate(address)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.geneDataStorageService.ListFiles(user.ID, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	files := make([]File, 0, len(page.Files))
+	for i := range page.Files {
+		files = append(files, toFile(&page.Files[i]))
+	}
+	return &Page{Files: files, NextCursor: page.NextCursor}, nil
+}
+
+// Withdraw asks for the raw data of a file the user uploaded to be deleted. The retention policy
+// deletes it at its next run; the report, hashes and on-chain references are kept.
+func (s *fileService) Withdraw(address string, fileID string) (*File, error) {
+	user, err := s.authService.Authenticate(address)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.geneDataStorageService.FindByFileID(fileID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrFileNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find file: %w", err)
+	}
+	// Other users' files are not found, so their IDs cannot be probed
+	if record.UserID != user.ID {
+		return nil, ErrFileNotFound
+	}
+
+	if record.WithdrawnAt == nil {
+		withdrawnAt := time.Now().UTC()
+		if err := s.geneDataStorageService.WithdrawGeneData(record.FileID, withdrawnAt); err != nil {
+			return nil, fmt.Errorf("failed to withdraw file: %w", err)
+		}
+		record.WithdrawnAt = &withdrawnAt
+	}
+	file := toFile(record)
+	return &file, nil
+}
+
+func toFile(record *storage.GeneData) File {
+	file := File{
+		FileID:       record.FileID,
+		Size:         record.BlobSize,
+		UploadedAt:   record.CreatedAt,
+		Format:       record.Format,
+		ModelVersion: record.ModelVersion,
+		SessionID:    record.SessionID,
+		DocID:        record.DocID,
+		TokenID:      record.TokenID,
+		Status:       record.UploadStatus,
+		WithdrawnAt:  record.WithdrawnAt,
+		PurgedAt:     record.PurgedAt,
+	}
+	if record.ShareRiskTier {
+		file.RiskTier = record.RiskScore
+	}
+	return file
+}
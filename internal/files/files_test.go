@@ -0,0 +1,179 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"gorm.io/gorm"
+)
+
+const (
+	userAddress  = "0x2222222222222222222222222222222222222222"
+	otherAddress = "0x3333333333333333333333333333333333333333"
+)
+
+func newTestService(t *testing.T, db *gorm.DB) (FileService, uint32, uint32) {
+	authService := auth.NewAuthService(auth.NewUserRepository(db))
+	userID, err := authService.Register(userAddress)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	otherID, _ := authService.Register(otherAddress)
+	return NewFileService(authService, storage.NewGeneDataStorageService(db, nil)), userID, otherID
+}
+
+func TestFileService_ListFiles(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		service, userID, otherID := newTestService(t, db)
+
+		// Uploaded in order f1 to f5; f3 and f4 have the same size
+		sizes := []int64{30, 10, 20, 20, 40}
+		for i, size := range sizes {
+			status := storage.UploadCompleted
+			if i == 4 {
+				status = storage.UploadPending
+			}
+			db.Create(&storage.GeneData{
+				FileID:        fmt.Sprintf("f%d", i+1),
+				UserID:        userID,
+				Format:        "markers-u64le",
+				BlobSize:      size,
+				RiskScore:     3,
+				ShareRiskTier: i == 0,
+				UploadStatus:  status,
+			})
+		}
+		db.Create(&storage.GeneData{FileID: "other", UserID: otherID, UploadStatus: storage.UploadCompleted})
+
+		// listAll follows the cursors to the last page
+		listAll := func(query storage.FileQuery) []string {
+			var fileIDs []string
+			for pages := 0; pages < 10; pages++ {
+				page, err := service.ListFiles(userAddress, query)
+				if err != nil {
+					t.Fatalf("ListFiles() error = %v", err)
+				}
+				for _, file := range page.Files {
+					fileIDs = append(fileIDs, file.FileID)
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				query.Cursor = page.NextCursor
+			}
+			return fileIDs
+		}
+
+		tests := []struct {
+			name  string
+			query storage.FileQuery
+			want  []string
+		}{
+			{
+				name:  "Newest first",
+				query: storage.FileQuery{Limit: 2},
+				want:  []string{"f5", "f4", "f3", "f2", "f1"},
+			},
+			{
+				name:  "Oldest first",
+				query: storage.FileQuery{Ascending: true, Limit: 3},
+				want:  []string{"f1", "f2", "f3", "f4", "f5"},
+			},
+			{
+				name:  "Largest first, ties by upload",
+				query: storage.FileQuery{Sort: storage.SortSize, Limit: 2},
+				want:  []string{"f5", "f1", "f4", "f3", "f2"},
+			},
+			{
+				name:  "Smallest first",
+				query: storage.FileQuery{Sort: storage.SortSize, Ascending: true, Limit: 1},
+				want:  []string{"f2", "f3", "f4", "f1", "f5"},
+			},
+			{
+				name:  "Filtered by status",
+				query: storage.FileQuery{Status: storage.UploadPending},
+				want:  []string{"f5"},
+			},
+			{
+				name:  "Filtered by format",
+				query: storage.FileQuery{Format: "vcf"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got := listAll(tt.query)
+				if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+					t.Errorf("ListFiles() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+
+		page, _ := service.ListFiles(userAddress, storage.FileQuery{Ascending: true, Limit: 2})
+		if page.Files[0].RiskTier != 3 || page.Files[1].RiskTier != 0 {
+			t.Errorf("Expected the risk tier only where the user shares it, got %+v", page.Files)
+		}
+		if page.Files[0].Size != 30 || page.Files[0].Format != "markers-u64le" || page.Files[0].Status != storage.UploadCompleted {
+			t.Errorf("Unexpected file %+v", page.Files[0])
+		}
+
+		if _, err := service.ListFiles(userAddress, storage.FileQuery{Cursor: "not a cursor"}); !errors.Is(err, storage.ErrInvalidCursor) {
+			t.Errorf("ListFiles() error = %v, want %v", err, storage.ErrInvalidCursor)
+		}
+		if _, err := service.ListFiles("0x4444444444444444444444444444444444444444", storage.FileQuery{}); !errors.Is(err, auth.ErrUserNotFound) {
+			t.Errorf("ListFiles() error = %v, want %v", err, auth.ErrUserNotFound)
+		}
+	})
+}
+
+func TestFileService_Withdraw(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		service, userID, otherID := newTestService(t, db)
+		db.Create(&storage.GeneData{FileID: "mine", UserID: userID, UploadStatus: storage.UploadCompleted})
+		db.Create(&storage.GeneData{FileID: "theirs", UserID: otherID, UploadStatus: storage.UploadCompleted})
+
+		tests := []struct {
+			name    string
+			fileID  string
+			wantErr error
+		}{
+			{name: "Own file", fileID: "mine"},
+			{name: "Withdrawn again", fileID: "mine"},
+			{name: "Another user's file", fileID: "theirs", wantErr: ErrFileNotFound},
+			{name: "Unknown file", fileID: "none", wantErr: ErrFileNotFound},
+		}
+
+		var firstWithdrawal time.Time
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				file, err := service.Withdraw(userAddress, tt.fileID)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Withdraw() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr != nil {
+					return
+				}
+				if file.WithdrawnAt == nil {
+					t.Fatalf("Withdraw() = %+v, want it withdrawn", file)
+				}
+				// The first withdrawal is kept
+				if firstWithdrawal.IsZero() {
+					firstWithdrawal = *file.WithdrawnAt
+				} else if file.WithdrawnAt.Sub(firstWithdrawal).Abs() > time.Millisecond {
+					t.Errorf("Withdraw() again at %s, want %s", file.WithdrawnAt, firstWithdrawal)
+				}
+			})
+		}
+
+		var theirs storage.GeneData
+		db.Where("file_id = ?", "theirs").First(&theirs)
+		if theirs.WithdrawnAt != nil {
+			t.Errorf("Another user's file was withdrawn")
+		}
+	})
+}
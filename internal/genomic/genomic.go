@@ -3,12 +3,16 @@ package genomic
 import (
 	"crypto/ecdsa"
 	"encoding/hex"
+	"errors"
 	"fmt"
 
+	"github.com/TropicalDog17/genomic-dao-service/internal/access"
+	"github.com/TropicalDog17/genomic-dao-service/internal/audit"
 	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
 	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
 	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
 	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
 )
@@ -16,19 +20,29 @@ import (
 type UploadResult struct {
 	SessionID string
 	FileID    string
+	TokenID   string
 	Message   string
+	// Queued uploads are stored and encrypted, with their on-chain steps left for when the chain is back
+	Queued bool
 }
 
 type genomicService struct {
-	teeService             tee.TeeService
-	geneDataStorageService storage.GeneDataStorageService
-	authService            auth.AuthService
-	onchainService         onchain.OnchainService
+	teeService               tee.TeeService
+	geneDataStorageService   storage.GeneDataStorageService
+	authService              auth.AuthService
+	onchainService           onchain.OnchainService
+	accessService            access.AccessService
+	auditService             audit.AuditService
+	uploadWorkflowRepository storage.UploadWorkflowRepository
+	chain                    onchain.ChainMonitor
+	// Set once the claims left by the last shutdown are released
+	staleClaimsReleased bool
 }
 
 type GenomicService interface {
-	ProcessAndUploadGenomicData(genomicData []byte, pubkey string, privateKey *ecdsa.PrivateKey) (*UploadResult, error)
-	RetrieveGenomicData(fileID string, privKey *ecdsa.PrivateKey) ([]byte, error)
+	ProcessAndUploadGenomicData(genomicData []byte, pubkey string, shareRiskTier bool, privateKey *ecdsa.PrivateKey) (*UploadResult, error)
+	RetrieveGenomicData(fileID string, purpose string, requester common.Address, privKey *ecdsa.PrivateKey) ([]byte, error)
+	RecoverUploads() error
 }
 
 func NewGenomicService(
@@ -36,17 +50,67 @@ func NewGenomicService(
 	geneDataStorageService storage.GeneDataStorageService,
 	authService auth.AuthService,
 	onchainService onchain.OnchainService,
+	accessService access.AccessService,
+	auditService audit.AuditService,
+	uploadWorkflowRepository storage.UploadWorkflowRepository,
+	chain onchain.ChainMonitor,
 ) GenomicService {
 	return &genomicService{
-		teeService:             teeService,
-		geneDataStorageService: geneDataStorageService,
-		authService:            authService,
-		onchainService:         onchainService,
+		teeService:               teeService,
+		geneDataStorageService:   geneDataStorageService,
+		authService:              authService,
+		onchainService:           onchainService,
+		accessService:            accessService,
+		auditService:             auditService,
+		uploadWorkflowRepository: uploadWorkflowRepository,
+		chain:                    chain,
 	}
 }
 
-func (s *genomicService) RetrieveGenomicData(fileID string, privKey *ecdsa.PrivateKey) ([]byte, error) {
+// RetrieveGenomicData decrypts a file for its GeneNFT holder or an approved operator. Every decision
+// on a file that exists goes into the audit log, and the data is only returned once its access is
+// recorded.
+func (s *genomicService) RetrieveGenomicData(fileID string, purpose string, requester common.Address, privKey *ecdsa.PrivateKey) ([]byte, error) {
+	record, err := s.geneDataStorageService.FindByFileID(fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve genomic data: %w", err)
+	}
+	event := &storage.AuditEvent{
+		Actor:   requester.Hex(),
+		Action:  storage.AuditRetrieve,
+		FileID:  record.FileID,
+		UserID:  record.UserID,
+		Purpose: purpose,
+	}
+
+	// Only the current GeneNFT holder, or an operator it approved, may decrypt the data
+	err = access.ErrAccessDenied
+	if record.TokenID != "" {
+		err = s.accessService.CheckTokenAccess(record.TokenID, requester)
+	}
+	if err != nil {
+		if errors.Is(err, access.ErrAccessDenied) {
+			event.Outcome = storage.AuditDenied
+			s.auditService.Record(event)
+		}
+		return nil, err
+	}
+
+	decryptedGenomicData, err := s.decrypt(record.FileID, privKey)
+	if err != nil {
+		event.Outcome = storage.AuditFailed
+		s.auditService.Record(event)
+		return nil, err
+	}
+
+	event.Outcome = storage.AuditAllowed
+	if err := s.auditService.Record(event); err != nil {
+		return nil, fmt.Errorf("failed to record access: %w", err)
+	}
+	return decryptedGenomicData, nil
+}
 
+func (s *genomicService) decrypt(fileID string, privKey *ecdsa.PrivateKey) ([]byte, error) {
 	// Retrieve genomic data from storage
 	genomicData, err := s.geneDataStorageService.RetrieveGeneData(fileID)
 	if err != nil {
@@ -62,7 +126,7 @@ func (s *genomicService) RetrieveGenomicData(fileID string, privKey *ecdsa.Priva
 	return decryptedGenomicData, nil
 }
 
-func (s *genomicService) ProcessAndUploadGenomicData(genomicData []byte, pubkey string, privateKey *ecdsa.PrivateKey) (*UploadResult, error) {
+func (s *genomicService) ProcessAndUploadGenomicData(genomicData []byte, pubkey string, shareRiskTier bool, privateKey *ecdsa.PrivateKey) (*UploadResult, error) {
 	// Authenticate user
 	user, err := s.authService.Authenticate(pubkey)
 	if err != nil {
@@ -75,38 +139,60 @@ func (s *genomicService) ProcessAndUploadGenomicData(genomicData []byte, pubkey
 		return nil, fmt.Errorf("failed to process gene data: %w", err)
 	}
 
+	// Each genotype may be stored once; the fingerprint is keyed inside the TEE
+	fingerprint, err := s.teeService.Fingerprint(genomicData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process gene data: %w", err)
+	}
+
 	// Sign the processed data
 	hash, signature, err := s.signEncryptedGeneData(privateKey, processedData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign gene data: %w", err)
 	}
 
-	// Store the data
-	fileID, err := s.geneDataStorageService.StoreGeneData(user.ID, processedData, hash, signature)
-	if err != nil {
+	// Store the data together with the workflow that tracks its upload
+	workflow := &storage.UploadWorkflow{
+		UserAddress:   user.Pubkey,
+		DocID:         uuid.New().String(),
+		ContentHash:   hex.EncodeToString(hash),
+		RiskScore:     riskScore,
+		ShareRiskTier: shareRiskTier,
+	}
+	if err := s.uploadWorkflowRepository.StartUpload(user.ID, processedData, signature, hash, tee.DataFormat, fingerprint, workflow); err != nil {
 		return nil, fmt.Errorf("failed to store gene data: %w", err)
 	}
 
-	// Upload to blockchain
-	sessionID, err := s.onchainService.UploadData(fileID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to upload data to blockchain: %w", err)
+	// Without the chain, the upload waits in its workflow until the queue is drained
+	if !s.chain.Available() {
+		s.release(workflow)
+		return queued(workflow), nil
 	}
-
-	// Confirm upload
-	docID := uuid.New().String()
-	err = s.onchainService.ConfirmUpload(docID, hex.EncodeToString(hash), "0x1234", sessionID, riskScore)
-	if err != nil {
-		return nil, fmt.Errorf("failed to confirm upload: %w", err)
+	if err := s.runUpload(workflow); err != nil {
+		s.release(workflow)
+		if !s.chain.Available() {
+			return queued(workflow), nil
+		}
+		return nil, err
 	}
 
 	return &UploadResult{
-		SessionID: sessionID,
+		SessionID: workflow.SessionID,
 		Message:   "Genomic data uploaded successfully",
-		FileID:    fileID,
+		FileID:    workflow.FileID,
+		TokenID:   workflow.TokenID,
 	}, nil
 }
 
+func queued(workflow *storage.UploadWorkflow) *UploadResult {
+	return &UploadResult{
+		SessionID: workflow.SessionID,
+		Message:   "Genomic data stored, on-chain steps queued until the blockchain is reachable",
+		FileID:    workflow.FileID,
+		Queued:    true,
+	}
+}
+
 func (s *genomicService) signEncryptedGeneData(privateKey *ecdsa.PrivateKey, encryptedData []byte) ([]byte, []byte, error) {
 	hashData := crypto.Keccak256Hash(encryptedData).Bytes()
 	signature, err := crypto.Sign(hashData, privateKey)
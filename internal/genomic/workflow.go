@@ -0,0 +1,236 @@
+package genomic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MaxUploadAttempts is how many times an upload that has not been confirmed on-chain is tried
+// before it is abandoned. Confirmed uploads are always rolled forward.
+const MaxUploadAttempts = 3
+
+// proof submitted with every confirmation
+const uploadProof = "0x1234"
+
+// runUpload drives the workflow from its last completed step. Every step first checks
+// what already happened on-chain, so a step interrupted by a crash is never repeated.
+func (s *genomicService) runUpload(workflow *storage.UploadWorkflow) error {
+	for workflow.Step != storage.WorkflowCompleted {
+		var err error
+		switch workflow.Step {
+		case storage.WorkflowStored:
+			err = s.openSession(workflow)
+		case storage.WorkflowSessionOpened:
+			err = s.confirmSession(workflow)
+		case storage.WorkflowConfirmed:
+			err = s.recordUpload(workflow)
+		case storage.WorkflowRecorded:
+			err = s.deliverToken(workflow)
+		default:
+			return fmt.Errorf("upload %s is %s", workflow.FileID, workflow.Step)
+		}
+
+		if err != nil {
+			// An outage does not count as an attempt, or the queue would be abandoned while waiting
+			if !s.chain.Available() {
+				return err
+			}
+			if recordErr := s.uploadWorkflowRepository.RecordFailure(workflow, err); recordErr != nil {
+				log.Printf("failed to record upload failure for %s: %v", workflow.FileID, recordErr)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// openSession starts the on-chain upload session, reusing the one opened before a crash.
+func (s *genomicService) openSession(workflow *storage.UploadWorkflow) error {
+	sessionID, err := s.onchainService.FindUploadSession(workflow.FileID)
+	if err != nil {
+		return fmt.Errorf("failed to look up upload session: %w", err)
+	}
+
+	if sessionID == "" {
+		if sessionID, err = s.onchainService.UploadData(workflow.FileID); err != nil {
+			return fmt.Errorf("failed to upload data to blockchain: %w", err)
+		}
+	} else {
+		session, err := s.onchainService.GetSession(sessionID)
+		if err != nil {
+			return err
+		}
+		if session.User != s.onchainService.ServiceAddress() {
+			return fmt.Errorf("upload session %s for file %s was opened by %s", sessionID, workflow.FileID, session.User.Hex())
+		}
+	}
+
+	workflow.SessionID = sessionID
+	return s.advance(workflow, storage.WorkflowSessionOpened)
+}
+
+// confirmSession confirms the upload, or picks up the GeneNFT if the confirmation was already mined.
+func (s *genomicService) confirmSession(workflow *storage.UploadWorkflow) error {
+	session, err := s.onchainService.GetSession(workflow.SessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.Confirmed {
+		tokenID, err := s.onchainService.FindMintedToken(workflow.DocID)
+		if err != nil {
+			return err
+		}
+		if tokenID == "" {
+			return fmt.Errorf("upload session %s was confirmed without minting a GeneNFT for doc %s", workflow.SessionID, workflow.DocID)
+		}
+		workflow.TokenID = tokenID
+	} else {
+		confirmResult, err := s.onchainService.ConfirmUpload(workflow.DocID, workflow.ContentHash, uploadProof, workflow.SessionID, workflow.RiskScore)
+		if err != nil {
+			return fmt.Errorf("failed to confirm upload: %w", err)
+		}
+		workflow.TokenID = confirmResult.TokenID
+	}
+
+	return s.advance(workflow, storage.WorkflowConfirmed)
+}
+
+// recordUpload links the stored data to its on-chain doc and GeneNFT.
+func (s *genomicService) recordUpload(workflow *storage.UploadWorkflow) error {
+	err := s.geneDataStorageService.UpdateUploadRecord(workflow.FileID, storage.UploadRecord{
+		SessionID:     workflow.SessionID,
+		DocID:         workflow.DocID,
+		TokenID:       workflow.TokenID,
+		RiskScore:     workflow.RiskScore,
+		ModelVersion:  tee.ModelVersion,
+		ShareRiskTier: workflow.ShareRiskTier,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record upload: %w", err)
+	}
+
+	return s.advance(workflow, storage.WorkflowRecorded)
+}
+
+// deliverToken hands the GeneNFT, minted to the service wallet, to the user so it grants access to the data.
+func (s *genomicService) deliverToken(workflow *storage.UploadWorkflow) error {
+	userAddress := common.HexToAddress(workflow.UserAddress)
+	owner, err := s.onchainService.GetTokenOwner(workflow.TokenID)
+	if err != nil {
+		return err
+	}
+
+	if owner != userAddress {
+		if _, err := s.onchainService.TransferToken(workflow.TokenID, userAddress); err != nil {
+			return fmt.Errorf("failed to transfer GeneNFT to user: %w", err)
+		}
+	}
+
+	if err := s.uploadWorkflowRepository.Complete(workflow); err != nil {
+		return fmt.Errorf("failed to complete upload: %w", err)
+	}
+	return nil
+}
+
+func (s *genomicService) advance(workflow *storage.UploadWorkflow, step string) error {
+	workflow.Step = step
+	if err := s.uploadWorkflowRepository.Save(workflow); err != nil {
+		return fmt.Errorf("failed to save upload progress: %w", err)
+	}
+	return nil
+}
+
+// RecoverUploads resumes the uploads left incomplete by a crash, a failed step or a chain outage.
+// Uploads that still fail before their confirmation is mined are abandoned after MaxUploadAttempts.
+// It stops at the first upload that fails because the chain became unavailable. Uploads another
+// replica, or this one, is running are left to it. It is only called by the breaker, one run at a
+// time.
+func (s *genomicService) RecoverUploads() error {
+	if !s.chain.Available() {
+		return onchain.ErrChainUnavailable
+	}
+
+	// The uploads interrupted by the last shutdown are taken back at once, not when their claim expires
+	if !s.staleClaimsReleased {
+		if err := s.uploadWorkflowRepository.ReleaseStale(); err != nil {
+			return fmt.Errorf("failed to release stale upload claims: %w", err)
+		}
+		s.staleClaimsReleased = true
+	}
+
+	for afterID := uint(0); ; {
+		workflow, err := s.uploadWorkflowRepository.ClaimNext(afterID)
+		if err != nil {
+			return fmt.Errorf("failed to claim incomplete upload: %w", err)
+		}
+		if workflow == nil {
+			return nil
+		}
+		afterID = workflow.ID
+
+		err = s.runUpload(workflow)
+		if err == nil {
+			log.Printf("recovered upload %s with GeneNFT %s", workflow.FileID, workflow.TokenID)
+			continue
+		}
+		if !s.chain.Available() {
+			s.release(workflow)
+			return fmt.Errorf("upload %s: %w", workflow.FileID, onchain.ErrChainUnavailable)
+		}
+
+		log.Printf("failed to recover upload %s at step %s (attempt %d): %v", workflow.FileID, workflow.Step, workflow.Attempts, err)
+		if workflow.Attempts >= MaxUploadAttempts {
+			if err := s.compensate(workflow); err != nil {
+				log.Printf("failed to abandon upload %s: %v", workflow.FileID, err)
+			}
+		}
+		s.release(workflow)
+	}
+}
+
+// release lets the next recovery retry an upload this replica stopped running. Should it fail, the
+// upload is only retried once its claim expires.
+func (s *genomicService) release(workflow *storage.UploadWorkflow) {
+	if err := s.uploadWorkflowRepository.Release(workflow); err != nil {
+		log.Printf("failed to release upload %s: %v", workflow.FileID, err)
+	}
+}
+
+// compensate abandons an upload that never got its confirmation mined. The file is marked failed
+// and the note records any session left open on-chain, whose doc can no longer be submitted.
+func (s *genomicService) compensate(workflow *storage.UploadWorkflow) error {
+	if workflow.Step != storage.WorkflowStored && workflow.Step != storage.WorkflowSessionOpened {
+		// The GeneNFT and reward exist on-chain, so only rolling forward is possible
+		return nil
+	}
+
+	if workflow.SessionID == "" {
+		sessionID, err := s.onchainService.FindUploadSession(workflow.FileID)
+		if err != nil {
+			return err
+		}
+		workflow.SessionID = sessionID
+	}
+
+	note := "no on-chain session was opened"
+	if workflow.SessionID != "" {
+		session, err := s.onchainService.GetSession(workflow.SessionID)
+		if err != nil {
+			return err
+		}
+		// A confirmation that landed since the last attempt makes the upload recoverable
+		if session.Confirmed {
+			return nil
+		}
+		note = fmt.Sprintf("on-chain session %s for doc %s left open", workflow.SessionID, workflow.FileID)
+	}
+
+	log.Printf("abandoning upload %s: %s", workflow.FileID, note)
+	return s.uploadWorkflowRepository.Fail(workflow, note)
+}
@@ -0,0 +1,345 @@
+package genomic
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+var (
+	serviceAddress = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	userAddress    = common.HexToAddress("0x2222222222222222222222222222222222222222")
+)
+
+// Mock chain keeping just enough Controller and GeneNFT state for the upload workflow
+type mockOnchainService struct {
+	onchain.OnchainService
+	sessions     map[string]*contracts.ControllerUploadSession
+	docSessions  map[string]string
+	docTokens    map[string]string
+	owners       map[string]common.Address
+	failConfirm  bool
+	down         bool
+	uploadCalls  int
+	confirmCalls int
+}
+
+func newMockOnchainService() *mockOnchainService {
+	return &mockOnchainService{
+		sessions:    make(map[string]*contracts.ControllerUploadSession),
+		docSessions: make(map[string]string),
+		docTokens:   make(map[string]string),
+		owners:      make(map[string]common.Address),
+	}
+}
+
+func (m *mockOnchainService) ServiceAddress() common.Address {
+	return serviceAddress
+}
+
+// The mock is also the chain monitor: while down, every call fails like an unreachable node
+func (m *mockOnchainService) Available() bool {
+	return !m.down
+}
+
+func (m *mockOnchainService) Status() onchain.ChainStatus {
+	return onchain.ChainStatus{Available: !m.down}
+}
+
+func (m *mockOnchainService) FindUploadSession(docID string) (string, error) {
+	if m.down {
+		return "", onchain.ErrChainUnavailable
+	}
+	return m.docSessions[docID], nil
+}
+
+func (m *mockOnchainService) UploadData(docID string) (string, error) {
+	m.uploadCalls++
+	if _, ok := m.docSessions[docID]; ok {
+		return "", errors.New("Doc already been submitted")
+	}
+	sessionID := fmt.Sprint(len(m.sessions))
+	m.sessions[sessionID] = &contracts.ControllerUploadSession{User: serviceAddress}
+	m.docSessions[docID] = sessionID
+	return sessionID, nil
+}
+
+func (m *mockOnchainService) GetSession(sessionID string) (*contracts.ControllerUploadSession, error) {
+	session, ok := m.sessions[sessionID]
+	if !ok {
+		return &contracts.ControllerUploadSession{}, nil
+	}
+	return session, nil
+}
+
+func (m *mockOnchainService) ConfirmUpload(docID string, contentHash string, proof string, sessionID string, riskScore int) (*onchain.ConfirmResult, error) {
+	m.confirmCalls++
+	if m.failConfirm {
+		return nil, errors.New("execution reverted")
+	}
+	if m.sessions[sessionID].Confirmed {
+		return nil, errors.New("Session is ended")
+	}
+	m.sessions[sessionID].Confirmed = true
+	tokenID := fmt.Sprint(len(m.docTokens) + 1)
+	m.docTokens[docID] = tokenID
+	m.owners[tokenID] = serviceAddress
+	return &onchain.ConfirmResult{TokenID: tokenID}, nil
+}
+
+func (m *mockOnchainService) FindMintedToken(docID string) (string, error) {
+	return m.docTokens[docID], nil
+}
+
+func (m *mockOnchainService) GetTokenOwner(tokenID string) (common.Address, error) {
+	return m.owners[tokenID], nil
+}
+
+func (m *mockOnchainService) TransferToken(tokenID string, to common.Address) (string, error) {
+	m.owners[tokenID] = to
+	return "0xtx", nil
+}
+
+func newTestService(t *testing.T, db *gorm.DB, chain *mockOnchainService) (*genomicService, storage.UploadWorkflowRepository) {
+	return newReplica(t, db, chain, "gateway-1")
+}
+
+// newReplica is one of several gateway replicas sharing db.
+func newReplica(t *testing.T, db *gorm.DB, chain *mockOnchainService, owner string) (*genomicService, storage.UploadWorkflowRepository) {
+	blobs, err := blobstore.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to open blob store: %v", err)
+	}
+	workflows := storage.NewUploadWorkflowRepository(db, blobs, owner)
+	service := NewGenomicService(nil, storage.NewGeneDataStorageService(db, blobs), nil, chain, nil, nil, workflows, chain).(*genomicService)
+	return service, workflows
+}
+
+func startUpload(t *testing.T, workflows storage.UploadWorkflowRepository, fileSeed byte) *storage.UploadWorkflow {
+	workflow := &storage.UploadWorkflow{UserAddress: userAddress.Hex(), DocID: fmt.Sprintf("doc-%d", fileSeed), ContentHash: "abcd", RiskScore: 2}
+	hash := []byte{fileSeed, 1, 2, 3, 4, 5, 6, 7}
+	if err := workflows.StartUpload(1, []byte(fmt.Sprintf("encrypted-%d", fileSeed)), hash, hash, tee.DataFormat, fmt.Sprintf("fingerprint-%d", fileSeed), workflow); err != nil {
+		t.Fatalf("StartUpload() error = %v", err)
+	}
+	return workflow
+}
+
+func TestRunUpload(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		chain := newMockOnchainService()
+		service, workflows := newTestService(t, db, chain)
+		workflow := startUpload(t, workflows, 1)
+
+		if err := service.runUpload(workflow); err != nil {
+			t.Fatalf("runUpload() error = %v", err)
+		}
+		if workflow.Step != storage.WorkflowCompleted || workflow.TokenID != "1" {
+			t.Errorf("Unexpected workflow %+v", workflow)
+		}
+		if chain.owners["1"] != userAddress {
+			t.Errorf("Expected the GeneNFT to be delivered to the user")
+		}
+
+		var geneData storage.GeneData
+		db.Where("file_id = ?", workflow.FileID).First(&geneData)
+		if geneData.UploadStatus != storage.UploadCompleted || geneData.TokenID != "1" {
+			t.Errorf("Unexpected gene data status %q token %q", geneData.UploadStatus, geneData.TokenID)
+		}
+	})
+}
+
+func TestStartUpload_RejectsDuplicates(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		_, workflows := newTestService(t, db, newMockOnchainService())
+		first := startUpload(t, workflows, 1)
+
+		// Same genotype under a different ciphertext and file ID
+		upload := func() error {
+			hash := []byte{9, 1, 2, 3, 4, 5, 6, 7}
+			return workflows.StartUpload(2, []byte("encrypted again"), hash, hash, tee.DataFormat, "fingerprint-1", &storage.UploadWorkflow{DocID: "doc-9"})
+		}
+		if err := upload(); !errors.Is(err, storage.ErrDuplicateGeneData) {
+			t.Fatalf("StartUpload() error = %v, want %v", err, storage.ErrDuplicateGeneData)
+		}
+
+		// An abandoned upload no longer holds the genotype
+		if err := workflows.Fail(first, ""); err != nil {
+			t.Fatalf("Fail() error = %v", err)
+		}
+		if err := upload(); err != nil {
+			t.Errorf("StartUpload() after the first upload failed error = %v", err)
+		}
+	})
+}
+
+func TestRecoverUploads_ResumesWithoutRepeatingSteps(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		chain := newMockOnchainService()
+		service, workflows := newTestService(t, db, chain)
+
+		// Crash after uploadData was mined but before the session was recorded
+		opened := startUpload(t, workflows, 1)
+		chain.UploadData(opened.FileID)
+
+		// Crash after confirm was mined but before the token was recorded
+		confirmed := startUpload(t, workflows, 2)
+		sessionID, _ := chain.UploadData(confirmed.FileID)
+		confirmed.SessionID = sessionID
+		confirmed.Step = storage.WorkflowSessionOpened
+		workflows.Save(confirmed)
+		chain.ConfirmUpload(confirmed.DocID, "abcd", uploadProof, sessionID, 2)
+
+		// The replica restarts under the same owner
+		service, _ = newTestService(t, db, chain)
+		chain.uploadCalls, chain.confirmCalls = 0, 0
+		if err := service.RecoverUploads(); err != nil {
+			t.Fatalf("RecoverUploads() error = %v", err)
+		}
+
+		if chain.uploadCalls != 0 {
+			t.Errorf("Expected the open session to be reused, got %d uploadData calls", chain.uploadCalls)
+		}
+		if chain.confirmCalls != 1 {
+			t.Errorf("Expected only the unconfirmed upload to be confirmed, got %d confirm calls", chain.confirmCalls)
+		}
+		if incomplete, _ := workflows.FindIncomplete(); len(incomplete) != 0 {
+			t.Errorf("Expected every upload to complete, %d left", len(incomplete))
+		}
+	})
+}
+
+func TestRecoverUploads_CompensatesAfterMaxAttempts(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		chain := newMockOnchainService()
+		chain.failConfirm = true
+		service, workflows := newTestService(t, db, chain)
+		workflow := startUpload(t, workflows, 1)
+
+		if err := service.runUpload(workflow); err == nil {
+			t.Fatalf("Expected the upload to fail")
+		}
+		// As the failed upload request does
+		service.release(workflow)
+		for i := 1; i < MaxUploadAttempts; i++ {
+			service.RecoverUploads()
+		}
+
+		var failed storage.UploadWorkflow
+		db.Where("file_id = ?", workflow.FileID).First(&failed)
+		if failed.Step != storage.WorkflowFailed || failed.Attempts != MaxUploadAttempts {
+			t.Fatalf("Expected the upload to be abandoned after %d attempts, got %+v", MaxUploadAttempts, failed)
+		}
+		if want := fmt.Sprintf("on-chain session 0 for doc %s left open", workflow.FileID); failed.Note != want {
+			t.Errorf("Note = %q, want %q", failed.Note, want)
+		}
+
+		var geneData storage.GeneData
+		db.Where("file_id = ?", workflow.FileID).First(&geneData)
+		if geneData.UploadStatus != storage.UploadFailed {
+			t.Errorf("Expected gene data to be marked failed, got %q", geneData.UploadStatus)
+		}
+	})
+}
+
+func TestRecoverUploads_WaitsForChain(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		chain := newMockOnchainService()
+		chain.down = true
+		service, workflows := newTestService(t, db, chain)
+		workflow := startUpload(t, workflows, 1)
+
+		// Failures during an outage are not attempts, so the upload is never abandoned for it
+		for i := 0; i < MaxUploadAttempts+1; i++ {
+			if err := service.runUpload(workflow); !errors.Is(err, onchain.ErrChainUnavailable) {
+				t.Fatalf("runUpload() error = %v, want %v", err, onchain.ErrChainUnavailable)
+			}
+		}
+		service.release(workflow)
+		if err := service.RecoverUploads(); !errors.Is(err, onchain.ErrChainUnavailable) {
+			t.Fatalf("RecoverUploads() error = %v, want %v", err, onchain.ErrChainUnavailable)
+		}
+
+		incomplete, _ := workflows.FindIncomplete()
+		if len(incomplete) != 1 || incomplete[0].Attempts != 0 {
+			t.Fatalf("Expected the upload to stay queued without attempts, got %+v", incomplete)
+		}
+
+		chain.down = false
+		if err := service.RecoverUploads(); err != nil {
+			t.Fatalf("RecoverUploads() error = %v", err)
+		}
+		if incomplete, _ := workflows.FindIncomplete(); len(incomplete) != 0 {
+			t.Errorf("Expected the queue to be drained, got %+v", incomplete)
+		}
+		if chain.owners["1"] != userAddress {
+			t.Errorf("Expected the GeneNFT to be delivered to the user")
+		}
+	})
+}
+
+func TestRecoverUploads_LeavesClaimedUploads(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		chain := newMockOnchainService()
+		_, workflows := newTestService(t, db, chain)
+		other, _ := newReplica(t, db, chain, "gateway-2")
+
+		// The first replica crashed while running the upload
+		workflow := startUpload(t, workflows, 1)
+		if err := other.RecoverUploads(); err != nil {
+			t.Fatalf("RecoverUploads() error = %v", err)
+		}
+		if chain.uploadCalls != 0 {
+			t.Fatalf("Expected an upload claimed by another replica to be left alone")
+		}
+
+		// Its claim expires
+		db.Model(&storage.UploadWorkflow{}).Where("id = ?", workflow.ID).Update("claimed_until", time.Now().UTC().Add(-time.Second))
+		if err := other.RecoverUploads(); err != nil {
+			t.Fatalf("RecoverUploads() error = %v", err)
+		}
+		var recovered storage.UploadWorkflow
+		db.First(&recovered, workflow.ID)
+		if recovered.Step != storage.WorkflowCompleted || recovered.ClaimedBy != "gateway-2" {
+			t.Errorf("Expected the other replica to take the upload over, got step %s claimed by %s", recovered.Step, recovered.ClaimedBy)
+		}
+	})
+}
+
+func TestRecoverUploads_LeavesRunningUploads(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		chain := newMockOnchainService()
+		service, workflows := newTestService(t, db, chain)
+
+		// An upload request of this replica is still running the upload
+		workflow := startUpload(t, workflows, 1)
+		for i := 0; i < 2; i++ {
+			if err := service.RecoverUploads(); err != nil {
+				t.Fatalf("RecoverUploads() error = %v", err)
+			}
+		}
+		if chain.uploadCalls != 0 {
+			t.Fatalf("Expected a running upload to be left alone, got %d uploadData calls", chain.uploadCalls)
+		}
+
+		// The replica crashes and restarts under the same owner before the claim expires
+		restarted, _ := newTestService(t, db, chain)
+		if err := restarted.RecoverUploads(); err != nil {
+			t.Fatalf("RecoverUploads() error = %v", err)
+		}
+		var recovered storage.UploadWorkflow
+		db.First(&recovered, workflow.ID)
+		if recovered.Step != storage.WorkflowCompleted || chain.uploadCalls != 1 {
+			t.Errorf("Expected the restarted replica to take its upload back once, got step %s after %d uploadData calls", recovered.Step, chain.uploadCalls)
+		}
+	})
+}
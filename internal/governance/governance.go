@@ -0,0 +1,551 @@
+package governance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+var (
+	ErrInvalidProposalID = errors.New("invalid proposal ID")
+	ErrProposalNotFound  = errors.New("proposal not found")
+	ErrInvalidSupport    = errors.New("support must be 0 (against), 1 (for) or 2 (abstain)")
+	ErrVotingClosed      = errors.New("proposal is not open for voting")
+	ErrAlreadyVoted      = errors.New("already voted on this proposal")
+	ErrNoVotingPower     = errors.New("no votes delegated at the proposal snapshot")
+	ErrBelowThreshold    = errors.New("delegated votes are below the author threshold")
+	ErrSignatureExpired  = errors.New("signature expired")
+)
+
+// Vote support, as counted by GovernorCountingSimple
+const (
+	SupportAgainst uint8 = 0
+	SupportFor     uint8 = 1
+	SupportAbstain uint8 = 2
+)
+
+// Names of the IGovernor.ProposalState values, in order
+var proposalStates = []string{"pending", "active", "canceled", "defeated", "succeeded", "queued", "expired", "executed"}
+
+// The Governor records the service wallet as proposer, so the author is kept in the description
+const authorSuffix = "\n\nProposed by "
+
+// proposalScanWindow bounds the blocks of each ProposalCreated log query.
+const proposalScanWindow = 10000
+
+var (
+	// Ballot is verified by the Governor in castVoteBySig
+	ballotType = []apitypes.Type{
+		{Name: "proposalId", Type: "uint256"},
+		{Name: "support", Type: "uint8"},
+	}
+	// Delegation is verified by the PCSP token in delegateBySig
+	delegationType = []apitypes.Type{
+		{Name: "delegatee", Type: "address"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "expiry", Type: "uint256"},
+	}
+	// Proposal is verified by the service before it proposes on the author's behalf
+	proposalType = []apitypes.Type{
+		{Name: "proposer", Type: "address"},
+		{Name: "description", Type: "string"},
+		{Name: "deadline", Type: "uint256"},
+	}
+)
+
+// Proposal is a governance proposal with its current state and tally.
+type Proposal struct {
+	ID          string `json:"id" example:"5873014512847061873..."`
+	Proposer    string `json:"proposer" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	Description string `json:"description" example:"Prioritise the post-covid stroke cohort"`
+	State       string `json:"state" example:"active"`
+	// Snapshot is the block whose delegated balances weigh the votes
+	Snapshot uint64 `json:"snapshot" example:"1200"`
+	Deadline uint64 `json:"deadline" example:"303600"`
+	Tally    Tally  `json:"tally"`
+	Quorum   string `json:"quorum" example:"40000000000000000000000000"`
+	TxHash   string `json:"txHash"`
+}
+
+// Tally is the votes counted so far, in PCSP wei.
+type Tally struct {
+	For     string `json:"for" example:"60000000000000000000000000"`
+	Against string `json:"against" example:"0"`
+	Abstain string `json:"abstain" example:"0"`
+}
+
+// VotingPower is the votes an account holds and where its own balance is delegated.
+type VotingPower struct {
+	Address  string `json:"address"`
+	Delegate string `json:"delegate"`
+	Votes    string `json:"votes"`
+	// Nonce to sign the next delegation with
+	Nonce string `json:"nonce"`
+}
+
+// ProposalRequest is a proposal signed by its author.
+type ProposalRequest struct {
+	Proposer    common.Address
+	Description string
+	Deadline    *big.Int
+	Signature   string
+}
+
+// VoteRequest is a Ballot signed by the voter.
+type VoteRequest struct {
+	ProposalID *big.Int
+	Voter      common.Address
+	Support    uint8
+	Signature  string
+}
+
+// DelegationRequest is a Delegation signed by the token holder.
+type DelegationRequest struct {
+	Delegator common.Address
+	Delegatee common.Address
+	Expiry    *big.Int
+	Signature string
+}
+
+type GovernanceService interface {
+	GetDomain() (*onchain.EIP712Domain, error)
+	GetVotingPower(account common.Address) (*VotingPower, error)
+	Propose(req *ProposalRequest) (*Proposal, error)
+	ListProposals() ([]Proposal, error)
+	GetProposal(proposalID string) (*Proposal, error)
+	CastVote(req *VoteRequest) (*onchain.TxResult, error)
+	Delegate(req *DelegationRequest) (*onchain.TxResult, error)
+}
+
+type governanceService struct {
+	transactor   *onchain.Transactor
+	governor     *contracts.PCSPGovernor
+	governorAddr common.Address
+	pcspToken    *onchain.Linked[contracts.PCSPToken]
+	deployBlock  uint64
+	now          func() time.Time
+
+	mu sync.Mutex
+	// ProposalCreated events of confirmed blocks, in block order, and the next block to index
+	indexed   []*contracts.PCSPGovernorProposalCreated
+	nextBlock uint64
+}
+
+// NewGovernanceService binds the Governor and the PCSP token it counts votes with; deployBlock is
+// where the proposal scan starts.
+func NewGovernanceService(transactor *onchain.Transactor, governorAddr common.Address, deployBlock uint64) GovernanceService {
+	governor, err := contracts.NewPCSPGovernor(governorAddr, transactor.Backend())
+	if err != nil {
+		panic(err)
+	}
+
+	pcspToken := onchain.NewLinked(func() (*contracts.PCSPToken, error) {
+		tokenAddr, err := governor.Token(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PCSP address: %v", err)
+		}
+		return contracts.NewPCSPToken(tokenAddr, transactor.Backend())
+	})
+
+	return &governanceService{
+		transactor:   transactor,
+		governor:     governor,
+		governorAddr: governorAddr,
+		pcspToken:    pcspToken,
+		deployBlock:  deployBlock,
+		now:          time.Now,
+		nextBlock:    deployBlock,
+	}
+}
+
+// GetDomain returns the EIP-712 domain ballots and proposals are signed under.
+func (s *governanceService) GetDomain() (*onchain.EIP712Domain, error) {
+	domain, err := s.governor.Eip712Domain(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EIP-712 domain: %v", err)
+	}
+
+	return &onchain.EIP712Domain{
+		Name:              domain.Name,
+		Version:           domain.Version,
+		ChainID:           domain.ChainId,
+		VerifyingContract: domain.VerifyingContract,
+	}, nil
+}
+
+func (s *governanceService) GetVotingPower(account common.Address) (*VotingPower, error) {
+	pcspToken, err := s.pcspToken.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := pcspToken.GetVotes(nil, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get votes: %v", err)
+	}
+	delegate, err := pcspToken.Delegates(nil, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delegate: %v", err)
+	}
+	nonce, err := pcspToken.Nonces(nil, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %v", err)
+	}
+
+	return &VotingPower{
+		Address:  account.Hex(),
+		Delegate: delegate.Hex(),
+		Votes:    votes.String(),
+		Nonce:    nonce.String(),
+	}, nil
+}
+
+// Propose submits a signalling proposal for an author holding at least the author threshold in
+// delegated votes, or any votes when the threshold is zero. The Governor only takes proposals from
+// the service wallet, so this check is what holds authors to the threshold.
+func (s *governanceService) Propose(req *ProposalRequest) (*Proposal, error) {
+	if req.Deadline.Cmp(big.NewInt(s.now().Unix())) < 0 {
+		return nil, ErrSignatureExpired
+	}
+
+	domain, err := s.GetDomain()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := onchain.VerifyTypedData(domain.TypedData("Proposal", proposalType, apitypes.TypedDataMessage{
+		"proposer":    req.Proposer.Hex(),
+		"description": req.Description,
+		"deadline":    req.Deadline,
+	}), req.Proposer, req.Signature); err != nil {
+		return nil, err
+	}
+
+	threshold, err := s.governor.AuthorThreshold(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get author threshold: %v", err)
+	}
+	if threshold.Sign() == 0 {
+		threshold = big.NewInt(1)
+	}
+	pcspToken, err := s.pcspToken.Get()
+	if err != nil {
+		return nil, err
+	}
+	votes, err := pcspToken.GetVotes(nil, req.Proposer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get votes: %v", err)
+	}
+	if votes.Cmp(threshold) < 0 {
+		return nil, ErrBelowThreshold
+	}
+
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return nil, err
+	}
+
+	// Signalling proposals carry a single no-op call, since the Governor requires one
+	description := req.Description + authorSuffix + req.Proposer.Hex()
+	tx, err := s.governor.Propose(opts, []common.Address{s.governorAddr}, []*big.Int{big.NewInt(0)}, [][]byte{{}}, description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit proposal: %v", err)
+	}
+
+	receipt, err := s.transactor.WaitMined(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for transaction: %v", err)
+	}
+	for _, log := range receipt.Logs {
+		event, err := s.governor.ParseProposalCreated(*log)
+		if err == nil && event != nil {
+			return s.loadProposal(event)
+		}
+	}
+
+	return nil, fmt.Errorf("proposal reverted: %s", tx.Hash().Hex())
+}
+
+// ListProposals returns every proposal, newest first.
+func (s *governanceService) ListProposals() ([]Proposal, error) {
+	events, err := s.proposalEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := make([]Proposal, 0, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		proposal, err := s.loadProposal(events[i])
+		if err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, *proposal)
+	}
+	return proposals, nil
+}
+
+func (s *governanceService) GetProposal(proposalID string) (*Proposal, error) {
+	id, ok := new(big.Int).SetString(proposalID, 10)
+	if !ok || id.Sign() <= 0 {
+		return nil, ErrInvalidProposalID
+	}
+
+	events, err := s.proposalEvents()
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.ProposalId.Cmp(id) == 0 {
+			return s.loadProposal(event)
+		}
+	}
+	return nil, ErrProposalNotFound
+}
+
+// CastVote relays a ballot signed by the voter, weighted by the votes delegated to the voter at the snapshot.
+func (s *governanceService) CastVote(req *VoteRequest) (*onchain.TxResult, error) {
+	if req.Support > SupportAbstain {
+		return nil, ErrInvalidSupport
+	}
+
+	domain, err := s.GetDomain()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := onchain.VerifyTypedData(domain.TypedData("Ballot", ballotType, apitypes.TypedDataMessage{
+		"proposalId": req.ProposalID,
+		"support":    big.NewInt(int64(req.Support)),
+	}), req.Voter, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked here so the caller gets a clear error instead of a reverted transaction
+	snapshot, err := s.governor.ProposalSnapshot(nil, req.ProposalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposal snapshot: %v", err)
+	}
+	if snapshot.Sign() == 0 {
+		return nil, ErrProposalNotFound
+	}
+	state, err := s.governor.State(nil, req.ProposalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposal state: %v", err)
+	}
+	if stateName(state) != "active" {
+		return nil, fmt.Errorf("%w: proposal is %s", ErrVotingClosed, stateName(state))
+	}
+	voted, err := s.governor.HasVoted(nil, req.ProposalID, req.Voter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check vote: %v", err)
+	}
+	if voted {
+		return nil, ErrAlreadyVoted
+	}
+	weight, err := s.governor.GetVotes(nil, req.Voter, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get votes: %v", err)
+	}
+	if weight.Sign() == 0 {
+		return nil, ErrNoVotingPower
+	}
+
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.governor.CastVoteBySig(opts, req.ProposalID, req.Support, sig.V, sig.R, sig.S)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit vote: %v", err)
+	}
+
+	return s.transactor.WaitForTx(tx)
+}
+
+// Delegate relays a holder-signed delegation. Balances only count as votes once delegated,
+// to the holder itself or to another account.
+func (s *governanceService) Delegate(req *DelegationRequest) (*onchain.TxResult, error) {
+	if req.Expiry.Cmp(big.NewInt(s.now().Unix())) < 0 {
+		return nil, ErrSignatureExpired
+	}
+
+	pcspToken, err := s.pcspToken.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenDomain, err := pcspToken.Eip712Domain(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EIP-712 domain: %v", err)
+	}
+	domain := onchain.EIP712Domain{
+		Name:              tokenDomain.Name,
+		Version:           tokenDomain.Version,
+		ChainID:           tokenDomain.ChainId,
+		VerifyingContract: tokenDomain.VerifyingContract,
+	}
+
+	// Delegations share the nonce sequence with permits
+	nonce, err := pcspToken.Nonces(nil, req.Delegator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %v", err)
+	}
+
+	sig, err := onchain.VerifyTypedData(domain.TypedData("Delegation", delegationType, apitypes.TypedDataMessage{
+		"delegatee": req.Delegatee.Hex(),
+		"nonce":     nonce,
+		"expiry":    req.Expiry,
+	}), req.Delegator, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := pcspToken.DelegateBySig(opts, req.Delegatee, nonce, req.Expiry, sig.V, sig.R, sig.S)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit delegation: %v", err)
+	}
+
+	return s.transactor.WaitForTx(tx)
+}
+
+// proposalEvents returns every ProposalCreated event in block order. Confirmed blocks are indexed once;
+// the blocks still within the confirmation depth are read again on each call, as they may be reorganised.
+func (s *governanceService) proposalEvents() ([]*contracts.PCSPGovernorProposalCreated, error) {
+	head, err := s.transactor.Backend().HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %v", err)
+	}
+	latest := head.Number.Uint64()
+	confirmed := uint64(0)
+	if depth := s.transactor.Confirmations() - 1; latest >= depth {
+		confirmed = latest - depth
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.nextBlock <= confirmed {
+		events, err := s.scanProposals(s.nextBlock, confirmed)
+		if err != nil {
+			return nil, err
+		}
+		s.indexed = append(s.indexed, events...)
+		s.nextBlock = confirmed + 1
+	}
+
+	events := append([]*contracts.PCSPGovernorProposalCreated(nil), s.indexed...)
+	if s.nextBlock <= latest {
+		recent, err := s.scanProposals(s.nextBlock, latest)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, recent...)
+	}
+	return events, nil
+}
+
+// scanProposals reads the ProposalCreated events from block start to end, proposalScanWindow blocks at a time.
+func (s *governanceService) scanProposals(start uint64, end uint64) ([]*contracts.PCSPGovernorProposalCreated, error) {
+	var events []*contracts.PCSPGovernorProposalCreated
+	for from := start; from <= end; from += proposalScanWindow {
+		to := min(from+proposalScanWindow-1, end)
+		it, err := s.governor.FilterProposalCreated(&bind.FilterOpts{Start: from, End: &to, Context: context.Background()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter proposals: %v", err)
+		}
+		var window []*contracts.PCSPGovernorProposalCreated
+		for it.Next() {
+			window = append(window, it.Event)
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read proposals: %v", err)
+		}
+
+		sort.SliceStable(window, func(i, j int) bool {
+			return window[i].Raw.BlockNumber < window[j].Raw.BlockNumber
+		})
+		events = append(events, window...)
+	}
+	return events, nil
+}
+
+// loadProposal completes a ProposalCreated event with the proposal's current state and tally.
+func (s *governanceService) loadProposal(event *contracts.PCSPGovernorProposalCreated) (*Proposal, error) {
+	state, err := s.governor.State(nil, event.ProposalId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposal state: %v", err)
+	}
+	votes, err := s.governor.ProposalVotes(nil, event.ProposalId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposal votes: %v", err)
+	}
+
+	// The quorum is only known once the snapshot block is mined
+	quorum := ""
+	if stateName(state) != "pending" {
+		q, err := s.governor.Quorum(nil, event.VoteStart)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get quorum: %v", err)
+		}
+		quorum = q.String()
+	}
+
+	description, proposer := splitAuthor(event.Description, event.Proposer, s.transactor.From())
+	return &Proposal{
+		ID:          event.ProposalId.String(),
+		Proposer:    proposer,
+		Description: description,
+		State:       stateName(state),
+		Snapshot:    event.VoteStart.Uint64(),
+		Deadline:    event.VoteEnd.Uint64(),
+		Tally: Tally{
+			For:     votes.ForVotes.String(),
+			Against: votes.AgainstVotes.String(),
+			Abstain: votes.AbstainVotes.String(),
+		},
+		Quorum: quorum,
+		TxHash: event.Raw.TxHash.Hex(),
+	}, nil
+}
+
+// splitAuthor separates the author appended by Propose. Only proposals sent by the service wallet
+// are trusted with an author; proposals sent directly keep the on-chain proposer and description.
+func splitAuthor(description string, proposer common.Address, serviceAddress common.Address) (string, string) {
+	if proposer != serviceAddress {
+		return description, proposer.Hex()
+	}
+	i := strings.LastIndex(description, authorSuffix)
+	if i < 0 {
+		return description, proposer.Hex()
+	}
+	author := description[i+len(authorSuffix):]
+	if !common.IsHexAddress(author) {
+		return description, proposer.Hex()
+	}
+	return description[:i], common.HexToAddress(author).Hex()
+}
+
+func stateName(state uint8) string {
+	if int(state) < len(proposalStates) {
+		return proposalStates[state]
+	}
+	return fmt.Sprintf("unknown(%d)", state)
+}
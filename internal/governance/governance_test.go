@@ -0,0 +1,266 @@
+package governance
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Compiled by `npx hardhat compile` in genomicdao
+const artifactsDir = "../../genomicdao/artifacts/contracts/"
+
+const votingPeriod = 40
+
+func loadBytecode(t *testing.T, artifact string) []byte {
+	data, err := os.ReadFile(artifactsDir + artifact)
+	if err != nil {
+		t.Skipf("contract artifacts not compiled: %v", err)
+	}
+	var compiled struct {
+		Bytecode string `json:"bytecode"`
+	}
+	if err := json.Unmarshal(data, &compiled); err != nil {
+		t.Fatalf("Failed to parse %s: %v", artifact, err)
+	}
+	return common.FromHex(compiled.Bytecode)
+}
+
+func deploy(t *testing.T, backend *simulated.Backend, auth *bind.TransactOpts, metaData *bind.MetaData, artifact string, params ...interface{}) common.Address {
+	parsed, err := metaData.GetAbi()
+	if err != nil {
+		t.Fatalf("Failed to parse ABI: %v", err)
+	}
+	address, _, _, err := bind.DeployContract(auth, *parsed, loadBytecode(t, artifact), backend.Client(), params...)
+	if err != nil {
+		t.Fatalf("Failed to deploy %s: %v", artifact, err)
+	}
+	backend.Commit()
+	return address
+}
+
+func sign(t *testing.T, typedData apitypes.TypedData, key *ecdsa.PrivateKey) string {
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		t.Fatalf("Failed to hash typed data: %v", err)
+	}
+	signature, err := crypto.Sign(digest, key)
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	return hexutil.Encode(signature)
+}
+
+// waitForState polls until the proposal leaves from, failing after a few seconds.
+func waitForState(t *testing.T, service GovernanceService, proposalID string, from string) *Proposal {
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+		proposal, err := service.GetProposal(proposalID)
+		if err != nil {
+			t.Fatalf("GetProposal() error = %v", err)
+		}
+		if proposal.State != from {
+			return proposal
+		}
+	}
+	t.Fatalf("Proposal %s still %s", proposalID, from)
+	return nil
+}
+
+func pcsp(amount int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(amount), big.NewInt(1e18))
+}
+
+func TestGovernance_EndToEnd(t *testing.T) {
+	serviceKey, _ := crypto.GenerateKey()
+	voterKey, _ := crypto.GenerateKey()
+	voter := crypto.PubkeyToAddress(voterKey.PublicKey)
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		crypto.PubkeyToAddress(serviceKey.PublicKey): {Balance: pcsp(1000)},
+		voter: {Balance: pcsp(1)},
+	})
+	defer backend.Close()
+
+	chainID, _ := backend.Client().ChainID(context.Background())
+	auth, _ := bind.NewKeyedTransactorWithChainID(serviceKey, chainID)
+	voterAuth, _ := bind.NewKeyedTransactorWithChainID(voterKey, chainID)
+
+	tokenAddr := deploy(t, backend, auth, contracts.PCSPTokenMetaData, "Token.sol/PostCovidStrokePrevention.json")
+	// The service wallet proposes without votes of its own, for authors above the threshold
+	governorAddr := deploy(t, backend, auth, contracts.PCSPGovernorMetaData, "Governor.sol/PCSPGovernor.json",
+		tokenAddr, big.NewInt(0), big.NewInt(votingPeriod), pcsp(1000), big.NewInt(4))
+
+	token, _ := contracts.NewPCSPToken(tokenAddr, backend.Client())
+	if _, err := token.Transfer(auth, voter, pcsp(60000000)); err != nil {
+		t.Fatalf("Failed to fund voter: %v", err)
+	}
+	backend.Commit()
+
+	// The service waits for its transactions, so blocks are mined in the background
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				backend.Commit()
+			}
+		}
+	}()
+
+	transactor := onchain.NewTransactor(backend.Client(), auth, onchain.DefaultFeeConfig(), 1)
+	service := NewGovernanceService(transactor, governorAddr, 0)
+	expiry := big.NewInt(time.Now().Add(time.Hour).Unix())
+
+	// Undelegated balances carry no votes
+	proposal := &ProposalRequest{Proposer: voter, Description: "Prioritise the post-covid stroke cohort", Deadline: expiry}
+	domain, err := service.GetDomain()
+	if err != nil {
+		t.Fatalf("GetDomain() error = %v", err)
+	}
+	proposal.Signature = sign(t, domain.TypedData("Proposal", proposalType, apitypes.TypedDataMessage{
+		"proposer":    voter.Hex(),
+		"description": proposal.Description,
+		"deadline":    expiry,
+	}), voterKey)
+	if _, err := service.Propose(proposal); !errors.Is(err, ErrBelowThreshold) {
+		t.Fatalf("Expected an undelegated author to be refused, got %v", err)
+	}
+
+	tokenDomain, _ := token.Eip712Domain(nil)
+	if _, err := service.Delegate(&DelegationRequest{
+		Delegator: voter,
+		Delegatee: voter,
+		Expiry:    expiry,
+		Signature: sign(t, onchain.EIP712Domain{
+			Name:              tokenDomain.Name,
+			Version:           tokenDomain.Version,
+			ChainID:           tokenDomain.ChainId,
+			VerifyingContract: tokenDomain.VerifyingContract,
+		}.TypedData("Delegation", delegationType, apitypes.TypedDataMessage{
+			"delegatee": voter.Hex(),
+			"nonce":     big.NewInt(0),
+			"expiry":    expiry,
+		}), voterKey),
+	}); err != nil {
+		t.Fatalf("Delegate() error = %v", err)
+	}
+	if power, _ := service.GetVotingPower(voter); power.Votes != pcsp(60000000).String() {
+		t.Fatalf("Expected delegated votes, got %+v", power)
+	}
+
+	created, err := service.Propose(proposal)
+	if err != nil {
+		t.Fatalf("Propose() error = %v", err)
+	}
+	if created.Proposer != voter.Hex() || created.Description != proposal.Description {
+		t.Errorf("Unexpected proposal %+v", created)
+	}
+
+	// Votes moved after the proposal block do not count
+	tx, err := token.Transfer(voterAuth, auth.From, pcsp(50000000))
+	if err != nil {
+		t.Fatalf("Failed to transfer: %v", err)
+	}
+	if _, err := bind.WaitMined(context.Background(), backend.Client(), tx); err != nil {
+		t.Fatalf("Failed to wait for transfer: %v", err)
+	}
+
+	waitForState(t, service, created.ID, "pending")
+	proposalID, _ := new(big.Int).SetString(created.ID, 10)
+	if _, err := service.CastVote(&VoteRequest{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Support:    SupportFor,
+		Signature: sign(t, domain.TypedData("Ballot", ballotType, apitypes.TypedDataMessage{
+			"proposalId": proposalID,
+			"support":    big.NewInt(int64(SupportFor)),
+		}), voterKey),
+	}); err != nil {
+		t.Fatalf("CastVote() error = %v", err)
+	}
+
+	outcome := waitForState(t, service, created.ID, "active")
+	if outcome.State != "succeeded" || outcome.Tally.For != pcsp(60000000).String() {
+		t.Errorf("Unexpected outcome %+v", outcome)
+	}
+
+	if proposals, _ := service.ListProposals(); len(proposals) != 1 {
+		t.Errorf("Expected 1 proposal, got %d", len(proposals))
+	}
+	// Confirmed blocks are not scanned again
+	if indexed := service.(*governanceService).indexed; len(indexed) != 1 {
+		t.Errorf("Expected the proposal to be indexed, got %d", len(indexed))
+	}
+}
+
+func TestSplitAuthor(t *testing.T) {
+	service := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	author := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	spoofer := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	tests := []struct {
+		name            string
+		proposer        common.Address
+		description     string
+		wantDescription string
+		wantProposer    string
+	}{
+		{
+			name:            "Proposed for an author",
+			description:     "Fund sequencing" + authorSuffix + author.Hex(),
+			wantDescription: "Fund sequencing",
+			wantProposer:    author.Hex(),
+		},
+		{
+			name:            "Proposed directly",
+			description:     "Fund sequencing",
+			wantDescription: "Fund sequencing",
+			wantProposer:    service.Hex(),
+		},
+		{
+			name:            "Suffix without an address",
+			description:     "Fund sequencing" + authorSuffix + "the lab",
+			wantDescription: "Fund sequencing" + authorSuffix + "the lab",
+			wantProposer:    service.Hex(),
+		},
+		{
+			name:            "Author spoofed by a direct proposer",
+			proposer:        spoofer,
+			description:     "Fund sequencing" + authorSuffix + author.Hex(),
+			wantDescription: "Fund sequencing" + authorSuffix + author.Hex(),
+			wantProposer:    spoofer.Hex(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proposer := service
+			if tt.proposer != (common.Address{}) {
+				proposer = tt.proposer
+			}
+			description, got := splitAuthor(tt.description, proposer, service)
+			if description != tt.wantDescription || got != tt.wantProposer {
+				t.Errorf("splitAuthor() = %q, %s, want %q, %s", description, got, tt.wantDescription, tt.wantProposer)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminKeyHeader carries the key of administrative requests.
+const AdminKeyHeader = "X-Admin-Key"
+
+// RequireAdmin refuses requests that do not carry the admin API key.
+func RequireAdmin(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiKey == "" || subtle.ConstantTimeCompare([]byte(c.GetHeader(AdminKeyHeader)), []byte(apiKey)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{Error: "invalid admin key"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/anchor"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+type AnchorHandler interface {
+	GetProof(c *gin.Context)
+}
+
+type anchorHandler struct {
+	anchorService anchor.AnchorService
+}
+
+func NewAnchorHandler(anchorService anchor.AnchorService) AnchorHandler {
+	return &anchorHandler{
+		anchorService: anchorService,
+	}
+}
+
+// @Summary Get content hash inclusion proof
+// @Description Get the Merkle proof that a file's content hash is in a root anchored on the Controller. Anyone can check it with the Controller's verifyAnchored(root, proof, docId, contentHash), or with OpenZeppelin's MerkleProof against the root.
+// @Tags records
+// @Produce json
+// @Param fileId path string true "File ID"
+// @Success 200 {object} anchor.Proof
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /files/{fileId}/proof [get]
+func (h *anchorHandler) GetProof(c *gin.Context) {
+	proof, err := h.anchorService.GetProof(c.Param("fileId"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, anchor.ErrFileNotFound), errors.Is(err, anchor.ErrNotAnchored):
+			status = http.StatusNotFound
+		case errors.Is(err, anchor.ErrAwaitingAnchor), errors.Is(err, storage.ErrAmbiguousFileID):
+			status = http.StatusConflict
+		}
+		c.JSON(status, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, proof)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/access"
+	"github.com/TropicalDog17/genomic-dao-service/internal/audit"
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler interface {
+	ListAccessLog(c *gin.Context)
+}
+
+type auditHandler struct {
+	accessService access.AccessService
+	auditService  audit.AuditService
+}
+
+func NewAuditHandler(auditService audit.AuditService, accessService access.AccessService) AuditHandler {
+	return &auditHandler{
+		accessService: accessService,
+		auditService:  auditService,
+	}
+}
+
+// @Summary List accesses to my data
+// @Description Lists who retrieved, analysed or exported the files of the signing wallet, when, for what purpose and with
+// @Description what outcome, newest first. Each entry has its hash in the audit log, whose heads the service wallet signs.
+// @Description The wallet signs "GenomicDAO access log request\nDomain: {domain}\nChain ID: {chainId}\nTimestamp: {timestamp}"
+// @Description with personal_sign, with "Cursor: {cursor}\n" before the timestamp for any page but the first. The domain is the
+// @Description sign-in domain and the chain the one of /auth/nonce. A signature is accepted only once, so each page is signed.
+// @Tags genomic
+// @Produce json
+// @Param address query string true "Requesting wallet address"
+// @Param timestamp query int true "Unix time the request was signed at"
+// @Param signature query string true "personal_sign signature of the access log message"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param limit query int false "Entries per page, at most 100"
+// @Success 200 {object} audit.Page
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/me/access-log [get]
+func (h *auditHandler) ListAccessLog(c *gin.Context) {
+	timestamp, err := strconv.ParseInt(c.Query("timestamp"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: access.ErrInvalidRequest.Error()})
+		return
+	}
+	address := c.Query("address")
+	if _, err := h.accessService.VerifyAccessLogRequest(address, c.Query("cursor"), timestamp, c.Query("signature")); err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > audit.MaxPageSize {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid query"})
+		return
+	}
+
+	page, err := h.auditService.ListForUser(address, c.Query("cursor"), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, auth.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
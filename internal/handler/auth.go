@@ -1,63 +1,155 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
 	"github.com/gin-gonic/gin"
 )
 
+// SessionUserKey is the context key RequireSession stores the signed-in user under.
+const SessionUserKey = "sessionUser"
+
 type authHandler struct {
-	authService auth.AuthService
+	loginService auth.LoginService
 }
 
 type AuthHandler interface {
+	Nonce(c *gin.Context)
 	Register(c *gin.Context)
+	Login(c *gin.Context)
 }
 
-func NewAuthHandler(authService auth.AuthService) AuthHandler {
+func NewAuthHandler(loginService auth.LoginService) AuthHandler {
 	return &authHandler{
-		authService,
+		loginService,
 	}
 }
 
 // @BasePath /
 
+// Nonce godoc
+// @Summary Get a sign-in nonce
+// @Description Hands out a nonce for one Sign-In with Ethereum (EIP-4361) message, with the domain and chain ID the message must be for.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} auth.Challenge
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/nonce [get]
+func (h *authHandler) Nonce(c *gin.Context) {
+	challenge, err := h.loginService.Challenge()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, challenge)
+}
+
 // Register godoc
 // @Summary Register a new user
-// @Description Register a new user with their public key
+// @Description Registers the wallet that signed a Sign-In with Ethereum (EIP-4361) message with personal_sign, which proves it holds the key.
+// @Description The message must be for this domain and chain, carry a nonce from GET /auth/nonce, which it uses up, and be currently valid.
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Param request body RegisterRequest true "User registration details"
+// @Param request body SignedMessageRequest true "Signed sign-in message"
 // @Success 200 {object} RegisterResponse "Successfully registered"
 // @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Invalid signature, nonce or validity"
+// @Failure 409 {object} ErrorResponse "Already registered"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /auth/register [post]
 func (h *authHandler) Register(c *gin.Context) {
-	// Get the public key from the request body
-	var req struct {
-		Address string `json:"address"`
-	}
-
+	var req SignedMessageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	// Register the user
-	userID, err := h.authService.Register(req.Address)
+	userID, err := h.loginService.Register(req.Message, req.Signature)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondLoginError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"userID": userID})
 }
 
-// RegisterRequest represents the request body for user registration
-type RegisterRequest struct {
-	Address string `json:"address" example:"0x6491414173c71986Ee031307Af447cE1DbDf2ED0"` // Public key in bytes
+// Login godoc
+// @Summary Sign in
+// @Description Opens a session for the registered wallet that signed a Sign-In with Ethereum (EIP-4361) message with personal_sign, checked as
+// @Description for registration. The token is sent as "Authorization: Bearer {token}" until it expires, after SIWE_SESSION_TTL (24 hours by default) or with the message.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body SignedMessageRequest true "Signed sign-in message"
+// @Success 200 {object} auth.Token
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Invalid signature, nonce or validity"
+// @Failure 404 {object} ErrorResponse "Not registered"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /auth/login [post]
+func (h *authHandler) Login(c *gin.Context) {
+	var req SignedMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	token, err := h.loginService.Login(req.Message, req.Signature)
+	if err != nil {
+		respondLoginError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+func respondLoginError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, auth.ErrInvalidMessage), errors.Is(err, auth.ErrDomainMismatch), errors.Is(err, auth.ErrChainMismatch):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case errors.Is(err, auth.ErrMessageExpired), errors.Is(err, auth.ErrInvalidNonce), errors.Is(err, auth.ErrInvalidSignature):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: err.Error()})
+	case errors.Is(err, auth.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+	case errors.Is(err, auth.ErrUserExists):
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
+
+// RequireSession refuses requests without the bearer token of an open session, and stores its user
+// under SessionUserKey.
+func RequireSession(loginService auth.LoginService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{Error: auth.ErrInvalidSession.Error()})
+			return
+		}
+		user, err := loginService.Authenticate(token)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, auth.ErrInvalidSession) || errors.Is(err, auth.ErrUserNotFound) {
+				status = http.StatusUnauthorized
+			}
+			c.AbortWithStatusJSON(status, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.Set(SessionUserKey, user)
+		c.Next()
+	}
+}
+
+// SignedMessageRequest is a Sign-In with Ethereum message and its personal_sign signature.
+type SignedMessageRequest struct {
+	Message   string `json:"message" binding:"required" example:"localhost:8080 wants you to sign in with your Ethereum account:\n0x62f563A2e09c7987dECBFF61fdcC89cd74717721\n\nSign in to GenomicDAO\n\nURI: http://localhost:8080\nVersion: 1\nChain ID: 9999\nNonce: q3vR8xKp2LmN7aTz\nIssued At: 2024-12-15T16:38:43Z"`
+	Signature string `json:"signature" binding:"required" example:"0x..."`
 }
 
 // RegisterResponse represents the response for successful registration
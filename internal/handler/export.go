@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/access"
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/bundle"
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gin-gonic/gin"
+)
+
+// ExportErrorTrailer reports an export that failed after the bundle started streaming. The bundle is
+// then cut short before its manifest, so it cannot be opened.
+const ExportErrorTrailer = "X-Export-Error"
+
+type ExportHandler interface {
+	Export(c *gin.Context)
+}
+
+type exportHandler struct {
+	accessService access.AccessService
+	exportService bundle.ExportService
+}
+
+func NewExportHandler(exportService bundle.ExportService, accessService access.AccessService) ExportHandler {
+	return &exportHandler{
+		accessService: accessService,
+		exportService: exportService,
+	}
+}
+
+// @Summary Export my files
+// @Description Exports the completed uploads of the signing wallet as a zip bundle signed by the service wallet: a manifest
+// @Description with the reports, consents and on-chain references of each file, and the files sealed under keys wrapped
+// @Description for the recipient. The wallet signs "GenomicDAO export request\nDomain: {domain}\nChain ID: {chainId}\nRecipient: {recipient}\nTimestamp: {timestamp}"
+// @Description with personal_sign, the recipient left empty when it is not given. The domain is the sign-in domain and the
+// @Description chain the one of /auth/nonce. A signature is accepted only once. Files whose GeneNFT the wallet no longer
+// @Description holds are left out. The bundle is streamed; an export failing after it started is cut short and reported in
+// @Description the X-Export-Error trailer.
+// @Tags genomic
+// @Produce application/zip
+// @Param address query string true "Requesting wallet address"
+// @Param timestamp query int true "Unix time the request was signed at"
+// @Param signature query string true "personal_sign signature of the export message"
+// @Param recipient query string false "Uncompressed secp256k1 public key of the deployment to import into, hex; this deployment by default"
+// @Success 200 {file} file "Export bundle"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /users/me/export [get]
+func (h *exportHandler) Export(c *gin.Context) {
+	timestamp, err := strconv.ParseInt(c.Query("timestamp"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: access.ErrInvalidRequest.Error()})
+		return
+	}
+	address := c.Query("address")
+	recipientHex := c.Query("recipient")
+	if _, err := h.accessService.VerifyExportRequest(address, recipientHex, timestamp, c.Query("signature")); err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var recipient *ecdsa.PublicKey
+	if recipientHex != "" {
+		key, err := hexutil.Decode(recipientHex)
+		if err == nil {
+			recipient, err = crypto.UnmarshalPubkey(key)
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid recipient public key"})
+			return
+		}
+	}
+
+	bundle := &bundleWriter{c: c, filename: fmt.Sprintf("genomicdao-export-%s-%d.zip", address, timestamp)}
+	if err := h.exportService.Export(address, recipient, bundle); err != nil {
+		if bundle.started {
+			log.Printf("export for %s failed after streaming started: %v", address, err)
+			c.Writer.Header().Set(ExportErrorTrailer, err.Error())
+			return
+		}
+		switch {
+		case errors.Is(err, auth.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, onchain.ErrChainUnavailable):
+			c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+}
+
+// bundleWriter streams the bundle to the response, sending the headers with its first bytes so an
+// export failing before then is still answered with an error status.
+type bundleWriter struct {
+	c        *gin.Context
+	filename string
+	started  bool
+}
+
+func (w *bundleWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, w.filename))
+		w.c.Header("Content-Type", "application/zip")
+		w.c.Header("Trailer", ExportErrorTrailer)
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/access"
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/files"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+type FileHandler interface {
+	ListFiles(c *gin.Context)
+	Withdraw(c *gin.Context)
+}
+
+type fileHandler struct {
+	accessService access.AccessService
+	fileService   files.FileService
+}
+
+func NewFileHandler(fileService files.FileService, accessService access.AccessService) FileHandler {
+	return &fileHandler{
+		accessService: accessService,
+		fileService:   fileService,
+	}
+}
+
+// @Summary List my files
+// @Description Lists the files uploaded by the signing wallet, with their upload status and on-chain references.
+// @Description The wallet signs "GenomicDAO list files request\nDomain: {domain}\nChain ID: {chainId}\nTimestamp: {timestamp}"
+// @Description with personal_sign, with "Cursor: {cursor}\n" before the timestamp for any page but the first. The domain is the
+// @Description sign-in domain and the chain the one of /auth/nonce. A signature is accepted only once, so each page is signed.
+// @Tags genomic
+// @Produce json
+// @Param address query string true "Requesting wallet address"
+// @Param timestamp query int true "Unix time the request was signed at"
+// @Param signature query string true "personal_sign signature of the list message"
+// @Param status query string false "Upload status" Enums(pending, completed, failed)
+// @Param format query string false "Data format, e.g. markers-u64le"
+// @Param sort query string false "Sort order, by upload time by default" Enums(uploaded, size)
+// @Param order query string false "Sort direction, desc by default" Enums(asc, desc)
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param limit query int false "Files per page, at most 100"
+// @Success 200 {object} files.Page
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/me/files [get]
+func (h *fileHandler) ListFiles(c *gin.Context) {
+	timestamp, err := strconv.ParseInt(c.Query("timestamp"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: access.ErrInvalidRequest.Error()})
+		return
+	}
+	address := c.Query("address")
+	if _, err := h.accessService.VerifyListFilesRequest(address, c.Query("cursor"), timestamp, c.Query("signature")); err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	query, ok := parseFileQuery(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid query"})
+		return
+	}
+
+	page, err := h.fileService.ListFiles(address, query)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, auth.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Withdraw my file
+// @Description Asks for the raw data of a file the signing wallet uploaded to be deleted under the retention policy. It is
+// @Description no longer used for research, and is soft-deleted at the next evaluation of the policy, then purged after the
+// @Description grace period. The report, hashes and on-chain references are kept.
+// @Description The wallet signs "GenomicDAO withdraw request\nDomain: {domain}\nChain ID: {chainId}\nFile ID: {fileId}\nTimestamp: {timestamp}"
+// @Description with personal_sign. The domain is the sign-in domain and the chain the one of /auth/nonce. A signature is
+// @Description accepted only once.
+// @Tags genomic
+// @Produce json
+// @Param fileId path string true "File ID"
+// @Param address query string true "Requesting wallet address"
+// @Param timestamp query int true "Unix time the request was signed at"
+// @Param signature query string true "personal_sign signature of the withdraw message"
+// @Success 200 {object} files.File
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/me/files/{fileId} [delete]
+func (h *fileHandler) Withdraw(c *gin.Context) {
+	fileID := c.Param("fileId")
+	timestamp, err := strconv.ParseInt(c.Query("timestamp"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: access.ErrInvalidRequest.Error()})
+		return
+	}
+	address := c.Query("address")
+	if _, err := h.accessService.VerifyWithdrawRequest(fileID, address, timestamp, c.Query("signature")); err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	file, err := h.fileService.Withdraw(address, fileID)
+	if err != nil {
+		switch {
+		case errors.Is(err, files.ErrFileNotFound), errors.Is(err, auth.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, storage.ErrAmbiguousFileID):
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, file)
+}
+
+func parseFileQuery(c *gin.Context) (storage.FileQuery, bool) {
+	query := storage.FileQuery{
+		Status: c.Query("status"),
+		Format: c.Query("format"),
+		Sort:   c.DefaultQuery("sort", storage.SortUploaded),
+		Cursor: c.Query("cursor"),
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > storage.MaxFilePageSize {
+		return query, false
+	}
+	query.Limit = limit
+
+	switch query.Status {
+	case "", storage.UploadPending, storage.UploadCompleted, storage.UploadFailed:
+	default:
+		return query, false
+	}
+	if query.Sort != storage.SortUploaded && query.Sort != storage.SortSize {
+		return query, false
+	}
+	switch c.DefaultQuery("order", "desc") {
+	case "asc":
+		query.Ascending = true
+	case "desc":
+	default:
+		return query, false
+	}
+	return query, true
+}
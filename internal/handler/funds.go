@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireFunds refuses requests that spend gas while the service wallet is below its floor.
+func RequireFunds(monitor onchain.BalanceMonitor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := monitor.CheckFunds(); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, onchain.ErrInsufficientFunds) {
+				status = http.StatusServiceUnavailable
+			}
+			c.AbortWithStatusJSON(status, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireFundsOrQueue lets requests through while the chain is unavailable, since they only queue
+// their transactions until it is back, and otherwise works like RequireFunds.
+func RequireFundsOrQueue(monitor onchain.BalanceMonitor, chain onchain.ChainMonitor) gin.HandlerFunc {
+	requireFunds := RequireFunds(monitor)
+	return func(c *gin.Context) {
+		if !chain.Available() {
+			c.Next()
+			return
+		}
+		requireFunds(c)
+	}
+}
@@ -2,17 +2,20 @@ package handler
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"net/http"
 	"os"
+	"strconv"
 
+	"github.com/TropicalDog17/genomic-dao-service/internal/access"
 	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
 	"github.com/TropicalDog17/genomic-dao-service/internal/genomic"
-	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
 	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
 	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
 	genomicCrypto "github.com/TropicalDog17/genomic-dao-service/pkg/crypto"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // Response structures for Swagger documentation
@@ -24,14 +27,14 @@ type UploadResponse struct {
 	SessionID string `json:"sessionId" example:"sess_123"`
 	Message   string `json:"message" example:"Upload successful"`
 	FileID    string `json:"fileId" example:"file_123"`
+	TokenID   string `json:"tokenId" example:"14"`
+	// Stored while the blockchain is unreachable; the GeneNFT is minted once it is back
+	Queued bool `json:"queued" example:"false"`
 }
 
 type genomicHandler struct {
-	teeService             tee.TeeService
-	geneDataStorageService storage.GeneDataStorageService
-	authService            auth.AuthService
-	onchainService         onchain.OnchainService
-	genomicService         genomic.GenomicService
+	accessService  access.AccessService
+	genomicService genomic.GenomicService
 }
 
 type GenomicHandler interface {
@@ -39,36 +42,70 @@ type GenomicHandler interface {
 	RetrieveGenomicData(c *gin.Context)
 }
 
-func NewGenomicHandler(teeService tee.TeeService, geneDataStorageService storage.GeneDataStorageService, authService auth.AuthService, onchainService onchain.OnchainService) GenomicHandler {
+func NewGenomicHandler(genomicService genomic.GenomicService, accessService access.AccessService) GenomicHandler {
 	return &genomicHandler{
-		teeService:             teeService,
-		geneDataStorageService: geneDataStorageService,
-		authService:            authService,
-		onchainService:         onchainService,
-		genomicService:         genomic.NewGenomicService(teeService, geneDataStorageService, authService, onchainService),
+		accessService:  accessService,
+		genomicService: genomicService,
 	}
 }
 
 // @Summary Retrieve genomic data
-// @Description Retrieves genomic data from the blockchain
+// @Description Decrypts genomic data for the current GeneNFT owner or an approved operator. Every retrieval, allowed or
+// @Description denied, is recorded in the owner's access log, and no data is returned unless it was recorded.
+// @Description The wallet signs "GenomicDAO retrieve request\nDomain: {domain}\nChain ID: {chainId}\nFile ID: {fileID}\nTimestamp: {timestamp}"
+// @Description with personal_sign, with "Purpose: {purpose}\n" before the timestamp when it states a purpose. The domain is the
+// @Description sign-in domain and the chain the one of /auth/nonce. A signature is accepted only once.
 // @Tags genomic
 // @Accept json
 // @Produce json
 // @Param fileID query string true "File ID of the genomic data"
+// @Param address query string true "Requesting wallet address"
+// @Param timestamp query int true "Unix time the request was signed at"
+// @Param signature query string true "personal_sign signature of the retrieve message"
+// @Param purpose query string false "Purpose of the retrieval, recorded in the access log"
 // @Success 200 {object} GenomicDataResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /retrieve [get]
 func (h *genomicHandler) RetrieveGenomicData(c *gin.Context) {
 	fileID := c.Query("fileID")
+	timestamp, err := strconv.ParseInt(c.Query("timestamp"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: access.ErrInvalidRequest.Error()})
+		return
+	}
+
+	purpose := c.Query("purpose")
+	requester, err := h.accessService.VerifyRetrieveRequest(fileID, purpose, c.Query("address"), timestamp, c.Query("signature"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: err.Error()})
+		return
+	}
+
 	privKey, _, _, err := genomicCrypto.DeriveEcdsaKeyPairAndEthAddress(os.Getenv("PRIVATE_KEY"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	genomicData, err := h.genomicService.RetrieveGenomicData(fileID, privKey)
+	genomicData, err := h.genomicService.RetrieveGenomicData(fileID, purpose, requester, privKey)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		switch {
+		case errors.Is(err, access.ErrAccessDenied):
+			c.JSON(http.StatusForbidden, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "file not found"})
+		case errors.Is(err, storage.ErrAmbiguousFileID):
+			c.JSON(http.StatusConflict, ErrorResponse{Error: storage.ErrAmbiguousFileID.Error()})
+		case errors.Is(err, storage.ErrRawDataPurged):
+			c.JSON(http.StatusGone, ErrorResponse{Error: storage.ErrRawDataPurged.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
 		return
 	}
 
@@ -78,18 +115,32 @@ func (h *genomicHandler) RetrieveGenomicData(c *gin.Context) {
 }
 
 // @Summary Upload genomic data for processing
-// @Description Processes genomic data in TEE, encrypts it, calculates risk score, and stores on blockchain
+// @Description Processes genomic data in TEE, encrypts it, calculates risk score, and stores on blockchain. While the blockchain is unreachable, the encrypted data is stored and the upload answers 202, its on-chain steps queued until the chain is back.
+// @Description The data is uploaded for the wallet signed in with POST /auth/login.
 // @Tags genomic
 // @Accept multipart/form-data
 // @Produce json
 // @Param genomicData formData string true "Raw genomic data to be processed"
-// @Param pubkey formData string true "User's public key for authentication"
+// @Param Authorization header string true "Bearer token from POST /auth/login"
+// @Param shareRiskTier formData bool false "Show the risk tier in the public GeneNFT metadata"
 // @Success 200 {object} UploadResponse
+// @Success 202 {object} UploadResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /upload [post]
 func (h *genomicHandler) UploadGenomicData(c *gin.Context) {
 	genomicData := c.PostForm("genomicData")
-	pubkey := c.PostForm("pubkey")
+	// Uploads are made for the wallet signed in, never for an address the request names
+	user := c.MustGet(SessionUserKey).(*auth.User)
+	shareRiskTier, err := strconv.ParseBool(c.DefaultPostForm("shareRiskTier", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid shareRiskTier"})
+		return
+	}
 
 	privateKey, _, _, err := genomicCrypto.DeriveEcdsaKeyPairAndEthAddress(os.Getenv("PRIVATE_KEY"))
 	if err != nil {
@@ -97,16 +148,31 @@ func (h *genomicHandler) UploadGenomicData(c *gin.Context) {
 		return
 	}
 
-	result, err := h.genomicService.ProcessAndUploadGenomicData([]byte(genomicData), pubkey, privateKey)
+	result, err := h.genomicService.ProcessAndUploadGenomicData([]byte(genomicData), user.Pubkey, shareRiskTier, privateKey)
 	if err != nil {
+		// Never tell whose upload it duplicates
+		if errors.Is(err, storage.ErrDuplicateGeneData) {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: storage.ErrDuplicateGeneData.Error()})
+			return
+		}
+		if errors.Is(err, tee.ErrDataTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{Error: tee.ErrDataTooLarge.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, UploadResponse{
+	status := http.StatusOK
+	if result.Queued {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, UploadResponse{
 		SessionID: result.SessionID,
 		Message:   result.Message,
 		FileID:    result.FileID,
+		TokenID:   result.TokenID,
+		Queued:    result.Queued,
 	})
 }
 
@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/governance"
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+type GovernanceHandler interface {
+	GetDomain(c *gin.Context)
+	GetVotingPower(c *gin.Context)
+	Delegate(c *gin.Context)
+	Propose(c *gin.Context)
+	ListProposals(c *gin.Context)
+	GetProposal(c *gin.Context)
+	CastVote(c *gin.Context)
+}
+
+type governanceHandler struct {
+	governanceService governance.GovernanceService
+}
+
+func NewGovernanceHandler(governanceService governance.GovernanceService) GovernanceHandler {
+	return &governanceHandler{
+		governanceService: governanceService,
+	}
+}
+
+// ProposalRequest is a Proposal signed by its author under the Governor domain
+type ProposalRequest struct {
+	Proposer    string `json:"proposer" binding:"required" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	Description string `json:"description" binding:"required" example:"Prioritise the post-covid stroke cohort"`
+	Deadline    string `json:"deadline" binding:"required" example:"1734280000"`
+	Signature   string `json:"signature" binding:"required" example:"0x..."`
+}
+
+// VoteRequest is a Ballot signed by the voter under the Governor domain
+type VoteRequest struct {
+	Voter string `json:"voter" binding:"required" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	// 0 against, 1 for, 2 abstain
+	Support   *uint8 `json:"support" binding:"required" example:"1"`
+	Signature string `json:"signature" binding:"required" example:"0x..."`
+}
+
+// DelegationRequest is a Delegation signed by the holder under the PCSP domain
+type DelegationRequest struct {
+	Delegator string `json:"delegator" binding:"required" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	Delegatee string `json:"delegatee" binding:"required" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	Expiry    string `json:"expiry" binding:"required" example:"1734280000"`
+	Signature string `json:"signature" binding:"required" example:"0x..."`
+}
+
+type ProposalListResponse struct {
+	Proposals []governance.Proposal `json:"proposals"`
+}
+
+// @Summary Get governance signing domain
+// @Description Get the EIP-712 domain for Ballot and Proposal payloads. Delegations are signed under the PCSP domain.
+// @Tags governance
+// @Produce json
+// @Success 200 {object} onchain.EIP712Domain
+// @Failure 500 {object} ErrorResponse
+// @Router /governance/domain [get]
+func (h *governanceHandler) GetDomain(c *gin.Context) {
+	domain, err := h.governanceService.GetDomain()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain)
+}
+
+// @Summary Get voting power
+// @Description Get the votes delegated to an address, its own delegate and the nonce for its next delegation
+// @Tags governance
+// @Produce json
+// @Param address query string true "Holder address"
+// @Success 200 {object} governance.VotingPower
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /governance/votes [get]
+func (h *governanceHandler) GetVotingPower(c *gin.Context) {
+	address := c.Query("address")
+	if !common.IsHexAddress(address) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+		return
+	}
+
+	power, err := h.governanceService.GetVotingPower(common.HexToAddress(address))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, power)
+}
+
+// @Summary Delegate votes
+// @Description Relays a holder-signed delegation. PCSP balances only count as votes once delegated, to the holder itself or another address.
+// @Tags governance
+// @Accept json
+// @Produce json
+// @Param request body DelegationRequest true "Signed delegation"
+// @Success 200 {object} onchain.TxResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /governance/delegate [post]
+func (h *governanceHandler) Delegate(c *gin.Context) {
+	var req DelegationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !common.IsHexAddress(req.Delegator) || !common.IsHexAddress(req.Delegatee) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+		return
+	}
+	expiry, ok := parseUint256(req.Expiry)
+	if !ok {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid expiry"})
+		return
+	}
+
+	result, err := h.governanceService.Delegate(&governance.DelegationRequest{
+		Delegator: common.HexToAddress(req.Delegator),
+		Delegatee: common.HexToAddress(req.Delegatee),
+		Expiry:    expiry,
+		Signature: req.Signature,
+	})
+	if err != nil {
+		c.JSON(governanceErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Create a proposal
+// @Description Submits a signalling proposal for an author holding at least the author threshold in delegated votes. Only the service wallet proposes to the Governor, so it submits the proposal for the author. Votes are weighed at the proposal block.
+// @Tags governance
+// @Accept json
+// @Produce json
+// @Param request body ProposalRequest true "Signed proposal"
+// @Success 201 {object} governance.Proposal
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /governance/proposals [post]
+func (h *governanceHandler) Propose(c *gin.Context) {
+	var req ProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !common.IsHexAddress(req.Proposer) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+		return
+	}
+	deadline, ok := parseUint256(req.Deadline)
+	if !ok {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid deadline"})
+		return
+	}
+
+	proposal, err := h.governanceService.Propose(&governance.ProposalRequest{
+		Proposer:    common.HexToAddress(req.Proposer),
+		Description: req.Description,
+		Deadline:    deadline,
+		Signature:   req.Signature,
+	})
+	if err != nil {
+		c.JSON(governanceErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, proposal)
+}
+
+// @Summary List proposals
+// @Description List every proposal, newest first, with its state and tally
+// @Tags governance
+// @Produce json
+// @Success 200 {object} ProposalListResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /governance/proposals [get]
+func (h *governanceHandler) ListProposals(c *gin.Context) {
+	proposals, err := h.governanceService.ListProposals()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ProposalListResponse{Proposals: proposals})
+}
+
+// @Summary Get a proposal
+// @Description Get a proposal with its state, tally and quorum
+// @Tags governance
+// @Produce json
+// @Param id path string true "Proposal ID"
+// @Success 200 {object} governance.Proposal
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /governance/proposals/{id} [get]
+func (h *governanceHandler) GetProposal(c *gin.Context) {
+	proposal, err := h.governanceService.GetProposal(c.Param("id"))
+	if err != nil {
+		c.JSON(governanceErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, proposal)
+}
+
+// @Summary Cast a vote
+// @Description Relays a voter-signed Ballot, weighted by the votes delegated to the voter at the proposal snapshot
+// @Tags governance
+// @Accept json
+// @Produce json
+// @Param id path string true "Proposal ID"
+// @Param request body VoteRequest true "Signed ballot"
+// @Success 200 {object} onchain.TxResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /governance/proposals/{id}/votes [post]
+func (h *governanceHandler) CastVote(c *gin.Context) {
+	proposalID, ok := parseUint256(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: governance.ErrInvalidProposalID.Error()})
+		return
+	}
+	var req VoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !common.IsHexAddress(req.Voter) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+		return
+	}
+
+	result, err := h.governanceService.CastVote(&governance.VoteRequest{
+		ProposalID: proposalID,
+		Voter:      common.HexToAddress(req.Voter),
+		Support:    *req.Support,
+		Signature:  req.Signature,
+	})
+	if err != nil {
+		c.JSON(governanceErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func governanceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, governance.ErrProposalNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, governance.ErrBelowThreshold),
+		errors.Is(err, governance.ErrNoVotingPower):
+		return http.StatusForbidden
+	case errors.Is(err, governance.ErrAlreadyVoted),
+		errors.Is(err, governance.ErrVotingClosed):
+		return http.StatusConflict
+	case errors.Is(err, onchain.ErrInvalidAuthorization),
+		errors.Is(err, governance.ErrInvalidProposalID),
+		errors.Is(err, governance.ErrInvalidSupport),
+		errors.Is(err, governance.ErrSignatureExpired):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
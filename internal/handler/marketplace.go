@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/marketplace"
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+type MarketplaceHandler interface {
+	GetDomain(c *gin.Context)
+	SetConsent(c *gin.Context)
+	RequestAnalysis(c *gin.Context)
+	GetAnalysis(c *gin.Context)
+}
+
+type marketplaceHandler struct {
+	marketplaceService marketplace.MarketplaceService
+}
+
+func NewMarketplaceHandler(marketplaceService marketplace.MarketplaceService) MarketplaceHandler {
+	return &marketplaceHandler{
+		marketplaceService: marketplaceService,
+	}
+}
+
+// ConsentRequest is a research consent decision signed by the GeneNFT holder with personal_sign
+type ConsentRequest struct {
+	Owner     string `json:"owner" binding:"required" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	Granted   *bool  `json:"granted" binding:"required" example:"true"`
+	Timestamp int64  `json:"timestamp" binding:"required" example:"1734280000"`
+	Signature string `json:"signature" binding:"required" example:"0x..."`
+}
+
+// AnalysisRequest is an AnalysisRequest signed by the researcher under the escrow domain
+type AnalysisRequest struct {
+	Researcher string `json:"researcher" binding:"required" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	Analysis   string `json:"analysis" binding:"required" example:"risk-summary"`
+	Amount     string `json:"amount" binding:"required" example:"100000000000000000000"`
+	Deadline   string `json:"deadline" binding:"required" example:"1734280000"`
+	Signature  string `json:"signature" binding:"required" example:"0x..."`
+}
+
+// @Summary Get research escrow signing domain
+// @Description Get the EIP-712 domain for AnalysisRequest and AnalysisResultRequest payloads, and the researcher's request nonce
+// @Tags marketplace
+// @Produce json
+// @Param researcher query string false "Researcher address to include the nonce for"
+// @Success 200 {object} marketplace.Domain
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /marketplace/domain [get]
+func (h *marketplaceHandler) GetDomain(c *gin.Context) {
+	var researcher *common.Address
+	if address := c.Query("researcher"); address != "" {
+		if !common.IsHexAddress(address) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+			return
+		}
+		a := common.HexToAddress(address)
+		researcher = &a
+	}
+
+	domain, err := h.marketplaceService.GetDomain(researcher)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain)
+}
+
+// @Summary Grant or withdraw research consent
+// @Description The holder signs "GenomicDAO research consent\nToken ID: {tokenId}\nGranted: {true|false}\nTimestamp: {timestamp}" with personal_sign. Consent lapses when the GeneNFT changes hands.
+// @Tags marketplace
+// @Accept json
+// @Produce json
+// @Param tokenId path string true "GeneNFT token ID"
+// @Param request body ConsentRequest true "Signed consent"
+// @Success 200 {object} marketplace.Consent
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /marketplace/consents/{tokenId} [put]
+func (h *marketplaceHandler) SetConsent(c *gin.Context) {
+	var req ConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !common.IsHexAddress(req.Owner) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+		return
+	}
+
+	consent, err := h.marketplaceService.SetConsent(&marketplace.ConsentRequest{
+		TokenID:   c.Param("tokenId"),
+		Owner:     common.HexToAddress(req.Owner),
+		Granted:   *req.Granted,
+		Timestamp: req.Timestamp,
+		Signature: req.Signature,
+	})
+	if err != nil {
+		c.JSON(marketplaceErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, consent)
+}
+
+// @Summary Request a paid analysis
+// @Description Locks the researcher's PCSP in escrow, which must be approved for the amount beforehand, and runs the analysis in the TEE over the GeneNFTs whose holders consented. The payment is split equally between the contributing tokens' owners, or refunded if the cohort is too small or the analysis fails.
+// @Tags marketplace
+// @Accept json
+// @Produce json
+// @Param request body AnalysisRequest true "Signed analysis request"
+// @Success 201 {object} marketplace.Job
+// @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /marketplace/requests [post]
+func (h *marketplaceHandler) RequestAnalysis(c *gin.Context) {
+	var req AnalysisRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !common.IsHexAddress(req.Researcher) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+		return
+	}
+	amount, ok := parseUint256(req.Amount)
+	if !ok {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid amount"})
+		return
+	}
+	deadline, ok := parseUint256(req.Deadline)
+	if !ok {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid deadline"})
+		return
+	}
+
+	job, err := h.marketplaceService.RequestAnalysis(&marketplace.AnalysisRequest{
+		Researcher: common.HexToAddress(req.Researcher),
+		Analysis:   req.Analysis,
+		Amount:     amount,
+		Deadline:   deadline,
+		Signature:  req.Signature,
+	})
+	if err != nil {
+		c.JSON(marketplaceErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, job)
+}
+
+// @Summary Get a paid analysis
+// @Description Get the escrow status of an analysis and, once its contributors are paid, the released aggregate. The caller signs AnalysisResultRequest(uint256 requestId,uint256 deadline) under the escrow domain; the aggregate is only released to the researcher who paid for it.
+// @Tags marketplace
+// @Produce json
+// @Param id path string true "Escrow request ID"
+// @Param researcher query string true "Researcher address"
+// @Param deadline query string true "Signature deadline (unix seconds)"
+// @Param signature query string true "EIP-712 signature of the AnalysisResultRequest"
+// @Success 200 {object} marketplace.Job
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /marketplace/requests/{id} [get]
+func (h *marketplaceHandler) GetAnalysis(c *gin.Context) {
+	researcher := c.Query("researcher")
+	if !common.IsHexAddress(researcher) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+		return
+	}
+	deadline, ok := parseUint256(c.Query("deadline"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid deadline"})
+		return
+	}
+
+	job, err := h.marketplaceService.GetJob(&marketplace.JobRequest{
+		RequestID:  c.Param("id"),
+		Researcher: common.HexToAddress(researcher),
+		Deadline:   deadline,
+		Signature:  c.Query("signature"),
+	})
+	if err != nil {
+		c.JSON(marketplaceErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+func marketplaceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, marketplace.ErrRequestNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, marketplace.ErrNotTokenOwner):
+		return http.StatusForbidden
+	case errors.Is(err, marketplace.ErrStaleConsent):
+		return http.StatusConflict
+	case errors.Is(err, marketplace.ErrInsufficientAllowance):
+		return http.StatusPaymentRequired
+	case errors.Is(err, onchain.ErrInvalidAuthorization),
+		errors.Is(err, tee.ErrUnsupportedAnalysis),
+		errors.Is(err, marketplace.ErrInvalidRequestID),
+		errors.Is(err, marketplace.ErrInvalidAmount),
+		errors.Is(err, marketplace.ErrInvalidConsent),
+		errors.Is(err, marketplace.ErrSignatureExpired):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/nft"
+	"github.com/gin-gonic/gin"
+)
+
+type NFTHandler interface {
+	GetMetadata(c *gin.Context)
+}
+
+type nftHandler struct {
+	metadataService nft.MetadataService
+}
+
+func NewNFTHandler(metadataService nft.MetadataService) NFTHandler {
+	return &nftHandler{
+		metadataService: metadataService,
+	}
+}
+
+// @Summary Get GeneNFT metadata
+// @Description Returns the ERC-721 metadata JSON of a GeneNFT token
+// @Tags nft
+// @Produce json
+// @Param tokenId path string true "GeneNFT token ID"
+// @Success 200 {object} nft.Metadata
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /nft/{tokenId} [get]
+func (h *nftHandler) GetMetadata(c *gin.Context) {
+	metadata, err := h.metadataService.GetMetadata(c.Param("tokenId"))
+	if err != nil {
+		switch {
+		case errors.Is(err, nft.ErrInvalidTokenID):
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, nft.ErrTokenNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, metadata)
+}
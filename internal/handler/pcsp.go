@@ -1,13 +1,26 @@
 package handler
 
 import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+
 	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gin-gonic/gin"
 )
 
 type PCSPHandler interface {
 	GetPCSPBalance(c *gin.Context)
+	GetAllowance(c *gin.Context)
+	GetTotalSupply(c *gin.Context)
+	GetDomain(c *gin.Context)
+	Approve(c *gin.Context)
+	Transfer(c *gin.Context)
+	Burn(c *gin.Context)
+	GetTransferHistory(c *gin.Context)
 }
 
 type pcspHandler struct {
@@ -21,7 +34,62 @@ func NewPCSPHandler(pcspService onchain.PcspService) PCSPHandler {
 }
 
 type PCSPBalanceResponse struct {
-	Balance string `json:"balance"`
+	Balance   string `json:"balance" example:"225000000000000000000"`
+	Formatted string `json:"formatted" example:"225"`
+	Decimals  uint8  `json:"decimals" example:"18"`
+}
+
+type PCSPAllowanceResponse struct {
+	Allowance string `json:"allowance" example:"1000000000000000000"`
+	Formatted string `json:"formatted" example:"1"`
+}
+
+type PCSPSupplyResponse struct {
+	TotalSupply string `json:"totalSupply" example:"1000000000000000000000000000"`
+	Formatted   string `json:"formatted" example:"1000000000"`
+	Decimals    uint8  `json:"decimals" example:"18"`
+}
+
+type PCSPDomainResponse struct {
+	Domain      *onchain.EIP712Domain `json:"domain"`
+	PermitNonce string                `json:"permitNonce,omitempty" example:"0"`
+}
+
+// PCSPApproveRequest is an EIP-2612 Permit signed by the owner
+type PCSPApproveRequest struct {
+	Owner     string `json:"owner" binding:"required" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	Spender   string `json:"spender" binding:"required" example:"0x6491414173c71986Ee031307Af447cE1DbDf2ED0"`
+	Value     string `json:"value" binding:"required" example:"1000000000000000000"`
+	Deadline  string `json:"deadline" binding:"required" example:"1734280000"`
+	Signature string `json:"signature" binding:"required" example:"0x..."`
+}
+
+// PCSPTransferRequest is a TransferWithAuthorization signed by the holder
+type PCSPTransferRequest struct {
+	From        string `json:"from" binding:"required" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	To          string `json:"to" binding:"required" example:"0x6491414173c71986Ee031307Af447cE1DbDf2ED0"`
+	Value       string `json:"value" binding:"required" example:"1000000000000000000"`
+	ValidAfter  string `json:"validAfter" binding:"required" example:"0"`
+	ValidBefore string `json:"validBefore" binding:"required" example:"1734280000"`
+	Nonce       string `json:"nonce" binding:"required" example:"0x6b3c...32 bytes"`
+	Signature   string `json:"signature" binding:"required" example:"0x..."`
+}
+
+// PCSPBurnRequest is a BurnWithAuthorization signed by the holder
+type PCSPBurnRequest struct {
+	From        string `json:"from" binding:"required" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	Value       string `json:"value" binding:"required" example:"1000000000000000000"`
+	ValidAfter  string `json:"validAfter" binding:"required" example:"0"`
+	ValidBefore string `json:"validBefore" binding:"required" example:"1734280000"`
+	Nonce       string `json:"nonce" binding:"required" example:"0x6b3c...32 bytes"`
+	Signature   string `json:"signature" binding:"required" example:"0x..."`
+}
+
+type PCSPTransferHistoryResponse struct {
+	Transfers []onchain.TransferRecord `json:"transfers"`
+	Page      int                      `json:"page" example:"1"`
+	PageSize  int                      `json:"pageSize" example:"20"`
+	Total     int                      `json:"total" example:"42"`
 }
 
 // @Summary Get PCSP balance
@@ -31,15 +99,306 @@ type PCSPBalanceResponse struct {
 // @Produce json
 // @Param address query string true "User's address"
 // @Success 200 {object} PCSPBalanceResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /pcsp/balance [get]
 func (h *pcspHandler) GetPCSPBalance(c *gin.Context) {
 	address := c.Query("address")
+	if !common.IsHexAddress(address) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+		return
+	}
 	ethAddress := common.HexToAddress(address)
 	balance, err := h.pcspService.GetTokenBalance(&ethAddress)
 	if err != nil {
 		c.JSON(500, ErrorResponse{Error: err.Error()})
 		return
 	}
-	c.JSON(200, gin.H{"balance": balance.String()})
+	decimals, err := h.pcspService.GetDecimals()
+	if err != nil {
+		c.JSON(500, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(200, PCSPBalanceResponse{
+		Balance:   balance.String(),
+		Formatted: onchain.FormatUnits(balance, decimals),
+		Decimals:  decimals,
+	})
+}
+
+// @Summary Get PCSP allowance
+// @Description Get the amount of PCSP a spender may move on behalf of an owner
+// @Tags pcsp
+// @Produce json
+// @Param owner query string true "Token owner address"
+// @Param spender query string true "Spender address"
+// @Success 200 {object} PCSPAllowanceResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pcsp/allowance [get]
+func (h *pcspHandler) GetAllowance(c *gin.Context) {
+	owner, spender := c.Query("owner"), c.Query("spender")
+	if !common.IsHexAddress(owner) || !common.IsHexAddress(spender) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+		return
+	}
+	allowance, err := h.pcspService.GetAllowance(common.HexToAddress(owner), common.HexToAddress(spender))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	decimals, err := h.pcspService.GetDecimals()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, PCSPAllowanceResponse{
+		Allowance: allowance.String(),
+		Formatted: onchain.FormatUnits(allowance, decimals),
+	})
+}
+
+// @Summary Get PCSP total supply
+// @Description Get the total supply of PCSP tokens
+// @Tags pcsp
+// @Produce json
+// @Success 200 {object} PCSPSupplyResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pcsp/supply [get]
+func (h *pcspHandler) GetTotalSupply(c *gin.Context) {
+	supply, err := h.pcspService.GetTotalSupply()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	decimals, err := h.pcspService.GetDecimals()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, PCSPSupplyResponse{
+		TotalSupply: supply.String(),
+		Formatted:   onchain.FormatUnits(supply, decimals),
+		Decimals:    decimals,
+	})
+}
+
+// @Summary Get PCSP signing domain
+// @Description Get the EIP-712 domain for approve, transfer and burn payloads, and the owner's permit nonce
+// @Tags pcsp
+// @Produce json
+// @Param owner query string false "Owner address to include the permit nonce for"
+// @Success 200 {object} PCSPDomainResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pcsp/domain [get]
+func (h *pcspHandler) GetDomain(c *gin.Context) {
+	domain, err := h.pcspService.GetDomain()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	resp := PCSPDomainResponse{Domain: domain}
+
+	if owner := c.Query("owner"); owner != "" {
+		if !common.IsHexAddress(owner) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+			return
+		}
+		nonce, err := h.pcspService.GetPermitNonce(common.HexToAddress(owner))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		resp.PermitNonce = nonce.String()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Approve a PCSP spender
+// @Description Relays an owner-signed EIP-2612 Permit and waits for it to be mined
+// @Tags pcsp
+// @Accept json
+// @Produce json
+// @Param request body PCSPApproveRequest true "Signed permit"
+// @Success 200 {object} onchain.TxResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /pcsp/approve [post]
+func (h *pcspHandler) Approve(c *gin.Context) {
+	var req PCSPApproveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	value, okValue := parseUint256(req.Value)
+	deadline, okDeadline := parseUint256(req.Deadline)
+	if !common.IsHexAddress(req.Owner) || !common.IsHexAddress(req.Spender) || !okValue || !okDeadline {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid permit"})
+		return
+	}
+
+	result, err := h.pcspService.Permit(&onchain.PermitRequest{
+		Owner:     common.HexToAddress(req.Owner),
+		Spender:   common.HexToAddress(req.Spender),
+		Value:     value,
+		Deadline:  deadline,
+		Signature: req.Signature,
+	})
+	respondTx(c, result, err)
+}
+
+// @Summary Transfer PCSP
+// @Description Relays a holder-signed TransferWithAuthorization of a positive value and waits for it to be mined
+// @Tags pcsp
+// @Accept json
+// @Produce json
+// @Param request body PCSPTransferRequest true "Signed transfer"
+// @Success 200 {object} onchain.TxResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /pcsp/transfer [post]
+func (h *pcspHandler) Transfer(c *gin.Context) {
+	var req PCSPTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	value, okValue := parseUint256(req.Value)
+	validAfter, okAfter := parseUint256(req.ValidAfter)
+	validBefore, okBefore := parseUint256(req.ValidBefore)
+	nonce, okNonce := parseBytes32(req.Nonce)
+	if !common.IsHexAddress(req.From) || !common.IsHexAddress(req.To) || !okValue || !okAfter || !okBefore || !okNonce {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid transfer authorization"})
+		return
+	}
+
+	result, err := h.pcspService.TransferWithAuthorization(&onchain.TransferAuthorization{
+		From:        common.HexToAddress(req.From),
+		To:          common.HexToAddress(req.To),
+		Value:       value,
+		ValidAfter:  validAfter,
+		ValidBefore: validBefore,
+		Nonce:       nonce,
+		Signature:   req.Signature,
+	})
+	respondTx(c, result, err)
+}
+
+// @Summary Burn PCSP
+// @Description Relays a holder-signed BurnWithAuthorization of a positive value and waits for it to be mined
+// @Tags pcsp
+// @Accept json
+// @Produce json
+// @Param request body PCSPBurnRequest true "Signed burn"
+// @Success 200 {object} onchain.TxResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /pcsp/burn [post]
+func (h *pcspHandler) Burn(c *gin.Context) {
+	var req PCSPBurnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	value, okValue := parseUint256(req.Value)
+	validAfter, okAfter := parseUint256(req.ValidAfter)
+	validBefore, okBefore := parseUint256(req.ValidBefore)
+	nonce, okNonce := parseBytes32(req.Nonce)
+	if !common.IsHexAddress(req.From) || !okValue || !okAfter || !okBefore || !okNonce {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid burn authorization"})
+		return
+	}
+
+	result, err := h.pcspService.BurnWithAuthorization(&onchain.BurnAuthorization{
+		From:        common.HexToAddress(req.From),
+		Value:       value,
+		ValidAfter:  validAfter,
+		ValidBefore: validBefore,
+		Nonce:       nonce,
+		Signature:   req.Signature,
+	})
+	respondTx(c, result, err)
+}
+
+// @Summary Get PCSP transfer history
+// @Description Lists PCSP transfers to or from an address, newest first
+// @Tags pcsp
+// @Produce json
+// @Param address query string true "User's address"
+// @Param page query int false "Page number, starting at 1"
+// @Param pageSize query int false "Transfers per page, at most 100"
+// @Success 200 {object} PCSPTransferHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pcsp/transfers [get]
+func (h *pcspHandler) GetTransferHistory(c *gin.Context) {
+	address := c.Query("address")
+	page, errPage := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, errSize := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if !common.IsHexAddress(address) || errPage != nil || errSize != nil || page < 1 || pageSize < 1 || pageSize > onchain.MaxTransferPageSize {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid query"})
+		return
+	}
+
+	transfers, total, err := h.pcspService.GetTransferHistory(common.HexToAddress(address), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, PCSPTransferHistoryResponse{
+		Transfers: transfers,
+		Page:      page,
+		PageSize:  pageSize,
+		Total:     total,
+	})
+}
+
+func respondTx(c *gin.Context, result *onchain.TxResult, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, onchain.ErrInvalidAuthorization), errors.Is(err, onchain.ErrZeroValue):
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, onchain.ErrRelayDenied):
+			c.JSON(http.StatusForbidden, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, onchain.ErrRelayQuota):
+			c.JSON(http.StatusTooManyRequests, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, onchain.ErrRelayCapacity):
+			c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func parseUint256(s string) (*big.Int, bool) {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok || v.Sign() < 0 || v.BitLen() > 256 {
+		return nil, false
+	}
+	return v, true
+}
+
+func parseBytes32(s string) (common.Hash, bool) {
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) != common.HashLength {
+		return common.Hash{}, false
+	}
+	return common.BytesToHash(b), true
 }
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/records"
+	"github.com/gin-gonic/gin"
+)
+
+type RecordHandler interface {
+	GetSession(c *gin.Context)
+	GetDoc(c *gin.Context)
+}
+
+type recordHandler struct {
+	recordService records.RecordService
+}
+
+func NewRecordHandler(recordService records.RecordService) RecordHandler {
+	return &recordHandler{
+		recordService: recordService,
+	}
+}
+
+// @Summary Get upload session
+// @Description Combines the on-chain upload session with the local record, with a verdict on whether they agree
+// @Tags records
+// @Produce json
+// @Param id path string true "Upload session ID"
+// @Success 200 {object} records.UploadView
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /sessions/{id} [get]
+func (h *recordHandler) GetSession(c *gin.Context) {
+	view, err := h.recordService.GetSession(c.Param("id"))
+	respondRecord(c, view, err)
+}
+
+// @Summary Get document
+// @Description Combines the on-chain doc and its confirmation with the local record, with a verdict on whether they agree
+// @Tags records
+// @Produce json
+// @Param docId path string true "Doc ID"
+// @Success 200 {object} records.UploadView
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /docs/{docId} [get]
+func (h *recordHandler) GetDoc(c *gin.Context) {
+	view, err := h.recordService.GetDoc(c.Param("docId"))
+	respondRecord(c, view, err)
+}
+
+func respondRecord(c *gin.Context, view *records.UploadView, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, records.ErrInvalidSessionID):
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, records.ErrNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, view)
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gin-gonic/gin"
+)
+
+type RelayHandler interface {
+	Relay(c *gin.Context)
+	GetNonce(c *gin.Context)
+	GetRelay(c *gin.Context)
+}
+
+type relayHandler struct {
+	relayerService onchain.RelayerService
+}
+
+func NewRelayHandler(relayerService onchain.RelayerService) RelayHandler {
+	return &relayHandler{
+		relayerService: relayerService,
+	}
+}
+
+// RelayRequest is a ForwardRequest signed by the user for the trusted forwarder
+type RelayRequest struct {
+	From      string `json:"from" binding:"required" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	To        string `json:"to" binding:"required" example:"0x6491414173c71986Ee031307Af447cE1DbDf2ED0"`
+	Value     string `json:"value" example:"0"`
+	Gas       string `json:"gas" binding:"required" example:"300000"`
+	Nonce     string `json:"nonce" binding:"required" example:"0"`
+	Data      string `json:"data" binding:"required" example:"0x..."`
+	Signature string `json:"signature" binding:"required" example:"0x..."`
+}
+
+type RelayNonceResponse struct {
+	Nonce string `json:"nonce" example:"0"`
+}
+
+// @Summary Relay a meta-transaction
+// @Description Verifies a user-signed ForwardRequest, simulates it and submits it through the trusted forwarder with the service paying gas
+// @Tags relay
+// @Accept json
+// @Produce json
+// @Param request body RelayRequest true "Signed forward request"
+// @Success 202 {object} onchain.RelayRecord
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /relay [post]
+func (h *relayHandler) Relay(c *gin.Context) {
+	var req RelayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if !common.IsHexAddress(req.From) || !common.IsHexAddress(req.To) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+		return
+	}
+	if req.Value == "" {
+		req.Value = "0"
+	}
+	value, okValue := parseUint256(req.Value)
+	gas, okGas := parseUint256(req.Gas)
+	nonce, okNonce := parseUint256(req.Nonce)
+	if !okValue || !okGas || !okNonce {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid amount"})
+		return
+	}
+	data, err := hexutil.Decode(req.Data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid data"})
+		return
+	}
+
+	record, err := h.relayerService.Relay(&onchain.ForwardRequest{
+		From:  common.HexToAddress(req.From),
+		To:    common.HexToAddress(req.To),
+		Value: value,
+		Gas:   gas,
+		Nonce: nonce,
+		Data:  data,
+	}, req.Signature)
+	if err != nil {
+		c.JSON(relayErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, record)
+}
+
+// @Summary Get forwarder nonce
+// @Description Get the nonce the next ForwardRequest from an address must be signed with
+// @Tags relay
+// @Produce json
+// @Param address query string true "User's address"
+// @Success 200 {object} RelayNonceResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /relay/nonce [get]
+func (h *relayHandler) GetNonce(c *gin.Context) {
+	address := c.Query("address")
+	if !common.IsHexAddress(address) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid address"})
+		return
+	}
+
+	nonce, err := h.relayerService.GetNonce(common.HexToAddress(address))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, RelayNonceResponse{Nonce: nonce.String()})
+}
+
+// @Summary Get relay status
+// @Description Track a relayed meta-transaction by its transaction hash
+// @Tags relay
+// @Produce json
+// @Param txHash path string true "Relay transaction hash"
+// @Success 200 {object} onchain.RelayRecord
+// @Failure 404 {object} ErrorResponse
+// @Router /relay/{txHash} [get]
+func (h *relayHandler) GetRelay(c *gin.Context) {
+	record, err := h.relayerService.GetRelay(c.Param("txHash"))
+	if err != nil {
+		c.JSON(relayErrorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+func relayErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, onchain.ErrRelayDenied):
+		return http.StatusForbidden
+	case errors.Is(err, onchain.ErrRelayQuota):
+		return http.StatusTooManyRequests
+	case errors.Is(err, onchain.ErrRelayCapacity):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, onchain.ErrRelayNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, onchain.ErrInvalidAuthorization),
+		errors.Is(err, onchain.ErrRelayTarget),
+		errors.Is(err, onchain.ErrRelayFunction),
+		errors.Is(err, onchain.ErrRelayValue),
+		errors.Is(err, onchain.ErrRelayGas),
+		errors.Is(err, onchain.ErrRelayNonce),
+		errors.Is(err, onchain.ErrRelaySimulation):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/rewards"
+	"github.com/gin-gonic/gin"
+)
+
+type RewardHandler interface {
+	GetSchedule(c *gin.Context)
+	ProposeSchedule(c *gin.Context)
+}
+
+type rewardHandler struct {
+	rewardService rewards.RewardService
+}
+
+func NewRewardHandler(rewardService rewards.RewardService) RewardHandler {
+	return &rewardHandler{
+		rewardService: rewardService,
+	}
+}
+
+// RewardScheduleRequest is a new PCSP reward per risk score, from 1 (low) to 4 (extremely high)
+type RewardScheduleRequest struct {
+	// Amounts in PCSP wei, one per risk score
+	Amounts []string `json:"amounts" binding:"required" example:"30000000000000000000,225000000000000000000,3000000000000000000000,15000000000000000000000"`
+	// Set the schedule on-chain; otherwise it is only checked and compared with the current one
+	Apply bool `json:"apply" example:"false"`
+}
+
+type RewardScheduleResponse struct {
+	Tiers []rewards.Tier `json:"tiers"`
+}
+
+// @Summary Get the reward schedule
+// @Description Get the PCSP reward for each risk score, as set on-chain
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Success 200 {object} RewardScheduleResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/rewards/schedule [get]
+func (h *rewardHandler) GetSchedule(c *gin.Context) {
+	tiers, err := h.rewardService.GetSchedule()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, RewardScheduleResponse{Tiers: tiers})
+}
+
+// @Summary Propose a reward schedule
+// @Description Checks a schedule against the TEE risk levels, where a higher risk must earn at least as much, and compares it with the current one. With apply, the service wallet sets it through the Controller.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param request body RewardScheduleRequest true "Proposed schedule"
+// @Success 200 {object} rewards.ScheduleChange
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/rewards/schedule [post]
+func (h *rewardHandler) ProposeSchedule(c *gin.Context) {
+	var req RewardScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var schedule rewards.Schedule
+	if len(req.Amounts) != len(schedule) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: rewards.ErrInvalidSchedule.Error()})
+		return
+	}
+	for i, amount := range req.Amounts {
+		value, ok := parseUint256(amount)
+		if !ok {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid amount"})
+			return
+		}
+		schedule[i] = value
+	}
+
+	change, err := h.rewardService.ProposeSchedule(schedule, req.Apply)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, rewards.ErrInvalidSchedule) || errors.Is(err, rewards.ErrInconsistentSchedule) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, change)
+}
@@ -0,0 +1,599 @@
+package marketplace
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/access"
+	"github.com/TropicalDog17/genomic-dao-service/internal/audit"
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
+	genomicCrypto "github.com/TropicalDog17/genomic-dao-service/pkg/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidRequestID      = errors.New("invalid request ID")
+	ErrRequestNotFound       = errors.New("analysis request not found")
+	ErrInvalidAmount         = errors.New("amount must be positive")
+	ErrSignatureExpired      = errors.New("signature expired")
+	ErrInsufficientAllowance = errors.New("PCSP balance or escrow allowance is below the amount")
+	ErrInvalidConsent        = errors.New("invalid signed consent")
+	ErrStaleConsent          = errors.New("a newer consent is already recorded")
+	ErrNotTokenOwner         = errors.New("signer does not hold the GeneNFT")
+)
+
+// maxCohortScan bounds the cohort when the escrow's contributor limit is larger
+const maxCohortScan = 1000
+
+// Request status in the escrow contract
+const (
+	escrowLocked   uint8 = 1
+	escrowSettled  uint8 = 2
+	escrowRefunded uint8 = 3
+)
+
+// AnalysisRequest is verified by the escrow before it locks the researcher's payment
+var analysisRequestType = []apitypes.Type{
+	{Name: "researcher", Type: "address"},
+	{Name: "analysis", Type: "string"},
+	{Name: "amount", Type: "uint256"},
+	{Name: "nonce", Type: "uint256"},
+	{Name: "deadline", Type: "uint256"},
+}
+
+// AnalysisResultRequest is signed by the researcher, under the escrow domain, to read an analysis
+var analysisResultRequestType = []apitypes.Type{
+	{Name: "requestId", Type: "uint256"},
+	{Name: "deadline", Type: "uint256"},
+}
+
+// ConsentMessage is the text a GeneNFT holder signs with personal_sign to grant or withdraw
+// research use of the token's data.
+func ConsentMessage(tokenID string, granted bool, timestamp int64) string {
+	return fmt.Sprintf("GenomicDAO research consent\nToken ID: %s\nGranted: %t\nTimestamp: %d", tokenID, granted, timestamp)
+}
+
+// Job is a paid analysis with its escrow status and, once settled, the released aggregate.
+type Job struct {
+	RequestID  string `json:"requestId" example:"1"`
+	Researcher string `json:"researcher" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	Analysis   string `json:"analysis" example:"risk-summary"`
+	Amount     string `json:"amount" example:"100000000000000000000"`
+	// locked, settled or refunded
+	Status        string              `json:"status" example:"settled"`
+	Contributors  int                 `json:"contributors" example:"12"`
+	Result        *tee.AnalysisResult `json:"result,omitempty"`
+	Reason        string              `json:"reason,omitempty" example:"cohort is smaller than 5 members"`
+	RequestTxHash string              `json:"requestTxHash"`
+	SettleTxHash  string              `json:"settleTxHash,omitempty"`
+}
+
+// Consent is the consent recorded for a GeneNFT.
+type Consent struct {
+	TokenID string `json:"tokenId" example:"14"`
+	Owner   string `json:"owner" example:"0x62f563A2e09c7987dECBFF61fdcC89cd74717721"`
+	Granted bool   `json:"granted" example:"true"`
+}
+
+// Domain is the escrow signing domain with the researcher's next request nonce.
+type Domain struct {
+	Domain *onchain.EIP712Domain `json:"domain"`
+	Nonce  string                `json:"nonce,omitempty" example:"0"`
+}
+
+// AnalysisRequest is an AnalysisRequest signed by the researcher.
+type AnalysisRequest struct {
+	Researcher common.Address
+	Analysis   string
+	Amount     *big.Int
+	Deadline   *big.Int
+	Signature  string
+}
+
+// JobRequest is an AnalysisResultRequest signed by a researcher to read an analysis.
+type JobRequest struct {
+	RequestID  string
+	Researcher common.Address
+	Deadline   *big.Int
+	Signature  string
+}
+
+// ConsentRequest is a consent decision signed by the GeneNFT holder.
+type ConsentRequest struct {
+	TokenID   string
+	Owner     common.Address
+	Granted   bool
+	Timestamp int64
+	Signature string
+}
+
+type MarketplaceService interface {
+	GetDomain(researcher *common.Address) (*Domain, error)
+	SetConsent(req *ConsentRequest) (*Consent, error)
+	RequestAnalysis(req *AnalysisRequest) (*Job, error)
+	GetJob(req *JobRequest) (*Job, error)
+	RecoverJobs() error
+}
+
+type marketplaceService struct {
+	transactor     *onchain.Transactor
+	escrow         *contracts.ResearchEscrow
+	escrowAddr     common.Address
+	pcspToken      *onchain.Linked[contracts.PCSPToken]
+	onchainService onchain.OnchainService
+	teeService     tee.TeeService
+	teeKey         *ecdsa.PrivateKey
+	repository     storage.MarketplaceRepository
+	auditService   audit.AuditService
+	now            func() time.Time
+}
+
+// NewMarketplaceService binds the escrow and the PCSP token it holds. teeKey decrypts the
+// consented data inside the TEE, where each file read is recorded in the audit log.
+func NewMarketplaceService(
+	transactor *onchain.Transactor,
+	escrowAddr common.Address,
+	onchainService onchain.OnchainService,
+	teeService tee.TeeService,
+	teeKey *ecdsa.PrivateKey,
+	repository storage.MarketplaceRepository,
+	auditService audit.AuditService,
+) MarketplaceService {
+	escrow, err := contracts.NewResearchEscrow(escrowAddr, transactor.Backend())
+	if err != nil {
+		panic(err)
+	}
+
+	pcspToken := onchain.NewLinked(func() (*contracts.PCSPToken, error) {
+		tokenAddr, err := escrow.PcspToken(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PCSP address: %v", err)
+		}
+		return contracts.NewPCSPToken(tokenAddr, transactor.Backend())
+	})
+
+	return &marketplaceService{
+		transactor:     transactor,
+		escrow:         escrow,
+		escrowAddr:     escrowAddr,
+		pcspToken:      pcspToken,
+		onchainService: onchainService,
+		teeService:     teeService,
+		teeKey:         teeKey,
+		repository:     repository,
+		auditService:   auditService,
+		now:            time.Now,
+	}
+}
+
+// GetDomain returns the EIP-712 domain analysis requests are signed under, with the
+// researcher's nonce when one is given.
+func (s *marketplaceService) GetDomain(researcher *common.Address) (*Domain, error) {
+	domain, err := s.escrow.Eip712Domain(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EIP-712 domain: %v", err)
+	}
+
+	resp := &Domain{Domain: &onchain.EIP712Domain{
+		Name:              domain.Name,
+		Version:           domain.Version,
+		ChainID:           domain.ChainId,
+		VerifyingContract: domain.VerifyingContract,
+	}}
+	if researcher != nil {
+		nonce, err := s.escrow.Nonces(nil, *researcher)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nonce: %v", err)
+		}
+		resp.Nonce = nonce.String()
+	}
+	return resp, nil
+}
+
+// SetConsent records a holder's decision for a GeneNFT. Consent lapses when the token changes hands.
+func (s *marketplaceService) SetConsent(req *ConsentRequest) (*Consent, error) {
+	if _, ok := new(big.Int).SetString(req.TokenID, 10); !ok {
+		return nil, ErrInvalidConsent
+	}
+	age := s.now().Sub(time.Unix(req.Timestamp, 0))
+	if age > access.RequestValidity || age < -access.RequestValidity {
+		return nil, ErrSignatureExpired
+	}
+	if !genomicCrypto.VerifyPersonalSign(req.Owner, []byte(ConsentMessage(req.TokenID, req.Granted, req.Timestamp)), req.Signature) {
+		return nil, ErrInvalidConsent
+	}
+
+	owner, err := s.onchainService.GetTokenOwner(req.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token owner: %w", err)
+	}
+	if owner != req.Owner {
+		return nil, ErrNotTokenOwner
+	}
+
+	// A replayed older decision must not undo a newer one
+	existing, err := s.repository.FindConsent(req.TokenID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find consent: %w", err)
+	}
+	if existing != nil && common.HexToAddress(existing.Owner) == req.Owner && existing.SignedAt >= req.Timestamp {
+		return nil, ErrStaleConsent
+	}
+
+	if err := s.repository.SaveConsent(&storage.ResearchConsent{
+		TokenID:  req.TokenID,
+		Owner:    req.Owner.Hex(),
+		Granted:  req.Granted,
+		SignedAt: req.Timestamp,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save consent: %w", err)
+	}
+
+	return &Consent{TokenID: req.TokenID, Owner: req.Owner.Hex(), Granted: req.Granted}, nil
+}
+
+// RequestAnalysis locks the researcher's payment in escrow, then runs the analysis over the consented
+// cohort. The payment is split between the contributing GeneNFT owners, or refunded if the analysis fails.
+func (s *marketplaceService) RequestAnalysis(req *AnalysisRequest) (*Job, error) {
+	if !tee.SupportedAnalysis(req.Analysis) {
+		return nil, fmt.Errorf("%w: %s", tee.ErrUnsupportedAnalysis, req.Analysis)
+	}
+	if req.Amount.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if req.Deadline.Cmp(big.NewInt(s.now().Unix())) < 0 {
+		return nil, ErrSignatureExpired
+	}
+
+	domain, err := s.GetDomain(&req.Researcher)
+	if err != nil {
+		return nil, err
+	}
+	nonce, _ := new(big.Int).SetString(domain.Nonce, 10)
+	sig, err := onchain.VerifyTypedData(domain.Domain.TypedData("AnalysisRequest", analysisRequestType, apitypes.TypedDataMessage{
+		"researcher": req.Researcher.Hex(),
+		"analysis":   req.Analysis,
+		"amount":     req.Amount,
+		"nonce":      nonce,
+		"deadline":   req.Deadline,
+	}), req.Researcher, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked here so the caller gets a clear error instead of a reverted transaction
+	pcspToken, err := s.pcspToken.Get()
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := pcspToken.Allowance(nil, req.Researcher, s.escrowAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowance: %v", err)
+	}
+	balance, err := pcspToken.BalanceOf(nil, req.Researcher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %v", err)
+	}
+	if allowance.Cmp(req.Amount) < 0 || balance.Cmp(req.Amount) < 0 {
+		return nil, ErrInsufficientAllowance
+	}
+
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.escrow.RequestAnalysis(opts, req.Researcher, req.Analysis, req.Amount, req.Deadline, sig.V, sig.R, sig.S)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock payment: %v", err)
+	}
+	receipt, err := s.transactor.WaitMined(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for transaction: %v", err)
+	}
+
+	var requested *contracts.ResearchEscrowAnalysisRequested
+	for _, log := range receipt.Logs {
+		if event, err := s.escrow.ParseAnalysisRequested(*log); err == nil && event != nil {
+			requested = event
+			break
+		}
+	}
+	if requested == nil {
+		return nil, fmt.Errorf("payment lock reverted: %s", tx.Hash().Hex())
+	}
+
+	job := &storage.AnalysisJob{
+		RequestID:     requested.RequestId.String(),
+		Researcher:    req.Researcher.Hex(),
+		Analysis:      req.Analysis,
+		Amount:        req.Amount.String(),
+		Status:        storage.AnalysisLocked,
+		RequestTxHash: tx.Hash().Hex(),
+	}
+	if err := s.repository.CreateJob(job); err != nil {
+		return nil, fmt.Errorf("failed to store analysis job: %w", err)
+	}
+
+	if err := s.run(job); err != nil {
+		return nil, err
+	}
+	return toJob(job, true), nil
+}
+
+// GetJob returns an analysis to the researcher who signed req. Its result is only released to the
+// researcher who paid for it.
+func (s *marketplaceService) GetJob(req *JobRequest) (*Job, error) {
+	if id, ok := new(big.Int).SetString(req.RequestID, 10); !ok || id.Sign() <= 0 {
+		return nil, ErrInvalidRequestID
+	}
+	if req.Deadline.Cmp(big.NewInt(s.now().Unix())) < 0 {
+		return nil, ErrSignatureExpired
+	}
+
+	domain, err := s.GetDomain(nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyJobRequest(domain.Domain, req); err != nil {
+		return nil, err
+	}
+
+	job, err := s.repository.FindJob(req.RequestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to find analysis job: %w", err)
+	}
+	return toJob(job, common.HexToAddress(job.Researcher) == req.Researcher), nil
+}
+
+// verifyJobRequest checks that req was signed by its researcher under the escrow domain.
+func verifyJobRequest(domain *onchain.EIP712Domain, req *JobRequest) error {
+	requestID, _ := new(big.Int).SetString(req.RequestID, 10)
+	_, err := onchain.VerifyTypedData(domain.TypedData("AnalysisResultRequest", analysisResultRequestType, apitypes.TypedDataMessage{
+		"requestId": requestID,
+		"deadline":  req.Deadline,
+	}), req.Researcher, req.Signature)
+	return err
+}
+
+// RecoverJobs finishes the jobs whose payment was still locked at the last shutdown, following the
+// escrow: a request settled or refunded on-chain is recorded as such, one still locked is run again.
+func (s *marketplaceService) RecoverJobs() error {
+	jobs, err := s.repository.FindLockedJobs()
+	if err != nil {
+		return fmt.Errorf("failed to find locked analysis jobs: %w", err)
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		requestID, _ := new(big.Int).SetString(job.RequestID, 10)
+		request, err := s.escrow.GetRequest(nil, requestID)
+		if err != nil {
+			log.Printf("analysis %s: failed to get escrow request: %v", job.RequestID, err)
+			continue
+		}
+
+		switch request.Status {
+		case escrowSettled:
+			job.Status = storage.AnalysisSettled
+			err = s.repository.SaveJob(job)
+		case escrowRefunded:
+			job.Status = storage.AnalysisRefunded
+			err = s.repository.SaveJob(job)
+		case escrowLocked:
+			err = s.run(job)
+		}
+		if err != nil {
+			log.Printf("analysis %s: recovery failed: %v", job.RequestID, err)
+		}
+	}
+	return nil
+}
+
+// run analyses the consented cohort and settles the escrowed payment with its contributors. Any
+// failure refunds the researcher; a job whose refund fails stays locked for RecoverJobs.
+func (s *marketplaceService) run(job *storage.AnalysisJob) error {
+	result, tokenIDs, err := s.analyze(job)
+	if err != nil {
+		return s.refund(job, err.Error())
+	}
+
+	released, err := json.Marshal(result)
+	if err != nil {
+		return s.refund(job, err.Error())
+	}
+	job.Result = string(released)
+	job.TokenIDs = joinTokenIDs(tokenIDs)
+	if err := s.repository.SaveJob(job); err != nil {
+		return fmt.Errorf("failed to store analysis result: %w", err)
+	}
+
+	receipt, err := s.send(func() (*types.Transaction, error) {
+		requestID, _ := new(big.Int).SetString(job.RequestID, 10)
+		opts, err := s.transactor.Opts()
+		if err != nil {
+			return nil, err
+		}
+		return s.escrow.Settle(opts, requestID, tokenIDs)
+	})
+	if err != nil {
+		return s.refund(job, fmt.Sprintf("settlement failed: %v", err))
+	}
+
+	job.Status = storage.AnalysisSettled
+	job.SettleTxHash = receipt.TxHash.Hex()
+	if err := s.repository.SaveJob(job); err != nil {
+		return fmt.Errorf("failed to store settlement: %w", err)
+	}
+	return nil
+}
+
+// analyze gathers the data of the GeneNFTs still held by the owner who consented, the first
+// MAX_CONTRIBUTORS tokens by ID, and returns the released aggregate with the tokens that contributed.
+// Every file the TEE read is recorded in the audit log, for the researcher, before anything is
+// released.
+func (s *marketplaceService) analyze(job *storage.AnalysisJob) (*tee.AnalysisResult, []*big.Int, error) {
+	maxContributors, err := s.escrow.MAXCONTRIBUTORS(nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get contributor limit: %v", err)
+	}
+	limit := maxCohortScan
+	if maxContributors.IsInt64() && maxContributors.Int64() < int64(limit) {
+		limit = int(maxContributors.Int64())
+	}
+	cohort, err := s.selectCohort(limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records := make([][]byte, len(cohort))
+	for i, m := range cohort {
+		records[i] = m.EncryptedData
+	}
+	result, contributors, analyzeErr := s.teeService.Analyze(job.Analysis, records, s.teeKey)
+	outcome := storage.AuditAllowed
+	if analyzeErr != nil {
+		outcome = storage.AuditFailed
+	}
+	for _, m := range cohort {
+		err := s.auditService.Record(&storage.AuditEvent{
+			Actor:   common.HexToAddress(job.Researcher).Hex(),
+			Action:  storage.AuditAnalyze,
+			FileID:  m.FileID,
+			UserID:  m.UserID,
+			Purpose: fmt.Sprintf("%s analysis, request %s", job.Analysis, job.RequestID),
+			Outcome: outcome,
+		})
+		if err != nil && analyzeErr == nil {
+			return nil, nil, fmt.Errorf("failed to record access: %w", err)
+		}
+	}
+	if analyzeErr != nil {
+		return nil, nil, analyzeErr
+	}
+
+	// The escrow takes token IDs in increasing order, as the cohort is selected
+	tokenIDs := make([]*big.Int, len(contributors))
+	for i, index := range contributors {
+		tokenIDs[i], _ = new(big.Int).SetString(cohort[index].TokenID, 10)
+	}
+	return result, tokenIDs, nil
+}
+
+// selectCohort picks the first limit consented tokens, by ID, still held by the owner who consented,
+// and only then loads their data.
+func (s *marketplaceService) selectCohort(limit int) ([]storage.ConsentedData, error) {
+	var cohort []storage.ConsentedData
+	for offset := 0; len(cohort) < limit; offset += limit {
+		page, err := s.repository.FindConsentedData(offset, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find consented data: %w", err)
+		}
+		for _, data := range page {
+			if _, ok := new(big.Int).SetString(data.TokenID, 10); !ok {
+				continue
+			}
+			owner, err := s.onchainService.GetTokenOwner(data.TokenID)
+			if err != nil || owner != common.HexToAddress(data.Owner) {
+				continue
+			}
+			if cohort = append(cohort, data); len(cohort) == limit {
+				break
+			}
+		}
+		if len(page) < limit {
+			break
+		}
+	}
+
+	if err := s.repository.LoadConsentedData(cohort); err != nil {
+		return nil, fmt.Errorf("failed to load consented data: %w", err)
+	}
+	return cohort, nil
+}
+
+func (s *marketplaceService) refund(job *storage.AnalysisJob, reason string) error {
+	job.Reason = reason
+	receipt, err := s.send(func() (*types.Transaction, error) {
+		requestID, _ := new(big.Int).SetString(job.RequestID, 10)
+		opts, err := s.transactor.Opts()
+		if err != nil {
+			return nil, err
+		}
+		return s.escrow.Refund(opts, requestID)
+	})
+	if err != nil {
+		if saveErr := s.repository.SaveJob(job); saveErr != nil {
+			log.Printf("analysis %s: failed to store refund failure: %v", job.RequestID, saveErr)
+		}
+		return fmt.Errorf("failed to refund analysis %s: %w", job.RequestID, err)
+	}
+
+	job.Status = storage.AnalysisRefunded
+	job.SettleTxHash = receipt.TxHash.Hex()
+	if err := s.repository.SaveJob(job); err != nil {
+		return fmt.Errorf("failed to store refund: %w", err)
+	}
+	return nil
+}
+
+// send submits a transaction and waits for it to succeed.
+func (s *marketplaceService) send(submit func() (*types.Transaction, error)) (*types.Receipt, error) {
+	tx, err := submit()
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := s.transactor.WaitMined(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for transaction: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction reverted: %s", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+func joinTokenIDs(tokenIDs []*big.Int) string {
+	ids := make([]string, len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		ids[i] = tokenID.String()
+	}
+	return strings.Join(ids, ",")
+}
+
+// toJob reports a job, with its result only when released to the caller.
+func toJob(job *storage.AnalysisJob, released bool) *Job {
+	resp := &Job{
+		RequestID:     job.RequestID,
+		Researcher:    job.Researcher,
+		Analysis:      job.Analysis,
+		Amount:        job.Amount,
+		Status:        job.Status,
+		Reason:        job.Reason,
+		RequestTxHash: job.RequestTxHash,
+		SettleTxHash:  job.SettleTxHash,
+	}
+	// Results are only released once the contributors are paid
+	if released && job.Status == storage.AnalysisSettled && job.Result != "" {
+		var result tee.AnalysisResult
+		if err := json.Unmarshal([]byte(job.Result), &result); err == nil {
+			resp.Result = &result
+		}
+		resp.Contributors = len(strings.Split(job.TokenIDs, ","))
+	}
+	return resp
+}
@@ -0,0 +1,285 @@
+package marketplace
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"gorm.io/gorm"
+)
+
+// Mock onchain service exposing only the GeneNFT owner lookup
+type mockOnchainService struct {
+	onchain.OnchainService
+	owners map[string]common.Address
+}
+
+func (m *mockOnchainService) GetTokenOwner(tokenID string) (common.Address, error) {
+	return m.owners[tokenID], nil
+}
+
+func newTestRepository(t *testing.T, db *gorm.DB) (storage.MarketplaceRepository, blobstore.BlobStore) {
+	blobs, err := blobstore.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to open blob store: %v", err)
+	}
+	return storage.NewMarketplaceRepository(db, blobs), blobs
+}
+
+// storedGeneData keeps data in the blob store and returns a completed record referencing it
+func storedGeneData(t *testing.T, blobs blobstore.BlobStore, fileID string, tokenID string, data string) *storage.GeneData {
+	blob, err := blobs.Put([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to store blob: %v", err)
+	}
+	return &storage.GeneData{FileID: fileID, TokenID: tokenID, UploadStatus: storage.UploadCompleted, BlobKey: blob.Key, BlobSize: blob.Size, BlobChecksum: blob.Checksum}
+}
+
+func TestMarketplaceService_SetConsent(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		holderKey, _ := crypto.GenerateKey()
+		holder := crypto.PubkeyToAddress(holderKey.PublicKey)
+		strangerKey, _ := crypto.GenerateKey()
+		now := time.Unix(1734280000, 0)
+
+		sign := func(key *ecdsa.PrivateKey, tokenID string, granted bool, timestamp int64) string {
+			sig, err := crypto.Sign(accounts.TextHash([]byte(ConsentMessage(tokenID, granted, timestamp))), key)
+			if err != nil {
+				t.Fatalf("Failed to sign: %v", err)
+			}
+			sig[crypto.RecoveryIDOffset] += 27
+			return hexutil.Encode(sig)
+		}
+
+		repository, blobs := newTestRepository(t, db)
+		// Token 1 is held by the signer, token 2 by someone else
+		db.Create(storedGeneData(t, blobs, "f1", "1", "one"))
+		db.Create(storedGeneData(t, blobs, "f2", "2", "two"))
+		service := &marketplaceService{
+			onchainService: &mockOnchainService{owners: map[string]common.Address{
+				"1": holder,
+				"2": crypto.PubkeyToAddress(strangerKey.PublicKey),
+			}},
+			repository: repository,
+			now:        func() time.Time { return now },
+		}
+
+		tests := []struct {
+			name        string
+			req         *ConsentRequest
+			wantErr     error
+			wantGranted []string
+		}{
+			{
+				name:        "Holder grants consent",
+				req:         &ConsentRequest{TokenID: "1", Owner: holder, Granted: true, Timestamp: now.Unix(), Signature: sign(holderKey, "1", true, now.Unix())},
+				wantGranted: []string{"1"},
+			},
+			{
+				name:        "Replayed decision",
+				req:         &ConsentRequest{TokenID: "1", Owner: holder, Granted: true, Timestamp: now.Unix(), Signature: sign(holderKey, "1", true, now.Unix())},
+				wantErr:     ErrStaleConsent,
+				wantGranted: []string{"1"},
+			},
+			{
+				name:        "Signed for another decision",
+				req:         &ConsentRequest{TokenID: "1", Owner: holder, Granted: false, Timestamp: now.Unix() + 1, Signature: sign(holderKey, "1", true, now.Unix()+1)},
+				wantErr:     ErrInvalidConsent,
+				wantGranted: []string{"1"},
+			},
+			{
+				name:        "Expired signature",
+				req:         &ConsentRequest{TokenID: "1", Owner: holder, Granted: false, Timestamp: now.Add(-time.Hour).Unix(), Signature: sign(holderKey, "1", false, now.Add(-time.Hour).Unix())},
+				wantErr:     ErrSignatureExpired,
+				wantGranted: []string{"1"},
+			},
+			{
+				name:        "Signer does not hold the token",
+				req:         &ConsentRequest{TokenID: "2", Owner: holder, Granted: true, Timestamp: now.Unix(), Signature: sign(holderKey, "2", true, now.Unix())},
+				wantErr:     ErrNotTokenOwner,
+				wantGranted: []string{"1"},
+			},
+			{
+				name: "Holder withdraws consent",
+				req:  &ConsentRequest{TokenID: "1", Owner: holder, Granted: false, Timestamp: now.Unix() + 1, Signature: sign(holderKey, "1", false, now.Unix()+1)},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := service.SetConsent(tt.req)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SetConsent() error = %v, want %v", err, tt.wantErr)
+				}
+
+				consented, err := repository.FindConsentedData(0, 10)
+				if err != nil {
+					t.Fatalf("FindConsentedData() error = %v", err)
+				}
+				if err := repository.LoadConsentedData(consented); err != nil {
+					t.Fatalf("LoadConsentedData() error = %v", err)
+				}
+				if len(consented) != len(tt.wantGranted) {
+					t.Fatalf("Expected consented tokens %v, got %+v", tt.wantGranted, consented)
+				}
+				for i, data := range consented {
+					if data.TokenID != tt.wantGranted[i] || data.Owner != holder.Hex() || len(data.EncryptedData) == 0 {
+						t.Errorf("Unexpected consented data %+v", data)
+					}
+				}
+			})
+		}
+	})
+}
+
+func TestMarketplaceService_SelectCohort(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		holder := common.HexToAddress("0x1111111111111111111111111111111111111111")
+		buyer := common.HexToAddress("0x2222222222222222222222222222222222222222")
+		repository, blobs := newTestRepository(t, db)
+
+		// Token 2 was sold since its holder consented, token 10 sorts after 9 by ID
+		owners := map[string]common.Address{}
+		for _, tokenID := range []string{"10", "9", "2", "3", "1"} {
+			db.Create(storedGeneData(t, blobs, "f"+tokenID, tokenID, "data "+tokenID))
+			db.Create(&storage.ResearchConsent{TokenID: tokenID, Owner: holder.Hex(), Granted: true})
+			owners[tokenID] = holder
+		}
+		owners["2"] = buyer
+		// Token 10 is beyond the limit, so its missing data is never read
+		db.Model(&storage.GeneData{}).Where("token_id = ?", "10").Update("blob_key", "missing")
+
+		service := &marketplaceService{
+			onchainService: &mockOnchainService{owners: owners},
+			repository:     repository,
+		}
+
+		cohort, err := service.selectCohort(3)
+		if err != nil {
+			t.Fatalf("selectCohort() error = %v", err)
+		}
+		var tokenIDs []string
+		for _, member := range cohort {
+			tokenIDs = append(tokenIDs, member.TokenID)
+			if string(member.EncryptedData) != "data "+member.TokenID {
+				t.Errorf("Unexpected data %q for token %s", member.EncryptedData, member.TokenID)
+			}
+		}
+		if strings.Join(tokenIDs, ",") != "1,3,9" {
+			t.Errorf("Expected tokens 1,3,9, got %v", tokenIDs)
+		}
+	})
+}
+
+func TestVerifyJobRequest(t *testing.T) {
+	researcherKey, _ := crypto.GenerateKey()
+	researcher := crypto.PubkeyToAddress(researcherKey.PublicKey)
+	strangerKey, _ := crypto.GenerateKey()
+	domain := &onchain.EIP712Domain{
+		Name:              "AnalysisEscrow",
+		Version:           "1",
+		ChainID:           big.NewInt(1337),
+		VerifyingContract: common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3"),
+	}
+	deadline := big.NewInt(1734280000)
+
+	sign := func(key *ecdsa.PrivateKey, requestID int64) string {
+		digest, _, err := apitypes.TypedDataAndHash(domain.TypedData("AnalysisResultRequest", analysisResultRequestType, apitypes.TypedDataMessage{
+			"requestId": big.NewInt(requestID),
+			"deadline":  deadline,
+		}))
+		if err != nil {
+			t.Fatalf("Failed to hash typed data: %v", err)
+		}
+		sig, err := crypto.Sign(digest, key)
+		if err != nil {
+			t.Fatalf("Failed to sign: %v", err)
+		}
+		return hexutil.Encode(sig)
+	}
+
+	tests := []struct {
+		name    string
+		req     *JobRequest
+		wantErr error
+	}{
+		{
+			name: "Signed by the researcher",
+			req:  &JobRequest{RequestID: "7", Researcher: researcher, Deadline: deadline, Signature: sign(researcherKey, 7)},
+		},
+		{
+			name:    "Signed for another request",
+			req:     &JobRequest{RequestID: "7", Researcher: researcher, Deadline: deadline, Signature: sign(researcherKey, 8)},
+			wantErr: onchain.ErrInvalidAuthorization,
+		},
+		{
+			name:    "Signed by someone else",
+			req:     &JobRequest{RequestID: "7", Researcher: researcher, Deadline: deadline, Signature: sign(strangerKey, 7)},
+			wantErr: onchain.ErrInvalidAuthorization,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyJobRequest(domain, tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("verifyJobRequest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToJob(t *testing.T) {
+	tests := []struct {
+		name             string
+		job              storage.AnalysisJob
+		released         bool
+		wantResult       bool
+		wantContributors int
+	}{
+		{
+			name:             "Settled job releases its result",
+			job:              storage.AnalysisJob{Status: storage.AnalysisSettled, TokenIDs: "1,4,9", Result: `{"analysis":"risk-summary","cohortSize":3}`},
+			released:         true,
+			wantResult:       true,
+			wantContributors: 3,
+		},
+		{
+			name: "Settled job read by another researcher",
+			job:  storage.AnalysisJob{Status: storage.AnalysisSettled, TokenIDs: "1,4,9", Result: `{"analysis":"risk-summary","cohortSize":3}`},
+		},
+		{
+			name:     "Locked job keeps its result until payout",
+			job:      storage.AnalysisJob{Status: storage.AnalysisLocked, TokenIDs: "1,4,9", Result: `{"analysis":"risk-summary","cohortSize":3}`},
+			released: true,
+		},
+		{
+			name:     "Refunded job",
+			job:      storage.AnalysisJob{Status: storage.AnalysisRefunded, Reason: "cohort is smaller than 5 members"},
+			released: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := toJob(&tt.job, tt.released)
+			if (job.Result != nil) != tt.wantResult {
+				t.Errorf("Expected result %v, got %+v", tt.wantResult, job.Result)
+			}
+			if job.Contributors != tt.wantContributors {
+				t.Errorf("Expected %d contributors, got %d", tt.wantContributors, job.Contributors)
+			}
+		})
+	}
+}
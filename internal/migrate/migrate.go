@@ -0,0 +1,317 @@
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/migrations"
+	"gorm.io/gorm"
+)
+
+// DownMarker separates the statements applying a migration from those reverting it.
+const DownMarker = "-- +migrate Down"
+
+var (
+	ErrSchemaBehind     = errors.New("database schema is behind, run the pending migrations")
+	ErrChecksumMismatch = errors.New("an applied migration file was modified")
+	ErrUnknownVersion   = errors.New("database has a migration this build does not know")
+	ErrIrreversible     = errors.New("migration cannot be reverted")
+	ErrNotBaselined     = errors.New("database predates schema_migrations, baseline it at the last migration it has")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)-([a-z0-9-]+)\.sql$`)
+
+// Migration is one versioned SQL file.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// MigrationStatus tells whether a migration was applied, and when.
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// SchemaMigration records an applied migration in the schema_migrations table.
+type SchemaMigration struct {
+	Version   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+type Migrator interface {
+	// Up applies every pending migration in order, each in its own transaction.
+	Up() ([]Migration, error)
+	// Down reverts the last steps applied migrations.
+	Down(steps int) ([]Migration, error)
+	Status() ([]MigrationStatus, error)
+	// Check fails unless every migration is applied, unmodified, and none is unknown.
+	Check() error
+	// Baseline records the migrations up to version as applied, without running them, for a
+	// database whose schema was created before schema_migrations.
+	Baseline(version int64) error
+}
+
+type migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator migrates db with the embedded migrations of its dialect.
+func NewMigrator(db *gorm.DB) Migrator {
+	fsys, err := migrations.For(db.Dialector.Name())
+	if err != nil {
+		panic(err)
+	}
+	m, err := New(db, fsys)
+	if err != nil {
+		panic(fmt.Errorf("invalid embedded migrations: %v", err))
+	}
+	return m
+}
+
+// New migrates db with the migrations found in fsys.
+func New(db *gorm.DB, fsys fs.FS) (Migrator, error) {
+	loaded, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &migrator{db: db, migrations: loaded}, nil
+}
+
+// Load reads the migrations of fsys, ordered by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var loaded []Migration
+	seen := make(map[int64]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %s is not named <version>-<name>.sql", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, entry.Name(), version)
+		}
+		seen[version] = entry.Name()
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		up, down, _ := strings.Cut(string(data), DownMarker)
+		loaded = append(loaded, Migration{
+			Version:  version,
+			Name:     match[2],
+			Up:       strings.TrimSpace(up),
+			Down:     strings.TrimSpace(down),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i].Version < loaded[j].Version })
+	return loaded, nil
+}
+
+func (m *migrator) ensureTable() error {
+	return m.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name TEXT NOT NULL,
+    checksum TEXT NOT NULL,
+    applied_at TIMESTAMP NOT NULL
+)`).Error
+}
+
+// applied returns the recorded migrations by version; none while schema_migrations does not exist.
+func (m *migrator) applied() (map[int64]SchemaMigration, error) {
+	applied := make(map[int64]SchemaMigration)
+	if !m.db.Migrator().HasTable(&SchemaMigration{}) {
+		return applied, nil
+	}
+
+	var records []SchemaMigration
+	if err := m.db.Order("version").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %v", err)
+	}
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// verify checks the applied migrations against the files and returns the pending ones.
+func (m *migrator) verify(applied map[int64]SchemaMigration) ([]Migration, error) {
+	known := make(map[int64]bool)
+	var pending []Migration
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		record, ok := applied[migration.Version]
+		if !ok {
+			pending = append(pending, migration)
+			continue
+		}
+		if record.Checksum != migration.Checksum {
+			return nil, fmt.Errorf("%w: %d-%s", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+	for version, record := range applied {
+		if !known[version] {
+			return nil, fmt.Errorf("%w: %d-%s", ErrUnknownVersion, version, record.Name)
+		}
+	}
+	return pending, nil
+}
+
+func (m *migrator) Up() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	// Running the first migrations again over tables created by hand would fail halfway
+	if len(applied) == 0 && m.db.Migrator().HasTable("gene_data") {
+		return nil, ErrNotBaselined
+	}
+	pending, err := m.verify(applied)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range pending {
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Checksum:  migration.Checksum,
+				AppliedAt: time.Now().UTC(),
+			}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d-%s failed: %v", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+func (m *migrator) Down(steps int) ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.verify(applied); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == "" {
+			return done, fmt.Errorf("%w: %d-%s", ErrIrreversible, migration.Version, migration.Name)
+		}
+
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("reverting migration %d-%s failed: %v", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+func (m *migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		record, ok := applied[migration.Version]
+		statuses = append(statuses, MigrationStatus{Migration: migration, Applied: ok, AppliedAt: record.AppliedAt})
+	}
+	return statuses, nil
+}
+
+func (m *migrator) Check() error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	pending, err := m.verify(applied)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d pending, from %d-%s", ErrSchemaBehind, len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
+
+func (m *migrator) Baseline(version int64) error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	if len(applied) > 0 {
+		return errors.New("database already has applied migrations")
+	}
+	if err := m.ensureTable(); err != nil {
+		return err
+	}
+
+	found := false
+	var records []SchemaMigration
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+		found = found || migration.Version == version
+		records = append(records, SchemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum,
+			AppliedAt: time.Now().UTC(),
+		})
+	}
+	if !found {
+		return fmt.Errorf("no migration has version %d", version)
+	}
+	return m.db.Create(&records).Error
+}
@@ -0,0 +1,238 @@
+package migrate_test
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"github.com/TropicalDog17/genomic-dao-service/internal/migrate"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/TropicalDog17/genomic-dao-service/migrations"
+	"gorm.io/gorm"
+)
+
+// migrationsBefore returns the embedded SQLite migrations older than version.
+func migrationsBefore(version string) fstest.MapFS {
+	sqliteMigrations, _ := migrations.For("sqlite")
+	files := fstest.MapFS{}
+	entries, _ := fs.ReadDir(sqliteMigrations, ".")
+	for _, entry := range entries {
+		if entry.Name() < version {
+			data, _ := fs.ReadFile(sqliteMigrations, entry.Name())
+			files[entry.Name()] = &fstest.MapFile{Data: data}
+		}
+	}
+	return files
+}
+
+// reversibleMigrations returns the embedded migrations of dialect up to the first one without down
+// statements.
+func reversibleMigrations(dialect string) fstest.MapFS {
+	dialectMigrations, _ := migrations.For(dialect)
+	files := fstest.MapFS{}
+	entries, _ := fs.ReadDir(dialectMigrations, ".")
+	for _, entry := range entries {
+		data, _ := fs.ReadFile(dialectMigrations, entry.Name())
+		if !strings.Contains(string(data), "-- +migrate Down") {
+			break
+		}
+		files[entry.Name()] = &fstest.MapFile{Data: data}
+	}
+	return files
+}
+
+// A database with gene data is only aligned once its blobs are moved and its files re-keyed
+func TestMigrationsUpgrade(t *testing.T) {
+	databasetest.RunEmpty(t, func(t *testing.T, db *gorm.DB) {
+		if db.Dialector.Name() != "sqlite" {
+			t.Skip("PostgreSQL databases start from the aligned schema")
+		}
+		before, _ := migrate.New(db, migrationsBefore("1735400000"))
+		if _, err := before.Up(); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		db.Exec("INSERT INTO users (id, pubkey) VALUES ('7', '0x1111111111111111111111111111111111111111')")
+		db.Exec("INSERT INTO gene_data (file_id, user_id, encrypted_data, data_hash, signature) VALUES ('0123456789abcdef', 7, 'ciphertext', 'hash', 'signature')")
+
+		migrator := migrate.NewMigrator(db)
+		if _, err := migrator.Up(); err == nil {
+			t.Fatalf("Expected the alignment to wait for the blobs to be moved")
+		}
+		if err := migrator.Check(); !errors.Is(err, migrate.ErrSchemaBehind) {
+			t.Errorf("Check() error = %v, want %v", err, migrate.ErrSchemaBehind)
+		}
+
+		blobs, err := blobstore.NewFileStore(t.TempDir())
+		if err != nil {
+			t.Fatalf("Failed to create blob store: %v", err)
+		}
+		if moved, err := storage.MoveBlobs(db, blobs, 10); err != nil || moved != 1 {
+			t.Fatalf("MoveBlobs() = %d, %v", moved, err)
+		}
+		if rekeyed, _, err := storage.RekeyFileIDs(db); err != nil || rekeyed != 1 {
+			t.Fatalf("RekeyFileIDs() = %d, %v", rekeyed, err)
+		}
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+
+		data, err := storage.NewGenDataRepository(db, blobs).RetrieveGeneData("0123456789abcdef")
+		if err != nil || string(data) != "ciphertext" {
+			t.Errorf("RetrieveGeneData() = %q, %v", data, err)
+		}
+	})
+}
+
+// Every migration before the first irreversible one reverts cleanly
+func TestMigrationsDown(t *testing.T) {
+	databasetest.RunEmpty(t, func(t *testing.T, db *gorm.DB) {
+		migrator, err := migrate.New(db, reversibleMigrations(db.Dialector.Name()))
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		applied, err := migrator.Up()
+		if err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		reverted, err := migrator.Down(len(applied))
+		if err != nil || len(reverted) != len(applied) {
+			t.Fatalf("Down() reverted %d of %d migrations, error = %v", len(reverted), len(applied), err)
+		}
+		for _, table := range []string{"users", "gene_data", "upload_workflows", "research_consents", "analysis_jobs", "content_anchors", "anchor_leaves"} {
+			if db.Migrator().HasTable(table) {
+				t.Errorf("Expected %s to be dropped", table)
+			}
+		}
+	})
+}
+
+func TestMigrator(t *testing.T) {
+	files := fstest.MapFS{
+		"1-create-items.sql":  {Data: []byte("CREATE TABLE items (id INTEGER PRIMARY KEY);\n-- +migrate Down\nDROP TABLE items;")},
+		"2-add-item-name.sql": {Data: []byte("ALTER TABLE items ADD COLUMN name TEXT;\n-- +migrate Down\nALTER TABLE items DROP COLUMN name;")},
+		"3-add-index.sql":     {Data: []byte("CREATE INDEX idx_items_name ON items(name);")},
+	}
+
+	databasetest.RunEmpty(t, func(t *testing.T, db *gorm.DB) {
+		migrator, err := migrate.New(db, files)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if err := migrator.Check(); !errors.Is(err, migrate.ErrSchemaBehind) {
+			t.Errorf("Check() of an empty database error = %v, want %v", err, migrate.ErrSchemaBehind)
+		}
+
+		if applied, err := migrator.Up(); err != nil || len(applied) != 3 {
+			t.Fatalf("Up() = %d migrations, %v", len(applied), err)
+		}
+		if err := migrator.Check(); err != nil {
+			t.Errorf("Check() error = %v", err)
+		}
+		if applied, err := migrator.Up(); err != nil || len(applied) != 0 {
+			t.Errorf("Up() again = %d migrations, %v, want none", len(applied), err)
+		}
+
+		// Migration 3 has no down statements
+		if _, err := migrator.Down(1); !errors.Is(err, migrate.ErrIrreversible) {
+			t.Errorf("Down() error = %v, want %v", err, migrate.ErrIrreversible)
+		}
+		db.Exec("DROP INDEX idx_items_name")
+		db.Delete(&migrate.SchemaMigration{}, 3)
+		if reverted, err := migrator.Down(1); err != nil || len(reverted) != 1 || reverted[0].Version != 2 {
+			t.Errorf("Down(1) = %+v, %v, want migration 2", reverted, err)
+		}
+		if db.Migrator().HasColumn("items", "name") {
+			t.Errorf("Expected migration 2 to be reverted")
+		}
+
+		statuses, _ := migrator.Status()
+		if len(statuses) != 3 || !statuses[0].Applied || statuses[1].Applied || statuses[2].Applied {
+			t.Errorf("Unexpected status %+v", statuses)
+		}
+
+		// Files are checked against what was applied
+		modified := fstest.MapFS{"1-create-items.sql": {Data: []byte("CREATE TABLE items (id INTEGER);")}}
+		for name, file := range files {
+			if name != "1-create-items.sql" {
+				modified[name] = file
+			}
+		}
+		if other, _ := migrate.New(db, modified); !errors.Is(other.Check(), migrate.ErrChecksumMismatch) {
+			t.Errorf("Check() with a modified file error = %v, want %v", other.Check(), migrate.ErrChecksumMismatch)
+		}
+		if other, _ := migrate.New(db, fstest.MapFS{}); !errors.Is(other.Check(), migrate.ErrUnknownVersion) {
+			t.Errorf("Check() without the applied files error = %v, want %v", other.Check(), migrate.ErrUnknownVersion)
+		}
+	})
+}
+
+func TestMigrator_Baseline(t *testing.T) {
+	databasetest.RunEmpty(t, func(t *testing.T, db *gorm.DB) {
+		// Created by hand before schema_migrations
+		db.Exec("CREATE TABLE gene_data (id INTEGER PRIMARY KEY)")
+
+		migrator := migrate.NewMigrator(db)
+		if _, err := migrator.Up(); !errors.Is(err, migrate.ErrNotBaselined) {
+			t.Fatalf("Up() error = %v, want %v", err, migrate.ErrNotBaselined)
+		}
+		loaded, _ := migrate.Load(embedded(t, db.Dialector.Name()))
+		if err := migrator.Baseline(loaded[0].Version); err != nil {
+			t.Fatalf("Baseline() error = %v", err)
+		}
+		statuses, _ := migrator.Status()
+		if !statuses[0].Applied || statuses[1].Applied {
+			t.Errorf("Expected only the first migration to be recorded, got %+v", statuses[:2])
+		}
+		if err := migrator.Baseline(loaded[0].Version); err == nil {
+			t.Errorf("Expected a second baseline to fail")
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fs.FS
+		wantErr bool
+	}{
+		{name: "SQLite migrations", files: embedded(t, "sqlite")},
+		{name: "PostgreSQL migrations", files: embedded(t, "postgres")},
+		{name: "Unversioned file", files: fstest.MapFS{"create-items.sql": {}}, wantErr: true},
+		{name: "Shared version", files: fstest.MapFS{"1-a.sql": {}, "01-b.sql": {}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := migrate.Load(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for i := 1; i < len(loaded); i++ {
+				if loaded[i].Version <= loaded[i-1].Version {
+					t.Errorf("Migrations out of order: %d after %d", loaded[i].Version, loaded[i-1].Version)
+				}
+			}
+		})
+	}
+}
+
+func embedded(t *testing.T, dialect string) fs.FS {
+	fsys, err := migrations.For(dialect)
+	if err != nil {
+		t.Fatalf("For(%q) error = %v", dialect, err)
+	}
+	return fsys
+}
+
+// Both dialects end at the same version, so a change is never added to only one of them
+func TestMigrationsDialectsInStep(t *testing.T) {
+	sqliteMigrations, _ := migrate.Load(embedded(t, "sqlite"))
+	postgresMigrations, _ := migrate.Load(embedded(t, "postgres"))
+	last := func(loaded []migrate.Migration) int64 { return loaded[len(loaded)-1].Version }
+	if last(sqliteMigrations) != last(postgresMigrations) {
+		t.Errorf("SQLite migrations end at %d, PostgreSQL at %d", last(sqliteMigrations), last(postgresMigrations))
+	}
+}
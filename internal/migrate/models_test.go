@@ -0,0 +1,67 @@
+package migrate_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"github.com/TropicalDog17/genomic-dao-service/internal/migrate"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// The migrated schema has every column and index the models declare
+func TestMigrationsMatchModels(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		if err := migrate.NewMigrator(db).Check(); err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+
+		models := []interface{}{
+			&auth.User{},
+			&auth.LoginNonce{},
+			&auth.LoginSession{},
+			&storage.GeneData{},
+			&storage.UploadWorkflow{},
+			&storage.ResearchConsent{},
+			&storage.AnalysisJob{},
+			&storage.ContentAnchor{},
+			&storage.AnchorLeaf{},
+			&storage.AuditEvent{},
+			&storage.AuditHead{},
+		}
+		for _, model := range models {
+			parsed, err := schema.Parse(model, &sync.Map{}, db.NamingStrategy)
+			if err != nil {
+				t.Fatalf("Failed to parse %T: %v", model, err)
+			}
+			for _, field := range parsed.Fields {
+				if field.DBName != "" && !db.Migrator().HasColumn(model, field.DBName) {
+					t.Errorf("%s has no column %s", parsed.Table, field.DBName)
+				}
+			}
+			for _, index := range parsed.ParseIndexes() {
+				if !db.Migrator().HasIndex(model, index.Name) {
+					t.Errorf("%s has no index %s", parsed.Table, index.Name)
+				}
+			}
+		}
+
+		// Rows are written as the services write them
+		if err := db.Create(&auth.User{ID: 4000000000, Pubkey: "0x2222222222222222222222222222222222222222"}).Error; err != nil {
+			t.Errorf("Failed to create user: %v", err)
+		}
+		var user auth.User
+		if err := db.First(&user, 4000000000).Error; err != nil {
+			t.Errorf("Failed to find user by its uint32 ID: %v", err)
+		}
+		if err := db.Create(&storage.GeneData{FileID: "f1", UserID: user.ID, BlobKey: "key"}).Error; err != nil {
+			t.Errorf("Failed to create gene data: %v", err)
+		}
+		if err := db.Create(&storage.GeneData{FileID: "f1", UserID: user.ID}).Error; err == nil {
+			t.Errorf("Expected file IDs to be unique")
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestLoad(t *testing.T) {
+	profile, err := Load(DefaultProfile, "")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if profile.ChainID != 9999 || profile.Name != DefaultProfile {
+		t.Errorf("Unexpected default profile %+v", profile)
+	}
+
+	if _, err := Load("mainnet", ""); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("Expected ErrUnknownProfile, got %v", err)
+	}
+
+	// A profiles file completes or replaces built-in profiles
+	path := filepath.Join(t.TempDir(), "networks.json")
+	err = os.WriteFile(path, []byte(`{
+		"fuji-subnet": {
+			"rpcUrl": "https://subnets.avax.network/life/testnet/rpc",
+			"chainId": 8386,
+			"contracts": {"controller": {"address": "0x1111111111111111111111111111111111111111"}},
+			"confirmations": 2
+		}
+	}`), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write profiles: %v", err)
+	}
+	profile, err = Load("fuji-subnet", path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if profile.ChainID != 8386 || profile.Contracts.Controller.Address != common.HexToAddress("0x1111111111111111111111111111111111111111") {
+		t.Errorf("Unexpected fuji profile %+v", profile)
+	}
+	if _, err := Load(DefaultProfile, path); err != nil {
+		t.Errorf("Built-in profiles should remain available: %v", err)
+	}
+}
+
+func TestProfile_Validate(t *testing.T) {
+	profile, err := Load("production", "")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := profile.Validate(); err == nil {
+		t.Errorf("Expected an incomplete production profile to be rejected")
+	}
+
+	profile.RPCURL = "http://127.0.0.1:8545"
+	profile.ChainID = 8386
+	profile.Contracts.Controller.Address = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	profile.Contracts.PCSP.Address = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	if err := profile.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	config, _ := profile.Fees.FeeConfig()
+	if config.MaxFeeCap == nil || config.MaxFeeCap.String() != "500000000000" {
+		t.Errorf("Expected a 500 gwei fee ceiling, got %v", config.MaxFeeCap)
+	}
+}
+
+func TestProfile_ApplyEnv(t *testing.T) {
+	pinned := common.HexToHash("0x01")
+	other := common.HexToHash("0x02")
+	controller := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		wantHash common.Hash
+		wantErr  bool
+	}{
+		{
+			name:     "Address override keeps the pinned hash",
+			env:      map[string]string{"CONTROLLER_ADDRESS": controller.Hex()},
+			wantHash: pinned,
+		},
+		{
+			name:     "Code hash pinned with the address",
+			env:      map[string]string{"CONTROLLER_ADDRESS": controller.Hex(), "CONTROLLER_CODE_HASH": other.Hex()},
+			wantHash: other,
+		},
+		{
+			name:    "Invalid code hash",
+			env:     map[string]string{"CONTROLLER_ADDRESS": controller.Hex(), "CONTROLLER_CODE_HASH": "0x02"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			profile := &Profile{Name: "test", Contracts: Contracts{
+				Controller: Contract{Address: common.HexToAddress("0x3333333333333333333333333333333333333333"), CodeHash: pinned},
+			}}
+			err := profile.ApplyEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if profile.Contracts.Controller.Address != controller || profile.Contracts.Controller.CodeHash != tt.wantHash {
+				t.Errorf("Controller = %+v, want %s with hash %s", profile.Contracts.Controller, controller.Hex(), tt.wantHash.Hex())
+			}
+		})
+	}
+}
+
+type mockChainReader struct {
+	chainID *big.Int
+	code    map[common.Address][]byte
+}
+
+func (m *mockChainReader) ChainID(ctx context.Context) (*big.Int, error) {
+	return m.chainID, nil
+}
+
+func (m *mockChainReader) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return m.code[account], nil
+}
+
+func TestVerify(t *testing.T) {
+	controller := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	pcsp := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	controllerCode := []byte{0x60, 0x80, 0x60, 0x40}
+
+	client := &mockChainReader{
+		chainID: big.NewInt(9999),
+		code: map[common.Address][]byte{
+			controller: controllerCode,
+			pcsp:       {0x60, 0x80},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		profile Profile
+		wantErr error
+	}{
+		{
+			name: "Matching chain and code",
+			profile: Profile{ChainID: 9999, Contracts: Contracts{
+				Controller: Contract{Address: controller, CodeHash: crypto.Keccak256Hash(controllerCode)},
+				PCSP:       Contract{Address: pcsp},
+			}},
+		},
+		{
+			name:    "Wrong chain",
+			profile: Profile{ChainID: 8386, Contracts: Contracts{Controller: Contract{Address: controller}}},
+			wantErr: ErrChainMismatch,
+		},
+		{
+			name: "Code hash differs",
+			profile: Profile{ChainID: 9999, Contracts: Contracts{
+				Controller: Contract{Address: controller, CodeHash: common.HexToHash("0x01")},
+			}},
+			wantErr: ErrCodeMismatch,
+		},
+		{
+			name: "Nothing deployed",
+			profile: Profile{ChainID: 9999, Contracts: Contracts{
+				Forwarder: Contract{Address: common.HexToAddress("0x3333333333333333333333333333333333333333")},
+			}},
+			wantErr: ErrCodeMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(context.Background(), client, &tt.profile); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package network
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DefaultProfile is used when NETWORK is not set.
+const DefaultProfile = "local-lifenetwork"
+
+var ErrUnknownProfile = errors.New("unknown network profile")
+
+// Built-in profiles. Deployment specific values such as contract addresses and, for
+// fuji-subnet and production, the RPC URL and chain ID come from a profiles file.
+//
+//go:embed profiles.json
+var builtinProfiles []byte
+
+// Profile bundles everything the service needs to know about one network.
+type Profile struct {
+	Name          string    `json:"-"`
+	RPCURL        string    `json:"rpcUrl"`
+	ChainID       uint64    `json:"chainId"`
+	Contracts     Contracts `json:"contracts"`
+	DeployBlock   uint64    `json:"deployBlock"`
+	Confirmations uint64    `json:"confirmations"`
+	Fees          FeePolicy `json:"fees"`
+}
+
+type Contracts struct {
+	Controller Contract `json:"controller"`
+	PCSP       Contract `json:"pcsp"`
+	Forwarder  Contract `json:"forwarder"`
+	Governor   Contract `json:"governor"`
+	Escrow     Contract `json:"escrow"`
+}
+
+// Contract is a deployed contract; a non-zero CodeHash pins its runtime bytecode.
+type Contract struct {
+	Address  common.Address `json:"address"`
+	CodeHash common.Hash    `json:"codeHash"`
+}
+
+// FeePolicy is the profile form of onchain.FeeConfig, with ceilings in gwei.
+type FeePolicy struct {
+	Legacy        bool    `json:"legacy"`
+	MaxFeeGwei    string  `json:"maxFeeGwei"`
+	MaxTipGwei    string  `json:"maxTipGwei"`
+	TipPercentile float64 `json:"tipPercentile"`
+	HistoryBlocks uint64  `json:"historyBlocks"`
+	GasMargin     uint64  `json:"gasMargin"`
+}
+
+// Load returns the named profile. Profiles in the optional file at path replace the
+// built-in profile of the same name.
+func Load(name string, path string) (*Profile, error) {
+	profiles, err := loadProfiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q, expected one of %v", ErrUnknownProfile, name, profileNames(profiles))
+	}
+	profile.Name = name
+	return &profile, nil
+}
+
+func loadProfiles(path string) (map[string]Profile, error) {
+	profiles := make(map[string]Profile)
+	if err := json.Unmarshal(builtinProfiles, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse built-in profiles: %v", err)
+	}
+	if path == "" {
+		return profiles, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network profiles: %v", err)
+	}
+	custom := make(map[string]Profile)
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return nil, fmt.Errorf("failed to parse network profiles %s: %v", path, err)
+	}
+	for name, profile := range custom {
+		profiles[name] = profile
+	}
+	return profiles, nil
+}
+
+func profileNames(profiles map[string]Profile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyEnv lets the legacy environment variables override the profile's RPC URL,
+// contract addresses and deployment block. The chain ID is never overridden. An overridden
+// address keeps the code hash the profile pins, so it must hold the same contract; a
+// <CONTRACT>_CODE_HASH variable pins another one.
+func (p *Profile) ApplyEnv() error {
+	if rpcURL := os.Getenv("RPC_URL"); rpcURL != "" {
+		p.RPCURL = rpcURL
+	}
+
+	contracts := []struct {
+		name     string
+		contract *Contract
+	}{
+		{"CONTROLLER", &p.Contracts.Controller},
+		{"PCSP", &p.Contracts.PCSP},
+		{"FORWARDER", &p.Contracts.Forwarder},
+		{"GOVERNOR", &p.Contracts.Governor},
+		{"ESCROW", &p.Contracts.Escrow},
+	}
+	for _, c := range contracts {
+		if value := os.Getenv(c.name + "_ADDRESS"); value != "" {
+			if !common.IsHexAddress(value) {
+				return fmt.Errorf("invalid %s_ADDRESS: %s", c.name, value)
+			}
+			c.contract.Address = common.HexToAddress(value)
+		}
+
+		value := os.Getenv(c.name + "_CODE_HASH")
+		if value == "" {
+			continue
+		}
+		hash, err := hexutil.Decode(value)
+		if err != nil || len(hash) != common.HashLength {
+			return fmt.Errorf("invalid %s_CODE_HASH: %s", c.name, value)
+		}
+		if pinned := c.contract.CodeHash; pinned != (common.Hash{}) && pinned != common.BytesToHash(hash) {
+			log.Printf("warning: %s_CODE_HASH replaces the code hash %s pinned by network profile %s", c.name, pinned.Hex(), p.Name)
+		}
+		c.contract.CodeHash = common.BytesToHash(hash)
+	}
+
+	if block := os.Getenv("PCSP_DEPLOY_BLOCK"); block != "" {
+		deployBlock, err := strconv.ParseUint(block, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid PCSP_DEPLOY_BLOCK: %s", block)
+		}
+		p.DeployBlock = deployBlock
+	}
+	return nil
+}
+
+// Validate checks the profile is complete enough to start the service.
+func (p *Profile) Validate() error {
+	if p.RPCURL == "" {
+		return fmt.Errorf("network profile %s has no RPC URL", p.Name)
+	}
+	if p.ChainID == 0 {
+		return fmt.Errorf("network profile %s has no chain ID", p.Name)
+	}
+	if p.Contracts.Controller.Address == (common.Address{}) {
+		return fmt.Errorf("network profile %s has no controller address", p.Name)
+	}
+	if p.Contracts.PCSP.Address == (common.Address{}) {
+		return fmt.Errorf("network profile %s has no PCSP address", p.Name)
+	}
+	if _, err := p.Fees.FeeConfig(); err != nil {
+		return fmt.Errorf("network profile %s: %v", p.Name, err)
+	}
+	return nil
+}
+
+// FeeConfig converts the policy to the onchain fee configuration, keeping defaults for unset fields.
+func (f FeePolicy) FeeConfig() (onchain.FeeConfig, error) {
+	config := onchain.DefaultFeeConfig()
+	config.Legacy = f.Legacy
+
+	var err error
+	if f.MaxFeeGwei != "" {
+		if config.MaxFeeCap, err = onchain.ParseUnits(f.MaxFeeGwei, 9); err != nil {
+			return config, fmt.Errorf("invalid maxFeeGwei: %v", err)
+		}
+	}
+	if f.MaxTipGwei != "" {
+		if config.MaxTipCap, err = onchain.ParseUnits(f.MaxTipGwei, 9); err != nil {
+			return config, fmt.Errorf("invalid maxTipGwei: %v", err)
+		}
+	}
+	if f.TipPercentile < 0 || f.TipPercentile > 100 {
+		return config, fmt.Errorf("invalid tipPercentile: %v", f.TipPercentile)
+	}
+	if f.TipPercentile > 0 {
+		config.TipPercentile = f.TipPercentile
+	}
+	if f.HistoryBlocks > 0 {
+		config.HistoryBlocks = f.HistoryBlocks
+	}
+	if f.GasMargin > 0 {
+		config.GasMargin = f.GasMargin
+	}
+	return config, nil
+}
@@ -0,0 +1,62 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrChainMismatch = errors.New("chain ID does not match the network profile")
+	ErrCodeMismatch  = errors.New("contract code does not match the network profile")
+)
+
+// ChainReader is the part of the RPC client needed to verify a profile.
+type ChainReader interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// Verify checks that the node serves the profile's chain and that every configured
+// contract is deployed, with the pinned bytecode where a code hash is set.
+func Verify(ctx context.Context, client ChainReader, profile *Profile) error {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %v", err)
+	}
+	if !chainID.IsUint64() || chainID.Uint64() != profile.ChainID {
+		return fmt.Errorf("%w: node reports %s, profile %s expects %d", ErrChainMismatch, chainID, profile.Name, profile.ChainID)
+	}
+
+	contracts := []struct {
+		name     string
+		contract Contract
+	}{
+		{"controller", profile.Contracts.Controller},
+		{"PCSP", profile.Contracts.PCSP},
+		{"forwarder", profile.Contracts.Forwarder},
+		{"governor", profile.Contracts.Governor},
+		{"escrow", profile.Contracts.Escrow},
+	}
+	for _, c := range contracts {
+		if c.contract.Address == (common.Address{}) {
+			continue
+		}
+
+		code, err := client.CodeAt(ctx, c.contract.Address, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get %s code: %v", c.name, err)
+		}
+		if len(code) == 0 {
+			return fmt.Errorf("%w: no %s deployed at %s", ErrCodeMismatch, c.name, c.contract.Address.Hex())
+		}
+		if c.contract.CodeHash != (common.Hash{}) && crypto.Keccak256Hash(code) != c.contract.CodeHash {
+			return fmt.Errorf("%w: %s at %s has code hash %s", ErrCodeMismatch, c.name, c.contract.Address.Hex(), crypto.Keccak256Hash(code).Hex())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package nft
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// tierColors maps a risk tier to the accent color of the token image; tier 0 means not shared.
+var tierColors = map[int]string{
+	0: "#5b6b8c",
+	1: "#2e9e6b",
+	2: "#d9a521",
+	3: "#e0662b",
+	4: "#c42d3a",
+}
+
+// RenderImage draws the token image as an SVG data URI.
+func RenderImage(tokenID string, riskTier int) string {
+	color, ok := tierColors[riskTier]
+	if !ok {
+		color = tierColors[0]
+	}
+
+	var b strings.Builder
+	b.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="350" height="350" viewBox="0 0 350 350">`)
+	b.WriteString(`<rect width="350" height="350" fill="#0f1626"/>`)
+
+	// Double helix: two phase-shifted strands joined by rungs
+	for i := 0; i < 14; i++ {
+		y := 40 + float64(i)*18
+		phase := float64(i) * math.Pi / 7
+		x1 := 175 + 60*math.Sin(phase)
+		x2 := 175 - 60*math.Sin(phase)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-opacity="0.4" stroke-width="2"/>`, x1, y, x2, y, color)
+		fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="5" fill="%s"/>`, x1, y, color)
+		fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="5" fill="#e8ecf4"/>`, x2, y)
+	}
+
+	fmt.Fprintf(&b, `<text x="175" y="320" fill="#e8ecf4" font-family="monospace" font-size="20" text-anchor="middle">GeneNFT #%s</text>`, tokenID)
+	b.WriteString(`</svg>`)
+
+	return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(b.String()))
+}
@@ -0,0 +1,106 @@
+package nft
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidTokenID = errors.New("invalid token ID")
+	ErrTokenNotFound  = errors.New("token not found")
+)
+
+// Metadata is the ERC-721 metadata JSON served for a GeneNFT token.
+type Metadata struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Image       string      `json:"image"`
+	Attributes  []Attribute `json:"attributes"`
+}
+
+// Attribute is a single ERC-721 metadata trait.
+type Attribute struct {
+	DisplayType string      `json:"display_type,omitempty"`
+	TraitType   string      `json:"trait_type"`
+	Value       interface{} `json:"value"`
+}
+
+type MetadataService interface {
+	GetMetadata(tokenID string) (*Metadata, error)
+}
+
+type metadataService struct {
+	onchainService         onchain.OnchainService
+	geneDataStorageService storage.GeneDataStorageService
+}
+
+func NewMetadataService(onchainService onchain.OnchainService, geneDataStorageService storage.GeneDataStorageService) MetadataService {
+	return &metadataService{
+		onchainService:         onchainService,
+		geneDataStorageService: geneDataStorageService,
+	}
+}
+
+// GetMetadata resolves a token to its doc through the Controller and builds metadata from the local record.
+func (s *metadataService) GetMetadata(tokenID string) (*Metadata, error) {
+	id, ok := new(big.Int).SetString(tokenID, 10)
+	if !ok || id.Sign() < 0 {
+		return nil, ErrInvalidTokenID
+	}
+
+	docID, err := s.onchainService.GetTokenDoc(id.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve token doc: %w", err)
+	}
+	if docID == "" {
+		return nil, ErrTokenNotFound
+	}
+
+	// Tokens minted through another gateway have no local record, so they get the bare metadata
+	record, err := s.geneDataStorageService.FindByDocID(docID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find gene data: %w", err)
+	}
+
+	return BuildMetadata(id.String(), record), nil
+}
+
+// BuildMetadata assembles the public metadata of a token. Only the creation date,
+// model version and, when the owner opted in, the risk tier are taken from the record;
+// nothing that identifies the owner or the genome is included.
+func BuildMetadata(tokenID string, record *storage.GeneData) *Metadata {
+	riskTier := 0
+	attributes := []Attribute{}
+	if record != nil {
+		attributes = append(attributes, Attribute{
+			DisplayType: "date",
+			TraitType:   "Created",
+			Value:       record.CreatedAt.Unix(),
+		})
+		if record.ModelVersion != "" {
+			attributes = append(attributes, Attribute{
+				TraitType: "Model Version",
+				Value:     record.ModelVersion,
+			})
+		}
+		if record.ShareRiskTier && record.RiskScore > 0 {
+			riskTier = record.RiskScore
+			attributes = append(attributes, Attribute{
+				TraitType: "Risk Tier",
+				Value:     riskTier,
+			})
+		}
+	}
+
+	return &Metadata{
+		Name:        fmt.Sprintf("GeneNFT #%s", tokenID),
+		Description: "Proof of ownership of a genetic profile processed by GenomicDAO G-Stroke.",
+		Image:       RenderImage(tokenID, riskTier),
+		Attributes:  attributes,
+	}
+}
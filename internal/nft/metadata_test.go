@@ -0,0 +1,99 @@
+package nft
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"gorm.io/gorm"
+)
+
+func TestBuildMetadata(t *testing.T) {
+	createdAt := time.Date(2024, 12, 15, 16, 38, 43, 0, time.UTC)
+	newRecord := func(shareRiskTier bool) *storage.GeneData {
+		return &storage.GeneData{
+			Model:         gorm.Model{CreatedAt: createdAt},
+			FileID:        "c29814719d660d3f",
+			UserID:        42,
+			DataHash:      []byte{0xde, 0xad},
+			Signature:     []byte{0xbe, 0xef},
+			DocID:         "doc-1",
+			TokenID:       "14",
+			RiskScore:     3,
+			ModelVersion:  "g-stroke-v1",
+			ShareRiskTier: shareRiskTier,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		record     *storage.GeneData
+		wantTraits []string
+	}{
+		{
+			name:       "Risk tier shared",
+			record:     newRecord(true),
+			wantTraits: []string{"Created", "Model Version", "Risk Tier"},
+		},
+		{
+			name:       "Risk tier not shared",
+			record:     newRecord(false),
+			wantTraits: []string{"Created", "Model Version"},
+		},
+		{
+			name:       "No local record",
+			record:     nil,
+			wantTraits: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := BuildMetadata("14", tt.record)
+
+			if metadata.Name != "GeneNFT #14" {
+				t.Errorf("Expected name %q, got %q", "GeneNFT #14", metadata.Name)
+			}
+
+			if len(metadata.Attributes) != len(tt.wantTraits) {
+				t.Fatalf("Expected %d attributes, got %d", len(tt.wantTraits), len(metadata.Attributes))
+			}
+			for i, trait := range tt.wantTraits {
+				if metadata.Attributes[i].TraitType != trait {
+					t.Errorf("Expected attribute %d to be %q, got %q", i, trait, metadata.Attributes[i].TraitType)
+				}
+			}
+
+			// Nothing identifying the owner or the genome may leak into the metadata
+			encoded, err := json.Marshal(metadata)
+			if err != nil {
+				t.Fatalf("Failed to marshal metadata: %v", err)
+			}
+			for _, secret := range []string{"c29814719d660d3f", "doc-1", "dead", "beef"} {
+				if strings.Contains(string(encoded), secret) {
+					t.Errorf("Metadata leaks %q", secret)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderImage(t *testing.T) {
+	image := RenderImage("7", 2)
+
+	prefix := "data:image/svg+xml;base64,"
+	if !strings.HasPrefix(image, prefix) {
+		t.Fatalf("Expected SVG data URI, got %q", image[:32])
+	}
+
+	svg, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(image, prefix))
+	if err != nil {
+		t.Fatalf("Failed to decode image: %v", err)
+	}
+	if !strings.Contains(string(svg), "GeneNFT #7") || !strings.Contains(string(svg), tierColors[2]) {
+		t.Errorf("Image does not render token ID and tier color")
+	}
+}
@@ -0,0 +1,121 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrInsufficientFunds = errors.New("service wallet balance is below the floor")
+
+// BalanceConfig sets the service wallet thresholds, in wei.
+type BalanceConfig struct {
+	// Warn flags the wallet as low on the health endpoint
+	Warn *big.Int
+	// Floor refuses new uploads, which would otherwise fail midway for lack of gas
+	Floor    *big.Int
+	Interval time.Duration
+}
+
+// WalletStatus is the last observed state of the service wallet.
+type WalletStatus struct {
+	Address    string    `json:"address"`
+	Balance    string    `json:"balance" example:"5000000000000000000"`
+	Formatted  string    `json:"formatted" example:"5"`
+	Low        bool      `json:"low"`
+	BelowFloor bool      `json:"belowFloor"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checkedAt"`
+}
+
+type BalanceMonitor interface {
+	Status() WalletStatus
+	CheckFunds() error
+	Run(ctx context.Context)
+}
+
+type balanceReader interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+type balanceMonitor struct {
+	client  balanceReader
+	address common.Address
+	config  BalanceConfig
+	now     func() time.Time
+
+	mu     sync.Mutex
+	status WalletStatus
+}
+
+func NewBalanceMonitor(client balanceReader, address common.Address, config BalanceConfig) BalanceMonitor {
+	return &balanceMonitor{
+		client:  client,
+		address: address,
+		config:  config,
+		now:     time.Now,
+		status:  WalletStatus{Address: address.Hex()},
+	}
+}
+
+// Run refreshes the balance every interval until ctx is done.
+func (m *balanceMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		m.refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *balanceMonitor) Status() WalletStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+// CheckFunds fails when the wallet is below the floor, refreshing first if the last check is stale.
+func (m *balanceMonitor) CheckFunds() error {
+	status := m.Status()
+	if m.now().Sub(status.CheckedAt) > m.config.Interval {
+		status = m.refresh(context.Background())
+	}
+
+	if status.Error != "" {
+		return fmt.Errorf("failed to check service wallet balance: %s", status.Error)
+	}
+	if status.BelowFloor {
+		return ErrInsufficientFunds
+	}
+	return nil
+}
+
+func (m *balanceMonitor) refresh(ctx context.Context) WalletStatus {
+	status := WalletStatus{Address: m.address.Hex(), CheckedAt: m.now()}
+
+	balance, err := m.client.BalanceAt(ctx, m.address, nil)
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.Balance = balance.String()
+		status.Formatted = FormatUnits(balance, 18)
+		status.Low = m.config.Warn != nil && balance.Cmp(m.config.Warn) < 0
+		status.BelowFloor = m.config.Floor != nil && balance.Cmp(m.config.Floor) < 0
+	}
+
+	m.mu.Lock()
+	m.status = status
+	m.mu.Unlock()
+
+	return status
+}
@@ -0,0 +1,58 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type mockBalanceReader struct {
+	balance *big.Int
+	err     error
+	calls   int
+}
+
+func (m *mockBalanceReader) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	m.calls++
+	return m.balance, m.err
+}
+
+func TestBalanceMonitor(t *testing.T) {
+	reader := &mockBalanceReader{balance: gwei(5e9)}
+	now := time.Unix(1734280000, 0)
+	monitor := NewBalanceMonitor(reader, common.HexToAddress("0x1111111111111111111111111111111111111111"), BalanceConfig{
+		Warn:     gwei(10e9),
+		Floor:    gwei(1e9),
+		Interval: time.Minute,
+	}).(*balanceMonitor)
+	monitor.now = func() time.Time { return now }
+
+	// Low but above the floor still accepts uploads
+	if err := monitor.CheckFunds(); err != nil {
+		t.Fatalf("CheckFunds() error = %v", err)
+	}
+	if status := monitor.Status(); !status.Low || status.BelowFloor || status.Formatted != "5" {
+		t.Errorf("Unexpected status %+v", status)
+	}
+
+	// The balance is not re-read within the interval
+	reader.balance = gwei(5e8)
+	if err := monitor.CheckFunds(); err != nil || reader.calls != 1 {
+		t.Errorf("Expected cached status, got err %v after %d reads", err, reader.calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if err := monitor.CheckFunds(); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("Expected ErrInsufficientFunds, got %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	reader.err = errors.New("rpc down")
+	if err := monitor.CheckFunds(); err == nil || errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("Expected lookup error, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package onchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// MaxBatchSize is the largest batch the Controller accepts.
+const MaxBatchSize = 50
+
+var (
+	ErrBatchSize         = fmt.Errorf("batch must hold between 1 and %d items", MaxBatchSize)
+	ErrBatchItemRejected = errors.New("rejected from batch")
+)
+
+// ConfirmRequest is one upload to confirm in a batch.
+type ConfirmRequest struct {
+	DocID       string
+	ContentHash string
+	Proof       string
+	SessionID   string
+	RiskScore   int
+}
+
+// UploadBatchResult is the session opened for one doc of a batch, or why the doc was left out.
+type UploadBatchResult struct {
+	DocID     string
+	SessionID string
+	TxHash    string
+	Err       error
+}
+
+// ConfirmBatchResult is the confirmation of one doc of a batch, or why the doc was left out.
+type ConfirmBatchResult struct {
+	DocID  string
+	Result *ConfirmResult
+	Err    error
+}
+
+// UploadDataBatch opens a session for every doc in one transaction. Results follow the order of docIDs.
+// A doc the Controller rejects gets an ErrBatchItemRejected result and the others are sent without it;
+// the returned error means none of the docs was uploaded.
+func (s *onchainService) UploadDataBatch(docIDs []string) ([]UploadBatchResult, error) {
+	if len(docIDs) == 0 || len(docIDs) > MaxBatchSize {
+		return nil, ErrBatchSize
+	}
+
+	results := make([]UploadBatchResult, len(docIDs))
+	for i, docID := range docIDs {
+		results[i].DocID = docID
+	}
+
+	receipt, included, err := s.sendBatch(len(docIDs), func(opts *bind.TransactOpts, pending []int) (*types.Transaction, error) {
+		batch := make([]string, len(pending))
+		for i, index := range pending {
+			batch[i] = docIDs[index]
+		}
+		return s.controller.UploadDataBatch(opts, batch)
+	}, func(index int, err error) {
+		results[index].Err = err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make(map[string]string)
+	for _, log := range receipt.Logs {
+		event, err := s.controller.ParseUploadData(*log)
+		if err == nil && event != nil {
+			sessions[event.DocId] = event.SessionId.String()
+		}
+	}
+	for _, index := range included {
+		result := &results[index]
+		result.TxHash = receipt.TxHash.Hex()
+		if result.SessionID = sessions[result.DocID]; result.SessionID == "" {
+			result.Err = fmt.Errorf("failed to get session ID from event")
+		}
+	}
+
+	fmt.Printf("Opened %d upload sessions with tx hash: %s\n", len(included), receipt.TxHash.Hex())
+	return results, nil
+}
+
+// ConfirmBatch confirms every upload in one transaction, mapping the minted tokens and rewards back to
+// each doc through the UploadConfirmed events. Rejected items are handled as in UploadDataBatch.
+func (s *onchainService) ConfirmBatch(requests []ConfirmRequest) ([]ConfirmBatchResult, error) {
+	if len(requests) == 0 || len(requests) > MaxBatchSize {
+		return nil, ErrBatchSize
+	}
+
+	results := make([]ConfirmBatchResult, len(requests))
+	items := make([]contracts.ControllerConfirmItem, len(requests))
+	for i, request := range requests {
+		results[i].DocID = request.DocID
+		sessionID, ok := new(big.Int).SetString(request.SessionID, 10)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse session ID of doc %s", request.DocID)
+		}
+		items[i] = contracts.ControllerConfirmItem{
+			DocId:       request.DocID,
+			ContentHash: request.ContentHash,
+			Proof:       request.Proof,
+			SessionId:   sessionID,
+			RiskScore:   big.NewInt(int64(request.RiskScore)),
+		}
+	}
+
+	receipt, included, err := s.sendBatch(len(requests), func(opts *bind.TransactOpts, pending []int) (*types.Transaction, error) {
+		batch := make([]contracts.ControllerConfirmItem, len(pending))
+		for i, index := range pending {
+			batch[i] = items[index]
+		}
+		return s.controller.ConfirmBatch(opts, batch)
+	}, func(index int, err error) {
+		results[index].Err = err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	confirmed := make(map[string]*ConfirmResult)
+	for _, log := range receipt.Logs {
+		event, err := s.controller.ParseUploadConfirmed(*log)
+		if err == nil && event != nil {
+			confirmed[event.DocId] = &ConfirmResult{
+				TxHash:       receipt.TxHash.Hex(),
+				TokenID:      event.TokenId.String(),
+				RewardAmount: event.RewardAmount.String(),
+			}
+		}
+	}
+	for _, index := range included {
+		result := &results[index]
+		if result.Result = confirmed[result.DocID]; result.Result == nil {
+			result.Err = fmt.Errorf("failed to get confirmation from event")
+		}
+	}
+
+	fmt.Printf("Confirmed %d uploads with tx hash: %s\n", len(included), receipt.TxHash.Hex())
+	return results, nil
+}
+
+// sendBatch sends the items of a batch, given by index. Each item the Controller rejects is reported
+// and dropped, and the batch is sent again with the rest. It returns the receipt and the items it holds.
+func (s *onchainService) sendBatch(size int, send func(opts *bind.TransactOpts, pending []int) (*types.Transaction, error), reject func(index int, err error)) (*types.Receipt, []int, error) {
+	pending := make([]int, size)
+	for i := range pending {
+		pending[i] = i
+	}
+
+	for {
+		opts, err := s.transactor.Opts()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Gas estimation simulates the batch, so a bad item is caught before anything is sent
+		tx, err := send(opts, pending)
+		if err != nil {
+			index, reason, ok := batchItemFailure(err)
+			if !ok || index >= len(pending) {
+				return nil, nil, fmt.Errorf("failed to send batch: %v", err)
+			}
+			reject(pending[index], fmt.Errorf("%w: %s", ErrBatchItemRejected, reason))
+			pending = append(pending[:index:index], pending[index+1:]...)
+			if len(pending) == 0 {
+				return nil, nil, fmt.Errorf("every item was rejected from the batch")
+			}
+			continue
+		}
+
+		receipt, err := s.transactor.WaitMined(tx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to wait for transaction: %v", err)
+		}
+		// The chain changed between estimation and inclusion; nothing in the batch took effect
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return nil, nil, fmt.Errorf("batch reverted: %s", tx.Hash().Hex())
+		}
+		return receipt, pending, nil
+	}
+}
+
+// batchItemFailure decodes the BatchItemFailed revert carried by an RPC error.
+func batchItemFailure(err error) (int, string, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return 0, "", false
+	}
+	data, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return 0, "", false
+	}
+	revert, err := hexutil.Decode(data)
+	if err != nil {
+		return 0, "", false
+	}
+
+	controllerABI, err := contracts.ControllerMetaData.GetAbi()
+	if err != nil {
+		return 0, "", false
+	}
+	batchError, ok := controllerABI.Errors["BatchItemFailed"]
+	if !ok {
+		return 0, "", false
+	}
+	unpacked, err := batchError.Unpack(revert)
+	if err != nil {
+		return 0, "", false
+	}
+
+	values, ok := unpacked.([]interface{})
+	if !ok || len(values) != 2 {
+		return 0, "", false
+	}
+	index, ok := values[0].(*big.Int)
+	if !ok || !index.IsInt64() {
+		return 0, "", false
+	}
+	reason, _ := values[1].(string)
+	return int(index.Int64()), reason, true
+}
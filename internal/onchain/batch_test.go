@@ -0,0 +1,67 @@
+package onchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string          { return "execution reverted" }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+func batchRevert(t *testing.T, index int64, reason string) string {
+	controllerABI, err := contracts.ControllerMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("Failed to load Controller ABI: %v", err)
+	}
+	batchError := controllerABI.Errors["BatchItemFailed"]
+	args, err := batchError.Inputs.Pack(big.NewInt(index), reason)
+	if err != nil {
+		t.Fatalf("Failed to pack revert: %v", err)
+	}
+	return hexutil.Encode(append(batchError.ID[:4], args...))
+}
+
+func TestBatchItemFailure(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantIndex  int
+		wantReason string
+		wantOk     bool
+	}{
+		{
+			name:       "Item rejected by the Controller",
+			err:        fmt.Errorf("estimate: %w", &revertError{batchRevert(t, 2, "Session is ended")}),
+			wantIndex:  2,
+			wantReason: "Session is ended",
+			wantOk:     true,
+		},
+		{
+			name: "Plain revert",
+			// Error(string) revert from a require
+			err: &revertError{"0x08c379a0"},
+		},
+		{
+			name: "Not a revert",
+			err:  errors.New("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, reason, ok := batchItemFailure(tt.err)
+			if ok != tt.wantOk || index != tt.wantIndex || reason != tt.wantReason {
+				t.Errorf("batchItemFailure() = %d, %q, %v, want %d, %q, %v", index, reason, ok, tt.wantIndex, tt.wantReason, tt.wantOk)
+			}
+		})
+	}
+}
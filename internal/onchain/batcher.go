@@ -0,0 +1,155 @@
+package onchain
+
+import (
+	"sync"
+	"time"
+)
+
+// BatchConfig groups uploads into batches of at most Size, sent as soon as a batch is full
+// or Window after its first upload arrived.
+type BatchConfig struct {
+	Size   int
+	Window time.Duration
+}
+
+// WithBatching returns service with UploadData and ConfirmUpload queued into shared batches, so
+// concurrent uploads cost one uploadDataBatch and one confirmBatch transaction. Each call still
+// blocks until its own batch is mined and gets its own session, token or error back.
+func WithBatching(service OnchainService, config BatchConfig) OnchainService {
+	if config.Size > MaxBatchSize {
+		config.Size = MaxBatchSize
+	}
+
+	return &batchingService{
+		OnchainService: service,
+		uploads: newBatcher(config, func(docIDs []string) ([]batchReply[string], error) {
+			results, err := service.UploadDataBatch(docIDs)
+			if err != nil {
+				return nil, err
+			}
+			replies := make([]batchReply[string], len(results))
+			for i, result := range results {
+				replies[i] = batchReply[string]{result: result.SessionID, err: result.Err}
+			}
+			return replies, nil
+		}),
+		confirms: newBatcher(config, func(requests []ConfirmRequest) ([]batchReply[*ConfirmResult], error) {
+			results, err := service.ConfirmBatch(requests)
+			if err != nil {
+				return nil, err
+			}
+			replies := make([]batchReply[*ConfirmResult], len(results))
+			for i, result := range results {
+				replies[i] = batchReply[*ConfirmResult]{result: result.Result, err: result.Err}
+			}
+			return replies, nil
+		}),
+	}
+}
+
+type batchingService struct {
+	OnchainService
+	uploads  *batcher[string, string]
+	confirms *batcher[ConfirmRequest, *ConfirmResult]
+}
+
+func (s *batchingService) UploadData(docID string) (string, error) {
+	return s.uploads.submit(docID)
+}
+
+func (s *batchingService) ConfirmUpload(docID string, contentHash string, proof string, sessionID string, riskScore int) (*ConfirmResult, error) {
+	return s.confirms.submit(ConfirmRequest{
+		DocID:       docID,
+		ContentHash: contentHash,
+		Proof:       proof,
+		SessionID:   sessionID,
+		RiskScore:   riskScore,
+	})
+}
+
+type batchReply[Res any] struct {
+	result Res
+	err    error
+}
+
+type batchCall[Req, Res any] struct {
+	request Req
+	reply   chan batchReply[Res]
+}
+
+// batcher collects requests and sends them together; send returns one reply per request, in order.
+type batcher[Req, Res any] struct {
+	config BatchConfig
+	send   func([]Req) ([]batchReply[Res], error)
+
+	mu      sync.Mutex
+	pending []batchCall[Req, Res]
+	timer   *time.Timer
+
+	// Batches go out one at a time, so their transactions never race for a nonce
+	sending sync.Mutex
+}
+
+func newBatcher[Req, Res any](config BatchConfig, send func([]Req) ([]batchReply[Res], error)) *batcher[Req, Res] {
+	return &batcher[Req, Res]{config: config, send: send}
+}
+
+func (b *batcher[Req, Res]) submit(request Req) (Res, error) {
+	call := batchCall[Req, Res]{request: request, reply: make(chan batchReply[Res], 1)}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, call)
+	if len(b.pending) >= b.config.Size {
+		batch := b.take()
+		b.mu.Unlock()
+		go b.flush(batch)
+	} else {
+		if len(b.pending) == 1 {
+			b.timer = time.AfterFunc(b.config.Window, b.flushPending)
+		}
+		b.mu.Unlock()
+	}
+
+	reply := <-call.reply
+	return reply.result, reply.err
+}
+
+// take empties the pending batch; the caller holds mu.
+func (b *batcher[Req, Res]) take() []batchCall[Req, Res] {
+	batch := b.pending
+	b.pending = nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	return batch
+}
+
+func (b *batcher[Req, Res]) flushPending() {
+	b.mu.Lock()
+	batch := b.take()
+	b.mu.Unlock()
+
+	if len(batch) > 0 {
+		b.flush(batch)
+	}
+}
+
+func (b *batcher[Req, Res]) flush(batch []batchCall[Req, Res]) {
+	b.sending.Lock()
+	defer b.sending.Unlock()
+
+	requests := make([]Req, len(batch))
+	for i, call := range batch {
+		requests[i] = call.request
+	}
+
+	replies, err := b.send(requests)
+	for i, call := range batch {
+		if err != nil {
+			call.reply <- batchReply[Res]{err: err}
+		} else {
+			call.reply <- replies[i]
+		}
+	}
+}
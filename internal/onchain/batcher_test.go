@@ -0,0 +1,101 @@
+package onchain
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]string
+}
+
+func (r *batchRecorder) send(docIDs []string) ([]batchReply[string], error) {
+	r.mu.Lock()
+	r.batches = append(r.batches, docIDs)
+	r.mu.Unlock()
+
+	replies := make([]batchReply[string], len(docIDs))
+	for i, docID := range docIDs {
+		if docID == "bad" {
+			replies[i].err = ErrBatchItemRejected
+			continue
+		}
+		replies[i].result = "session-" + docID
+	}
+	return replies, nil
+}
+
+func submitAll(b *batcher[string, string], docIDs ...string) ([]string, []error) {
+	results := make([]string, len(docIDs))
+	errs := make([]error, len(docIDs))
+	var wg sync.WaitGroup
+	for i, docID := range docIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = b.submit(docID)
+		}()
+	}
+	wg.Wait()
+	return results, errs
+}
+
+func TestBatcher(t *testing.T) {
+	t.Run("Full batch is sent without waiting", func(t *testing.T) {
+		recorder := &batchRecorder{}
+		b := newBatcher(BatchConfig{Size: 3, Window: time.Hour}, recorder.send)
+
+		results, errs := submitAll(b, "a", "b", "c")
+		if len(recorder.batches) != 1 || len(recorder.batches[0]) != 3 {
+			t.Fatalf("Expected one batch of 3, got %v", recorder.batches)
+		}
+		for i, docID := range []string{"a", "b", "c"} {
+			if errs[i] != nil || results[i] != "session-"+docID {
+				t.Errorf("submit(%s) = %q, %v", docID, results[i], errs[i])
+			}
+		}
+	})
+
+	t.Run("Partial batch is sent after the window", func(t *testing.T) {
+		recorder := &batchRecorder{}
+		b := newBatcher(BatchConfig{Size: 10, Window: 20 * time.Millisecond}, recorder.send)
+
+		start := time.Now()
+		if _, errs := submitAll(b, "a", "b"); errs[0] != nil || errs[1] != nil {
+			t.Fatalf("Unexpected errors %v", errs)
+		}
+		if len(recorder.batches) != 1 || len(recorder.batches[0]) != 2 {
+			t.Errorf("Expected one batch of 2, got %v", recorder.batches)
+		}
+		if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+			t.Errorf("Batch sent after %v, before the window", elapsed)
+		}
+	})
+
+	t.Run("Rejected item fails alone", func(t *testing.T) {
+		recorder := &batchRecorder{}
+		b := newBatcher(BatchConfig{Size: 2, Window: time.Hour}, recorder.send)
+
+		results, errs := submitAll(b, "bad", "good")
+		if !errors.Is(errs[0], ErrBatchItemRejected) {
+			t.Errorf("Expected the bad item to be rejected, got %v", errs[0])
+		}
+		if errs[1] != nil || results[1] != "session-good" {
+			t.Errorf("submit(good) = %q, %v", results[1], errs[1])
+		}
+	})
+
+	t.Run("Failed batch fails every item", func(t *testing.T) {
+		b := newBatcher(BatchConfig{Size: 2, Window: time.Hour}, func(docIDs []string) ([]batchReply[string], error) {
+			return nil, fmt.Errorf("failed to send batch")
+		})
+
+		if _, errs := submitAll(b, "a", "b"); errs[0] == nil || errs[1] == nil {
+			t.Errorf("Expected every item to fail, got %v", errs)
+		}
+	})
+}
@@ -29,6 +29,15 @@ var (
 	_ = abi.ConvertType
 )
 
+// ControllerConfirmItem is an auto generated low-level Go binding around an user-defined struct.
+type ControllerConfirmItem struct {
+	DocId       string
+	ContentHash string
+	Proof       string
+	SessionId   *big.Int
+	RiskScore   *big.Int
+}
+
 // ControllerDataDoc is an auto generated low-level Go binding around an user-defined struct.
 type ControllerDataDoc struct {
 	Id          string
@@ -45,7 +54,7 @@ type ControllerUploadSession struct {
 
 // ControllerMetaData contains all meta data concerning the Controller contract.
 var ControllerMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"nftAddress\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"pcspAddress\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"hashContent\",\"type\":\"string\"}],\"name\":\"GeneDataSubmitted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"}],\"name\":\"GeneNFTMinted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"riskScore\",\"type\":\"uint256\"}],\"name\":\"PCSPRewarded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"sessionId\",\"type\":\"uint256\"}],\"name\":\"UploadData\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"contentHash\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"proof\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"sessionId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"riskScore\",\"type\":\"uint256\"}],\"name\":\"confirm\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"geneNFT\",\"outputs\":[{\"internalType\":\"contractGeneNFT\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"}],\"name\":\"getDoc\",\"outputs\":[{\"components\":[{\"internalType\":\"string\",\"name\":\"id\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"hashContent\",\"type\":\"string\"}],\"internalType\":\"structController.DataDoc\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"sessionId\",\"type\":\"uint256\"}],\"name\":\"getSession\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"proof\",\"type\":\"string\"},{\"internalType\":\"bool\",\"name\":\"confirmed\",\"type\":\"bool\"}],\"internalType\":\"structController.UploadSession\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pcspToken\",\"outputs\":[{\"internalType\":\"contractPostCovidStrokePrevention\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"}],\"name\":\"uploadData\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"nftAddress\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"pcspAddress\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"trustedForwarder\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"reason\",\"type\":\"string\"}],\"name\":\"BatchItemFailed\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"hashContent\",\"type\":\"string\"}],\"name\":\"GeneDataSubmitted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"}],\"name\":\"GeneNFTMinted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"riskScore\",\"type\":\"uint256\"}],\"name\":\"PCSPRewarded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"root\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"leafCount\",\"type\":\"uint256\"}],\"name\":\"RootAnchored\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"sessionId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"rewardAmount\",\"type\":\"uint256\"}],\"name\":\"UploadConfirmed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"sessionId\",\"type\":\"uint256\"}],\"name\":\"UploadData\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"MAX_BATCH_SIZE\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"root\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"leafCount\",\"type\":\"uint256\"}],\"name\":\"anchorRoot\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"anchoredRoots\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"contentHash\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"proof\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"sessionId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"riskScore\",\"type\":\"uint256\"}],\"name\":\"confirm\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"contentHash\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"proof\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"sessionId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"riskScore\",\"type\":\"uint256\"}],\"internalType\":\"structController.ConfirmItem[]\",\"name\":\"items\",\"type\":\"tuple[]\"}],\"name\":\"confirmBatch\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"geneNFT\",\"outputs\":[{\"internalType\":\"contractGeneNFT\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"}],\"name\":\"getDoc\",\"outputs\":[{\"components\":[{\"internalType\":\"string\",\"name\":\"id\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"hashContent\",\"type\":\"string\"}],\"internalType\":\"structController.DataDoc\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"sessionId\",\"type\":\"uint256\"}],\"name\":\"getSession\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"proof\",\"type\":\"string\"},{\"internalType\":\"bool\",\"name\":\"confirmed\",\"type\":\"bool\"}],\"internalType\":\"structController.UploadSession\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"getTokenDoc\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"forwarder\",\"type\":\"address\"}],\"name\":\"isTrustedForwarder\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pcspToken\",\"outputs\":[{\"internalType\":\"contractPostCovidStrokePrevention\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"baseURI\",\"type\":\"string\"}],\"name\":\"setNFTBaseURI\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256[4]\",\"name\":\"amounts\",\"type\":\"uint256[4]\"}],\"name\":\"setRewardSchedule\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"}],\"name\":\"uploadData\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string[]\",\"name\":\"docIds\",\"type\":\"string[]\"}],\"name\":\"uploadDataBatch\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"root\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32[]\",\"name\":\"proof\",\"type\":\"bytes32[]\"},{\"internalType\":\"string\",\"name\":\"docId\",\"type\":\"string\"},{\"internalType\":\"bytes32\",\"name\":\"contentHash\",\"type\":\"bytes32\"}],\"name\":\"verifyAnchored\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
 }
 
 // ControllerABI is the input ABI used to generate the binding from.
@@ -194,6 +203,68 @@ func (_Controller *ControllerTransactorRaw) Transact(opts *bind.TransactOpts, me
 	return _Controller.Contract.contract.Transact(opts, method, params...)
 }
 
+// MAXBATCHSIZE is a free data retrieval call binding the contract method 0xcfdbf254.
+//
+// Solidity: function MAX_BATCH_SIZE() view returns(uint256)
+func (_Controller *ControllerCaller) MAXBATCHSIZE(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Controller.contract.Call(opts, &out, "MAX_BATCH_SIZE")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXBATCHSIZE is a free data retrieval call binding the contract method 0xcfdbf254.
+//
+// Solidity: function MAX_BATCH_SIZE() view returns(uint256)
+func (_Controller *ControllerSession) MAXBATCHSIZE() (*big.Int, error) {
+	return _Controller.Contract.MAXBATCHSIZE(&_Controller.CallOpts)
+}
+
+// MAXBATCHSIZE is a free data retrieval call binding the contract method 0xcfdbf254.
+//
+// Solidity: function MAX_BATCH_SIZE() view returns(uint256)
+func (_Controller *ControllerCallerSession) MAXBATCHSIZE() (*big.Int, error) {
+	return _Controller.Contract.MAXBATCHSIZE(&_Controller.CallOpts)
+}
+
+// AnchoredRoots is a free data retrieval call binding the contract method 0xce993b8c.
+//
+// Solidity: function anchoredRoots(bytes32 ) view returns(uint256)
+func (_Controller *ControllerCaller) AnchoredRoots(opts *bind.CallOpts, arg0 [32]byte) (*big.Int, error) {
+	var out []interface{}
+	err := _Controller.contract.Call(opts, &out, "anchoredRoots", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AnchoredRoots is a free data retrieval call binding the contract method 0xce993b8c.
+//
+// Solidity: function anchoredRoots(bytes32 ) view returns(uint256)
+func (_Controller *ControllerSession) AnchoredRoots(arg0 [32]byte) (*big.Int, error) {
+	return _Controller.Contract.AnchoredRoots(&_Controller.CallOpts, arg0)
+}
+
+// AnchoredRoots is a free data retrieval call binding the contract method 0xce993b8c.
+//
+// Solidity: function anchoredRoots(bytes32 ) view returns(uint256)
+func (_Controller *ControllerCallerSession) AnchoredRoots(arg0 [32]byte) (*big.Int, error) {
+	return _Controller.Contract.AnchoredRoots(&_Controller.CallOpts, arg0)
+}
+
 // GeneNFT is a free data retrieval call binding the contract method 0x5231f627.
 //
 // Solidity: function geneNFT() view returns(address)
@@ -287,6 +358,99 @@ func (_Controller *ControllerCallerSession) GetSession(sessionId *big.Int) (Cont
 	return _Controller.Contract.GetSession(&_Controller.CallOpts, sessionId)
 }
 
+// GetTokenDoc is a free data retrieval call binding the contract method 0x9dd9056b.
+//
+// Solidity: function getTokenDoc(uint256 tokenId) view returns(string)
+func (_Controller *ControllerCaller) GetTokenDoc(opts *bind.CallOpts, tokenId *big.Int) (string, error) {
+	var out []interface{}
+	err := _Controller.contract.Call(opts, &out, "getTokenDoc", tokenId)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// GetTokenDoc is a free data retrieval call binding the contract method 0x9dd9056b.
+//
+// Solidity: function getTokenDoc(uint256 tokenId) view returns(string)
+func (_Controller *ControllerSession) GetTokenDoc(tokenId *big.Int) (string, error) {
+	return _Controller.Contract.GetTokenDoc(&_Controller.CallOpts, tokenId)
+}
+
+// GetTokenDoc is a free data retrieval call binding the contract method 0x9dd9056b.
+//
+// Solidity: function getTokenDoc(uint256 tokenId) view returns(string)
+func (_Controller *ControllerCallerSession) GetTokenDoc(tokenId *big.Int) (string, error) {
+	return _Controller.Contract.GetTokenDoc(&_Controller.CallOpts, tokenId)
+}
+
+// IsTrustedForwarder is a free data retrieval call binding the contract method 0x572b6c05.
+//
+// Solidity: function isTrustedForwarder(address forwarder) view returns(bool)
+func (_Controller *ControllerCaller) IsTrustedForwarder(opts *bind.CallOpts, forwarder common.Address) (bool, error) {
+	var out []interface{}
+	err := _Controller.contract.Call(opts, &out, "isTrustedForwarder", forwarder)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsTrustedForwarder is a free data retrieval call binding the contract method 0x572b6c05.
+//
+// Solidity: function isTrustedForwarder(address forwarder) view returns(bool)
+func (_Controller *ControllerSession) IsTrustedForwarder(forwarder common.Address) (bool, error) {
+	return _Controller.Contract.IsTrustedForwarder(&_Controller.CallOpts, forwarder)
+}
+
+// IsTrustedForwarder is a free data retrieval call binding the contract method 0x572b6c05.
+//
+// Solidity: function isTrustedForwarder(address forwarder) view returns(bool)
+func (_Controller *ControllerCallerSession) IsTrustedForwarder(forwarder common.Address) (bool, error) {
+	return _Controller.Contract.IsTrustedForwarder(&_Controller.CallOpts, forwarder)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Controller *ControllerCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Controller.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Controller *ControllerSession) Owner() (common.Address, error) {
+	return _Controller.Contract.Owner(&_Controller.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Controller *ControllerCallerSession) Owner() (common.Address, error) {
+	return _Controller.Contract.Owner(&_Controller.CallOpts)
+}
+
 // PcspToken is a free data retrieval call binding the contract method 0xdab3761e.
 //
 // Solidity: function pcspToken() view returns(address)
@@ -318,6 +482,58 @@ func (_Controller *ControllerCallerSession) PcspToken() (common.Address, error)
 	return _Controller.Contract.PcspToken(&_Controller.CallOpts)
 }
 
+// VerifyAnchored is a free data retrieval call binding the contract method 0x419d9897.
+//
+// Solidity: function verifyAnchored(bytes32 root, bytes32[] proof, string docId, bytes32 contentHash) view returns(bool)
+func (_Controller *ControllerCaller) VerifyAnchored(opts *bind.CallOpts, root [32]byte, proof [][32]byte, docId string, contentHash [32]byte) (bool, error) {
+	var out []interface{}
+	err := _Controller.contract.Call(opts, &out, "verifyAnchored", root, proof, docId, contentHash)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// VerifyAnchored is a free data retrieval call binding the contract method 0x419d9897.
+//
+// Solidity: function verifyAnchored(bytes32 root, bytes32[] proof, string docId, bytes32 contentHash) view returns(bool)
+func (_Controller *ControllerSession) VerifyAnchored(root [32]byte, proof [][32]byte, docId string, contentHash [32]byte) (bool, error) {
+	return _Controller.Contract.VerifyAnchored(&_Controller.CallOpts, root, proof, docId, contentHash)
+}
+
+// VerifyAnchored is a free data retrieval call binding the contract method 0x419d9897.
+//
+// Solidity: function verifyAnchored(bytes32 root, bytes32[] proof, string docId, bytes32 contentHash) view returns(bool)
+func (_Controller *ControllerCallerSession) VerifyAnchored(root [32]byte, proof [][32]byte, docId string, contentHash [32]byte) (bool, error) {
+	return _Controller.Contract.VerifyAnchored(&_Controller.CallOpts, root, proof, docId, contentHash)
+}
+
+// AnchorRoot is a paid mutator transaction binding the contract method 0xb4e6bbf2.
+//
+// Solidity: function anchorRoot(bytes32 root, uint256 leafCount) returns()
+func (_Controller *ControllerTransactor) AnchorRoot(opts *bind.TransactOpts, root [32]byte, leafCount *big.Int) (*types.Transaction, error) {
+	return _Controller.contract.Transact(opts, "anchorRoot", root, leafCount)
+}
+
+// AnchorRoot is a paid mutator transaction binding the contract method 0xb4e6bbf2.
+//
+// Solidity: function anchorRoot(bytes32 root, uint256 leafCount) returns()
+func (_Controller *ControllerSession) AnchorRoot(root [32]byte, leafCount *big.Int) (*types.Transaction, error) {
+	return _Controller.Contract.AnchorRoot(&_Controller.TransactOpts, root, leafCount)
+}
+
+// AnchorRoot is a paid mutator transaction binding the contract method 0xb4e6bbf2.
+//
+// Solidity: function anchorRoot(bytes32 root, uint256 leafCount) returns()
+func (_Controller *ControllerTransactorSession) AnchorRoot(root [32]byte, leafCount *big.Int) (*types.Transaction, error) {
+	return _Controller.Contract.AnchorRoot(&_Controller.TransactOpts, root, leafCount)
+}
+
 // Confirm is a paid mutator transaction binding the contract method 0xb62fdfce.
 //
 // Solidity: function confirm(string docId, string contentHash, string proof, uint256 sessionId, uint256 riskScore) returns()
@@ -339,6 +555,111 @@ func (_Controller *ControllerTransactorSession) Confirm(docId string, contentHas
 	return _Controller.Contract.Confirm(&_Controller.TransactOpts, docId, contentHash, proof, sessionId, riskScore)
 }
 
+// ConfirmBatch is a paid mutator transaction binding the contract method 0x33d45203.
+//
+// Solidity: function confirmBatch((string,string,string,uint256,uint256)[] items) returns()
+func (_Controller *ControllerTransactor) ConfirmBatch(opts *bind.TransactOpts, items []ControllerConfirmItem) (*types.Transaction, error) {
+	return _Controller.contract.Transact(opts, "confirmBatch", items)
+}
+
+// ConfirmBatch is a paid mutator transaction binding the contract method 0x33d45203.
+//
+// Solidity: function confirmBatch((string,string,string,uint256,uint256)[] items) returns()
+func (_Controller *ControllerSession) ConfirmBatch(items []ControllerConfirmItem) (*types.Transaction, error) {
+	return _Controller.Contract.ConfirmBatch(&_Controller.TransactOpts, items)
+}
+
+// ConfirmBatch is a paid mutator transaction binding the contract method 0x33d45203.
+//
+// Solidity: function confirmBatch((string,string,string,uint256,uint256)[] items) returns()
+func (_Controller *ControllerTransactorSession) ConfirmBatch(items []ControllerConfirmItem) (*types.Transaction, error) {
+	return _Controller.Contract.ConfirmBatch(&_Controller.TransactOpts, items)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_Controller *ControllerTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Controller.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_Controller *ControllerSession) RenounceOwnership() (*types.Transaction, error) {
+	return _Controller.Contract.RenounceOwnership(&_Controller.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_Controller *ControllerTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _Controller.Contract.RenounceOwnership(&_Controller.TransactOpts)
+}
+
+// SetNFTBaseURI is a paid mutator transaction binding the contract method 0xdaa886b1.
+//
+// Solidity: function setNFTBaseURI(string baseURI) returns()
+func (_Controller *ControllerTransactor) SetNFTBaseURI(opts *bind.TransactOpts, baseURI string) (*types.Transaction, error) {
+	return _Controller.contract.Transact(opts, "setNFTBaseURI", baseURI)
+}
+
+// SetNFTBaseURI is a paid mutator transaction binding the contract method 0xdaa886b1.
+//
+// Solidity: function setNFTBaseURI(string baseURI) returns()
+func (_Controller *ControllerSession) SetNFTBaseURI(baseURI string) (*types.Transaction, error) {
+	return _Controller.Contract.SetNFTBaseURI(&_Controller.TransactOpts, baseURI)
+}
+
+// SetNFTBaseURI is a paid mutator transaction binding the contract method 0xdaa886b1.
+//
+// Solidity: function setNFTBaseURI(string baseURI) returns()
+func (_Controller *ControllerTransactorSession) SetNFTBaseURI(baseURI string) (*types.Transaction, error) {
+	return _Controller.Contract.SetNFTBaseURI(&_Controller.TransactOpts, baseURI)
+}
+
+// SetRewardSchedule is a paid mutator transaction binding the contract method 0x242b6a57.
+//
+// Solidity: function setRewardSchedule(uint256[4] amounts) returns()
+func (_Controller *ControllerTransactor) SetRewardSchedule(opts *bind.TransactOpts, amounts [4]*big.Int) (*types.Transaction, error) {
+	return _Controller.contract.Transact(opts, "setRewardSchedule", amounts)
+}
+
+// SetRewardSchedule is a paid mutator transaction binding the contract method 0x242b6a57.
+//
+// Solidity: function setRewardSchedule(uint256[4] amounts) returns()
+func (_Controller *ControllerSession) SetRewardSchedule(amounts [4]*big.Int) (*types.Transaction, error) {
+	return _Controller.Contract.SetRewardSchedule(&_Controller.TransactOpts, amounts)
+}
+
+// SetRewardSchedule is a paid mutator transaction binding the contract method 0x242b6a57.
+//
+// Solidity: function setRewardSchedule(uint256[4] amounts) returns()
+func (_Controller *ControllerTransactorSession) SetRewardSchedule(amounts [4]*big.Int) (*types.Transaction, error) {
+	return _Controller.Contract.SetRewardSchedule(&_Controller.TransactOpts, amounts)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_Controller *ControllerTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _Controller.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_Controller *ControllerSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _Controller.Contract.TransferOwnership(&_Controller.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_Controller *ControllerTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _Controller.Contract.TransferOwnership(&_Controller.TransactOpts, newOwner)
+}
+
 // UploadData is a paid mutator transaction binding the contract method 0x50969f44.
 //
 // Solidity: function uploadData(string docId) returns(uint256)
@@ -360,6 +681,27 @@ func (_Controller *ControllerTransactorSession) UploadData(docId string) (*types
 	return _Controller.Contract.UploadData(&_Controller.TransactOpts, docId)
 }
 
+// UploadDataBatch is a paid mutator transaction binding the contract method 0xb3c2cefd.
+//
+// Solidity: function uploadDataBatch(string[] docIds) returns(uint256[])
+func (_Controller *ControllerTransactor) UploadDataBatch(opts *bind.TransactOpts, docIds []string) (*types.Transaction, error) {
+	return _Controller.contract.Transact(opts, "uploadDataBatch", docIds)
+}
+
+// UploadDataBatch is a paid mutator transaction binding the contract method 0xb3c2cefd.
+//
+// Solidity: function uploadDataBatch(string[] docIds) returns(uint256[])
+func (_Controller *ControllerSession) UploadDataBatch(docIds []string) (*types.Transaction, error) {
+	return _Controller.Contract.UploadDataBatch(&_Controller.TransactOpts, docIds)
+}
+
+// UploadDataBatch is a paid mutator transaction binding the contract method 0xb3c2cefd.
+//
+// Solidity: function uploadDataBatch(string[] docIds) returns(uint256[])
+func (_Controller *ControllerTransactorSession) UploadDataBatch(docIds []string) (*types.Transaction, error) {
+	return _Controller.Contract.UploadDataBatch(&_Controller.TransactOpts, docIds)
+}
+
 // ControllerGeneDataSubmittedIterator is returned from FilterGeneDataSubmitted and is used to iterate over the raw logs and unpacked data for GeneDataSubmitted events raised by the Controller contract.
 type ControllerGeneDataSubmittedIterator struct {
 	Event *ControllerGeneDataSubmitted // Event containing the contract specifics and raw log
@@ -668,6 +1010,159 @@ func (_Controller *ControllerFilterer) ParseGeneNFTMinted(log types.Log) (*Contr
 	return event, nil
 }
 
+// ControllerOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the Controller contract.
+type ControllerOwnershipTransferredIterator struct {
+	Event *ControllerOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ControllerOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ControllerOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ControllerOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ControllerOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ControllerOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ControllerOwnershipTransferred represents a OwnershipTransferred event raised by the Controller contract.
+type ControllerOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_Controller *ControllerFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*ControllerOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _Controller.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ControllerOwnershipTransferredIterator{contract: _Controller.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_Controller *ControllerFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *ControllerOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _Controller.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ControllerOwnershipTransferred)
+				if err := _Controller.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_Controller *ControllerFilterer) ParseOwnershipTransferred(log types.Log) (*ControllerOwnershipTransferred, error) {
+	event := new(ControllerOwnershipTransferred)
+	if err := _Controller.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
 // ControllerPCSPRewardedIterator is returned from FilterPCSPRewarded and is used to iterate over the raw logs and unpacked data for PCSPRewarded events raised by the Controller contract.
 type ControllerPCSPRewardedIterator struct {
 	Event *ControllerPCSPRewarded // Event containing the contract specifics and raw log
@@ -814,6 +1309,306 @@ func (_Controller *ControllerFilterer) ParsePCSPRewarded(log types.Log) (*Contro
 	return event, nil
 }
 
+// ControllerRootAnchoredIterator is returned from FilterRootAnchored and is used to iterate over the raw logs and unpacked data for RootAnchored events raised by the Controller contract.
+type ControllerRootAnchoredIterator struct {
+	Event *ControllerRootAnchored // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ControllerRootAnchoredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ControllerRootAnchored)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ControllerRootAnchored)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ControllerRootAnchoredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ControllerRootAnchoredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ControllerRootAnchored represents a RootAnchored event raised by the Controller contract.
+type ControllerRootAnchored struct {
+	Root      [32]byte
+	LeafCount *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterRootAnchored is a free log retrieval operation binding the contract event 0x2e8856af32a4e4b53d984c6f9424a615f670a7d22f2b2c8ed435a4ac2ff09b90.
+//
+// Solidity: event RootAnchored(bytes32 indexed root, uint256 leafCount)
+func (_Controller *ControllerFilterer) FilterRootAnchored(opts *bind.FilterOpts, root [][32]byte) (*ControllerRootAnchoredIterator, error) {
+
+	var rootRule []interface{}
+	for _, rootItem := range root {
+		rootRule = append(rootRule, rootItem)
+	}
+
+	logs, sub, err := _Controller.contract.FilterLogs(opts, "RootAnchored", rootRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ControllerRootAnchoredIterator{contract: _Controller.contract, event: "RootAnchored", logs: logs, sub: sub}, nil
+}
+
+// WatchRootAnchored is a free log subscription operation binding the contract event 0x2e8856af32a4e4b53d984c6f9424a615f670a7d22f2b2c8ed435a4ac2ff09b90.
+//
+// Solidity: event RootAnchored(bytes32 indexed root, uint256 leafCount)
+func (_Controller *ControllerFilterer) WatchRootAnchored(opts *bind.WatchOpts, sink chan<- *ControllerRootAnchored, root [][32]byte) (event.Subscription, error) {
+
+	var rootRule []interface{}
+	for _, rootItem := range root {
+		rootRule = append(rootRule, rootItem)
+	}
+
+	logs, sub, err := _Controller.contract.WatchLogs(opts, "RootAnchored", rootRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ControllerRootAnchored)
+				if err := _Controller.contract.UnpackLog(event, "RootAnchored", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRootAnchored is a log parse operation binding the contract event 0x2e8856af32a4e4b53d984c6f9424a615f670a7d22f2b2c8ed435a4ac2ff09b90.
+//
+// Solidity: event RootAnchored(bytes32 indexed root, uint256 leafCount)
+func (_Controller *ControllerFilterer) ParseRootAnchored(log types.Log) (*ControllerRootAnchored, error) {
+	event := new(ControllerRootAnchored)
+	if err := _Controller.contract.UnpackLog(event, "RootAnchored", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ControllerUploadConfirmedIterator is returned from FilterUploadConfirmed and is used to iterate over the raw logs and unpacked data for UploadConfirmed events raised by the Controller contract.
+type ControllerUploadConfirmedIterator struct {
+	Event *ControllerUploadConfirmed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ControllerUploadConfirmedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ControllerUploadConfirmed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ControllerUploadConfirmed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ControllerUploadConfirmedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ControllerUploadConfirmedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ControllerUploadConfirmed represents a UploadConfirmed event raised by the Controller contract.
+type ControllerUploadConfirmed struct {
+	DocId        string
+	SessionId    *big.Int
+	TokenId      *big.Int
+	RewardAmount *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterUploadConfirmed is a free log retrieval operation binding the contract event 0x5a4bcb3e0100a334fe499a10f26f0a95a4e90c84c6c97e3a42a095651b54c7a5.
+//
+// Solidity: event UploadConfirmed(string docId, uint256 indexed sessionId, uint256 indexed tokenId, uint256 rewardAmount)
+func (_Controller *ControllerFilterer) FilterUploadConfirmed(opts *bind.FilterOpts, sessionId []*big.Int, tokenId []*big.Int) (*ControllerUploadConfirmedIterator, error) {
+
+	var sessionIdRule []interface{}
+	for _, sessionIdItem := range sessionId {
+		sessionIdRule = append(sessionIdRule, sessionIdItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _Controller.contract.FilterLogs(opts, "UploadConfirmed", sessionIdRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ControllerUploadConfirmedIterator{contract: _Controller.contract, event: "UploadConfirmed", logs: logs, sub: sub}, nil
+}
+
+// WatchUploadConfirmed is a free log subscription operation binding the contract event 0x5a4bcb3e0100a334fe499a10f26f0a95a4e90c84c6c97e3a42a095651b54c7a5.
+//
+// Solidity: event UploadConfirmed(string docId, uint256 indexed sessionId, uint256 indexed tokenId, uint256 rewardAmount)
+func (_Controller *ControllerFilterer) WatchUploadConfirmed(opts *bind.WatchOpts, sink chan<- *ControllerUploadConfirmed, sessionId []*big.Int, tokenId []*big.Int) (event.Subscription, error) {
+
+	var sessionIdRule []interface{}
+	for _, sessionIdItem := range sessionId {
+		sessionIdRule = append(sessionIdRule, sessionIdItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _Controller.contract.WatchLogs(opts, "UploadConfirmed", sessionIdRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ControllerUploadConfirmed)
+				if err := _Controller.contract.UnpackLog(event, "UploadConfirmed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUploadConfirmed is a log parse operation binding the contract event 0x5a4bcb3e0100a334fe499a10f26f0a95a4e90c84c6c97e3a42a095651b54c7a5.
+//
+// Solidity: event UploadConfirmed(string docId, uint256 indexed sessionId, uint256 indexed tokenId, uint256 rewardAmount)
+func (_Controller *ControllerFilterer) ParseUploadConfirmed(log types.Log) (*ControllerUploadConfirmed, error) {
+	event := new(ControllerUploadConfirmed)
+	if err := _Controller.contract.UnpackLog(event, "UploadConfirmed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
 // ControllerUploadDataIterator is returned from FilterUploadData and is used to iterate over the raw logs and unpacked data for UploadData events raised by the Controller contract.
 type ControllerUploadDataIterator struct {
 	Event *ControllerUploadData // Event containing the contract specifics and raw log
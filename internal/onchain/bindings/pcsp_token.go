@@ -29,9 +29,15 @@ var (
 	_ = abi.ConvertType
 )
 
+// ERC20VotesCheckpoint is an auto generated low-level Go binding around an user-defined struct.
+type ERC20VotesCheckpoint struct {
+	FromBlock uint32
+	Votes     *big.Int
+}
+
 // PCSPTokenMetaData contains all meta data concerning the PCSPToken contract.
 var PCSPTokenMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"burn\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"burnFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"subtractedValue\",\"type\":\"uint256\"}],\"name\":\"decreaseAllowance\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"addedValue\",\"type\":\"uint256\"}],\"name\":\"increaseAllowance\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"mint\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"riskScore\",\"type\":\"uint256\"}],\"name\":\"reward\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"authorizer\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"nonce\",\"type\":\"bytes32\"}],\"name\":\"AuthorizationUsed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"delegator\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"fromDelegate\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"toDelegate\",\"type\":\"address\"}],\"name\":\"DelegateChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"delegate\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"previousBalance\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newBalance\",\"type\":\"uint256\"}],\"name\":\"DelegateVotesChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"EIP712DomainChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256[4]\",\"name\":\"amounts\",\"type\":\"uint256[4]\"}],\"name\":\"RewardScheduleUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"BURN_WITH_AUTHORIZATION_TYPEHASH\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"CLOCK_MODE\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"DOMAIN_SEPARATOR\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TRANSFER_WITH_AUTHORIZATION_TYPEHASH\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"authorizer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"nonce\",\"type\":\"bytes32\"}],\"name\":\"authorizationState\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"burn\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"burnFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"validAfter\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"validBefore\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"nonce\",\"type\":\"bytes32\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"burnWithAuthorization\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint32\",\"name\":\"pos\",\"type\":\"uint32\"}],\"name\":\"checkpoints\",\"outputs\":[{\"components\":[{\"internalType\":\"uint32\",\"name\":\"fromBlock\",\"type\":\"uint32\"},{\"internalType\":\"uint224\",\"name\":\"votes\",\"type\":\"uint224\"}],\"internalType\":\"structERC20Votes.Checkpoint\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"clock\",\"outputs\":[{\"internalType\":\"uint48\",\"name\":\"\",\"type\":\"uint48\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"subtractedValue\",\"type\":\"uint256\"}],\"name\":\"decreaseAllowance\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"delegatee\",\"type\":\"address\"}],\"name\":\"delegate\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"delegatee\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"expiry\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"delegateBySig\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"delegates\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"eip712Domain\",\"outputs\":[{\"internalType\":\"bytes1\",\"name\":\"fields\",\"type\":\"bytes1\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"version\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"chainId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"verifyingContract\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"salt\",\"type\":\"bytes32\"},{\"internalType\":\"uint256[]\",\"name\":\"extensions\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"timepoint\",\"type\":\"uint256\"}],\"name\":\"getPastTotalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"timepoint\",\"type\":\"uint256\"}],\"name\":\"getPastVotes\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"getVotes\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"addedValue\",\"type\":\"uint256\"}],\"name\":\"increaseAllowance\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"mint\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"nonces\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"numCheckpoints\",\"outputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"deadline\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"permit\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"riskScore\",\"type\":\"uint256\"}],\"name\":\"reward\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"rewardSchedule\",\"outputs\":[{\"internalType\":\"uint256[4]\",\"name\":\"\",\"type\":\"uint256[4]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256[4]\",\"name\":\"amounts\",\"type\":\"uint256[4]\"}],\"name\":\"setRewardSchedule\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"validAfter\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"validBefore\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"nonce\",\"type\":\"bytes32\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"transferWithAuthorization\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
 }
 
 // PCSPTokenABI is the input ABI used to generate the binding from.
@@ -180,6 +186,130 @@ func (_PCSPToken *PCSPTokenTransactorRaw) Transact(opts *bind.TransactOpts, meth
 	return _PCSPToken.Contract.contract.Transact(opts, method, params...)
 }
 
+// BURNWITHAUTHORIZATIONTYPEHASH is a free data retrieval call binding the contract method 0xf9428b81.
+//
+// Solidity: function BURN_WITH_AUTHORIZATION_TYPEHASH() view returns(bytes32)
+func (_PCSPToken *PCSPTokenCaller) BURNWITHAUTHORIZATIONTYPEHASH(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "BURN_WITH_AUTHORIZATION_TYPEHASH")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// BURNWITHAUTHORIZATIONTYPEHASH is a free data retrieval call binding the contract method 0xf9428b81.
+//
+// Solidity: function BURN_WITH_AUTHORIZATION_TYPEHASH() view returns(bytes32)
+func (_PCSPToken *PCSPTokenSession) BURNWITHAUTHORIZATIONTYPEHASH() ([32]byte, error) {
+	return _PCSPToken.Contract.BURNWITHAUTHORIZATIONTYPEHASH(&_PCSPToken.CallOpts)
+}
+
+// BURNWITHAUTHORIZATIONTYPEHASH is a free data retrieval call binding the contract method 0xf9428b81.
+//
+// Solidity: function BURN_WITH_AUTHORIZATION_TYPEHASH() view returns(bytes32)
+func (_PCSPToken *PCSPTokenCallerSession) BURNWITHAUTHORIZATIONTYPEHASH() ([32]byte, error) {
+	return _PCSPToken.Contract.BURNWITHAUTHORIZATIONTYPEHASH(&_PCSPToken.CallOpts)
+}
+
+// CLOCKMODE is a free data retrieval call binding the contract method 0x4bf5d7e9.
+//
+// Solidity: function CLOCK_MODE() view returns(string)
+func (_PCSPToken *PCSPTokenCaller) CLOCKMODE(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "CLOCK_MODE")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// CLOCKMODE is a free data retrieval call binding the contract method 0x4bf5d7e9.
+//
+// Solidity: function CLOCK_MODE() view returns(string)
+func (_PCSPToken *PCSPTokenSession) CLOCKMODE() (string, error) {
+	return _PCSPToken.Contract.CLOCKMODE(&_PCSPToken.CallOpts)
+}
+
+// CLOCKMODE is a free data retrieval call binding the contract method 0x4bf5d7e9.
+//
+// Solidity: function CLOCK_MODE() view returns(string)
+func (_PCSPToken *PCSPTokenCallerSession) CLOCKMODE() (string, error) {
+	return _PCSPToken.Contract.CLOCKMODE(&_PCSPToken.CallOpts)
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_PCSPToken *PCSPTokenCaller) DOMAINSEPARATOR(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "DOMAIN_SEPARATOR")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_PCSPToken *PCSPTokenSession) DOMAINSEPARATOR() ([32]byte, error) {
+	return _PCSPToken.Contract.DOMAINSEPARATOR(&_PCSPToken.CallOpts)
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_PCSPToken *PCSPTokenCallerSession) DOMAINSEPARATOR() ([32]byte, error) {
+	return _PCSPToken.Contract.DOMAINSEPARATOR(&_PCSPToken.CallOpts)
+}
+
+// TRANSFERWITHAUTHORIZATIONTYPEHASH is a free data retrieval call binding the contract method 0xa0cc6a68.
+//
+// Solidity: function TRANSFER_WITH_AUTHORIZATION_TYPEHASH() view returns(bytes32)
+func (_PCSPToken *PCSPTokenCaller) TRANSFERWITHAUTHORIZATIONTYPEHASH(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "TRANSFER_WITH_AUTHORIZATION_TYPEHASH")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// TRANSFERWITHAUTHORIZATIONTYPEHASH is a free data retrieval call binding the contract method 0xa0cc6a68.
+//
+// Solidity: function TRANSFER_WITH_AUTHORIZATION_TYPEHASH() view returns(bytes32)
+func (_PCSPToken *PCSPTokenSession) TRANSFERWITHAUTHORIZATIONTYPEHASH() ([32]byte, error) {
+	return _PCSPToken.Contract.TRANSFERWITHAUTHORIZATIONTYPEHASH(&_PCSPToken.CallOpts)
+}
+
+// TRANSFERWITHAUTHORIZATIONTYPEHASH is a free data retrieval call binding the contract method 0xa0cc6a68.
+//
+// Solidity: function TRANSFER_WITH_AUTHORIZATION_TYPEHASH() view returns(bytes32)
+func (_PCSPToken *PCSPTokenCallerSession) TRANSFERWITHAUTHORIZATIONTYPEHASH() ([32]byte, error) {
+	return _PCSPToken.Contract.TRANSFERWITHAUTHORIZATIONTYPEHASH(&_PCSPToken.CallOpts)
+}
+
 // Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
 //
 // Solidity: function allowance(address owner, address spender) view returns(uint256)
@@ -211,6 +341,37 @@ func (_PCSPToken *PCSPTokenCallerSession) Allowance(owner common.Address, spende
 	return _PCSPToken.Contract.Allowance(&_PCSPToken.CallOpts, owner, spender)
 }
 
+// AuthorizationState is a free data retrieval call binding the contract method 0xe94a0102.
+//
+// Solidity: function authorizationState(address authorizer, bytes32 nonce) view returns(bool)
+func (_PCSPToken *PCSPTokenCaller) AuthorizationState(opts *bind.CallOpts, authorizer common.Address, nonce [32]byte) (bool, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "authorizationState", authorizer, nonce)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// AuthorizationState is a free data retrieval call binding the contract method 0xe94a0102.
+//
+// Solidity: function authorizationState(address authorizer, bytes32 nonce) view returns(bool)
+func (_PCSPToken *PCSPTokenSession) AuthorizationState(authorizer common.Address, nonce [32]byte) (bool, error) {
+	return _PCSPToken.Contract.AuthorizationState(&_PCSPToken.CallOpts, authorizer, nonce)
+}
+
+// AuthorizationState is a free data retrieval call binding the contract method 0xe94a0102.
+//
+// Solidity: function authorizationState(address authorizer, bytes32 nonce) view returns(bool)
+func (_PCSPToken *PCSPTokenCallerSession) AuthorizationState(authorizer common.Address, nonce [32]byte) (bool, error) {
+	return _PCSPToken.Contract.AuthorizationState(&_PCSPToken.CallOpts, authorizer, nonce)
+}
+
 // BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
 //
 // Solidity: function balanceOf(address account) view returns(uint256)
@@ -242,6 +403,68 @@ func (_PCSPToken *PCSPTokenCallerSession) BalanceOf(account common.Address) (*bi
 	return _PCSPToken.Contract.BalanceOf(&_PCSPToken.CallOpts, account)
 }
 
+// Checkpoints is a free data retrieval call binding the contract method 0xf1127ed8.
+//
+// Solidity: function checkpoints(address account, uint32 pos) view returns((uint32,uint224))
+func (_PCSPToken *PCSPTokenCaller) Checkpoints(opts *bind.CallOpts, account common.Address, pos uint32) (ERC20VotesCheckpoint, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "checkpoints", account, pos)
+
+	if err != nil {
+		return *new(ERC20VotesCheckpoint), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(ERC20VotesCheckpoint)).(*ERC20VotesCheckpoint)
+
+	return out0, err
+
+}
+
+// Checkpoints is a free data retrieval call binding the contract method 0xf1127ed8.
+//
+// Solidity: function checkpoints(address account, uint32 pos) view returns((uint32,uint224))
+func (_PCSPToken *PCSPTokenSession) Checkpoints(account common.Address, pos uint32) (ERC20VotesCheckpoint, error) {
+	return _PCSPToken.Contract.Checkpoints(&_PCSPToken.CallOpts, account, pos)
+}
+
+// Checkpoints is a free data retrieval call binding the contract method 0xf1127ed8.
+//
+// Solidity: function checkpoints(address account, uint32 pos) view returns((uint32,uint224))
+func (_PCSPToken *PCSPTokenCallerSession) Checkpoints(account common.Address, pos uint32) (ERC20VotesCheckpoint, error) {
+	return _PCSPToken.Contract.Checkpoints(&_PCSPToken.CallOpts, account, pos)
+}
+
+// Clock is a free data retrieval call binding the contract method 0x91ddadf4.
+//
+// Solidity: function clock() view returns(uint48)
+func (_PCSPToken *PCSPTokenCaller) Clock(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "clock")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Clock is a free data retrieval call binding the contract method 0x91ddadf4.
+//
+// Solidity: function clock() view returns(uint48)
+func (_PCSPToken *PCSPTokenSession) Clock() (*big.Int, error) {
+	return _PCSPToken.Contract.Clock(&_PCSPToken.CallOpts)
+}
+
+// Clock is a free data retrieval call binding the contract method 0x91ddadf4.
+//
+// Solidity: function clock() view returns(uint48)
+func (_PCSPToken *PCSPTokenCallerSession) Clock() (*big.Int, error) {
+	return _PCSPToken.Contract.Clock(&_PCSPToken.CallOpts)
+}
+
 // Decimals is a free data retrieval call binding the contract method 0x313ce567.
 //
 // Solidity: function decimals() view returns(uint8)
@@ -273,105 +496,175 @@ func (_PCSPToken *PCSPTokenCallerSession) Decimals() (uint8, error) {
 	return _PCSPToken.Contract.Decimals(&_PCSPToken.CallOpts)
 }
 
-// Name is a free data retrieval call binding the contract method 0x06fdde03.
+// Delegates is a free data retrieval call binding the contract method 0x587cde1e.
 //
-// Solidity: function name() view returns(string)
-func (_PCSPToken *PCSPTokenCaller) Name(opts *bind.CallOpts) (string, error) {
+// Solidity: function delegates(address account) view returns(address)
+func (_PCSPToken *PCSPTokenCaller) Delegates(opts *bind.CallOpts, account common.Address) (common.Address, error) {
 	var out []interface{}
-	err := _PCSPToken.contract.Call(opts, &out, "name")
+	err := _PCSPToken.contract.Call(opts, &out, "delegates", account)
 
 	if err != nil {
-		return *new(string), err
+		return *new(common.Address), err
 	}
 
-	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
 
 	return out0, err
 
 }
 
-// Name is a free data retrieval call binding the contract method 0x06fdde03.
+// Delegates is a free data retrieval call binding the contract method 0x587cde1e.
 //
-// Solidity: function name() view returns(string)
-func (_PCSPToken *PCSPTokenSession) Name() (string, error) {
-	return _PCSPToken.Contract.Name(&_PCSPToken.CallOpts)
+// Solidity: function delegates(address account) view returns(address)
+func (_PCSPToken *PCSPTokenSession) Delegates(account common.Address) (common.Address, error) {
+	return _PCSPToken.Contract.Delegates(&_PCSPToken.CallOpts, account)
 }
 
-// Name is a free data retrieval call binding the contract method 0x06fdde03.
+// Delegates is a free data retrieval call binding the contract method 0x587cde1e.
 //
-// Solidity: function name() view returns(string)
-func (_PCSPToken *PCSPTokenCallerSession) Name() (string, error) {
-	return _PCSPToken.Contract.Name(&_PCSPToken.CallOpts)
+// Solidity: function delegates(address account) view returns(address)
+func (_PCSPToken *PCSPTokenCallerSession) Delegates(account common.Address) (common.Address, error) {
+	return _PCSPToken.Contract.Delegates(&_PCSPToken.CallOpts, account)
 }
 
-// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
 //
-// Solidity: function owner() view returns(address)
-func (_PCSPToken *PCSPTokenCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_PCSPToken *PCSPTokenCaller) Eip712Domain(opts *bind.CallOpts) (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
 	var out []interface{}
-	err := _PCSPToken.contract.Call(opts, &out, "owner")
+	err := _PCSPToken.contract.Call(opts, &out, "eip712Domain")
+
+	outstruct := new(struct {
+		Fields            [1]byte
+		Name              string
+		Version           string
+		ChainId           *big.Int
+		VerifyingContract common.Address
+		Salt              [32]byte
+		Extensions        []*big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Fields = *abi.ConvertType(out[0], new([1]byte)).(*[1]byte)
+	outstruct.Name = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.Version = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.ChainId = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.VerifyingContract = *abi.ConvertType(out[4], new(common.Address)).(*common.Address)
+	outstruct.Salt = *abi.ConvertType(out[5], new([32]byte)).(*[32]byte)
+	outstruct.Extensions = *abi.ConvertType(out[6], new([]*big.Int)).(*[]*big.Int)
+
+	return *outstruct, err
+
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_PCSPToken *PCSPTokenSession) Eip712Domain() (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	return _PCSPToken.Contract.Eip712Domain(&_PCSPToken.CallOpts)
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_PCSPToken *PCSPTokenCallerSession) Eip712Domain() (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	return _PCSPToken.Contract.Eip712Domain(&_PCSPToken.CallOpts)
+}
+
+// GetPastTotalSupply is a free data retrieval call binding the contract method 0x8e539e8c.
+//
+// Solidity: function getPastTotalSupply(uint256 timepoint) view returns(uint256)
+func (_PCSPToken *PCSPTokenCaller) GetPastTotalSupply(opts *bind.CallOpts, timepoint *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "getPastTotalSupply", timepoint)
 
 	if err != nil {
-		return *new(common.Address), err
+		return *new(*big.Int), err
 	}
 
-	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
 
 	return out0, err
 
 }
 
-// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+// GetPastTotalSupply is a free data retrieval call binding the contract method 0x8e539e8c.
 //
-// Solidity: function owner() view returns(address)
-func (_PCSPToken *PCSPTokenSession) Owner() (common.Address, error) {
-	return _PCSPToken.Contract.Owner(&_PCSPToken.CallOpts)
+// Solidity: function getPastTotalSupply(uint256 timepoint) view returns(uint256)
+func (_PCSPToken *PCSPTokenSession) GetPastTotalSupply(timepoint *big.Int) (*big.Int, error) {
+	return _PCSPToken.Contract.GetPastTotalSupply(&_PCSPToken.CallOpts, timepoint)
 }
 
-// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+// GetPastTotalSupply is a free data retrieval call binding the contract method 0x8e539e8c.
 //
-// Solidity: function owner() view returns(address)
-func (_PCSPToken *PCSPTokenCallerSession) Owner() (common.Address, error) {
-	return _PCSPToken.Contract.Owner(&_PCSPToken.CallOpts)
+// Solidity: function getPastTotalSupply(uint256 timepoint) view returns(uint256)
+func (_PCSPToken *PCSPTokenCallerSession) GetPastTotalSupply(timepoint *big.Int) (*big.Int, error) {
+	return _PCSPToken.Contract.GetPastTotalSupply(&_PCSPToken.CallOpts, timepoint)
 }
 
-// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+// GetPastVotes is a free data retrieval call binding the contract method 0x3a46b1a8.
 //
-// Solidity: function symbol() view returns(string)
-func (_PCSPToken *PCSPTokenCaller) Symbol(opts *bind.CallOpts) (string, error) {
+// Solidity: function getPastVotes(address account, uint256 timepoint) view returns(uint256)
+func (_PCSPToken *PCSPTokenCaller) GetPastVotes(opts *bind.CallOpts, account common.Address, timepoint *big.Int) (*big.Int, error) {
 	var out []interface{}
-	err := _PCSPToken.contract.Call(opts, &out, "symbol")
+	err := _PCSPToken.contract.Call(opts, &out, "getPastVotes", account, timepoint)
 
 	if err != nil {
-		return *new(string), err
+		return *new(*big.Int), err
 	}
 
-	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
 
 	return out0, err
 
 }
 
-// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+// GetPastVotes is a free data retrieval call binding the contract method 0x3a46b1a8.
 //
-// Solidity: function symbol() view returns(string)
-func (_PCSPToken *PCSPTokenSession) Symbol() (string, error) {
-	return _PCSPToken.Contract.Symbol(&_PCSPToken.CallOpts)
+// Solidity: function getPastVotes(address account, uint256 timepoint) view returns(uint256)
+func (_PCSPToken *PCSPTokenSession) GetPastVotes(account common.Address, timepoint *big.Int) (*big.Int, error) {
+	return _PCSPToken.Contract.GetPastVotes(&_PCSPToken.CallOpts, account, timepoint)
 }
 
-// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+// GetPastVotes is a free data retrieval call binding the contract method 0x3a46b1a8.
 //
-// Solidity: function symbol() view returns(string)
-func (_PCSPToken *PCSPTokenCallerSession) Symbol() (string, error) {
-	return _PCSPToken.Contract.Symbol(&_PCSPToken.CallOpts)
+// Solidity: function getPastVotes(address account, uint256 timepoint) view returns(uint256)
+func (_PCSPToken *PCSPTokenCallerSession) GetPastVotes(account common.Address, timepoint *big.Int) (*big.Int, error) {
+	return _PCSPToken.Contract.GetPastVotes(&_PCSPToken.CallOpts, account, timepoint)
 }
 
-// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+// GetVotes is a free data retrieval call binding the contract method 0x9ab24eb0.
 //
-// Solidity: function totalSupply() view returns(uint256)
-func (_PCSPToken *PCSPTokenCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+// Solidity: function getVotes(address account) view returns(uint256)
+func (_PCSPToken *PCSPTokenCaller) GetVotes(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
 	var out []interface{}
-	err := _PCSPToken.contract.Call(opts, &out, "totalSupply")
+	err := _PCSPToken.contract.Call(opts, &out, "getVotes", account)
 
 	if err != nil {
 		return *new(*big.Int), err
@@ -383,67 +676,284 @@ func (_PCSPToken *PCSPTokenCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, e
 
 }
 
-// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+// GetVotes is a free data retrieval call binding the contract method 0x9ab24eb0.
 //
-// Solidity: function totalSupply() view returns(uint256)
-func (_PCSPToken *PCSPTokenSession) TotalSupply() (*big.Int, error) {
-	return _PCSPToken.Contract.TotalSupply(&_PCSPToken.CallOpts)
+// Solidity: function getVotes(address account) view returns(uint256)
+func (_PCSPToken *PCSPTokenSession) GetVotes(account common.Address) (*big.Int, error) {
+	return _PCSPToken.Contract.GetVotes(&_PCSPToken.CallOpts, account)
 }
 
-// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+// GetVotes is a free data retrieval call binding the contract method 0x9ab24eb0.
 //
-// Solidity: function totalSupply() view returns(uint256)
-func (_PCSPToken *PCSPTokenCallerSession) TotalSupply() (*big.Int, error) {
-	return _PCSPToken.Contract.TotalSupply(&_PCSPToken.CallOpts)
+// Solidity: function getVotes(address account) view returns(uint256)
+func (_PCSPToken *PCSPTokenCallerSession) GetVotes(account common.Address) (*big.Int, error) {
+	return _PCSPToken.Contract.GetVotes(&_PCSPToken.CallOpts, account)
 }
 
-// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
 //
-// Solidity: function approve(address spender, uint256 amount) returns(bool)
-func (_PCSPToken *PCSPTokenTransactor) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
-	return _PCSPToken.contract.Transact(opts, "approve", spender, amount)
+// Solidity: function name() view returns(string)
+func (_PCSPToken *PCSPTokenCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
 }
 
-// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
 //
-// Solidity: function approve(address spender, uint256 amount) returns(bool)
-func (_PCSPToken *PCSPTokenSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
-	return _PCSPToken.Contract.Approve(&_PCSPToken.TransactOpts, spender, amount)
+// Solidity: function name() view returns(string)
+func (_PCSPToken *PCSPTokenSession) Name() (string, error) {
+	return _PCSPToken.Contract.Name(&_PCSPToken.CallOpts)
 }
 
-// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
 //
-// Solidity: function approve(address spender, uint256 amount) returns(bool)
-func (_PCSPToken *PCSPTokenTransactorSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
-	return _PCSPToken.Contract.Approve(&_PCSPToken.TransactOpts, spender, amount)
+// Solidity: function name() view returns(string)
+func (_PCSPToken *PCSPTokenCallerSession) Name() (string, error) {
+	return _PCSPToken.Contract.Name(&_PCSPToken.CallOpts)
 }
 
-// Burn is a paid mutator transaction binding the contract method 0x42966c68.
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
 //
-// Solidity: function burn(uint256 amount) returns()
-func (_PCSPToken *PCSPTokenTransactor) Burn(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
-	return _PCSPToken.contract.Transact(opts, "burn", amount)
+// Solidity: function nonces(address owner) view returns(uint256)
+func (_PCSPToken *PCSPTokenCaller) Nonces(opts *bind.CallOpts, owner common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "nonces", owner)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
 }
 
-// Burn is a paid mutator transaction binding the contract method 0x42966c68.
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
 //
-// Solidity: function burn(uint256 amount) returns()
-func (_PCSPToken *PCSPTokenSession) Burn(amount *big.Int) (*types.Transaction, error) {
-	return _PCSPToken.Contract.Burn(&_PCSPToken.TransactOpts, amount)
+// Solidity: function nonces(address owner) view returns(uint256)
+func (_PCSPToken *PCSPTokenSession) Nonces(owner common.Address) (*big.Int, error) {
+	return _PCSPToken.Contract.Nonces(&_PCSPToken.CallOpts, owner)
 }
 
-// Burn is a paid mutator transaction binding the contract method 0x42966c68.
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
 //
-// Solidity: function burn(uint256 amount) returns()
-func (_PCSPToken *PCSPTokenTransactorSession) Burn(amount *big.Int) (*types.Transaction, error) {
-	return _PCSPToken.Contract.Burn(&_PCSPToken.TransactOpts, amount)
+// Solidity: function nonces(address owner) view returns(uint256)
+func (_PCSPToken *PCSPTokenCallerSession) Nonces(owner common.Address) (*big.Int, error) {
+	return _PCSPToken.Contract.Nonces(&_PCSPToken.CallOpts, owner)
 }
 
-// BurnFrom is a paid mutator transaction binding the contract method 0x79cc6790.
+// NumCheckpoints is a free data retrieval call binding the contract method 0x6fcfff45.
 //
-// Solidity: function burnFrom(address account, uint256 amount) returns()
-func (_PCSPToken *PCSPTokenTransactor) BurnFrom(opts *bind.TransactOpts, account common.Address, amount *big.Int) (*types.Transaction, error) {
-	return _PCSPToken.contract.Transact(opts, "burnFrom", account, amount)
+// Solidity: function numCheckpoints(address account) view returns(uint32)
+func (_PCSPToken *PCSPTokenCaller) NumCheckpoints(opts *bind.CallOpts, account common.Address) (uint32, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "numCheckpoints", account)
+
+	if err != nil {
+		return *new(uint32), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint32)).(*uint32)
+
+	return out0, err
+
+}
+
+// NumCheckpoints is a free data retrieval call binding the contract method 0x6fcfff45.
+//
+// Solidity: function numCheckpoints(address account) view returns(uint32)
+func (_PCSPToken *PCSPTokenSession) NumCheckpoints(account common.Address) (uint32, error) {
+	return _PCSPToken.Contract.NumCheckpoints(&_PCSPToken.CallOpts, account)
+}
+
+// NumCheckpoints is a free data retrieval call binding the contract method 0x6fcfff45.
+//
+// Solidity: function numCheckpoints(address account) view returns(uint32)
+func (_PCSPToken *PCSPTokenCallerSession) NumCheckpoints(account common.Address) (uint32, error) {
+	return _PCSPToken.Contract.NumCheckpoints(&_PCSPToken.CallOpts, account)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_PCSPToken *PCSPTokenCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_PCSPToken *PCSPTokenSession) Owner() (common.Address, error) {
+	return _PCSPToken.Contract.Owner(&_PCSPToken.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_PCSPToken *PCSPTokenCallerSession) Owner() (common.Address, error) {
+	return _PCSPToken.Contract.Owner(&_PCSPToken.CallOpts)
+}
+
+// RewardSchedule is a free data retrieval call binding the contract method 0xa4cbc98b.
+//
+// Solidity: function rewardSchedule() view returns(uint256[4])
+func (_PCSPToken *PCSPTokenCaller) RewardSchedule(opts *bind.CallOpts) ([4]*big.Int, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "rewardSchedule")
+
+	if err != nil {
+		return *new([4]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([4]*big.Int)).(*[4]*big.Int)
+
+	return out0, err
+
+}
+
+// RewardSchedule is a free data retrieval call binding the contract method 0xa4cbc98b.
+//
+// Solidity: function rewardSchedule() view returns(uint256[4])
+func (_PCSPToken *PCSPTokenSession) RewardSchedule() ([4]*big.Int, error) {
+	return _PCSPToken.Contract.RewardSchedule(&_PCSPToken.CallOpts)
+}
+
+// RewardSchedule is a free data retrieval call binding the contract method 0xa4cbc98b.
+//
+// Solidity: function rewardSchedule() view returns(uint256[4])
+func (_PCSPToken *PCSPTokenCallerSession) RewardSchedule() ([4]*big.Int, error) {
+	return _PCSPToken.Contract.RewardSchedule(&_PCSPToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_PCSPToken *PCSPTokenCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_PCSPToken *PCSPTokenSession) Symbol() (string, error) {
+	return _PCSPToken.Contract.Symbol(&_PCSPToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_PCSPToken *PCSPTokenCallerSession) Symbol() (string, error) {
+	return _PCSPToken.Contract.Symbol(&_PCSPToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_PCSPToken *PCSPTokenCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PCSPToken.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_PCSPToken *PCSPTokenSession) TotalSupply() (*big.Int, error) {
+	return _PCSPToken.Contract.TotalSupply(&_PCSPToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_PCSPToken *PCSPTokenCallerSession) TotalSupply() (*big.Int, error) {
+	return _PCSPToken.Contract.TotalSupply(&_PCSPToken.CallOpts)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_PCSPToken *PCSPTokenTransactor) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _PCSPToken.contract.Transact(opts, "approve", spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_PCSPToken *PCSPTokenSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _PCSPToken.Contract.Approve(&_PCSPToken.TransactOpts, spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_PCSPToken *PCSPTokenTransactorSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _PCSPToken.Contract.Approve(&_PCSPToken.TransactOpts, spender, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x42966c68.
+//
+// Solidity: function burn(uint256 amount) returns()
+func (_PCSPToken *PCSPTokenTransactor) Burn(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return _PCSPToken.contract.Transact(opts, "burn", amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x42966c68.
+//
+// Solidity: function burn(uint256 amount) returns()
+func (_PCSPToken *PCSPTokenSession) Burn(amount *big.Int) (*types.Transaction, error) {
+	return _PCSPToken.Contract.Burn(&_PCSPToken.TransactOpts, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x42966c68.
+//
+// Solidity: function burn(uint256 amount) returns()
+func (_PCSPToken *PCSPTokenTransactorSession) Burn(amount *big.Int) (*types.Transaction, error) {
+	return _PCSPToken.Contract.Burn(&_PCSPToken.TransactOpts, amount)
+}
+
+// BurnFrom is a paid mutator transaction binding the contract method 0x79cc6790.
+//
+// Solidity: function burnFrom(address account, uint256 amount) returns()
+func (_PCSPToken *PCSPTokenTransactor) BurnFrom(opts *bind.TransactOpts, account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _PCSPToken.contract.Transact(opts, "burnFrom", account, amount)
 }
 
 // BurnFrom is a paid mutator transaction binding the contract method 0x79cc6790.
@@ -460,6 +970,27 @@ func (_PCSPToken *PCSPTokenTransactorSession) BurnFrom(account common.Address, a
 	return _PCSPToken.Contract.BurnFrom(&_PCSPToken.TransactOpts, account, amount)
 }
 
+// BurnWithAuthorization is a paid mutator transaction binding the contract method 0x4239c41a.
+//
+// Solidity: function burnWithAuthorization(address from, uint256 value, uint256 validAfter, uint256 validBefore, bytes32 nonce, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenTransactor) BurnWithAuthorization(opts *bind.TransactOpts, from common.Address, value *big.Int, validAfter *big.Int, validBefore *big.Int, nonce [32]byte, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.contract.Transact(opts, "burnWithAuthorization", from, value, validAfter, validBefore, nonce, v, r, s)
+}
+
+// BurnWithAuthorization is a paid mutator transaction binding the contract method 0x4239c41a.
+//
+// Solidity: function burnWithAuthorization(address from, uint256 value, uint256 validAfter, uint256 validBefore, bytes32 nonce, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenSession) BurnWithAuthorization(from common.Address, value *big.Int, validAfter *big.Int, validBefore *big.Int, nonce [32]byte, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.Contract.BurnWithAuthorization(&_PCSPToken.TransactOpts, from, value, validAfter, validBefore, nonce, v, r, s)
+}
+
+// BurnWithAuthorization is a paid mutator transaction binding the contract method 0x4239c41a.
+//
+// Solidity: function burnWithAuthorization(address from, uint256 value, uint256 validAfter, uint256 validBefore, bytes32 nonce, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenTransactorSession) BurnWithAuthorization(from common.Address, value *big.Int, validAfter *big.Int, validBefore *big.Int, nonce [32]byte, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.Contract.BurnWithAuthorization(&_PCSPToken.TransactOpts, from, value, validAfter, validBefore, nonce, v, r, s)
+}
+
 // DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
 //
 // Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
@@ -481,6 +1012,48 @@ func (_PCSPToken *PCSPTokenTransactorSession) DecreaseAllowance(spender common.A
 	return _PCSPToken.Contract.DecreaseAllowance(&_PCSPToken.TransactOpts, spender, subtractedValue)
 }
 
+// Delegate is a paid mutator transaction binding the contract method 0x5c19a95c.
+//
+// Solidity: function delegate(address delegatee) returns()
+func (_PCSPToken *PCSPTokenTransactor) Delegate(opts *bind.TransactOpts, delegatee common.Address) (*types.Transaction, error) {
+	return _PCSPToken.contract.Transact(opts, "delegate", delegatee)
+}
+
+// Delegate is a paid mutator transaction binding the contract method 0x5c19a95c.
+//
+// Solidity: function delegate(address delegatee) returns()
+func (_PCSPToken *PCSPTokenSession) Delegate(delegatee common.Address) (*types.Transaction, error) {
+	return _PCSPToken.Contract.Delegate(&_PCSPToken.TransactOpts, delegatee)
+}
+
+// Delegate is a paid mutator transaction binding the contract method 0x5c19a95c.
+//
+// Solidity: function delegate(address delegatee) returns()
+func (_PCSPToken *PCSPTokenTransactorSession) Delegate(delegatee common.Address) (*types.Transaction, error) {
+	return _PCSPToken.Contract.Delegate(&_PCSPToken.TransactOpts, delegatee)
+}
+
+// DelegateBySig is a paid mutator transaction binding the contract method 0xc3cda520.
+//
+// Solidity: function delegateBySig(address delegatee, uint256 nonce, uint256 expiry, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenTransactor) DelegateBySig(opts *bind.TransactOpts, delegatee common.Address, nonce *big.Int, expiry *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.contract.Transact(opts, "delegateBySig", delegatee, nonce, expiry, v, r, s)
+}
+
+// DelegateBySig is a paid mutator transaction binding the contract method 0xc3cda520.
+//
+// Solidity: function delegateBySig(address delegatee, uint256 nonce, uint256 expiry, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenSession) DelegateBySig(delegatee common.Address, nonce *big.Int, expiry *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.Contract.DelegateBySig(&_PCSPToken.TransactOpts, delegatee, nonce, expiry, v, r, s)
+}
+
+// DelegateBySig is a paid mutator transaction binding the contract method 0xc3cda520.
+//
+// Solidity: function delegateBySig(address delegatee, uint256 nonce, uint256 expiry, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenTransactorSession) DelegateBySig(delegatee common.Address, nonce *big.Int, expiry *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.Contract.DelegateBySig(&_PCSPToken.TransactOpts, delegatee, nonce, expiry, v, r, s)
+}
+
 // IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
 //
 // Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
@@ -523,6 +1096,27 @@ func (_PCSPToken *PCSPTokenTransactorSession) Mint(to common.Address, amount *bi
 	return _PCSPToken.Contract.Mint(&_PCSPToken.TransactOpts, to, amount)
 }
 
+// Permit is a paid mutator transaction binding the contract method 0xd505accf.
+//
+// Solidity: function permit(address owner, address spender, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenTransactor) Permit(opts *bind.TransactOpts, owner common.Address, spender common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.contract.Transact(opts, "permit", owner, spender, value, deadline, v, r, s)
+}
+
+// Permit is a paid mutator transaction binding the contract method 0xd505accf.
+//
+// Solidity: function permit(address owner, address spender, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenSession) Permit(owner common.Address, spender common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.Contract.Permit(&_PCSPToken.TransactOpts, owner, spender, value, deadline, v, r, s)
+}
+
+// Permit is a paid mutator transaction binding the contract method 0xd505accf.
+//
+// Solidity: function permit(address owner, address spender, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenTransactorSession) Permit(owner common.Address, spender common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.Contract.Permit(&_PCSPToken.TransactOpts, owner, spender, value, deadline, v, r, s)
+}
+
 // RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
 //
 // Solidity: function renounceOwnership() returns()
@@ -565,6 +1159,27 @@ func (_PCSPToken *PCSPTokenTransactorSession) Reward(to common.Address, riskScor
 	return _PCSPToken.Contract.Reward(&_PCSPToken.TransactOpts, to, riskScore)
 }
 
+// SetRewardSchedule is a paid mutator transaction binding the contract method 0x242b6a57.
+//
+// Solidity: function setRewardSchedule(uint256[4] amounts) returns()
+func (_PCSPToken *PCSPTokenTransactor) SetRewardSchedule(opts *bind.TransactOpts, amounts [4]*big.Int) (*types.Transaction, error) {
+	return _PCSPToken.contract.Transact(opts, "setRewardSchedule", amounts)
+}
+
+// SetRewardSchedule is a paid mutator transaction binding the contract method 0x242b6a57.
+//
+// Solidity: function setRewardSchedule(uint256[4] amounts) returns()
+func (_PCSPToken *PCSPTokenSession) SetRewardSchedule(amounts [4]*big.Int) (*types.Transaction, error) {
+	return _PCSPToken.Contract.SetRewardSchedule(&_PCSPToken.TransactOpts, amounts)
+}
+
+// SetRewardSchedule is a paid mutator transaction binding the contract method 0x242b6a57.
+//
+// Solidity: function setRewardSchedule(uint256[4] amounts) returns()
+func (_PCSPToken *PCSPTokenTransactorSession) SetRewardSchedule(amounts [4]*big.Int) (*types.Transaction, error) {
+	return _PCSPToken.Contract.SetRewardSchedule(&_PCSPToken.TransactOpts, amounts)
+}
+
 // Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
 //
 // Solidity: function transfer(address to, uint256 amount) returns(bool)
@@ -628,6 +1243,27 @@ func (_PCSPToken *PCSPTokenTransactorSession) TransferOwnership(newOwner common.
 	return _PCSPToken.Contract.TransferOwnership(&_PCSPToken.TransactOpts, newOwner)
 }
 
+// TransferWithAuthorization is a paid mutator transaction binding the contract method 0xe3ee160e.
+//
+// Solidity: function transferWithAuthorization(address from, address to, uint256 value, uint256 validAfter, uint256 validBefore, bytes32 nonce, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenTransactor) TransferWithAuthorization(opts *bind.TransactOpts, from common.Address, to common.Address, value *big.Int, validAfter *big.Int, validBefore *big.Int, nonce [32]byte, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.contract.Transact(opts, "transferWithAuthorization", from, to, value, validAfter, validBefore, nonce, v, r, s)
+}
+
+// TransferWithAuthorization is a paid mutator transaction binding the contract method 0xe3ee160e.
+//
+// Solidity: function transferWithAuthorization(address from, address to, uint256 value, uint256 validAfter, uint256 validBefore, bytes32 nonce, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenSession) TransferWithAuthorization(from common.Address, to common.Address, value *big.Int, validAfter *big.Int, validBefore *big.Int, nonce [32]byte, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.Contract.TransferWithAuthorization(&_PCSPToken.TransactOpts, from, to, value, validAfter, validBefore, nonce, v, r, s)
+}
+
+// TransferWithAuthorization is a paid mutator transaction binding the contract method 0xe3ee160e.
+//
+// Solidity: function transferWithAuthorization(address from, address to, uint256 value, uint256 validAfter, uint256 validBefore, bytes32 nonce, uint8 v, bytes32 r, bytes32 s) returns()
+func (_PCSPToken *PCSPTokenTransactorSession) TransferWithAuthorization(from common.Address, to common.Address, value *big.Int, validAfter *big.Int, validBefore *big.Int, nonce [32]byte, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _PCSPToken.Contract.TransferWithAuthorization(&_PCSPToken.TransactOpts, from, to, value, validAfter, validBefore, nonce, v, r, s)
+}
+
 // PCSPTokenApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the PCSPToken contract.
 type PCSPTokenApprovalIterator struct {
 	Event *PCSPTokenApproval // Event containing the contract specifics and raw log
@@ -782,9 +1418,9 @@ func (_PCSPToken *PCSPTokenFilterer) ParseApproval(log types.Log) (*PCSPTokenApp
 	return event, nil
 }
 
-// PCSPTokenOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the PCSPToken contract.
-type PCSPTokenOwnershipTransferredIterator struct {
-	Event *PCSPTokenOwnershipTransferred // Event containing the contract specifics and raw log
+// PCSPTokenAuthorizationUsedIterator is returned from FilterAuthorizationUsed and is used to iterate over the raw logs and unpacked data for AuthorizationUsed events raised by the PCSPToken contract.
+type PCSPTokenAuthorizationUsedIterator struct {
+	Event *PCSPTokenAuthorizationUsed // Event containing the contract specifics and raw log
 
 	contract *bind.BoundContract // Generic contract to use for unpacking event data
 	event    string              // Event name to use for unpacking event data
@@ -798,7 +1434,7 @@ type PCSPTokenOwnershipTransferredIterator struct {
 // Next advances the iterator to the subsequent event, returning whether there
 // are any more events found. In case of a retrieval or parsing error, false is
 // returned and Error() can be queried for the exact failure.
-func (it *PCSPTokenOwnershipTransferredIterator) Next() bool {
+func (it *PCSPTokenAuthorizationUsedIterator) Next() bool {
 	// If the iterator failed, stop iterating
 	if it.fail != nil {
 		return false
@@ -807,7 +1443,7 @@ func (it *PCSPTokenOwnershipTransferredIterator) Next() bool {
 	if it.done {
 		select {
 		case log := <-it.logs:
-			it.Event = new(PCSPTokenOwnershipTransferred)
+			it.Event = new(PCSPTokenAuthorizationUsed)
 			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
 				it.fail = err
 				return false
@@ -822,7 +1458,7 @@ func (it *PCSPTokenOwnershipTransferredIterator) Next() bool {
 	// Iterator still in progress, wait for either a data or an error event
 	select {
 	case log := <-it.logs:
-		it.Event = new(PCSPTokenOwnershipTransferred)
+		it.Event = new(PCSPTokenAuthorizationUsed)
 		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
 			it.fail = err
 			return false
@@ -838,52 +1474,646 @@ func (it *PCSPTokenOwnershipTransferredIterator) Next() bool {
 }
 
 // Error returns any retrieval or parsing error occurred during filtering.
-func (it *PCSPTokenOwnershipTransferredIterator) Error() error {
+func (it *PCSPTokenAuthorizationUsedIterator) Error() error {
 	return it.fail
 }
 
 // Close terminates the iteration process, releasing any pending underlying
 // resources.
-func (it *PCSPTokenOwnershipTransferredIterator) Close() error {
+func (it *PCSPTokenAuthorizationUsedIterator) Close() error {
 	it.sub.Unsubscribe()
 	return nil
 }
 
-// PCSPTokenOwnershipTransferred represents a OwnershipTransferred event raised by the PCSPToken contract.
-type PCSPTokenOwnershipTransferred struct {
-	PreviousOwner common.Address
-	NewOwner      common.Address
-	Raw           types.Log // Blockchain specific contextual infos
+// PCSPTokenAuthorizationUsed represents a AuthorizationUsed event raised by the PCSPToken contract.
+type PCSPTokenAuthorizationUsed struct {
+	Authorizer common.Address
+	Nonce      [32]byte
+	Raw        types.Log // Blockchain specific contextual infos
 }
 
-// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+// FilterAuthorizationUsed is a free log retrieval operation binding the contract event 0x98de503528ee59b575ef0c0a2576a82497bfc029a5685b209e9ec333479b10a5.
 //
-// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
-func (_PCSPToken *PCSPTokenFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*PCSPTokenOwnershipTransferredIterator, error) {
+// Solidity: event AuthorizationUsed(address indexed authorizer, bytes32 indexed nonce)
+func (_PCSPToken *PCSPTokenFilterer) FilterAuthorizationUsed(opts *bind.FilterOpts, authorizer []common.Address, nonce [][32]byte) (*PCSPTokenAuthorizationUsedIterator, error) {
 
-	var previousOwnerRule []interface{}
-	for _, previousOwnerItem := range previousOwner {
-		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	var authorizerRule []interface{}
+	for _, authorizerItem := range authorizer {
+		authorizerRule = append(authorizerRule, authorizerItem)
 	}
-	var newOwnerRule []interface{}
-	for _, newOwnerItem := range newOwner {
-		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
 	}
 
-	logs, sub, err := _PCSPToken.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	logs, sub, err := _PCSPToken.contract.FilterLogs(opts, "AuthorizationUsed", authorizerRule, nonceRule)
 	if err != nil {
 		return nil, err
 	}
-	return &PCSPTokenOwnershipTransferredIterator{contract: _PCSPToken.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+	return &PCSPTokenAuthorizationUsedIterator{contract: _PCSPToken.contract, event: "AuthorizationUsed", logs: logs, sub: sub}, nil
 }
 
-// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+// WatchAuthorizationUsed is a free log subscription operation binding the contract event 0x98de503528ee59b575ef0c0a2576a82497bfc029a5685b209e9ec333479b10a5.
 //
-// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
-func (_PCSPToken *PCSPTokenFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *PCSPTokenOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+// Solidity: event AuthorizationUsed(address indexed authorizer, bytes32 indexed nonce)
+func (_PCSPToken *PCSPTokenFilterer) WatchAuthorizationUsed(opts *bind.WatchOpts, sink chan<- *PCSPTokenAuthorizationUsed, authorizer []common.Address, nonce [][32]byte) (event.Subscription, error) {
 
-	var previousOwnerRule []interface{}
-	for _, previousOwnerItem := range previousOwner {
+	var authorizerRule []interface{}
+	for _, authorizerItem := range authorizer {
+		authorizerRule = append(authorizerRule, authorizerItem)
+	}
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+
+	logs, sub, err := _PCSPToken.contract.WatchLogs(opts, "AuthorizationUsed", authorizerRule, nonceRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PCSPTokenAuthorizationUsed)
+				if err := _PCSPToken.contract.UnpackLog(event, "AuthorizationUsed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAuthorizationUsed is a log parse operation binding the contract event 0x98de503528ee59b575ef0c0a2576a82497bfc029a5685b209e9ec333479b10a5.
+//
+// Solidity: event AuthorizationUsed(address indexed authorizer, bytes32 indexed nonce)
+func (_PCSPToken *PCSPTokenFilterer) ParseAuthorizationUsed(log types.Log) (*PCSPTokenAuthorizationUsed, error) {
+	event := new(PCSPTokenAuthorizationUsed)
+	if err := _PCSPToken.contract.UnpackLog(event, "AuthorizationUsed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PCSPTokenDelegateChangedIterator is returned from FilterDelegateChanged and is used to iterate over the raw logs and unpacked data for DelegateChanged events raised by the PCSPToken contract.
+type PCSPTokenDelegateChangedIterator struct {
+	Event *PCSPTokenDelegateChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PCSPTokenDelegateChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PCSPTokenDelegateChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PCSPTokenDelegateChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PCSPTokenDelegateChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PCSPTokenDelegateChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PCSPTokenDelegateChanged represents a DelegateChanged event raised by the PCSPToken contract.
+type PCSPTokenDelegateChanged struct {
+	Delegator    common.Address
+	FromDelegate common.Address
+	ToDelegate   common.Address
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterDelegateChanged is a free log retrieval operation binding the contract event 0x3134e8a2e6d97e929a7e54011ea5485d7d196dd5f0ba4d4ef95803e8e3fc257f.
+//
+// Solidity: event DelegateChanged(address indexed delegator, address indexed fromDelegate, address indexed toDelegate)
+func (_PCSPToken *PCSPTokenFilterer) FilterDelegateChanged(opts *bind.FilterOpts, delegator []common.Address, fromDelegate []common.Address, toDelegate []common.Address) (*PCSPTokenDelegateChangedIterator, error) {
+
+	var delegatorRule []interface{}
+	for _, delegatorItem := range delegator {
+		delegatorRule = append(delegatorRule, delegatorItem)
+	}
+	var fromDelegateRule []interface{}
+	for _, fromDelegateItem := range fromDelegate {
+		fromDelegateRule = append(fromDelegateRule, fromDelegateItem)
+	}
+	var toDelegateRule []interface{}
+	for _, toDelegateItem := range toDelegate {
+		toDelegateRule = append(toDelegateRule, toDelegateItem)
+	}
+
+	logs, sub, err := _PCSPToken.contract.FilterLogs(opts, "DelegateChanged", delegatorRule, fromDelegateRule, toDelegateRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PCSPTokenDelegateChangedIterator{contract: _PCSPToken.contract, event: "DelegateChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchDelegateChanged is a free log subscription operation binding the contract event 0x3134e8a2e6d97e929a7e54011ea5485d7d196dd5f0ba4d4ef95803e8e3fc257f.
+//
+// Solidity: event DelegateChanged(address indexed delegator, address indexed fromDelegate, address indexed toDelegate)
+func (_PCSPToken *PCSPTokenFilterer) WatchDelegateChanged(opts *bind.WatchOpts, sink chan<- *PCSPTokenDelegateChanged, delegator []common.Address, fromDelegate []common.Address, toDelegate []common.Address) (event.Subscription, error) {
+
+	var delegatorRule []interface{}
+	for _, delegatorItem := range delegator {
+		delegatorRule = append(delegatorRule, delegatorItem)
+	}
+	var fromDelegateRule []interface{}
+	for _, fromDelegateItem := range fromDelegate {
+		fromDelegateRule = append(fromDelegateRule, fromDelegateItem)
+	}
+	var toDelegateRule []interface{}
+	for _, toDelegateItem := range toDelegate {
+		toDelegateRule = append(toDelegateRule, toDelegateItem)
+	}
+
+	logs, sub, err := _PCSPToken.contract.WatchLogs(opts, "DelegateChanged", delegatorRule, fromDelegateRule, toDelegateRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PCSPTokenDelegateChanged)
+				if err := _PCSPToken.contract.UnpackLog(event, "DelegateChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDelegateChanged is a log parse operation binding the contract event 0x3134e8a2e6d97e929a7e54011ea5485d7d196dd5f0ba4d4ef95803e8e3fc257f.
+//
+// Solidity: event DelegateChanged(address indexed delegator, address indexed fromDelegate, address indexed toDelegate)
+func (_PCSPToken *PCSPTokenFilterer) ParseDelegateChanged(log types.Log) (*PCSPTokenDelegateChanged, error) {
+	event := new(PCSPTokenDelegateChanged)
+	if err := _PCSPToken.contract.UnpackLog(event, "DelegateChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PCSPTokenDelegateVotesChangedIterator is returned from FilterDelegateVotesChanged and is used to iterate over the raw logs and unpacked data for DelegateVotesChanged events raised by the PCSPToken contract.
+type PCSPTokenDelegateVotesChangedIterator struct {
+	Event *PCSPTokenDelegateVotesChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PCSPTokenDelegateVotesChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PCSPTokenDelegateVotesChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PCSPTokenDelegateVotesChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PCSPTokenDelegateVotesChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PCSPTokenDelegateVotesChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PCSPTokenDelegateVotesChanged represents a DelegateVotesChanged event raised by the PCSPToken contract.
+type PCSPTokenDelegateVotesChanged struct {
+	Delegate        common.Address
+	PreviousBalance *big.Int
+	NewBalance      *big.Int
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterDelegateVotesChanged is a free log retrieval operation binding the contract event 0xdec2bacdd2f05b59de34da9b523dff8be42e5e38e818c82fdb0bae774387a724.
+//
+// Solidity: event DelegateVotesChanged(address indexed delegate, uint256 previousBalance, uint256 newBalance)
+func (_PCSPToken *PCSPTokenFilterer) FilterDelegateVotesChanged(opts *bind.FilterOpts, delegate []common.Address) (*PCSPTokenDelegateVotesChangedIterator, error) {
+
+	var delegateRule []interface{}
+	for _, delegateItem := range delegate {
+		delegateRule = append(delegateRule, delegateItem)
+	}
+
+	logs, sub, err := _PCSPToken.contract.FilterLogs(opts, "DelegateVotesChanged", delegateRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PCSPTokenDelegateVotesChangedIterator{contract: _PCSPToken.contract, event: "DelegateVotesChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchDelegateVotesChanged is a free log subscription operation binding the contract event 0xdec2bacdd2f05b59de34da9b523dff8be42e5e38e818c82fdb0bae774387a724.
+//
+// Solidity: event DelegateVotesChanged(address indexed delegate, uint256 previousBalance, uint256 newBalance)
+func (_PCSPToken *PCSPTokenFilterer) WatchDelegateVotesChanged(opts *bind.WatchOpts, sink chan<- *PCSPTokenDelegateVotesChanged, delegate []common.Address) (event.Subscription, error) {
+
+	var delegateRule []interface{}
+	for _, delegateItem := range delegate {
+		delegateRule = append(delegateRule, delegateItem)
+	}
+
+	logs, sub, err := _PCSPToken.contract.WatchLogs(opts, "DelegateVotesChanged", delegateRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PCSPTokenDelegateVotesChanged)
+				if err := _PCSPToken.contract.UnpackLog(event, "DelegateVotesChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDelegateVotesChanged is a log parse operation binding the contract event 0xdec2bacdd2f05b59de34da9b523dff8be42e5e38e818c82fdb0bae774387a724.
+//
+// Solidity: event DelegateVotesChanged(address indexed delegate, uint256 previousBalance, uint256 newBalance)
+func (_PCSPToken *PCSPTokenFilterer) ParseDelegateVotesChanged(log types.Log) (*PCSPTokenDelegateVotesChanged, error) {
+	event := new(PCSPTokenDelegateVotesChanged)
+	if err := _PCSPToken.contract.UnpackLog(event, "DelegateVotesChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PCSPTokenEIP712DomainChangedIterator is returned from FilterEIP712DomainChanged and is used to iterate over the raw logs and unpacked data for EIP712DomainChanged events raised by the PCSPToken contract.
+type PCSPTokenEIP712DomainChangedIterator struct {
+	Event *PCSPTokenEIP712DomainChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PCSPTokenEIP712DomainChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PCSPTokenEIP712DomainChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PCSPTokenEIP712DomainChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PCSPTokenEIP712DomainChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PCSPTokenEIP712DomainChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PCSPTokenEIP712DomainChanged represents a EIP712DomainChanged event raised by the PCSPToken contract.
+type PCSPTokenEIP712DomainChanged struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterEIP712DomainChanged is a free log retrieval operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_PCSPToken *PCSPTokenFilterer) FilterEIP712DomainChanged(opts *bind.FilterOpts) (*PCSPTokenEIP712DomainChangedIterator, error) {
+
+	logs, sub, err := _PCSPToken.contract.FilterLogs(opts, "EIP712DomainChanged")
+	if err != nil {
+		return nil, err
+	}
+	return &PCSPTokenEIP712DomainChangedIterator{contract: _PCSPToken.contract, event: "EIP712DomainChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchEIP712DomainChanged is a free log subscription operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_PCSPToken *PCSPTokenFilterer) WatchEIP712DomainChanged(opts *bind.WatchOpts, sink chan<- *PCSPTokenEIP712DomainChanged) (event.Subscription, error) {
+
+	logs, sub, err := _PCSPToken.contract.WatchLogs(opts, "EIP712DomainChanged")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PCSPTokenEIP712DomainChanged)
+				if err := _PCSPToken.contract.UnpackLog(event, "EIP712DomainChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEIP712DomainChanged is a log parse operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_PCSPToken *PCSPTokenFilterer) ParseEIP712DomainChanged(log types.Log) (*PCSPTokenEIP712DomainChanged, error) {
+	event := new(PCSPTokenEIP712DomainChanged)
+	if err := _PCSPToken.contract.UnpackLog(event, "EIP712DomainChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PCSPTokenOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the PCSPToken contract.
+type PCSPTokenOwnershipTransferredIterator struct {
+	Event *PCSPTokenOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PCSPTokenOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PCSPTokenOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PCSPTokenOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PCSPTokenOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PCSPTokenOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PCSPTokenOwnershipTransferred represents a OwnershipTransferred event raised by the PCSPToken contract.
+type PCSPTokenOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_PCSPToken *PCSPTokenFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*PCSPTokenOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _PCSPToken.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PCSPTokenOwnershipTransferredIterator{contract: _PCSPToken.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_PCSPToken *PCSPTokenFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *PCSPTokenOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
 		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
 	}
 	var newOwnerRule []interface{}
@@ -935,6 +2165,140 @@ func (_PCSPToken *PCSPTokenFilterer) ParseOwnershipTransferred(log types.Log) (*
 	return event, nil
 }
 
+// PCSPTokenRewardScheduleUpdatedIterator is returned from FilterRewardScheduleUpdated and is used to iterate over the raw logs and unpacked data for RewardScheduleUpdated events raised by the PCSPToken contract.
+type PCSPTokenRewardScheduleUpdatedIterator struct {
+	Event *PCSPTokenRewardScheduleUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PCSPTokenRewardScheduleUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PCSPTokenRewardScheduleUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PCSPTokenRewardScheduleUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PCSPTokenRewardScheduleUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PCSPTokenRewardScheduleUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PCSPTokenRewardScheduleUpdated represents a RewardScheduleUpdated event raised by the PCSPToken contract.
+type PCSPTokenRewardScheduleUpdated struct {
+	Amounts [4]*big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRewardScheduleUpdated is a free log retrieval operation binding the contract event 0x82ee60874d212b423a76225ee1bf9c022555d0e7f7ed6648b3a008c8bdc3aa8b.
+//
+// Solidity: event RewardScheduleUpdated(uint256[4] amounts)
+func (_PCSPToken *PCSPTokenFilterer) FilterRewardScheduleUpdated(opts *bind.FilterOpts) (*PCSPTokenRewardScheduleUpdatedIterator, error) {
+
+	logs, sub, err := _PCSPToken.contract.FilterLogs(opts, "RewardScheduleUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &PCSPTokenRewardScheduleUpdatedIterator{contract: _PCSPToken.contract, event: "RewardScheduleUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchRewardScheduleUpdated is a free log subscription operation binding the contract event 0x82ee60874d212b423a76225ee1bf9c022555d0e7f7ed6648b3a008c8bdc3aa8b.
+//
+// Solidity: event RewardScheduleUpdated(uint256[4] amounts)
+func (_PCSPToken *PCSPTokenFilterer) WatchRewardScheduleUpdated(opts *bind.WatchOpts, sink chan<- *PCSPTokenRewardScheduleUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _PCSPToken.contract.WatchLogs(opts, "RewardScheduleUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PCSPTokenRewardScheduleUpdated)
+				if err := _PCSPToken.contract.UnpackLog(event, "RewardScheduleUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRewardScheduleUpdated is a log parse operation binding the contract event 0x82ee60874d212b423a76225ee1bf9c022555d0e7f7ed6648b3a008c8bdc3aa8b.
+//
+// Solidity: event RewardScheduleUpdated(uint256[4] amounts)
+func (_PCSPToken *PCSPTokenFilterer) ParseRewardScheduleUpdated(log types.Log) (*PCSPTokenRewardScheduleUpdated, error) {
+	event := new(PCSPTokenRewardScheduleUpdated)
+	if err := _PCSPToken.contract.UnpackLog(event, "RewardScheduleUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
 // PCSPTokenTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the PCSPToken contract.
 type PCSPTokenTransferIterator struct {
 	Event *PCSPTokenTransfer // Event containing the contract specifics and raw log
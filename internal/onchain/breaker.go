@@ -0,0 +1,282 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var ErrChainUnavailable = errors.New("blockchain RPC is unavailable")
+
+// Breaker states
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// BreakerConfig opens the breaker after Threshold consecutive failed RPC requests. The node is
+// then probed after MinBackoff, doubling up to MaxBackoff while it stays unreachable.
+type BreakerConfig struct {
+	Threshold  int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// ChainStatus reports whether RPC requests are let through to the node.
+type ChainStatus struct {
+	State     string     `json:"state" example:"closed"`
+	Available bool       `json:"available"`
+	Failures  int        `json:"failures"`
+	LastError string     `json:"lastError,omitempty"`
+	RetryAt   *time.Time `json:"retryAt,omitempty"`
+}
+
+// ChainMonitor tells whether the chain can be reached.
+type ChainMonitor interface {
+	Available() bool
+	Status() ChainStatus
+}
+
+// Breaker is a circuit breaker around the RPC client. It starts open, so nothing reaches the node
+// before the probe has verified it, and closes again each time the probe succeeds.
+type Breaker struct {
+	config  BreakerConfig
+	probe   func(ctx context.Context) error
+	now     func() time.Time
+	wake    chan struct{}
+	recover []func()
+
+	mu        sync.Mutex
+	state     string
+	failures  int
+	backoff   time.Duration
+	retryAt   time.Time
+	lastError string
+}
+
+func NewBreaker(config BreakerConfig, probe func(ctx context.Context) error) *Breaker {
+	b := &Breaker{
+		config:    config,
+		probe:     probe,
+		now:       time.Now,
+		wake:      make(chan struct{}, 1),
+		state:     BreakerOpen,
+		lastError: "not verified yet",
+	}
+	b.retryAt = b.now()
+	b.wake <- struct{}{}
+	return b
+}
+
+// OnRecover registers fn to run each time the breaker closes, before the next probe can start.
+func (b *Breaker) OnRecover(fn func()) {
+	b.recover = append(b.recover, fn)
+}
+
+// Allow fails fast unless the breaker is closed.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != BreakerClosed {
+		return ErrChainUnavailable
+	}
+	return nil
+}
+
+// Failure counts a request the node did not answer, opening the breaker at the threshold.
+func (b *Breaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != BreakerClosed {
+		return
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+	if b.failures >= b.config.Threshold {
+		b.open(b.config.MinBackoff)
+	}
+}
+
+// Success resets the count of consecutive failures.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerClosed {
+		b.failures = 0
+	}
+}
+
+func (b *Breaker) Available() bool {
+	return b.Allow() == nil
+}
+
+func (b *Breaker) Status() ChainStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := ChainStatus{
+		State:     b.state,
+		Available: b.state == BreakerClosed,
+		Failures:  b.failures,
+		LastError: b.lastError,
+	}
+	if b.state == BreakerOpen {
+		retryAt := b.retryAt
+		status.RetryAt = &retryAt
+	}
+	return status
+}
+
+// Run probes the node whenever the breaker is open and its backoff has passed, until ctx is done.
+func (b *Breaker) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.wake:
+		}
+
+		for !b.tryClose(ctx) {
+			b.mu.Lock()
+			wait := b.retryAt.Sub(b.now())
+			b.mu.Unlock()
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+
+		for _, fn := range b.recover {
+			fn()
+		}
+	}
+}
+
+// tryClose probes the node once the backoff has passed, and closes the breaker if it answers.
+func (b *Breaker) tryClose(ctx context.Context) bool {
+	b.mu.Lock()
+	if b.state == BreakerClosed {
+		b.mu.Unlock()
+		return true
+	}
+	if b.now().Before(b.retryAt) {
+		b.mu.Unlock()
+		return false
+	}
+	b.mu.Unlock()
+
+	return b.probeNow(ctx) == nil
+}
+
+// Probe verifies the node at once, without waiting for the backoff, and closes the breaker if it
+// answers. After an error, Run keeps probing as usual.
+func (b *Breaker) Probe(ctx context.Context) error {
+	b.mu.Lock()
+	if b.state == BreakerClosed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.mu.Unlock()
+
+	return b.probeNow(ctx)
+}
+
+// probeNow runs the probe and closes the breaker, or opens it again with a longer backoff.
+func (b *Breaker) probeNow(ctx context.Context) error {
+	b.mu.Lock()
+	b.state = BreakerHalfOpen
+	b.mu.Unlock()
+
+	err := b.probe(probeContext(ctx))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		b.lastError = err.Error()
+		b.open(min(max(2*b.backoff, b.config.MinBackoff), b.config.MaxBackoff))
+		return err
+	}
+
+	b.state = BreakerClosed
+	b.failures = 0
+	b.backoff = 0
+	b.lastError = ""
+	return nil
+}
+
+// open schedules the next probe; the caller holds mu.
+func (b *Breaker) open(backoff time.Duration) {
+	wasClosed := b.state == BreakerClosed
+	b.state = BreakerOpen
+	b.backoff = backoff
+	b.retryAt = b.now().Add(backoff)
+	if wasClosed {
+		select {
+		case b.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+type probeKey struct{}
+
+// Requests made by the probe reach the node while the breaker is not closed.
+func probeContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, probeKey{}, true)
+}
+
+// Transport fails RPC requests fast while the breaker is not closed, and counts the ones the
+// node does not answer.
+func (b *Breaker) Transport(next http.RoundTripper) http.RoundTripper {
+	return &breakerTransport{breaker: b, next: next}
+}
+
+type breakerTransport struct {
+	breaker *Breaker
+	next    http.RoundTripper
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Context().Value(probeKey{}) != nil {
+		return t.next.RoundTrip(req)
+	}
+	if err := t.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// Given up by the caller, which says nothing about the node
+	case err != nil:
+		t.breaker.Failure(err)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		t.breaker.Failure(fmt.Errorf("RPC node answered %s", resp.Status))
+	default:
+		t.breaker.Success()
+	}
+	return resp, err
+}
+
+// Dial creates an RPC client whose HTTP requests go through the breaker. Nothing is sent
+// before the first call, so the node does not have to be reachable.
+func Dial(rawurl string, breaker *Breaker) (*ethclient.Client, error) {
+	httpClient := &http.Client{Transport: breaker.Transport(http.DefaultTransport)}
+	client, err := rpc.DialOptions(context.Background(), rawurl, rpc.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(client), nil
+}
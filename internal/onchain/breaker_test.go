@@ -0,0 +1,135 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	var nodeUp atomic.Bool
+	probes := make(chan error, 10)
+	breaker := NewBreaker(BreakerConfig{Threshold: 2, MinBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}, func(ctx context.Context) error {
+		var err error
+		if !nodeUp.Load() {
+			err = errors.New("connection refused")
+		}
+		probes <- err
+		return err
+	})
+	recovered := make(chan struct{}, 10)
+	breaker.OnRecover(func() { recovered <- struct{}{} })
+
+	if breaker.Available() {
+		t.Fatalf("Expected the breaker to stay open before the first probe")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go breaker.Run(ctx)
+
+	// Probed again with backoff while the node is down
+	for i := 0; i < 3; i++ {
+		if err := <-probes; err == nil {
+			t.Fatalf("Expected probe %d to fail", i)
+		}
+	}
+	if status := breaker.Status(); status.State == BreakerClosed || status.LastError != "connection refused" {
+		t.Errorf("Unexpected status %+v", status)
+	}
+
+	nodeUp.Store(true)
+	<-recovered
+	if !breaker.Available() {
+		t.Fatalf("Expected the breaker to close once the probe succeeds")
+	}
+
+	// Consecutive failures open it again, a success in between resets the count
+	breaker.Failure(errors.New("timeout"))
+	breaker.Success()
+	breaker.Failure(errors.New("timeout"))
+	if !breaker.Available() {
+		t.Fatalf("Expected the breaker to stay closed below the threshold")
+	}
+	breaker.Failure(errors.New("timeout"))
+	if err := breaker.Allow(); !errors.Is(err, ErrChainUnavailable) {
+		t.Errorf("Allow() error = %v, want %v", err, ErrChainUnavailable)
+	}
+
+	<-recovered
+	if !breaker.Available() {
+		t.Errorf("Expected the breaker to close again once the node answers")
+	}
+}
+
+func TestBreakerTransport(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	var requests atomic.Int32
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer node.Close()
+
+	breaker := NewBreaker(BreakerConfig{Threshold: 2, MinBackoff: time.Hour, MaxBackoff: time.Hour}, func(ctx context.Context) error {
+		return nil
+	})
+	client := &http.Client{Transport: breaker.Transport(http.DefaultTransport)}
+
+	// Open until the probe verified the node, except for the probe itself
+	if _, err := client.Get(node.URL); !errors.Is(err, ErrChainUnavailable) {
+		t.Fatalf("Get() error = %v, want %v", err, ErrChainUnavailable)
+	}
+	req, _ := http.NewRequestWithContext(probeContext(context.Background()), http.MethodGet, node.URL, nil)
+	if _, err := client.Do(req); err != nil {
+		t.Fatalf("Probe request error = %v", err)
+	}
+	if !breaker.tryClose(context.Background()) {
+		t.Fatalf("Expected the breaker to close")
+	}
+
+	status.Store(http.StatusBadGateway)
+	for i := 0; i < 2; i++ {
+		if resp, err := client.Get(node.URL); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		} else {
+			resp.Body.Close()
+		}
+	}
+	if _, err := client.Get(node.URL); !errors.Is(err, ErrChainUnavailable) {
+		t.Errorf("Get() error = %v, want %v", err, ErrChainUnavailable)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Node received %d requests, want 3", got)
+	}
+}
+
+func TestBreakerProbe(t *testing.T) {
+	mismatch := errors.New("chain ID does not match")
+	probeErr := mismatch
+	breaker := NewBreaker(BreakerConfig{Threshold: 1, MinBackoff: time.Hour, MaxBackoff: time.Hour}, func(ctx context.Context) error {
+		return probeErr
+	})
+
+	// The error is returned to the caller and the breaker stays open
+	if err := breaker.Probe(context.Background()); !errors.Is(err, mismatch) {
+		t.Fatalf("Probe() error = %v, want %v", err, mismatch)
+	}
+	if status := breaker.Status(); status.State != BreakerOpen || status.LastError != mismatch.Error() {
+		t.Errorf("Unexpected status %+v", status)
+	}
+
+	// Probed at once, without waiting for the backoff
+	probeErr = nil
+	if err := breaker.Probe(context.Background()); err != nil {
+		t.Fatalf("Probe() error = %v", err)
+	}
+	if !breaker.Available() {
+		t.Errorf("Expected the breaker to close once the probe succeeds")
+	}
+}
@@ -8,56 +8,88 @@ import (
 	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 type OnchainService interface {
 	UploadData(docID string) (string, error)
-	ConfirmUpload(docID string, contentHash string, proof string, sessionID string, riskScore int) error
+	ConfirmUpload(docID string, contentHash string, proof string, sessionID string, riskScore int) (*ConfirmResult, error)
 	GetSession(sessionID string) (*contracts.ControllerUploadSession, error)
+	GetTokenDoc(tokenID string) (string, error)
+	GetTokenOwner(tokenID string) (common.Address, error)
+	IsApprovedOperator(tokenID string, owner common.Address, operator common.Address) (bool, error)
+	TransferToken(tokenID string, to common.Address) (string, error)
+	FindUploadSession(docID string) (string, error)
+	FindMintedToken(docID string) (string, error)
+	GetDoc(docID string) (*contracts.ControllerDataDoc, error)
+	FindUploadTx(sessionID string) (*UploadTx, error)
+	FindConfirmTx(docID string) (*ConfirmResult, error)
+	UploadDataBatch(docIDs []string) ([]UploadBatchResult, error)
+	ConfirmBatch(requests []ConfirmRequest) ([]ConfirmBatchResult, error)
+	ServiceAddress() common.Address
 }
 
-type onchainService struct {
-	client     *ethclient.Client
-	auth       *bind.TransactOpts
-	controller *contracts.Controller
-	geneNFT    *contracts.GeneNFT
+// ConfirmResult holds the outcome of a confirmed upload.
+type ConfirmResult struct {
+	TxHash       string
+	TokenID      string
+	RewardAmount string
 }
 
-func NewOnchainService(client *ethclient.Client, auth *bind.TransactOpts, controllerAddr common.Address) OnchainService {
-	controller, err := contracts.NewController(controllerAddr, client)
-	if err != nil {
-		panic(err)
-	}
+// UploadTx is the transaction that opened an upload session.
+type UploadTx struct {
+	TxHash string
+	DocID  string
+}
 
-	nftAddr, err := controller.GeneNFT(nil)
-	if err != nil {
-		panic(err)
-	}
+type onchainService struct {
+	client      *ethclient.Client
+	transactor  *Transactor
+	controller  *contracts.Controller
+	geneNFT     *Linked[contracts.GeneNFT]
+	deployBlock uint64
+}
 
-	geneNFT, err := contracts.NewGeneNFT(nftAddr, client)
+// NewOnchainService binds the Controller; deployBlock is where event lookups start.
+func NewOnchainService(client *ethclient.Client, transactor *Transactor, controllerAddr common.Address, deployBlock uint64) OnchainService {
+	controller, err := contracts.NewController(controllerAddr, transactor.Backend())
 	if err != nil {
 		panic(err)
 	}
 
+	geneNFT := NewLinked(func() (*contracts.GeneNFT, error) {
+		nftAddr, err := controller.GeneNFT(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GeneNFT address: %v", err)
+		}
+		return contracts.NewGeneNFT(nftAddr, transactor.Backend())
+	})
+
 	return &onchainService{
-		client:     client,
-		auth:       auth,
-		controller: controller,
-		geneNFT:    geneNFT,
+		client:      client,
+		transactor:  transactor,
+		controller:  controller,
+		geneNFT:     geneNFT,
+		deployBlock: deployBlock,
 	}
 }
 
 // UploadData starts the upload session on blockchain
 func (s *onchainService) UploadData(docID string) (string, error) {
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return "", err
+	}
+
 	// Call uploadData on controller contract
-	tx, err := s.controller.UploadData(s.auth, docID)
+	tx, err := s.controller.UploadData(opts, docID)
 	if err != nil {
 		return "", fmt.Errorf("failed to initiate upload: %v", err)
 	}
 
 	// Wait for transaction to be mined
-	receipt, err := bind.WaitMined(context.Background(), s.client, tx)
+	receipt, err := s.transactor.WaitMined(tx)
 	if err != nil {
 		return "", fmt.Errorf("failed to wait for transaction: %v", err)
 	}
@@ -73,41 +105,62 @@ func (s *onchainService) UploadData(docID string) (string, error) {
 	return "", fmt.Errorf("failed to get session ID from event")
 }
 
-func (s *onchainService) ConfirmUpload(docID string, contentHash string, proof string, sessionID string, riskScore int) error {
+func (s *onchainService) ConfirmUpload(docID string, contentHash string, proof string, sessionID string, riskScore int) (*ConfirmResult, error) {
 	// parse sessionID to big.Int
 	bigSessionID, ok := new(big.Int).SetString(sessionID, 10)
 	bigRiskScore := big.NewInt(int64(riskScore))
 	if !ok {
-		return fmt.Errorf("failed to parse session ID")
+		return nil, fmt.Errorf("failed to parse session ID")
+	}
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return nil, err
 	}
+
 	// Call confirmUpload on controller contract
-	tx, err := s.controller.Confirm(s.auth, docID, contentHash, proof, bigSessionID, bigRiskScore)
+	tx, err := s.controller.Confirm(opts, docID, contentHash, proof, bigSessionID, bigRiskScore)
 	if err != nil {
-		return fmt.Errorf("failed to confirm upload: %v", err)
+		return nil, fmt.Errorf("failed to confirm upload: %v", err)
 	}
 
 	// Wait for transaction to be mined
-	receipt, err := bind.WaitMined(context.Background(), s.client, tx)
+	receipt, err := s.transactor.WaitMined(tx)
 	if err != nil {
-		return fmt.Errorf("failed to wait for transaction: %v", err)
+		return nil, fmt.Errorf("failed to wait for transaction: %v", err)
 	}
 
 	// Log the transaction hash
 	fmt.Printf("Confirmed upload with tx hash: %s\n", tx.Hash().Hex())
 
-	// Process events
+	result := s.parseConfirmReceipt(receipt, docID)
+	fmt.Printf("Minted GeneNFT with token ID: %s\n", result.TokenID)
+	fmt.Printf("Rewarded PCSP with amount %s to %s\n", result.RewardAmount, opts.From.String())
+	return result, nil
+}
+
+// parseConfirmReceipt reads the token minted and the reward paid for docID from the events of a
+// confirm transaction, which holds several docs when the confirmation was batched.
+func (s *onchainService) parseConfirmReceipt(receipt *types.Receipt, docID string) *ConfirmResult {
+	result := &ConfirmResult{TxHash: receipt.TxHash.Hex()}
+	minted := false
 	for _, log := range receipt.Logs {
 		event, err := s.controller.ParseGeneNFTMinted(*log)
 		if err == nil && event != nil {
-			fmt.Printf("Minted GeneNFT with token ID: %s\n", event.TokenId.String())
+			minted = event.DocId == docID
+			if minted {
+				result.TokenID = event.TokenId.String()
+			}
+			continue
 		}
 
+		// The reward follows the mint of the same doc
 		event2, err := s.controller.ParsePCSPRewarded(*log)
-		if err == nil && event2 != nil {
-			fmt.Printf("Rewarded PCSP with amount %s to %s\n", event2.Amount.String(), event2.User.String())
+		if err == nil && event2 != nil && minted {
+			result.RewardAmount = event2.Amount.String()
+			minted = false
 		}
 	}
-	return nil
+	return result
 }
 
 func (s *onchainService) GetSession(sessionID string) (*contracts.ControllerUploadSession, error) {
@@ -125,3 +178,198 @@ func (s *onchainService) GetSession(sessionID string) (*contracts.ControllerUplo
 
 	return &data, nil
 }
+
+// GetTokenDoc returns the doc ID linked to a GeneNFT token, or an empty string if the token was never minted.
+func (s *onchainService) GetTokenDoc(tokenID string) (string, error) {
+	// parse tokenID to big.Int
+	bigTokenID, ok := new(big.Int).SetString(tokenID, 10)
+	if !ok {
+		return "", fmt.Errorf("failed to parse token ID")
+	}
+
+	docID, err := s.controller.GetTokenDoc(nil, bigTokenID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token doc: %v", err)
+	}
+
+	return docID, nil
+}
+
+// GetTokenOwner returns the current owner of a GeneNFT token.
+func (s *onchainService) GetTokenOwner(tokenID string) (common.Address, error) {
+	bigTokenID, ok := new(big.Int).SetString(tokenID, 10)
+	if !ok {
+		return common.Address{}, fmt.Errorf("failed to parse token ID")
+	}
+
+	geneNFT, err := s.geneNFT.Get()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	owner, err := geneNFT.OwnerOf(nil, bigTokenID)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get token owner: %v", err)
+	}
+
+	return owner, nil
+}
+
+// IsApprovedOperator reports whether operator may act for owner on a GeneNFT token,
+// either through a per-token approval or an approval for all of the owner's tokens.
+func (s *onchainService) IsApprovedOperator(tokenID string, owner common.Address, operator common.Address) (bool, error) {
+	bigTokenID, ok := new(big.Int).SetString(tokenID, 10)
+	if !ok {
+		return false, fmt.Errorf("failed to parse token ID")
+	}
+
+	geneNFT, err := s.geneNFT.Get()
+	if err != nil {
+		return false, err
+	}
+
+	approved, err := geneNFT.GetApproved(nil, bigTokenID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get token approval: %v", err)
+	}
+	if approved == operator {
+		return true, nil
+	}
+
+	approvedForAll, err := geneNFT.IsApprovedForAll(nil, owner, operator)
+	if err != nil {
+		return false, fmt.Errorf("failed to get operator approval: %v", err)
+	}
+
+	return approvedForAll, nil
+}
+
+// TransferToken moves a GeneNFT held by the service wallet to another address.
+func (s *onchainService) TransferToken(tokenID string, to common.Address) (string, error) {
+	bigTokenID, ok := new(big.Int).SetString(tokenID, 10)
+	if !ok {
+		return "", fmt.Errorf("failed to parse token ID")
+	}
+
+	geneNFT, err := s.geneNFT.Get()
+	if err != nil {
+		return "", err
+	}
+
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := geneNFT.SafeTransferFrom(opts, opts.From, to, bigTokenID)
+	if err != nil {
+		return "", fmt.Errorf("failed to transfer token: %v", err)
+	}
+
+	receipt, err := s.transactor.WaitMined(tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to wait for transaction: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return "", fmt.Errorf("token transfer reverted: %s", tx.Hash().Hex())
+	}
+
+	return tx.Hash().Hex(), nil
+}
+
+// ServiceAddress is the wallet that sends the service's transactions.
+func (s *onchainService) ServiceAddress() common.Address {
+	return s.transactor.From()
+}
+
+// FindUploadSession returns the session opened for docID, or an empty string if uploadData was never mined for it.
+func (s *onchainService) FindUploadSession(docID string) (string, error) {
+	// docId is not indexed, so every UploadData event is scanned
+	it, err := s.controller.FilterUploadData(&bind.FilterOpts{Start: s.deployBlock, Context: context.Background()})
+	if err != nil {
+		return "", fmt.Errorf("failed to filter upload sessions: %v", err)
+	}
+	defer it.Close()
+
+	for it.Next() {
+		if it.Event.DocId == docID {
+			return it.Event.SessionId.String(), nil
+		}
+	}
+	if err := it.Error(); err != nil {
+		return "", fmt.Errorf("failed to read upload sessions: %v", err)
+	}
+	return "", nil
+}
+
+// FindMintedToken returns the GeneNFT minted to the service wallet for docID, or an empty string if none was.
+func (s *onchainService) FindMintedToken(docID string) (string, error) {
+	opts := &bind.FilterOpts{Start: s.deployBlock, Context: context.Background()}
+	it, err := s.controller.FilterGeneNFTMinted(opts, []common.Address{s.transactor.From()}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to filter minted tokens: %v", err)
+	}
+	defer it.Close()
+
+	for it.Next() {
+		if it.Event.DocId == docID {
+			return it.Event.TokenId.String(), nil
+		}
+	}
+	if err := it.Error(); err != nil {
+		return "", fmt.Errorf("failed to read minted tokens: %v", err)
+	}
+	return "", nil
+}
+
+// GetDoc returns the doc submitted on-chain; its Id is empty if docID was never confirmed.
+func (s *onchainService) GetDoc(docID string) (*contracts.ControllerDataDoc, error) {
+	doc, err := s.controller.GetDoc(nil, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get doc: %v", err)
+	}
+
+	return &doc, nil
+}
+
+// FindUploadTx returns the transaction that opened sessionID, or nil if none was mined.
+func (s *onchainService) FindUploadTx(sessionID string) (*UploadTx, error) {
+	it, err := s.controller.FilterUploadData(&bind.FilterOpts{Start: s.deployBlock, Context: context.Background()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter upload sessions: %v", err)
+	}
+	defer it.Close()
+
+	for it.Next() {
+		if it.Event.SessionId.String() == sessionID {
+			return &UploadTx{TxHash: it.Event.Raw.TxHash.Hex(), DocID: it.Event.DocId}, nil
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read upload sessions: %v", err)
+	}
+	return nil, nil
+}
+
+// FindConfirmTx returns the confirmation of docID with its minted token and reward, or nil if none was mined.
+func (s *onchainService) FindConfirmTx(docID string) (*ConfirmResult, error) {
+	opts := &bind.FilterOpts{Start: s.deployBlock, Context: context.Background()}
+	it, err := s.controller.FilterGeneDataSubmitted(opts, []string{docID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter doc submissions: %v", err)
+	}
+	defer it.Close()
+
+	if !it.Next() {
+		if err := it.Error(); err != nil {
+			return nil, fmt.Errorf("failed to read doc submissions: %v", err)
+		}
+		return nil, nil
+	}
+
+	receipt, err := s.client.TransactionReceipt(context.Background(), it.Event.Raw.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirm receipt: %v", err)
+	}
+	return s.parseConfirmReceipt(receipt, docID), nil
+}
@@ -0,0 +1,80 @@
+package onchain
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+var ErrInvalidAuthorization = errors.New("signature does not match the authorizer")
+
+// EIP712Domain is the signing domain a contract verifies typed data against.
+type EIP712Domain struct {
+	Name              string         `json:"name"`
+	Version           string         `json:"version"`
+	ChainID           *big.Int       `json:"chainId" swaggertype:"integer"`
+	VerifyingContract common.Address `json:"verifyingContract" swaggertype:"string"`
+}
+
+var eip712DomainType = []apitypes.Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+}
+
+// TypedData wraps a message of primaryType in the domain.
+func (d EIP712Domain) TypedData(primaryType string, fields []apitypes.Type, message apitypes.TypedDataMessage) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": eip712DomainType,
+			primaryType:    fields,
+		},
+		PrimaryType: primaryType,
+		Domain: apitypes.TypedDataDomain{
+			Name:              d.Name,
+			Version:           d.Version,
+			ChainId:           (*math.HexOrDecimal256)(d.ChainID),
+			VerifyingContract: d.VerifyingContract.Hex(),
+		},
+		Message: message,
+	}
+}
+
+// TypedSignature is a signature split into the v, r, s form contracts take.
+type TypedSignature struct {
+	V uint8
+	R [32]byte
+	S [32]byte
+}
+
+// VerifyTypedData checks that typedData was signed by signer and returns the split signature.
+func VerifyTypedData(typedData apitypes.TypedData, signer common.Address, signature string) (*TypedSignature, error) {
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return nil, ErrInvalidAuthorization
+	}
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(digest, sig)
+	if err != nil || crypto.PubkeyToAddress(*pubKey) != signer {
+		return nil, ErrInvalidAuthorization
+	}
+
+	split := &TypedSignature{V: sig[crypto.RecoveryIDOffset] + 27}
+	copy(split.R[:], sig[:32])
+	copy(split.S[:], sig[32:64])
+	return split, nil
+}
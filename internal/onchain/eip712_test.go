@@ -0,0 +1,54 @@
+package onchain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func TestVerifyTypedData(t *testing.T) {
+	holder, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+	holderAddr := crypto.PubkeyToAddress(holder.PublicKey)
+
+	domain := EIP712Domain{
+		Name:              "Post-Covid Stroke Prevention",
+		Version:           "1",
+		ChainID:           big.NewInt(9999),
+		VerifyingContract: common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3"),
+	}
+	typedData := domain.TypedData("BurnWithAuthorization", burnWithAuthorizationType, apitypes.TypedDataMessage{
+		"from":        holderAddr.Hex(),
+		"value":       big.NewInt(1000),
+		"validAfter":  big.NewInt(0),
+		"validBefore": big.NewInt(1734280000),
+		"nonce":       common.HexToHash("0x01").Hex(),
+	})
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		t.Fatalf("Failed to hash typed data: %v", err)
+	}
+
+	holderSig, _ := crypto.Sign(digest, holder)
+	otherSig, _ := crypto.Sign(digest, other)
+
+	split, err := VerifyTypedData(typedData, holderAddr, hexutil.Encode(holderSig))
+	if err != nil {
+		t.Fatalf("Expected holder signature to verify: %v", err)
+	}
+	if split.V != holderSig[64]+27 || common.Hash(split.R) != common.BytesToHash(holderSig[:32]) {
+		t.Errorf("Signature split into wrong v, r, s")
+	}
+
+	if _, err := VerifyTypedData(typedData, holderAddr, hexutil.Encode(otherSig)); !errors.Is(err, ErrInvalidAuthorization) {
+		t.Errorf("Expected ErrInvalidAuthorization for foreign signature, got %v", err)
+	}
+	if _, err := VerifyTypedData(typedData, holderAddr, "0xdead"); !errors.Is(err, ErrInvalidAuthorization) {
+		t.Errorf("Expected ErrInvalidAuthorization for malformed signature, got %v", err)
+	}
+}
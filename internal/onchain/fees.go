@@ -0,0 +1,188 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrFeeCeiling = errors.New("network fees exceed the configured ceiling")
+
+// FeeConfig is the fee policy applied to every transaction sent by the service wallet.
+type FeeConfig struct {
+	// Legacy sends gasPrice transactions, for subnets without EIP-1559
+	Legacy bool
+	// MaxFeeCap bounds maxFeePerGas, or gasPrice in legacy mode; nil means no ceiling
+	MaxFeeCap *big.Int
+	// MaxTipCap bounds maxPriorityFeePerGas; nil means no ceiling
+	MaxTipCap *big.Int
+	// TipPercentile of recent priority fees, read from eth_feeHistory
+	TipPercentile float64
+	HistoryBlocks uint64
+	// GasMargin is the percentage added on top of the gas estimate
+	GasMargin uint64
+}
+
+func DefaultFeeConfig() FeeConfig {
+	return FeeConfig{
+		TipPercentile: 50,
+		HistoryBlocks: 20,
+		GasMargin:     20,
+	}
+}
+
+// Client is the node API the transactor needs. *ethclient.Client satisfies it, as does the
+// simulated backend used in tests.
+type Client interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ethereum.BlockNumberReader
+	ethereum.FeeHistoryReader
+}
+
+// Transactor hands out transact options priced by the fee policy and waits for the configured
+// confirmation depth, where 1 means the transaction is included in a block.
+type Transactor struct {
+	client        Client
+	auth          *bind.TransactOpts
+	config        FeeConfig
+	confirmations uint64
+}
+
+func NewTransactor(client Client, auth *bind.TransactOpts, config FeeConfig, confirmations uint64) *Transactor {
+	return &Transactor{
+		client:        client,
+		auth:          auth,
+		config:        config,
+		confirmations: confirmations,
+	}
+}
+
+// From is the service wallet address.
+func (t *Transactor) From() common.Address {
+	return t.auth.From
+}
+
+// Confirmations is the depth at which a block is no longer expected to be reorganised, at least 1.
+func (t *Transactor) Confirmations() uint64 {
+	if t.confirmations == 0 {
+		return 1
+	}
+	return t.confirmations
+}
+
+// Backend is the contract backend bindings should use, so gas estimates carry the safety margin.
+func (t *Transactor) Backend() bind.ContractBackend {
+	return &marginBackend{Client: t.client, margin: t.config.GasMargin}
+}
+
+// Opts returns a copy of the transact options with fees set for the current network conditions.
+func (t *Transactor) Opts() (*bind.TransactOpts, error) {
+	ctx := context.Background()
+	opts := *t.auth
+	opts.Context = ctx
+
+	header, err := t.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %v", err)
+	}
+
+	if t.config.Legacy || header.BaseFee == nil {
+		price, err := t.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to suggest gas price: %v", err)
+		}
+		if opts.GasPrice, err = legacyPrice(t.config, price); err != nil {
+			return nil, err
+		}
+		return &opts, nil
+	}
+
+	tip, err := t.suggestTip(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if opts.GasFeeCap, opts.GasTipCap, err = dynamicFees(t.config, header.BaseFee, tip); err != nil {
+		return nil, err
+	}
+	return &opts, nil
+}
+
+// suggestTip takes the configured percentile of recent priority fees, falling back to the node's suggestion.
+func (t *Transactor) suggestTip(ctx context.Context) (*big.Int, error) {
+	history, err := t.client.FeeHistory(ctx, t.config.HistoryBlocks, nil, []float64{t.config.TipPercentile})
+	if err == nil {
+		if tip := medianReward(history.Reward); tip != nil {
+			return tip, nil
+		}
+	}
+
+	tip, err := t.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest priority fee: %v", err)
+	}
+	return tip, nil
+}
+
+// medianReward is the median over blocks of the single requested reward percentile.
+func medianReward(rewards [][]*big.Int) *big.Int {
+	var tips []*big.Int
+	for _, reward := range rewards {
+		if len(reward) > 0 && reward[0] != nil {
+			tips = append(tips, reward[0])
+		}
+	}
+	if len(tips) == 0 {
+		return nil
+	}
+
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+	return new(big.Int).Set(tips[len(tips)/2])
+}
+
+// dynamicFees allows the base fee to double before the transaction is priced out, within the ceilings.
+func dynamicFees(config FeeConfig, baseFee *big.Int, tip *big.Int) (*big.Int, *big.Int, error) {
+	tipCap := new(big.Int).Set(tip)
+	if config.MaxTipCap != nil && tipCap.Cmp(config.MaxTipCap) > 0 {
+		tipCap.Set(config.MaxTipCap)
+	}
+
+	feeCap := new(big.Int).Mul(baseFee, big.NewInt(2))
+	feeCap.Add(feeCap, tipCap)
+	if config.MaxFeeCap != nil && feeCap.Cmp(config.MaxFeeCap) > 0 {
+		// Still includable as long as the current base fee fits under the ceiling
+		if new(big.Int).Add(baseFee, tipCap).Cmp(config.MaxFeeCap) > 0 {
+			return nil, nil, fmt.Errorf("%w: base fee %s wei", ErrFeeCeiling, baseFee)
+		}
+		feeCap.Set(config.MaxFeeCap)
+	}
+
+	return feeCap, tipCap, nil
+}
+
+func legacyPrice(config FeeConfig, price *big.Int) (*big.Int, error) {
+	if config.MaxFeeCap != nil && price.Cmp(config.MaxFeeCap) > 0 {
+		return nil, fmt.Errorf("%w: gas price %s wei", ErrFeeCeiling, price)
+	}
+	return price, nil
+}
+
+// marginBackend pads gas estimates so small state changes between estimation and inclusion do not run out of gas.
+type marginBackend struct {
+	Client
+	margin uint64
+}
+
+func (b *marginBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	gas, err := b.Client.EstimateGas(ctx, call)
+	if err != nil {
+		return 0, err
+	}
+	return gas + gas*b.margin/100, nil
+}
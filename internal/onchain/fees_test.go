@@ -0,0 +1,89 @@
+package onchain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e9))
+}
+
+func TestDynamicFees(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     FeeConfig
+		baseFee    *big.Int
+		tip        *big.Int
+		wantFeeCap *big.Int
+		wantTipCap *big.Int
+		wantErr    error
+	}{
+		{
+			name:       "No ceilings",
+			baseFee:    gwei(25),
+			tip:        gwei(2),
+			wantFeeCap: gwei(52),
+			wantTipCap: gwei(2),
+		},
+		{
+			name:       "Tip above ceiling",
+			config:     FeeConfig{MaxTipCap: gwei(1)},
+			baseFee:    gwei(25),
+			tip:        gwei(5),
+			wantFeeCap: gwei(51),
+			wantTipCap: gwei(1),
+		},
+		{
+			name:       "Fee cap clamped to ceiling",
+			config:     FeeConfig{MaxFeeCap: gwei(40)},
+			baseFee:    gwei(25),
+			tip:        gwei(2),
+			wantFeeCap: gwei(40),
+			wantTipCap: gwei(2),
+		},
+		{
+			name:    "Base fee above ceiling",
+			config:  FeeConfig{MaxFeeCap: gwei(40)},
+			baseFee: gwei(39),
+			tip:     gwei(2),
+			wantErr: ErrFeeCeiling,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feeCap, tipCap, err := dynamicFees(tt.config, tt.baseFee, tt.tip)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("dynamicFees() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if feeCap.Cmp(tt.wantFeeCap) != 0 || tipCap.Cmp(tt.wantTipCap) != 0 {
+				t.Errorf("dynamicFees() = (%s, %s), want (%s, %s)", feeCap, tipCap, tt.wantFeeCap, tt.wantTipCap)
+			}
+		})
+	}
+}
+
+func TestLegacyPrice(t *testing.T) {
+	config := FeeConfig{MaxFeeCap: gwei(50)}
+	if price, err := legacyPrice(config, gwei(30)); err != nil || price.Cmp(gwei(30)) != 0 {
+		t.Errorf("legacyPrice() = %v, %v, want 30 gwei", price, err)
+	}
+	if _, err := legacyPrice(config, gwei(60)); !errors.Is(err, ErrFeeCeiling) {
+		t.Errorf("Expected ErrFeeCeiling, got %v", err)
+	}
+}
+
+func TestMedianReward(t *testing.T) {
+	rewards := [][]*big.Int{{gwei(3)}, {gwei(1)}, {}, {gwei(2)}}
+	if tip := medianReward(rewards); tip.Cmp(gwei(2)) != 0 {
+		t.Errorf("medianReward() = %s, want 2 gwei", tip)
+	}
+	if tip := medianReward(nil); tip != nil {
+		t.Errorf("Expected no tip for empty history, got %s", tip)
+	}
+}
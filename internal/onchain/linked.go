@@ -0,0 +1,33 @@
+package onchain
+
+import (
+	"sync"
+)
+
+// Linked binds a contract whose address is read from another contract. The address is read on
+// first use and kept once found, so services can be created while the chain is unreachable.
+type Linked[T any] struct {
+	resolve func() (*T, error)
+
+	mu    sync.Mutex
+	bound *T
+}
+
+func NewLinked[T any](resolve func() (*T, error)) *Linked[T] {
+	return &Linked[T]{resolve: resolve}
+}
+
+func (l *Linked[T]) Get() (*T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.bound != nil {
+		return l.bound, nil
+	}
+
+	bound, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+	l.bound = bound
+	return bound, nil
+}
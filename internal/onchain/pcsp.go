@@ -1,37 +1,386 @@
 package onchain
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"sort"
+	"time"
 
 	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
+// MaxTransferPageSize caps the page size of the transfer history.
+const MaxTransferPageSize = 100
+
+var ErrZeroValue = errors.New("value must be positive")
+
+// PcspRelayConfig bounds the holder-signed operations the service wallet pays gas for, like the
+// quota and denylist of RelayerConfig.
+type PcspRelayConfig struct {
+	QuotaPerWindow       int
+	GlobalQuotaPerWindow int
+	QuotaWindow          time.Duration
+	Denylist             []common.Address
+}
+
 type pcspService struct {
-	client    *ethclient.Client
-	auth      *bind.TransactOpts
-	pcspToken *contracts.PCSPToken
+	client      *ethclient.Client
+	transactor  *Transactor
+	pcspToken   *contracts.PCSPToken
+	tokenAddr   common.Address
+	deployBlock uint64
+	denylist    map[common.Address]bool
+	quota       *relayQuota
 }
 
 type PcspService interface {
 	GetTokenBalance(address *common.Address) (*big.Int, error)
+	GetAllowance(owner common.Address, spender common.Address) (*big.Int, error)
+	GetTotalSupply() (*big.Int, error)
+	GetDecimals() (uint8, error)
+	GetDomain() (*EIP712Domain, error)
+	GetPermitNonce(owner common.Address) (*big.Int, error)
+	Permit(req *PermitRequest) (*TxResult, error)
+	TransferWithAuthorization(req *TransferAuthorization) (*TxResult, error)
+	BurnWithAuthorization(req *BurnAuthorization) (*TxResult, error)
+	GetTransferHistory(address common.Address, page int, pageSize int) ([]TransferRecord, int, error)
+}
+
+// PermitRequest is an EIP-2612 approval signed by the token owner.
+type PermitRequest struct {
+	Owner     common.Address
+	Spender   common.Address
+	Value     *big.Int
+	Deadline  *big.Int
+	Signature string
+}
+
+// TransferAuthorization is a transfer signed by the token holder.
+type TransferAuthorization struct {
+	From        common.Address
+	To          common.Address
+	Value       *big.Int
+	ValidAfter  *big.Int
+	ValidBefore *big.Int
+	Nonce       common.Hash
+	Signature   string
 }
 
-func NewPcspService(client *ethclient.Client, auth *bind.TransactOpts, pcspTokenAddr common.Address) *pcspService {
-	pcspToken, err := contracts.NewPCSPToken(pcspTokenAddr, client)
+// BurnAuthorization is a burn signed by the token holder.
+type BurnAuthorization struct {
+	From        common.Address
+	Value       *big.Int
+	ValidAfter  *big.Int
+	ValidBefore *big.Int
+	Nonce       common.Hash
+	Signature   string
+}
+
+// TransferRecord is a single PCSP Transfer event.
+type TransferRecord struct {
+	TxHash      string `json:"txHash"`
+	BlockNumber uint64 `json:"blockNumber"`
+	LogIndex    uint   `json:"logIndex"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Value       string `json:"value"`
+}
+
+var (
+	permitType = []apitypes.Type{
+		{Name: "owner", Type: "address"},
+		{Name: "spender", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "deadline", Type: "uint256"},
+	}
+	transferWithAuthorizationType = []apitypes.Type{
+		{Name: "from", Type: "address"},
+		{Name: "to", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "validAfter", Type: "uint256"},
+		{Name: "validBefore", Type: "uint256"},
+		{Name: "nonce", Type: "bytes32"},
+	}
+	burnWithAuthorizationType = []apitypes.Type{
+		{Name: "from", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "validAfter", Type: "uint256"},
+		{Name: "validBefore", Type: "uint256"},
+		{Name: "nonce", Type: "bytes32"},
+	}
+)
+
+// NewPcspService binds the PCSP token; deployBlock is where the transfer history scan starts.
+func NewPcspService(client *ethclient.Client, transactor *Transactor, pcspTokenAddr common.Address, deployBlock uint64, config PcspRelayConfig) *pcspService {
+	pcspToken, err := contracts.NewPCSPToken(pcspTokenAddr, transactor.Backend())
 	if err != nil {
 		panic(err)
 	}
 
+	denylist := make(map[common.Address]bool)
+	for _, address := range config.Denylist {
+		denylist[address] = true
+	}
+
 	return &pcspService{
-		client:    client,
-		auth:      auth,
-		pcspToken: pcspToken,
+		client:      client,
+		transactor:  transactor,
+		pcspToken:   pcspToken,
+		tokenAddr:   pcspTokenAddr,
+		deployBlock: deployBlock,
+		denylist:    denylist,
+		quota:       newRelayQuota(config.QuotaPerWindow, config.GlobalQuotaPerWindow, config.QuotaWindow),
 	}
 }
 
 func (s *pcspService) GetTokenBalance(address *common.Address) (*big.Int, error) {
 	return s.pcspToken.BalanceOf(nil, *address)
 }
+
+func (s *pcspService) GetAllowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return s.pcspToken.Allowance(nil, owner, spender)
+}
+
+func (s *pcspService) GetTotalSupply() (*big.Int, error) {
+	return s.pcspToken.TotalSupply(nil)
+}
+
+func (s *pcspService) GetDecimals() (uint8, error) {
+	return s.pcspToken.Decimals(nil)
+}
+
+// GetDomain returns the EIP-712 domain wallets must sign PCSP authorizations under.
+func (s *pcspService) GetDomain() (*EIP712Domain, error) {
+	domain, err := s.pcspToken.Eip712Domain(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EIP-712 domain: %v", err)
+	}
+
+	return &EIP712Domain{
+		Name:              domain.Name,
+		Version:           domain.Version,
+		ChainID:           domain.ChainId,
+		VerifyingContract: domain.VerifyingContract,
+	}, nil
+}
+
+func (s *pcspService) GetPermitNonce(owner common.Address) (*big.Int, error) {
+	return s.pcspToken.Nonces(nil, owner)
+}
+
+// Permit relays an owner-signed EIP-2612 approval.
+func (s *pcspService) Permit(req *PermitRequest) (*TxResult, error) {
+	if s.denylist[req.Owner] {
+		return nil, ErrRelayDenied
+	}
+	domain, err := s.GetDomain()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := s.GetPermitNonce(req.Owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permit nonce: %v", err)
+	}
+
+	sig, err := VerifyTypedData(domain.TypedData("Permit", permitType, apitypes.TypedDataMessage{
+		"owner":    req.Owner.Hex(),
+		"spender":  req.Spender.Hex(),
+		"value":    req.Value,
+		"nonce":    nonce,
+		"deadline": req.Deadline,
+	}), req.Owner, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.allow(req.Owner); err != nil {
+		return nil, err
+	}
+
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pcspToken.Permit(opts, req.Owner, req.Spender, req.Value, req.Deadline, sig.V, sig.R, sig.S)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit permit: %v", err)
+	}
+
+	return s.transactor.WaitForTx(tx)
+}
+
+// TransferWithAuthorization relays a holder-signed transfer.
+func (s *pcspService) TransferWithAuthorization(req *TransferAuthorization) (*TxResult, error) {
+	if err := s.checkPolicy(req.From, req.Value); err != nil {
+		return nil, err
+	}
+	domain, err := s.GetDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := VerifyTypedData(domain.TypedData("TransferWithAuthorization", transferWithAuthorizationType, apitypes.TypedDataMessage{
+		"from":        req.From.Hex(),
+		"to":          req.To.Hex(),
+		"value":       req.Value,
+		"validAfter":  req.ValidAfter,
+		"validBefore": req.ValidBefore,
+		"nonce":       req.Nonce.Hex(),
+	}), req.From, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.allow(req.From); err != nil {
+		return nil, err
+	}
+
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pcspToken.TransferWithAuthorization(opts, req.From, req.To, req.Value, req.ValidAfter, req.ValidBefore, req.Nonce, sig.V, sig.R, sig.S)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit transfer: %v", err)
+	}
+
+	return s.transactor.WaitForTx(tx)
+}
+
+// BurnWithAuthorization relays a holder-signed burn.
+func (s *pcspService) BurnWithAuthorization(req *BurnAuthorization) (*TxResult, error) {
+	if err := s.checkPolicy(req.From, req.Value); err != nil {
+		return nil, err
+	}
+	domain, err := s.GetDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := VerifyTypedData(domain.TypedData("BurnWithAuthorization", burnWithAuthorizationType, apitypes.TypedDataMessage{
+		"from":        req.From.Hex(),
+		"value":       req.Value,
+		"validAfter":  req.ValidAfter,
+		"validBefore": req.ValidBefore,
+		"nonce":       req.Nonce.Hex(),
+	}), req.From, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.allow(req.From); err != nil {
+		return nil, err
+	}
+
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pcspToken.BurnWithAuthorization(opts, req.From, req.Value, req.ValidAfter, req.ValidBefore, req.Nonce, sig.V, sig.R, sig.S)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit burn: %v", err)
+	}
+
+	return s.transactor.WaitForTx(tx)
+}
+
+// checkPolicy rejects transfers and burns the service will not pay gas for, before any RPC is made.
+func (s *pcspService) checkPolicy(from common.Address, value *big.Int) error {
+	if s.denylist[from] {
+		return ErrRelayDenied
+	}
+	if value == nil || value.Sign() <= 0 {
+		return ErrZeroValue
+	}
+	return nil
+}
+
+// allow counts a validly signed operation against the signer's quota and the global one.
+func (s *pcspService) allow(signer common.Address) error {
+	return s.quota.allow(signer, time.Now())
+}
+
+// GetTransferHistory returns one page of transfers to or from address, newest first, and the total count.
+func (s *pcspService) GetTransferHistory(address common.Address, page int, pageSize int) ([]TransferRecord, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > MaxTransferPageSize {
+		pageSize = MaxTransferPageSize
+	}
+
+	opts := &bind.FilterOpts{Start: s.deployBlock, Context: context.Background()}
+
+	// Topics are ANDed, so incoming and outgoing transfers need separate queries
+	sent, err := s.filterTransfers(opts, []common.Address{address}, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	received, err := s.filterTransfers(opts, nil, []common.Address{address})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	seen := make(map[string]bool)
+	records := make([]TransferRecord, 0, len(sent)+len(received))
+	for _, record := range append(sent, received...) {
+		// Self-transfers match both queries
+		key := fmt.Sprintf("%s:%d", record.TxHash, record.LogIndex)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].BlockNumber != records[j].BlockNumber {
+			return records[i].BlockNumber > records[j].BlockNumber
+		}
+		return records[i].LogIndex > records[j].LogIndex
+	})
+
+	total := len(records)
+	start := (page - 1) * pageSize
+	if start >= total {
+		return []TransferRecord{}, total, nil
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+
+	return records[start:end], total, nil
+}
+
+func (s *pcspService) filterTransfers(opts *bind.FilterOpts, from []common.Address, to []common.Address) ([]TransferRecord, error) {
+	it, err := s.pcspToken.FilterTransfer(opts, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter transfers: %v", err)
+	}
+	defer it.Close()
+
+	var records []TransferRecord
+	for it.Next() {
+		records = append(records, TransferRecord{
+			TxHash:      it.Event.Raw.TxHash.Hex(),
+			BlockNumber: it.Event.Raw.BlockNumber,
+			LogIndex:    it.Event.Raw.Index,
+			From:        it.Event.From.Hex(),
+			To:          it.Event.To.Hex(),
+			Value:       it.Event.Value.String(),
+		})
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read transfers: %v", err)
+	}
+
+	return records, nil
+}
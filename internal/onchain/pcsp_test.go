@@ -0,0 +1,92 @@
+package onchain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPcspService_RelayPolicy(t *testing.T) {
+	user := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	denied := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	// Without a bound token, any request that got past the policy would panic on its first RPC
+	service := &pcspService{
+		denylist: map[common.Address]bool{denied: true},
+		quota:    newRelayQuota(1, 0, time.Hour),
+	}
+
+	tests := []struct {
+		name    string
+		run     func() error
+		wantErr error
+	}{
+		{
+			name: "Zero-value transfer",
+			run: func() error {
+				_, err := service.TransferWithAuthorization(&TransferAuthorization{From: user, To: user, Value: big.NewInt(0)})
+				return err
+			},
+			wantErr: ErrZeroValue,
+		},
+		{
+			name: "Transfer without value",
+			run: func() error {
+				_, err := service.TransferWithAuthorization(&TransferAuthorization{From: user, To: user})
+				return err
+			},
+			wantErr: ErrZeroValue,
+		},
+		{
+			name: "Zero-value burn",
+			run: func() error {
+				_, err := service.BurnWithAuthorization(&BurnAuthorization{From: user, Value: big.NewInt(0)})
+				return err
+			},
+			wantErr: ErrZeroValue,
+		},
+		{
+			name: "Denylisted transfer",
+			run: func() error {
+				_, err := service.TransferWithAuthorization(&TransferAuthorization{From: denied, To: user, Value: big.NewInt(1)})
+				return err
+			},
+			wantErr: ErrRelayDenied,
+		},
+		{
+			name: "Denylisted burn",
+			run: func() error {
+				_, err := service.BurnWithAuthorization(&BurnAuthorization{From: denied, Value: big.NewInt(1)})
+				return err
+			},
+			wantErr: ErrRelayDenied,
+		},
+		{
+			name: "Denylisted permit",
+			run: func() error {
+				_, err := service.Permit(&PermitRequest{Owner: denied, Spender: user, Value: big.NewInt(1)})
+				return err
+			},
+			wantErr: ErrRelayDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Signed operations share the signer's quota
+	if err := service.allow(user); err != nil {
+		t.Errorf("allow() error = %v", err)
+	}
+	if err := service.allow(user); !errors.Is(err, ErrRelayQuota) {
+		t.Errorf("allow() error = %v, want %v", err, ErrRelayQuota)
+	}
+}
@@ -0,0 +1,386 @@
+package onchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+var (
+	ErrRelayDenied     = errors.New("sender is denylisted")
+	ErrRelayQuota      = errors.New("relay quota exceeded")
+	ErrRelayCapacity   = errors.New("relay capacity exceeded, try again later")
+	ErrRelayTarget     = errors.New("target is not relayable")
+	ErrRelayFunction   = errors.New("function is not relayable")
+	ErrRelayValue      = errors.New("relayed calls cannot carry value")
+	ErrRelayGas        = errors.New("requested gas exceeds the relay limit")
+	ErrRelayNonce      = errors.New("forwarder nonce mismatch")
+	ErrRelaySimulation = errors.New("relayed call would fail")
+	ErrRelayNotFound   = errors.New("relay not found")
+)
+
+const (
+	RelayStatusPending = "pending"
+	RelayStatusFailed  = "failed"
+
+	// relayOverheadGas covers the forwarder's own work on top of the gas forwarded to the target
+	relayOverheadGas = 100000
+)
+
+// RelayerConfig bounds what the relayer will pay gas for. GlobalQuotaPerWindow caps the relays of
+// all senders together, so fresh addresses cannot get around the per-sender quota. Settled relays
+// are tracked for RecordTTL.
+type RelayerConfig struct {
+	ForwarderAddress     common.Address
+	AllowedTargets       []RelayTarget
+	MaxGas               uint64
+	QuotaPerWindow       int
+	GlobalQuotaPerWindow int
+	QuotaWindow          time.Duration
+	Denylist             []common.Address
+	RecordTTL            time.Duration
+}
+
+// RelayTarget is a contract the relayer calls, and the functions of it, by selector, it may call.
+type RelayTarget struct {
+	Address   common.Address
+	Selectors [][4]byte
+}
+
+// ControllerRelayTarget lets users open upload sessions on the Controller at controller. Nothing
+// else is relayed there: confirming an upload mints a GeneNFT and rewards PCSP for the risk score,
+// which only the service wallet may vouch for.
+func ControllerRelayTarget(controller common.Address) RelayTarget {
+	controllerABI, err := contracts.ControllerMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return RelayTarget{
+		Address:   controller,
+		Selectors: [][4]byte{[4]byte(controllerABI.Methods["uploadData"].ID)},
+	}
+}
+
+// ForwardRequest is the EIP-2771 request a user signs for the trusted forwarder.
+type ForwardRequest struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Gas   *big.Int
+	Nonce *big.Int
+	Data  []byte
+}
+
+// RelayRecord tracks a relayed request from submission to its final status.
+type RelayRecord struct {
+	TxHash      string    `json:"txHash"`
+	From        string    `json:"from"`
+	To          string    `json:"to"`
+	Nonce       string    `json:"nonce"`
+	Status      string    `json:"status" example:"pending"`
+	BlockNumber uint64    `json:"blockNumber,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	SubmittedAt time.Time `json:"submittedAt"`
+
+	settledAt time.Time
+}
+
+type RelayerService interface {
+	GetNonce(from common.Address) (*big.Int, error)
+	Relay(req *ForwardRequest, signature string) (*RelayRecord, error)
+	GetRelay(txHash string) (*RelayRecord, error)
+}
+
+type relayerService struct {
+	client     *ethclient.Client
+	transactor *Transactor
+	forwarder  *contracts.Forwarder
+	config     RelayerConfig
+	targets    map[common.Address]map[[4]byte]bool
+	denylist   map[common.Address]bool
+	quota      *relayQuota
+
+	mu     sync.Mutex
+	relays map[string]*RelayRecord
+}
+
+var forwardRequestType = []apitypes.Type{
+	{Name: "from", Type: "address"},
+	{Name: "to", Type: "address"},
+	{Name: "value", Type: "uint256"},
+	{Name: "gas", Type: "uint256"},
+	{Name: "nonce", Type: "uint256"},
+	{Name: "data", Type: "bytes"},
+}
+
+func NewRelayerService(client *ethclient.Client, transactor *Transactor, config RelayerConfig) RelayerService {
+	forwarder, err := contracts.NewForwarder(config.ForwarderAddress, transactor.Backend())
+	if err != nil {
+		panic(err)
+	}
+
+	targets := make(map[common.Address]map[[4]byte]bool)
+	for _, target := range config.AllowedTargets {
+		selectors := make(map[[4]byte]bool)
+		for _, selector := range target.Selectors {
+			selectors[selector] = true
+		}
+		targets[target.Address] = selectors
+	}
+	denylist := make(map[common.Address]bool)
+	for _, address := range config.Denylist {
+		denylist[address] = true
+	}
+
+	return &relayerService{
+		client:     client,
+		transactor: transactor,
+		forwarder:  forwarder,
+		config:     config,
+		targets:    targets,
+		denylist:   denylist,
+		quota:      newRelayQuota(config.QuotaPerWindow, config.GlobalQuotaPerWindow, config.QuotaWindow),
+		relays:     make(map[string]*RelayRecord),
+	}
+}
+
+func (s *relayerService) GetNonce(from common.Address) (*big.Int, error) {
+	return s.forwarder.GetNonce(nil, from)
+}
+
+// Relay verifies a signed forward request, simulates it and submits it through the forwarder.
+// The returned record is pending; GetRelay reports the final status once the transaction is mined.
+func (s *relayerService) Relay(req *ForwardRequest, signature string) (*RelayRecord, error) {
+	if err := s.checkPolicy(req); err != nil {
+		return nil, err
+	}
+
+	domain, err := s.forwarder.Eip712Domain(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forwarder domain: %v", err)
+	}
+	typedData := EIP712Domain{
+		Name:              domain.Name,
+		Version:           domain.Version,
+		ChainID:           domain.ChainId,
+		VerifyingContract: domain.VerifyingContract,
+	}.TypedData("ForwardRequest", forwardRequestType, apitypes.TypedDataMessage{
+		"from":  req.From.Hex(),
+		"to":    req.To.Hex(),
+		"value": req.Value,
+		"gas":   req.Gas,
+		"nonce": req.Nonce,
+		"data":  hexutil.Encode(req.Data),
+	})
+	if _, err := VerifyTypedData(typedData, req.From, signature); err != nil {
+		return nil, err
+	}
+
+	nonce, err := s.GetNonce(req.From)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forwarder nonce: %v", err)
+	}
+	if nonce.Cmp(req.Nonce) != 0 {
+		return nil, ErrRelayNonce
+	}
+
+	// Only validly signed requests count against the sender's quota
+	if err := s.quota.allow(req.From, time.Now()); err != nil {
+		return nil, err
+	}
+
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return nil, ErrInvalidAuthorization
+	}
+	forwardReq := contracts.MinimalForwarderForwardRequest{
+		From:  req.From,
+		To:    req.To,
+		Value: req.Value,
+		Gas:   req.Gas,
+		Nonce: req.Nonce,
+		Data:  req.Data,
+	}
+
+	if err := s.simulate(forwardReq, sig); err != nil {
+		return nil, err
+	}
+
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return nil, err
+	}
+	// The forwarder must keep 1/64 of the gas after forwarding req.Gas to the target
+	opts.GasLimit = req.Gas.Uint64()*64/63 + relayOverheadGas
+	tx, err := s.forwarder.Execute(opts, forwardReq, sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit relay: %v", err)
+	}
+
+	record := &RelayRecord{
+		TxHash:      tx.Hash().Hex(),
+		From:        req.From.Hex(),
+		To:          req.To.Hex(),
+		Nonce:       req.Nonce.String(),
+		Status:      RelayStatusPending,
+		SubmittedAt: time.Now(),
+	}
+	s.mu.Lock()
+	s.evictLocked(record.SubmittedAt)
+	s.relays[record.TxHash] = record
+	s.mu.Unlock()
+
+	go func() {
+		result, err := s.transactor.WaitForTx(tx)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		record.settledAt = time.Now()
+		if err != nil {
+			record.Status = RelayStatusFailed
+			record.Error = err.Error()
+			return
+		}
+		record.Status = result.Status
+		record.BlockNumber = result.BlockNumber
+	}()
+
+	return s.copyRecord(record), nil
+}
+
+func (s *relayerService) GetRelay(txHash string) (*RelayRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.relays[common.HexToHash(txHash).Hex()]
+	if !ok {
+		return nil, ErrRelayNotFound
+	}
+	copied := *record
+	return &copied, nil
+}
+
+// evictLocked forgets the relays settled more than RecordTTL before now. Pending relays are kept.
+func (s *relayerService) evictLocked(now time.Time) {
+	for txHash, record := range s.relays {
+		if !record.settledAt.IsZero() && now.Sub(record.settledAt) > s.config.RecordTTL {
+			delete(s.relays, txHash)
+		}
+	}
+}
+
+func (s *relayerService) copyRecord(record *RelayRecord) *RelayRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *record
+	return &copied
+}
+
+// checkPolicy rejects requests the relayer will not pay for, before any RPC is made.
+func (s *relayerService) checkPolicy(req *ForwardRequest) error {
+	if s.denylist[req.From] {
+		return ErrRelayDenied
+	}
+	selectors, ok := s.targets[req.To]
+	if !ok {
+		return ErrRelayTarget
+	}
+	if len(req.Data) < 4 || !selectors[[4]byte(req.Data[:4])] {
+		return ErrRelayFunction
+	}
+	if req.Value != nil && req.Value.Sign() != 0 {
+		return ErrRelayValue
+	}
+	if req.Gas == nil || !req.Gas.IsUint64() || req.Gas.Uint64() > s.config.MaxGas {
+		return ErrRelayGas
+	}
+	if req.Nonce == nil {
+		return ErrRelayNonce
+	}
+	return nil
+}
+
+// simulate runs execute as a call; the forwarder reports a failed target call through its
+// return value rather than reverting, so both paths are checked.
+func (s *relayerService) simulate(req contracts.MinimalForwarderForwardRequest, signature []byte) error {
+	var out []interface{}
+	raw := &contracts.ForwarderCallerRaw{Contract: &s.forwarder.ForwarderCaller}
+	err := raw.Call(&bind.CallOpts{From: s.transactor.From()}, &out, "execute", req, signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRelaySimulation, err)
+	}
+
+	if success, ok := out[0].(bool); !ok || !success {
+		return ErrRelaySimulation
+	}
+	return nil
+}
+
+// relayQuota is a sliding-window count of relays per sender, and of all relays together.
+type relayQuota struct {
+	limit       int
+	globalLimit int
+	window      time.Duration
+
+	mu   sync.Mutex
+	sent map[common.Address][]time.Time
+	all  []time.Time
+}
+
+// newRelayQuota allows limit relays per sender and globalLimit in all per window. A zero
+// globalLimit leaves the total uncapped.
+func newRelayQuota(limit int, globalLimit int, window time.Duration) *relayQuota {
+	return &relayQuota{
+		limit:       limit,
+		globalLimit: globalLimit,
+		window:      window,
+		sent:        make(map[common.Address][]time.Time),
+	}
+}
+
+// allow records a relay for from at now, unless it already used its quota in the window, or
+// all senders together used the global one.
+func (q *relayQuota) allow(from common.Address, now time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// Senders whose relays all left the window are forgotten
+	for sender, times := range q.sent {
+		if recent := q.slide(times, now); len(recent) > 0 {
+			q.sent[sender] = recent
+		} else {
+			delete(q.sent, sender)
+		}
+	}
+	q.all = q.slide(q.all, now)
+
+	if len(q.sent[from]) >= q.limit {
+		return ErrRelayQuota
+	}
+	if q.globalLimit > 0 && len(q.all) >= q.globalLimit {
+		return ErrRelayCapacity
+	}
+	q.sent[from] = append(q.sent[from], now)
+	q.all = append(q.all, now)
+	return nil
+}
+
+// slide drops the times that left the window before now, in place.
+func (q *relayQuota) slide(times []time.Time, now time.Time) []time.Time {
+	recent := times[:0]
+	for _, at := range times {
+		if now.Sub(at) < q.window {
+			recent = append(recent, at)
+		}
+	}
+	return recent
+}
@@ -0,0 +1,152 @@
+package onchain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestRelayerService_CheckPolicy(t *testing.T) {
+	controller := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	user := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	denied := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	target := ControllerRelayTarget(controller)
+	service := &relayerService{
+		config:   RelayerConfig{MaxGas: 500000},
+		targets:  map[common.Address]map[[4]byte]bool{controller: {target.Selectors[0]: true}},
+		denylist: map[common.Address]bool{denied: true},
+	}
+
+	controllerABI, err := contracts.ControllerMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("Failed to parse Controller ABI: %v", err)
+	}
+	upload, _ := controllerABI.Pack("uploadData", "doc1")
+	confirm, _ := controllerABI.Pack("confirm", "doc1", "dochash", "success", big.NewInt(0), big.NewInt(4))
+
+	tests := []struct {
+		name    string
+		req     ForwardRequest
+		wantErr error
+	}{
+		{
+			name: "Valid request",
+			req:  ForwardRequest{From: user, To: controller, Value: big.NewInt(0), Gas: big.NewInt(300000), Nonce: big.NewInt(0), Data: upload},
+		},
+		{
+			name:    "Denylisted sender",
+			req:     ForwardRequest{From: denied, To: controller, Value: big.NewInt(0), Gas: big.NewInt(300000), Nonce: big.NewInt(0)},
+			wantErr: ErrRelayDenied,
+		},
+		{
+			name:    "Target not allowed",
+			req:     ForwardRequest{From: user, To: user, Value: big.NewInt(0), Gas: big.NewInt(300000), Nonce: big.NewInt(0)},
+			wantErr: ErrRelayTarget,
+		},
+		{
+			name:    "Confirms its own upload",
+			req:     ForwardRequest{From: user, To: controller, Value: big.NewInt(0), Gas: big.NewInt(300000), Nonce: big.NewInt(0), Data: confirm},
+			wantErr: ErrRelayFunction,
+		},
+		{
+			name:    "No calldata",
+			req:     ForwardRequest{From: user, To: controller, Value: big.NewInt(0), Gas: big.NewInt(300000), Nonce: big.NewInt(0)},
+			wantErr: ErrRelayFunction,
+		},
+		{
+			name:    "Carries value",
+			req:     ForwardRequest{From: user, To: controller, Value: big.NewInt(1), Gas: big.NewInt(300000), Nonce: big.NewInt(0), Data: upload},
+			wantErr: ErrRelayValue,
+		},
+		{
+			name:    "Gas above limit",
+			req:     ForwardRequest{From: user, To: controller, Value: big.NewInt(0), Gas: big.NewInt(500001), Nonce: big.NewInt(0), Data: upload},
+			wantErr: ErrRelayGas,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.checkPolicy(&tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRelayQuota(t *testing.T) {
+	user := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	other := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	now := time.Unix(1734280000, 0)
+	quota := newRelayQuota(2, 0, time.Hour)
+
+	if quota.allow(user, now) != nil || quota.allow(user, now.Add(time.Minute)) != nil {
+		t.Fatalf("Expected the first two relays to be allowed")
+	}
+	if err := quota.allow(user, now.Add(2*time.Minute)); !errors.Is(err, ErrRelayQuota) {
+		t.Errorf("Expected the third relay in the window to be rejected, got %v", err)
+	}
+	if quota.allow(other, now.Add(2*time.Minute)) != nil {
+		t.Errorf("Expected quotas to be tracked per sender")
+	}
+
+	// The oldest relay leaves the window
+	if quota.allow(user, now.Add(time.Hour)) != nil {
+		t.Errorf("Expected a relay once the window slides")
+	}
+	if err := quota.allow(user, now.Add(time.Hour+time.Second)); !errors.Is(err, ErrRelayQuota) {
+		t.Errorf("Expected the quota to be used up again, got %v", err)
+	}
+
+	// Senders without a relay in the window are forgotten
+	if quota.allow(user, now.Add(3*time.Hour)) != nil {
+		t.Errorf("Expected a relay in a new window")
+	}
+	if len(quota.sent) != 1 {
+		t.Errorf("Expected idle senders to be forgotten, %d tracked", len(quota.sent))
+	}
+}
+
+func TestRelayQuota_Global(t *testing.T) {
+	now := time.Unix(1734280000, 0)
+	quota := newRelayQuota(2, 3, time.Hour)
+
+	// Fresh addresses each stay within their own quota, but not within the global one
+	for i := 0; i < 3; i++ {
+		sender := common.BigToAddress(big.NewInt(int64(i + 1)))
+		if err := quota.allow(sender, now); err != nil {
+			t.Fatalf("Relay %d rejected: %v", i, err)
+		}
+	}
+	if err := quota.allow(common.BigToAddress(big.NewInt(4)), now); !errors.Is(err, ErrRelayCapacity) {
+		t.Errorf("Expected the global quota to be used up, got %v", err)
+	}
+	if err := quota.allow(common.BigToAddress(big.NewInt(4)), now.Add(time.Hour)); err != nil {
+		t.Errorf("Expected a relay once the window slides, got %v", err)
+	}
+}
+
+func TestRelayerService_EvictsSettledRelays(t *testing.T) {
+	now := time.Unix(1734280000, 0)
+	service := &relayerService{
+		config: RelayerConfig{RecordTTL: time.Hour},
+		relays: map[string]*RelayRecord{
+			"settled": {Status: "success", settledAt: now.Add(-2 * time.Hour)},
+			"recent":  {Status: "reverted", settledAt: now.Add(-time.Minute)},
+			"pending": {Status: RelayStatusPending},
+		},
+	}
+
+	service.evictLocked(now)
+	if _, ok := service.relays["settled"]; ok {
+		t.Errorf("Expected the relay settled past the TTL to be evicted")
+	}
+	if len(service.relays) != 2 {
+		t.Errorf("Expected the recent and pending relays to be kept, got %d", len(service.relays))
+	}
+}
@@ -0,0 +1,73 @@
+package onchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	TxStatusSuccess  = "success"
+	TxStatusReverted = "reverted"
+
+	confirmationPollInterval = time.Second
+)
+
+// TxResult reports a submitted transaction once it is mined.
+type TxResult struct {
+	TxHash      string `json:"txHash"`
+	Status      string `json:"status"`
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+// WaitMined waits for tx to be mined and then for the configured number of confirmations.
+func (t *Transactor) WaitMined(tx *types.Transaction) (*types.Receipt, error) {
+	ctx := context.Background()
+	receipt, err := bind.WaitMined(ctx, t.client, tx)
+	if err != nil || t.confirmations <= 1 {
+		return receipt, err
+	}
+
+	target := receipt.BlockNumber.Uint64() + t.confirmations - 1
+	ticker := time.NewTicker(confirmationPollInterval)
+	defer ticker.Stop()
+	for {
+		head, err := t.client.BlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if head >= target {
+			break
+		}
+		<-ticker.C
+	}
+
+	// The receipt is gone if a reorg dropped the transaction while we waited
+	receipt, err = t.client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s lost before %d confirmations: %v", tx.Hash().Hex(), t.confirmations, err)
+	}
+	return receipt, nil
+}
+
+// WaitForTx waits for tx to be confirmed and reports its final status.
+func (t *Transactor) WaitForTx(tx *types.Transaction) (*TxResult, error) {
+	receipt, err := t.WaitMined(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for transaction: %v", err)
+	}
+
+	status := TxStatusSuccess
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		status = TxStatusReverted
+	}
+
+	return &TxResult{
+		TxHash:      tx.Hash().Hex(),
+		Status:      status,
+		BlockNumber: receipt.BlockNumber.Uint64(),
+	}, nil
+}
@@ -0,0 +1,50 @@
+package onchain
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// FormatUnits renders a base-unit amount as a decimal string with the given number of decimals.
+func FormatUnits(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		return "0"
+	}
+
+	negative := amount.Sign() < 0
+	digits := new(big.Int).Abs(amount).String()
+	if decimals > 0 {
+		if len(digits) <= int(decimals) {
+			digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+		}
+		point := len(digits) - int(decimals)
+		fraction := strings.TrimRight(digits[point:], "0")
+		digits = digits[:point]
+		if fraction != "" {
+			digits += "." + fraction
+		}
+	}
+
+	if negative {
+		return "-" + digits
+	}
+	return digits
+}
+
+// ParseUnits converts a decimal string such as "1.5" into base units with the given number of decimals.
+func ParseUnits(value string, decimals uint8) (*big.Int, error) {
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(value), ".")
+	if len(fraction) > int(decimals) {
+		return nil, fmt.Errorf("too many decimal places in %q", value)
+	}
+	if whole == "" && fraction == "" {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+
+	amount, ok := new(big.Int).SetString("0"+whole+fraction+strings.Repeat("0", int(decimals)-len(fraction)), 10)
+	if !ok || strings.ContainsAny(whole+fraction, "+-") {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
@@ -0,0 +1,61 @@
+package onchain
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFormatUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		decimals uint8
+		want     string
+	}{
+		{name: "Whole tokens", amount: "225000000000000000000", decimals: 18, want: "225"},
+		{name: "Fractional tokens", amount: "1500000000000000000", decimals: 18, want: "1.5"},
+		{name: "Less than one token", amount: "1000", decimals: 18, want: "0.000000000000001"},
+		{name: "Zero", amount: "0", decimals: 18, want: "0"},
+		{name: "No decimals", amount: "42", decimals: 0, want: "42"},
+		{name: "Negative", amount: "-2500000000000000000", decimals: 18, want: "-2.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, _ := new(big.Int).SetString(tt.amount, 10)
+			if got := FormatUnits(amount, tt.decimals); got != tt.want {
+				t.Errorf("FormatUnits(%s, %d) = %s, want %s", tt.amount, tt.decimals, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		decimals uint8
+		want     string
+		wantErr  bool
+	}{
+		{name: "Whole tokens", value: "225", decimals: 18, want: "225000000000000000000"},
+		{name: "Fractional tokens", value: "1.5", decimals: 18, want: "1500000000000000000"},
+		{name: "Leading point", value: ".25", decimals: 2, want: "25"},
+		{name: "Gwei", value: "30", decimals: 9, want: "30000000000"},
+		{name: "Too many decimals", value: "0.001", decimals: 2, wantErr: true},
+		{name: "Negative", value: "-1", decimals: 18, wantErr: true},
+		{name: "Not a number", value: "abc", decimals: 18, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUnits(tt.value, tt.decimals)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseUnits(%q, %d) error = %v, wantErr %v", tt.value, tt.decimals, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ParseUnits(%q, %d) = %s, want %s", tt.value, tt.decimals, got, tt.want)
+			}
+		})
+	}
+}
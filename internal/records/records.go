@@ -0,0 +1,266 @@
+package records
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidSessionID = errors.New("invalid session ID")
+	ErrNotFound         = errors.New("not found on-chain or locally")
+)
+
+// Consistency verdicts
+const (
+	Consistent   = "consistent"
+	Inconsistent = "inconsistent"
+)
+
+// UploadView combines the on-chain state of an upload with the local record.
+type UploadView struct {
+	SessionID   string       `json:"sessionId,omitempty" example:"14"`
+	DocID       string       `json:"docId,omitempty" example:"0b1c2d3e-..."`
+	Chain       ChainState   `json:"chain"`
+	Local       *LocalRecord `json:"local,omitempty"`
+	Consistency Consistency  `json:"consistency"`
+}
+
+type ChainState struct {
+	SessionFound  bool   `json:"sessionFound"`
+	User          string `json:"user,omitempty"`
+	UploadDocID   string `json:"uploadDocId,omitempty" example:"c29814719d660d3f"`
+	Confirmed     bool   `json:"confirmed"`
+	Proof         string `json:"proof,omitempty"`
+	DocFound      bool   `json:"docFound"`
+	ContentHash   string `json:"contentHash,omitempty"`
+	TokenID       string `json:"tokenId,omitempty"`
+	RewardAmount  string `json:"rewardAmount,omitempty"`
+	UploadTxHash  string `json:"uploadTxHash,omitempty"`
+	ConfirmTxHash string `json:"confirmTxHash,omitempty"`
+}
+
+type LocalRecord struct {
+	FileID string `json:"fileId"`
+	// The ID the file was opened under on-chain, before it was re-keyed
+	LegacyFileID string `json:"legacyFileId,omitempty"`
+	SessionID    string `json:"sessionId,omitempty"`
+	DocID        string `json:"docId,omitempty"`
+	TokenID      string `json:"tokenId,omitempty"`
+	RiskScore    int    `json:"riskScore"`
+	UploadStatus string `json:"uploadStatus"`
+	// ContentHash is recomputed from the stored ciphertext
+	ContentHash string `json:"contentHash"`
+}
+
+// Consistency is the verdict of comparing the chain with the database.
+type Consistency struct {
+	Status string   `json:"status" example:"consistent"`
+	Issues []string `json:"issues"`
+}
+
+type RecordService interface {
+	GetSession(sessionID string) (*UploadView, error)
+	GetDoc(docID string) (*UploadView, error)
+}
+
+type recordService struct {
+	onchainService         onchain.OnchainService
+	geneDataStorageService storage.GeneDataStorageService
+}
+
+func NewRecordService(onchainService onchain.OnchainService, geneDataStorageService storage.GeneDataStorageService) RecordService {
+	return &recordService{
+		onchainService:         onchainService,
+		geneDataStorageService: geneDataStorageService,
+	}
+}
+
+// GetSession reports an upload session, following the local record to its confirmed doc.
+func (s *recordService) GetSession(sessionID string) (*UploadView, error) {
+	id, ok := new(big.Int).SetString(sessionID, 10)
+	if !ok || id.Sign() < 0 {
+		return nil, ErrInvalidSessionID
+	}
+	view := &UploadView{SessionID: id.String()}
+
+	local, err := s.findLocal(s.geneDataStorageService.FindBySessionID(view.SessionID))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loadSession(view); err != nil {
+		return nil, err
+	}
+	if local == nil && !view.Chain.SessionFound {
+		return nil, ErrNotFound
+	}
+
+	if local != nil && local.DocID != "" {
+		view.DocID = local.DocID
+		if err := s.loadDoc(view); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.finish(view, local)
+}
+
+// GetDoc reports a confirmed doc, following the local record back to its upload session.
+func (s *recordService) GetDoc(docID string) (*UploadView, error) {
+	view := &UploadView{DocID: docID}
+
+	local, err := s.findLocal(s.geneDataStorageService.FindByDocID(docID))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loadDoc(view); err != nil {
+		return nil, err
+	}
+	if local == nil && !view.Chain.DocFound {
+		return nil, ErrNotFound
+	}
+
+	if local != nil && local.SessionID != "" {
+		view.SessionID = local.SessionID
+		if err := s.loadSession(view); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.finish(view, local)
+}
+
+func (s *recordService) findLocal(record *storage.GeneData, err error) (*storage.GeneData, error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find gene data: %w", err)
+	}
+	return record, nil
+}
+
+func (s *recordService) loadSession(view *UploadView) error {
+	session, err := s.onchainService.GetSession(view.SessionID)
+	if err != nil {
+		return err
+	}
+	// Sessions that were never opened read back as zero values
+	if session.User == (common.Address{}) {
+		return nil
+	}
+
+	view.Chain.SessionFound = true
+	view.Chain.User = session.User.Hex()
+	view.Chain.Confirmed = session.Confirmed
+	view.Chain.Proof = session.Proof
+
+	uploadTx, err := s.onchainService.FindUploadTx(view.SessionID)
+	if err != nil {
+		return err
+	}
+	if uploadTx != nil {
+		view.Chain.UploadTxHash = uploadTx.TxHash
+		view.Chain.UploadDocID = uploadTx.DocID
+	}
+	return nil
+}
+
+func (s *recordService) loadDoc(view *UploadView) error {
+	doc, err := s.onchainService.GetDoc(view.DocID)
+	if err != nil {
+		return err
+	}
+	if doc.Id == "" {
+		return nil
+	}
+
+	view.Chain.DocFound = true
+	view.Chain.ContentHash = doc.HashContent
+
+	confirmTx, err := s.onchainService.FindConfirmTx(view.DocID)
+	if err != nil {
+		return err
+	}
+	if confirmTx != nil {
+		view.Chain.ConfirmTxHash = confirmTx.TxHash
+		view.Chain.TokenID = confirmTx.TokenID
+		view.Chain.RewardAmount = confirmTx.RewardAmount
+	}
+	return nil
+}
+
+func (s *recordService) finish(view *UploadView, record *storage.GeneData) (*UploadView, error) {
+	if record != nil {
+		// Once the retention policy purged the ciphertext, only the hash recorded at upload is left
+		contentHash := hex.EncodeToString(record.DataHash)
+		if record.PurgedAt == nil {
+			encryptedData, err := s.geneDataStorageService.RetrieveGeneData(record.FileID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve gene data: %w", err)
+			}
+			contentHash = hex.EncodeToString(crypto.Keccak256(encryptedData))
+		}
+
+		view.Local = &LocalRecord{
+			FileID:       record.FileID,
+			LegacyFileID: record.LegacyFileID,
+			SessionID:    record.SessionID,
+			DocID:        record.DocID,
+			TokenID:      record.TokenID,
+			RiskScore:    record.RiskScore,
+			UploadStatus: record.UploadStatus,
+			ContentHash:  contentHash,
+		}
+	}
+
+	view.Consistency = Check(view.Chain, view.Local)
+	return view, nil
+}
+
+// Check lists every disagreement between the chain and the local record.
+func Check(chain ChainState, local *LocalRecord) Consistency {
+	issues := []string{}
+	if local == nil {
+		issues = append(issues, "no local record")
+		return Consistency{Status: Inconsistent, Issues: issues}
+	}
+
+	if local.SessionID != "" && !chain.SessionFound {
+		issues = append(issues, "session not found on-chain")
+	}
+	if chain.UploadDocID != "" && chain.UploadDocID != local.FileID && chain.UploadDocID != local.LegacyFileID {
+		issues = append(issues, fmt.Sprintf("session was opened for %s, not file %s", chain.UploadDocID, local.FileID))
+	}
+
+	completed := local.UploadStatus == "" || local.UploadStatus == storage.UploadCompleted
+	if chain.Confirmed && !completed {
+		issues = append(issues, fmt.Sprintf("confirmed on-chain but %s locally", local.UploadStatus))
+	}
+	if completed && local.SessionID != "" && chain.SessionFound && !chain.Confirmed {
+		issues = append(issues, "completed locally but not confirmed on-chain")
+	}
+	if local.DocID != "" && completed && !chain.DocFound {
+		issues = append(issues, "doc not found on-chain")
+	}
+
+	if chain.TokenID != "" && chain.TokenID != local.TokenID {
+		issues = append(issues, fmt.Sprintf("token %s minted on-chain, %q recorded locally", chain.TokenID, local.TokenID))
+	}
+	// Docs confirmed in anchoring mode have their hash in an anchored Merkle root instead
+	if chain.DocFound && chain.ContentHash != "" && chain.ContentHash != local.ContentHash {
+		issues = append(issues, "stored ciphertext does not match the on-chain content hash")
+	}
+
+	if len(issues) > 0 {
+		return Consistency{Status: Inconsistent, Issues: issues}
+	}
+	return Consistency{Status: Consistent, Issues: issues}
+}
@@ -0,0 +1,117 @@
+package records
+
+import (
+	"testing"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+)
+
+func TestCheck(t *testing.T) {
+	confirmed := ChainState{
+		SessionFound: true,
+		UploadDocID:  "c29814719d660d3f",
+		Confirmed:    true,
+		DocFound:     true,
+		ContentHash:  "abcd",
+		TokenID:      "14",
+	}
+	completed := LocalRecord{
+		FileID:       "c29814719d660d3f",
+		SessionID:    "3",
+		DocID:        "doc-1",
+		TokenID:      "14",
+		UploadStatus: storage.UploadCompleted,
+		ContentHash:  "abcd",
+	}
+
+	tests := []struct {
+		name       string
+		chain      ChainState
+		local      *LocalRecord
+		wantStatus string
+		wantIssues int
+	}{
+		{
+			name:       "Chain and database agree",
+			chain:      confirmed,
+			local:      &completed,
+			wantStatus: Consistent,
+		},
+		{
+			name: "Content hash anchored in a Merkle root",
+			chain: func() ChainState {
+				chain := confirmed
+				chain.ContentHash = ""
+				return chain
+			}(),
+			local:      &completed,
+			wantStatus: Consistent,
+		},
+		{
+			name:  "Re-keyed file opened under its legacy ID",
+			chain: confirmed,
+			local: func() *LocalRecord {
+				local := completed
+				local.FileID = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+				local.LegacyFileID = "c29814719d660d3f"
+				return &local
+			}(),
+			wantStatus: Consistent,
+		},
+		{
+			name:       "Only on-chain",
+			chain:      confirmed,
+			wantStatus: Inconsistent,
+			wantIssues: 1,
+		},
+		{
+			name:  "Upload still pending locally",
+			chain: ChainState{SessionFound: true, UploadDocID: "c29814719d660d3f"},
+			local: &LocalRecord{
+				FileID:       "c29814719d660d3f",
+				SessionID:    "3",
+				UploadStatus: storage.UploadPending,
+			},
+			wantStatus: Consistent,
+		},
+		{
+			name:  "Confirmed on-chain but failed locally",
+			chain: confirmed,
+			local: func() *LocalRecord {
+				record := completed
+				record.UploadStatus = storage.UploadFailed
+				return &record
+			}(),
+			wantStatus: Inconsistent,
+			wantIssues: 1,
+		},
+		{
+			name: "Token and content hash differ",
+			chain: func() ChainState {
+				chain := confirmed
+				chain.TokenID = "15"
+				chain.ContentHash = "ef01"
+				return chain
+			}(),
+			local:      &completed,
+			wantStatus: Inconsistent,
+			wantIssues: 2,
+		},
+		{
+			name:       "Completed locally but not on-chain",
+			chain:      ChainState{},
+			local:      &completed,
+			wantStatus: Inconsistent,
+			wantIssues: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Check(tt.chain, tt.local)
+			if got.Status != tt.wantStatus || len(got.Issues) != tt.wantIssues {
+				t.Errorf("Check() = %+v, want status %s with %d issues", got, tt.wantStatus, tt.wantIssues)
+			}
+		})
+	}
+}
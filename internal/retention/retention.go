@@ -0,0 +1,216 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+)
+
+// Retention rules
+const (
+	// KeepUntilWithdrawn keeps raw data until the user withdraws it
+	KeepUntilWithdrawn = "until-withdrawn"
+	// DeleteRawAfter deletes raw data a number of days after its report, as in "raw-after:30d"
+	DeleteRawAfter = "raw-after"
+)
+
+// Actions taken on a file, in order
+const (
+	ActionSoftDelete = "soft-delete"
+	ActionPurge      = "purge"
+)
+
+const (
+	// DefaultGrace is how long soft-deleted raw data can be restored when no grace is configured.
+	DefaultGrace = 7 * 24 * time.Hour
+	// DefaultInterval is how often the policy is evaluated when no interval is configured.
+	DefaultInterval = time.Hour
+
+	batchSize = 100
+)
+
+var actionsDone = map[string]string{
+	ActionSoftDelete: "soft-deleted",
+	ActionPurge:      "purged",
+}
+
+// Policy decides when the raw genomic data of a file is deleted. Withdrawn files are always due;
+// the report, hashes and on-chain references of a file are never deleted.
+type Policy struct {
+	// Raw data is deleted this long after its report; zero keeps it until the user withdraws it
+	RawDataTTL time.Duration
+	// Soft-deleted raw data is purged this long after
+	Grace time.Duration
+	// Only log what would be deleted
+	DryRun bool
+}
+
+// ParsePolicy reads a retention rule: "until-withdrawn" (also the empty rule), or "raw-after:" and a
+// number of days such as "30d", or a duration such as "720h".
+func ParsePolicy(rule string) (Policy, error) {
+	policy := Policy{Grace: DefaultGrace}
+	if rule == "" || rule == KeepUntilWithdrawn {
+		return policy, nil
+	}
+
+	after, ok := strings.CutPrefix(rule, DeleteRawAfter+":")
+	if !ok {
+		return policy, fmt.Errorf("unknown retention rule %q", rule)
+	}
+	ttl, err := parseDays(after)
+	if err != nil || ttl <= 0 {
+		return policy, fmt.Errorf("invalid retention period %q", after)
+	}
+	policy.RawDataTTL = ttl
+	return policy, nil
+}
+
+func parseDays(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+func (p Policy) String() string {
+	if p.RawDataTTL == 0 {
+		return KeepUntilWithdrawn
+	}
+	return fmt.Sprintf("%s:%s", DeleteRawAfter, p.RawDataTTL)
+}
+
+// Action is a step taken, or in a dry run that would be taken, on the raw data of a file.
+type Action struct {
+	FileID string `json:"fileId"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+// Result lists the actions of one evaluation of the policy.
+type Result struct {
+	DryRun  bool     `json:"dryRun"`
+	Actions []Action `json:"actions"`
+}
+
+type RetentionService interface {
+	Evaluate() (*Result, error)
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type retentionService struct {
+	repository storage.RetentionRepository
+	policy     Policy
+	now        func() time.Time
+}
+
+func NewRetentionService(repository storage.RetentionRepository, policy Policy) RetentionService {
+	return &retentionService{
+		repository: repository,
+		policy:     policy,
+		now:        time.Now,
+	}
+}
+
+// Evaluate soft-deletes the raw data the policy expires, then purges the raw data soft-deleted
+// longer than the grace period ago. Every action is logged. A failed action does not stop the
+// others; the failures are returned together.
+func (s *retentionService) Evaluate() (*Result, error) {
+	result := &Result{DryRun: s.policy.DryRun, Actions: []Action{}}
+	now := s.now().UTC()
+	var errs []error
+
+	var reportedBefore *time.Time
+	if s.policy.RawDataTTL > 0 {
+		cutoff := now.Add(-s.policy.RawDataTTL)
+		reportedBefore = &cutoff
+	}
+	for afterID := uint(0); ; {
+		expired, err := s.repository.FindExpired(reportedBefore, afterID, batchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to find expired raw data: %w", err)
+		}
+		for i := range expired {
+			geneData := &expired[i]
+			afterID = geneData.ID
+			reason := "expired"
+			if geneData.WithdrawnAt != nil {
+				reason = "withdrawn"
+			}
+			if err := s.act(result, geneData, ActionSoftDelete, reason, func() error {
+				return s.repository.SoftDelete(geneData, now)
+			}); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(expired) < batchSize {
+			break
+		}
+	}
+
+	deletedBefore := now.Add(-s.policy.Grace)
+	for afterID := uint(0); ; {
+		purgeable, err := s.repository.FindPurgeable(deletedBefore, afterID, batchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to find soft-deleted raw data: %w", err)
+		}
+		for i := range purgeable {
+			geneData := &purgeable[i]
+			afterID = geneData.ID
+			if err := s.act(result, geneData, ActionPurge, "grace period over", func() error {
+				return s.repository.Purge(geneData, now)
+			}); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(purgeable) < batchSize {
+			break
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func (s *retentionService) act(result *Result, geneData *storage.GeneData, action string, reason string, do func() error) error {
+	if s.policy.DryRun {
+		log.Printf("retention (dry run): would %s raw data of file %s, %s", action, geneData.FileID, reason)
+	} else {
+		if err := do(); err != nil {
+			log.Printf("retention: failed to %s raw data of file %s: %v", action, geneData.FileID, err)
+			return fmt.Errorf("failed to %s file %s: %w", action, geneData.FileID, err)
+		}
+		log.Printf("retention: %s raw data of file %s, %s", actionsDone[action], geneData.FileID, reason)
+	}
+	result.Actions = append(result.Actions, Action{FileID: geneData.FileID, Action: action, Reason: reason})
+	return nil
+}
+
+// Run evaluates the policy at start and then every interval, until ctx is done.
+func (s *retentionService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("retention: evaluating %s every %s, purging after %s (dry run: %v)", s.policy, interval, s.policy.Grace, s.policy.DryRun)
+	for {
+		if _, err := s.Evaluate(); err != nil {
+			log.Printf("retention evaluation failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
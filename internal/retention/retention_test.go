@@ -0,0 +1,158 @@
+package retention
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"gorm.io/gorm"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		rule    string
+		wantTTL time.Duration
+		wantErr bool
+	}{
+		{rule: "", wantTTL: 0},
+		{rule: "until-withdrawn", wantTTL: 0},
+		{rule: "raw-after:30d", wantTTL: 30 * 24 * time.Hour},
+		{rule: "raw-after:36h", wantTTL: 36 * time.Hour},
+		{rule: "raw-after:0d", wantErr: true},
+		{rule: "raw-after:soon", wantErr: true},
+		{rule: "forever", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			policy, err := ParsePolicy(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if policy.RawDataTTL != tt.wantTTL || policy.Grace != DefaultGrace {
+				t.Errorf("ParsePolicy() = %+v, want TTL %s and the default grace", policy, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestRetentionService_Evaluate(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		blobs, err := blobstore.NewFileStore(t.TempDir())
+		if err != nil {
+			t.Fatalf("Failed to open blob store: %v", err)
+		}
+		geneData := storage.NewGeneDataStorageService(db, blobs)
+
+		now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+		// reportedAt is nil for uploads that delivered no report
+		store := func(data string, uploadedAt time.Time, reportedAt *time.Time, status string) string {
+			fileID, err := geneData.StoreGeneData(1, []byte(data), []byte("signature"), []byte("hash"))
+			if err != nil {
+				t.Fatalf("StoreGeneData() error = %v", err)
+			}
+			db.Model(&storage.GeneData{}).Where("file_id = ?", fileID).Updates(map[string]interface{}{
+				"created_at":    uploadedAt,
+				"reported_at":   reportedAt,
+				"upload_status": status,
+			})
+			return fileID
+		}
+		daysAgo := func(days int) *time.Time {
+			at := now.AddDate(0, 0, -days)
+			return &at
+		}
+		old := store("old", now.AddDate(0, 0, -40), daysAgo(40), storage.UploadCompleted)
+		recent := store("recent", now.AddDate(0, 0, -5), daysAgo(5), storage.UploadCompleted)
+		withdrawn := store("withdrawn", now.AddDate(0, 0, -1), daysAgo(1), storage.UploadCompleted)
+		pending := store("pending", now.AddDate(0, 0, -40), nil, storage.UploadPending)
+		// Stalled in its workflow for weeks before its report was delivered
+		stalled := store("stalled", now.AddDate(0, 0, -40), daysAgo(5), storage.UploadCompleted)
+		// Restored from a bundle, keeping the report time of the source
+		restored := store("restored", now.AddDate(0, 0, -5), daysAgo(40), storage.UploadCompleted)
+		failed := store("failed", now.AddDate(0, 0, -40), nil, storage.UploadFailed)
+		if err := geneData.WithdrawGeneData(withdrawn, now); err != nil {
+			t.Fatalf("WithdrawGeneData() error = %v", err)
+		}
+
+		evaluate := func(policy Policy, at time.Time) []Action {
+			service := NewRetentionService(storage.NewRetentionRepository(db, blobs), policy).(*retentionService)
+			service.now = func() time.Time { return at }
+			result, err := service.Evaluate()
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			return result.Actions
+		}
+		wantActions := func(got []Action, want ...Action) {
+			t.Helper()
+			if len(got) != len(want) {
+				t.Fatalf("Evaluate() actions = %+v, want %+v", got, want)
+			}
+			for i := range want {
+				if got[i].FileID != want[i].FileID || got[i].Action != want[i].Action {
+					t.Errorf("Evaluate() action %d = %+v, want %+v", i, got[i], want[i])
+				}
+			}
+		}
+		policy := Policy{RawDataTTL: 30 * 24 * time.Hour, Grace: 7 * 24 * time.Hour}
+
+		// Until withdrawn, only the withdrawn file is due
+		dryRun := Policy{Grace: policy.Grace, DryRun: true}
+		wantActions(evaluate(dryRun, now), Action{FileID: withdrawn, Action: ActionSoftDelete})
+		// A dry run deletes nothing
+		dryRun.RawDataTTL = policy.RawDataTTL
+		wantActions(evaluate(dryRun, now),
+			Action{FileID: old, Action: ActionSoftDelete},
+			Action{FileID: withdrawn, Action: ActionSoftDelete},
+			Action{FileID: restored, Action: ActionSoftDelete},
+			Action{FileID: failed, Action: ActionSoftDelete},
+		)
+
+		wantActions(evaluate(policy, now),
+			Action{FileID: old, Action: ActionSoftDelete},
+			Action{FileID: withdrawn, Action: ActionSoftDelete},
+			Action{FileID: restored, Action: ActionSoftDelete},
+			Action{FileID: failed, Action: ActionSoftDelete},
+		)
+		if _, err := geneData.FindByFileID(old); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("FindByFileID() of a soft-deleted file error = %v, want %v", err, gorm.ErrRecordNotFound)
+		}
+		// Nothing more until the grace period is over
+		wantActions(evaluate(policy, now.AddDate(0, 0, 1)))
+
+		wantActions(evaluate(policy, now.AddDate(0, 0, 8)),
+			Action{FileID: old, Action: ActionPurge},
+			Action{FileID: withdrawn, Action: ActionPurge},
+			Action{FileID: restored, Action: ActionPurge},
+			Action{FileID: failed, Action: ActionPurge},
+		)
+		record, err := geneData.FindByFileID(old)
+		if err != nil {
+			t.Fatalf("FindByFileID() of a purged file error = %v", err)
+		}
+		if record.PurgedAt == nil || record.BlobKey != "" || string(record.DataHash) != "hash" {
+			t.Errorf("Purged record = %+v, want its hashes without its blob", record)
+		}
+		if _, err := blobs.Get(old); !errors.Is(err, blobstore.ErrNotFound) {
+			t.Errorf("Get() of a purged blob error = %v, want %v", err, blobstore.ErrNotFound)
+		}
+		if _, err := geneData.RetrieveGeneData(old); !errors.Is(err, storage.ErrRawDataPurged) {
+			t.Errorf("RetrieveGeneData() of a purged file error = %v, want %v", err, storage.ErrRawDataPurged)
+		}
+
+		for _, fileID := range []string{recent, pending, stalled} {
+			if _, err := geneData.RetrieveGeneData(fileID); err != nil {
+				t.Errorf("RetrieveGeneData(%s) error = %v", fileID, err)
+			}
+		}
+		// Purged files are done with
+		wantActions(evaluate(policy, now.AddDate(0, 0, 9)))
+	})
+}
@@ -0,0 +1,176 @@
+package rewards
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	contracts "github.com/TropicalDog17/genomic-dao-service/internal/onchain/bindings"
+	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrInvalidSchedule      = errors.New("reward schedule must hold one positive amount per risk score")
+	ErrInconsistentSchedule = errors.New("reward schedule contradicts the TEE risk levels")
+)
+
+// Schedule holds the reward for each risk score, in PCSP wei; index 0 is risk score 1.
+type Schedule [tee.RiskExtremelyHigh]*big.Int
+
+// Tier is the PCSP reward for one risk score.
+type Tier struct {
+	RiskScore int    `json:"riskScore" example:"4"`
+	Risk      string `json:"risk" example:"extremely high"`
+	Amount    string `json:"amount" example:"15000000000000000000000"`
+	Formatted string `json:"formatted" example:"15000"`
+}
+
+// ScheduleChange compares the on-chain schedule with a proposed one.
+type ScheduleChange struct {
+	Current  []Tier `json:"current"`
+	Proposed []Tier `json:"proposed"`
+	Applied  bool   `json:"applied"`
+	TxHash   string `json:"txHash,omitempty"`
+}
+
+type RewardService interface {
+	GetSchedule() ([]Tier, error)
+	ProposeSchedule(schedule Schedule, apply bool) (*ScheduleChange, error)
+	CheckConsistency() error
+}
+
+type rewardService struct {
+	transactor *onchain.Transactor
+	controller *contracts.Controller
+	pcspToken  *onchain.Linked[contracts.PCSPToken]
+}
+
+// NewRewardService binds the Controller, which owns PCSP and so sets its reward schedule.
+func NewRewardService(transactor *onchain.Transactor, controllerAddr common.Address) RewardService {
+	controller, err := contracts.NewController(controllerAddr, transactor.Backend())
+	if err != nil {
+		panic(err)
+	}
+
+	pcspToken := onchain.NewLinked(func() (*contracts.PCSPToken, error) {
+		tokenAddr, err := controller.PcspToken(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PCSP address: %v", err)
+		}
+		return contracts.NewPCSPToken(tokenAddr, transactor.Backend())
+	})
+
+	return &rewardService{
+		transactor: transactor,
+		controller: controller,
+		pcspToken:  pcspToken,
+	}
+}
+
+// CheckSchedule checks a schedule against the TEE, which gives the highest risk score to the
+// highest risk: every score must be rewarded, and a higher score at least as much as a lower one.
+func CheckSchedule(schedule Schedule) error {
+	for i, amount := range schedule {
+		if amount == nil || amount.Sign() <= 0 {
+			return ErrInvalidSchedule
+		}
+		if i > 0 && amount.Cmp(schedule[i-1]) < 0 {
+			return fmt.Errorf("%w: %s risk earns less than %s risk", ErrInconsistentSchedule, tee.RiskLevelName(i+1), tee.RiskLevelName(i))
+		}
+	}
+	return nil
+}
+
+func (s *rewardService) GetSchedule() ([]Tier, error) {
+	schedule, err := s.schedule()
+	if err != nil {
+		return nil, err
+	}
+	return s.tiers(schedule)
+}
+
+// ProposeSchedule checks the proposed schedule and shows it next to the current one. With apply,
+// the service wallet then sets it through the Controller.
+func (s *rewardService) ProposeSchedule(schedule Schedule, apply bool) (*ScheduleChange, error) {
+	if err := CheckSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetSchedule()
+	if err != nil {
+		return nil, err
+	}
+	proposed, err := s.tiers(schedule)
+	if err != nil {
+		return nil, err
+	}
+	change := &ScheduleChange{Current: current, Proposed: proposed}
+	if !apply {
+		return change, nil
+	}
+
+	opts, err := s.transactor.Opts()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.controller.SetRewardSchedule(opts, schedule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set reward schedule: %v", err)
+	}
+	result, err := s.transactor.WaitForTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	if result.Status != onchain.TxStatusSuccess {
+		return nil, fmt.Errorf("reward schedule update reverted: %s", result.TxHash)
+	}
+
+	change.Applied = true
+	change.TxHash = result.TxHash
+	return change, nil
+}
+
+// CheckConsistency checks the on-chain schedule against the TEE risk levels.
+func (s *rewardService) CheckConsistency() error {
+	schedule, err := s.schedule()
+	if err != nil {
+		return err
+	}
+	return CheckSchedule(schedule)
+}
+
+func (s *rewardService) schedule() (Schedule, error) {
+	pcspToken, err := s.pcspToken.Get()
+	if err != nil {
+		return Schedule{}, err
+	}
+	schedule, err := pcspToken.RewardSchedule(nil)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("failed to get reward schedule: %v", err)
+	}
+	return schedule, nil
+}
+
+func (s *rewardService) tiers(schedule Schedule) ([]Tier, error) {
+	pcspToken, err := s.pcspToken.Get()
+	if err != nil {
+		return nil, err
+	}
+	decimals, err := pcspToken.Decimals(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decimals: %v", err)
+	}
+
+	tiers := make([]Tier, len(schedule))
+	for i, amount := range schedule {
+		tiers[i] = Tier{
+			RiskScore: i + 1,
+			Risk:      tee.RiskLevelName(i + 1),
+			Amount:    amount.String(),
+			Formatted: onchain.FormatUnits(amount, decimals),
+		}
+	}
+	return tiers, nil
+}
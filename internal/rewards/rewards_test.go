@@ -0,0 +1,55 @@
+package rewards
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func pcsp(amounts ...int64) Schedule {
+	var schedule Schedule
+	for i, amount := range amounts {
+		schedule[i] = new(big.Int).Mul(big.NewInt(amount), big.NewInt(1e18))
+	}
+	return schedule
+}
+
+func TestCheckSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+		wantErr  error
+	}{
+		{
+			name:     "Highest risk earns the most",
+			schedule: pcsp(30, 225, 3000, 15000),
+		},
+		{
+			name:     "Flat schedule",
+			schedule: pcsp(100, 100, 100, 100),
+		},
+		{
+			name:     "Lowest risk earns the most",
+			schedule: pcsp(15000, 3000, 225, 30),
+			wantErr:  ErrInconsistentSchedule,
+		},
+		{
+			name:     "Unrewarded risk score",
+			schedule: pcsp(0, 225, 3000, 15000),
+			wantErr:  ErrInvalidSchedule,
+		},
+		{
+			name:     "Missing risk score",
+			schedule: pcsp(30, 225, 3000),
+			wantErr:  ErrInvalidSchedule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckSchedule(tt.schedule); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckSchedule() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,13 +2,35 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"math/big"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/TropicalDog17/genomic-dao-service/internal/access"
+	"github.com/TropicalDog17/genomic-dao-service/internal/anchor"
+	"github.com/TropicalDog17/genomic-dao-service/internal/audit"
 	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/bundle"
+	"github.com/TropicalDog17/genomic-dao-service/internal/files"
+	"github.com/TropicalDog17/genomic-dao-service/internal/genomic"
+	"github.com/TropicalDog17/genomic-dao-service/internal/governance"
 	"github.com/TropicalDog17/genomic-dao-service/internal/handler"
+	"github.com/TropicalDog17/genomic-dao-service/internal/marketplace"
+	"github.com/TropicalDog17/genomic-dao-service/internal/migrate"
+	"github.com/TropicalDog17/genomic-dao-service/internal/network"
+	"github.com/TropicalDog17/genomic-dao-service/internal/nft"
 	"github.com/TropicalDog17/genomic-dao-service/internal/onchain"
+	"github.com/TropicalDog17/genomic-dao-service/internal/records"
+	"github.com/TropicalDog17/genomic-dao-service/internal/retention"
+	"github.com/TropicalDog17/genomic-dao-service/internal/rewards"
 	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
 	"github.com/TropicalDog17/genomic-dao-service/internal/tee"
 	genomicCrypto "github.com/TropicalDog17/genomic-dao-service/pkg/crypto"
@@ -23,8 +45,15 @@ import (
 
 var startOnce sync.Once
 
+// startupVerifyTimeout bounds the verification of the network profile before serving.
+const startupVerifyTimeout = 30 * time.Second
+
 func StartService(db *gorm.DB) {
 	startOnce.Do(func() {
+		// The schema is only changed by `migrate up`, never while serving
+		if err := migrate.NewMigrator(db).Check(); err != nil {
+			panic(fmt.Errorf("database schema: %v", err))
+		}
 		r := route(db)
 		err := r.Run(":8080")
 		if err != nil {
@@ -37,17 +66,36 @@ func StartService(db *gorm.DB) {
 // Routing by gin
 func route(db *gorm.DB) *gin.Engine {
 	r := gin.Default()
-	client, err := ethclient.Dial(os.Getenv("RPC_URL"))
+	profile := loadProfile()
+
+	// RPC requests fail fast while the node is unreachable. The node is verified before the first
+	// request gets through, and again each time it comes back. A node that stops matching the
+	// profile keeps the breaker open and the service unready.
+	var client *ethclient.Client
+	var profileMismatch atomic.Bool
+	breaker := onchain.NewBreaker(breakerConfig(), func(ctx context.Context) error {
+		err := network.Verify(ctx, client, profile)
+		if err == nil || isProfileMismatch(err) {
+			profileMismatch.Store(err != nil)
+		}
+		return err
+	})
+	client, err := onchain.Dial(profile.RPCURL, breaker)
 	if err != nil {
 		panic(err)
 	}
-
-	// Get the chain ID
-	chainID, err := client.NetworkID(context.Background())
+	// Refuse to start against the wrong chain or unexpected contracts. An unreachable node only
+	// leaves the service degraded until it answers.
+	verifyCtx, cancel := context.WithTimeout(context.Background(), startupVerifyTimeout)
+	err = breaker.Probe(verifyCtx)
+	cancel()
+	if isProfileMismatch(err) {
+		panic(fmt.Errorf("network profile %s: %v", profile.Name, err))
+	}
 	if err != nil {
-		panic(fmt.Errorf("failed to get chain ID: %v", err))
-
+		log.Printf("network profile %s not verified, starting degraded: %v", profile.Name, err)
 	}
+	chainID := new(big.Int).SetUint64(profile.ChainID)
 
 	// get owner private key(BIP44) from env for simplicity
 	privKey, _, _, err := genomicCrypto.DeriveEcdsaKeyPairAndEthAddress(os.Getenv("PRIVATE_KEY"))
@@ -60,33 +108,498 @@ func route(db *gorm.DB) *gin.Engine {
 		panic(fmt.Errorf("failed to create transactor: %v", err))
 	}
 	authService := auth.NewAuthService(auth.NewUserRepository(db))
-	authHandler := handler.NewAuthHandler(authService)
-	teeService := tee.NewTeeService()
-	geneDataStorageService := storage.NewGeneDataStorageService(db)
+	// Wallets sign in with EIP-4361 messages for this domain and the profile's chain
+	loginService := auth.NewLoginService(authService, auth.NewSessionRepository(db), loginConfig(profile))
+	authHandler := handler.NewAuthHandler(loginService)
+	teeService := tee.NewTeeService(fingerprintKey(), teeCodec())
+	// Encrypted gene data is kept out of the database, in the store selected by BLOB_STORE
+	blobs, err := blobstore.NewFromEnv()
+	if err != nil {
+		panic(fmt.Errorf("failed to open blob store: %v", err))
+	}
+	geneDataStorageService := storage.NewGeneDataStorageService(db, blobs)
+
+	controllerContractAddress := profile.Contracts.Controller.Address
+
+	transactor := onchain.NewTransactor(client, opts, feeConfig(profile), profile.Confirmations)
+
+	// Refuse to start if the on-chain rewards contradict the risk levels the TEE assigns. A schedule
+	// changed while serving only makes the service unready, and is checked again on each recovery.
+	rewardService := rewards.NewRewardService(transactor, controllerContractAddress)
+	var scheduleMismatch atomic.Bool
+	checkSchedule := func() error {
+		err := rewardService.CheckConsistency()
+		if err == nil || isScheduleMismatch(err) {
+			scheduleMismatch.Store(err != nil)
+		}
+		return err
+	}
+	err = checkSchedule()
+	if isScheduleMismatch(err) {
+		panic(fmt.Errorf("reward schedule: %v", err))
+	}
+	if err != nil {
+		log.Printf("failed to check reward schedule: %v", err)
+	}
+	breaker.OnRecover(func() {
+		if err := checkSchedule(); err != nil {
+			log.Printf("failed to check reward schedule: %v", err)
+		}
+	})
+	onchainService := onchain.NewOnchainService(client, transactor, controllerContractAddress, profile.DeployBlock)
+	// Requests signed without a session are bound to the sign-in domain and the profile's chain
+	accessService := access.NewAccessService(onchainService, access.DefaultCacheTTL, loginConfig(profile).Domain, profile.ChainID)
+	uploadChain := onchainService
+	if config := batchConfig(); config.Size > 1 {
+		uploadChain = onchain.WithBatching(onchainService, config)
+	}
+	anchorRepository := storage.NewAnchorRepository(db)
+	anchorService := anchor.NewAnchorService(transactor, controllerContractAddress, geneDataStorageService, anchorRepository)
+	anchorWindow, anchoring := anchorConfig()
+	if anchoring {
+		uploadChain = anchor.WithAnchoring(uploadChain, anchorService)
+	}
+	// Also anchors what was queued before anchoring was turned off, and retries unmined roots
+	go anchorService.Run(context.Background(), anchorWindow)
+	retentionService := retention.NewRetentionService(storage.NewRetentionRepository(db, blobs), retentionPolicy())
+	go retentionService.Run(context.Background(), retentionInterval())
+	// Accesses to genomic data are logged, and the log's head signed and optionally anchored
+	var auditAnchors storage.AnchorRepository
+	if auditAnchor() {
+		auditAnchors = anchorRepository
+	}
+	auditService := audit.NewAuditService(storage.NewAuditRepository(db), authService, privKey, auditAnchors)
+	go auditService.Run(context.Background(), auditSignInterval())
+	auditHandler := handler.NewAuditHandler(auditService, accessService)
+	genomicService := genomic.NewGenomicService(teeService, geneDataStorageService, authService, uploadChain, accessService, auditService, storage.NewUploadWorkflowRepository(db, blobs, gatewayID()), breaker)
+	genomicHandler := handler.NewGenomicHandler(genomicService, accessService)
+	fileHandler := handler.NewFileHandler(files.NewFileService(authService, geneDataStorageService), accessService)
+	exportService := bundle.NewExportService(teeService, authService, geneDataStorageService, storage.NewMarketplaceRepository(db, blobs), onchainService, accessService, auditService, privKey, profile.ChainID)
+	exportHandler := handler.NewExportHandler(exportService, accessService)
+
+	// Drain the uploads queued while the chain was down, and finish or abandon the ones
+	// interrupted by the last shutdown
+	breaker.OnRecover(func() {
+		if err := genomicService.RecoverUploads(); err != nil {
+			log.Printf("upload recovery failed: %v", err)
+		}
+	})
+	// Transfer history is scanned from the token deployment block. Relayed PCSP operations are
+	// bounded by the relayer's quota and denylist.
+	pcspHandler := handler.NewPCSPHandler(onchain.NewPcspService(client, transactor, profile.Contracts.PCSP.Address, profile.DeployBlock, pcspRelayConfig()))
+	nftHandler := handler.NewNFTHandler(nft.NewMetadataService(onchainService, geneDataStorageService))
+	recordHandler := handler.NewRecordHandler(records.NewRecordService(onchainService, geneDataStorageService))
+	anchorHandler := handler.NewAnchorHandler(anchorService)
+
+	// Gasless uploads are only offered when a trusted forwarder is deployed
+	var relayHandler handler.RelayHandler
+	if forwarderAddress := profile.Contracts.Forwarder.Address; forwarderAddress != (common.Address{}) {
+		relayHandler = handler.NewRelayHandler(onchain.NewRelayerService(client, transactor, relayerConfig(forwarderAddress, controllerContractAddress)))
+	}
+
+	// PCSP governance is only offered when a Governor is deployed
+	var governanceHandler handler.GovernanceHandler
+	if governorAddress := profile.Contracts.Governor.Address; governorAddress != (common.Address{}) {
+		governanceHandler = handler.NewGovernanceHandler(governance.NewGovernanceService(transactor, governorAddress, profile.DeployBlock))
+	}
 
-	controllerContractAddress := common.HexToAddress(os.Getenv("CONTROLLER_ADDRESS"))
-	_ = common.HexToAddress(os.Getenv("PCSP_ADDRESS"))
+	// Paid research analyses are only offered when the escrow is deployed
+	var marketplaceHandler handler.MarketplaceHandler
+	if escrowAddress := profile.Contracts.Escrow.Address; escrowAddress != (common.Address{}) {
+		marketplaceService := marketplace.NewMarketplaceService(transactor, escrowAddress, onchainService, teeService, privKey, storage.NewMarketplaceRepository(db, blobs), auditService)
+		marketplaceHandler = handler.NewMarketplaceHandler(marketplaceService)
 
-	onchainService := onchain.NewOnchainService(client, opts, controllerContractAddress)
-	genomicHandler := handler.NewGenomicHandler(teeService, geneDataStorageService, authService, onchainService)
-	pcspHandler := handler.NewPCSPHandler(onchain.NewPcspService(client, opts, common.HexToAddress(os.Getenv("PCSP_ADDRESS"))))
+		// Settle or refund the analyses whose payment was left in escrow by the last shutdown
+		breaker.OnRecover(func() {
+			if err := marketplaceService.RecoverJobs(); err != nil {
+				log.Printf("analysis recovery failed: %v", err)
+			}
+		})
+	}
+
+	go breaker.Run(context.Background())
+
+	balanceMonitor := onchain.NewBalanceMonitor(client, opts.From, balanceConfig())
+	go balanceMonitor.Run(context.Background())
+	requireFunds := handler.RequireFunds(balanceMonitor)
 
 	r.GET("/health", func(c *gin.Context) {
+		wallet := balanceMonitor.Status()
+		warnings := []string{}
+		if wallet.Low {
+			warnings = append(warnings, "service wallet balance is low")
+		}
+		if wallet.BelowFloor {
+			warnings = append(warnings, "service wallet balance is below the floor, uploads are refused")
+		}
+		chain := breaker.Status()
+		if profileMismatch.Load() {
+			warnings = append(warnings, "blockchain node does not match the network profile")
+		} else if !chain.Available {
+			warnings = append(warnings, "blockchain RPC is unreachable, uploads are queued")
+		}
+		if scheduleMismatch.Load() {
+			warnings = append(warnings, "reward schedule contradicts the TEE risk levels")
+		}
 		c.JSON(200, gin.H{
-			"message": "ok",
+			"message":  "ok",
+			"wallet":   wallet,
+			"chain":    chain,
+			"warnings": warnings,
+		})
+	})
+	// Ready in degraded mode too, since uploads are still accepted and queued, but not against a
+	// node that does not match the profile or a reward schedule that contradicts the TEE
+	r.GET("/ready", func(c *gin.Context) {
+		chain := breaker.Status()
+		if profileMismatch.Load() || scheduleMismatch.Load() {
+			c.JSON(503, gin.H{
+				"status": "misconfigured",
+				"chain":  chain,
+			})
+			return
+		}
+		status := "ready"
+		if !chain.Available {
+			status = "degraded"
+		}
+		c.JSON(200, gin.H{
+			"status": status,
+			"chain":  chain,
 		})
 	})
 	r.GET("/pcsp/balance", pcspHandler.GetPCSPBalance)
+	r.GET("/pcsp/allowance", pcspHandler.GetAllowance)
+	r.GET("/pcsp/supply", pcspHandler.GetTotalSupply)
+	r.GET("/pcsp/domain", pcspHandler.GetDomain)
+	r.GET("/pcsp/transfers", pcspHandler.GetTransferHistory)
+
+	// User-signed PCSP operations relayed by the service wallet
+	r.POST("/pcsp/approve", requireFunds, pcspHandler.Approve)
+	r.POST("/pcsp/transfer", requireFunds, pcspHandler.Transfer)
+	r.POST("/pcsp/burn", requireFunds, pcspHandler.Burn)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
-	// Create a new user
+	// Register a wallet, and sign it in, with Sign-In with Ethereum messages
+	r.GET("/auth/nonce", authHandler.Nonce)
 	r.POST("/auth/register", authHandler.Register)
+	r.POST("/auth/login", authHandler.Login)
 
 	// Upload genomic data
-	r.POST("/upload", genomicHandler.UploadGenomicData)
+	r.POST("/upload", handler.RequireSession(loginService), handler.RequireFundsOrQueue(balanceMonitor, breaker), genomicHandler.UploadGenomicData)
 
 	// Retrieve genomic data
 	r.GET("/retrieve", genomicHandler.RetrieveGenomicData)
 
+	// Files uploaded by the signing wallet
+	r.GET("/users/me/files", fileHandler.ListFiles)
+	r.DELETE("/users/me/files/:fileId", fileHandler.Withdraw)
+
+	// Signed bundle of the files of the signing wallet, for cmd/import-bundle
+	r.GET("/users/me/export", exportHandler.Export)
+
+	// Who accessed the data of the signing wallet, from the audit log
+	r.GET("/users/me/access-log", auditHandler.ListAccessLog)
+
+	// GeneNFT metadata, served as the token URI
+	r.GET("/nft/:tokenId", nftHandler.GetMetadata)
+
+	// Upload sessions and docs, checked against the local records
+	r.GET("/sessions/:id", recordHandler.GetSession)
+	r.GET("/docs/:docId", recordHandler.GetDoc)
+
+	// Inclusion proofs of content hashes anchored in Merkle roots
+	r.GET("/files/:fileId/proof", anchorHandler.GetProof)
+
+	// Meta-transactions relayed through the trusted forwarder
+	if relayHandler != nil {
+		r.POST("/relay", requireFunds, relayHandler.Relay)
+		r.GET("/relay/nonce", relayHandler.GetNonce)
+		r.GET("/relay/:txHash", relayHandler.GetRelay)
+	}
+
+	// Proposals and votes weighted by delegated PCSP, relayed by the service wallet
+	if governanceHandler != nil {
+		r.GET("/governance/domain", governanceHandler.GetDomain)
+		r.GET("/governance/votes", governanceHandler.GetVotingPower)
+		r.POST("/governance/delegate", requireFunds, governanceHandler.Delegate)
+		r.GET("/governance/proposals", governanceHandler.ListProposals)
+		r.POST("/governance/proposals", requireFunds, governanceHandler.Propose)
+		r.GET("/governance/proposals/:id", governanceHandler.GetProposal)
+		r.POST("/governance/proposals/:id/votes", requireFunds, governanceHandler.CastVote)
+	}
+
+	// Administrative endpoints are only served when ADMIN_API_KEY is set
+	if adminKey := os.Getenv("ADMIN_API_KEY"); adminKey != "" {
+		rewardHandler := handler.NewRewardHandler(rewardService)
+		admin := r.Group("/admin", handler.RequireAdmin(adminKey))
+		admin.GET("/rewards/schedule", rewardHandler.GetSchedule)
+		admin.POST("/rewards/schedule", requireFunds, rewardHandler.ProposeSchedule)
+	}
+
+	// Research analyses paid in PCSP through the escrow, run in the TEE over consented data
+	if marketplaceHandler != nil {
+		r.GET("/marketplace/domain", marketplaceHandler.GetDomain)
+		r.PUT("/marketplace/consents/:tokenId", marketplaceHandler.SetConsent)
+		r.POST("/marketplace/requests", requireFunds, marketplaceHandler.RequestAnalysis)
+		r.GET("/marketplace/requests/:id", marketplaceHandler.GetAnalysis)
+	}
+
 	return r
 }
+
+// relayerConfig only relays uploadData calls to the Controller, bounded by RELAYER_MAX_GAS and the quotas of
+// pcspRelayConfig, and tracks settled relays for RELAYER_RECORD_TTL (default 24h).
+func relayerConfig(forwarder common.Address, controller common.Address) onchain.RelayerConfig {
+	maxGas, err := strconv.ParseUint(os.Getenv("RELAYER_MAX_GAS"), 10, 64)
+	if err != nil || maxGas == 0 {
+		maxGas = 1000000
+	}
+	recordTTL, err := time.ParseDuration(os.Getenv("RELAYER_RECORD_TTL"))
+	if err != nil || recordTTL <= 0 {
+		recordTTL = 24 * time.Hour
+	}
+	limits := pcspRelayConfig()
+
+	return onchain.RelayerConfig{
+		ForwarderAddress:     forwarder,
+		AllowedTargets:       []onchain.RelayTarget{onchain.ControllerRelayTarget(controller)},
+		MaxGas:               maxGas,
+		QuotaPerWindow:       limits.QuotaPerWindow,
+		GlobalQuotaPerWindow: limits.GlobalQuotaPerWindow,
+		QuotaWindow:          limits.QuotaWindow,
+		Denylist:             limits.Denylist,
+		RecordTTL:            recordTTL,
+	}
+}
+
+// pcspRelayConfig allows RELAYER_QUOTA (default 20) relayed PCSP operations per signer per hour,
+// and RELAYER_GLOBAL_QUOTA (default 500) in all, and refuses signers in the comma-separated
+// RELAYER_DENYLIST.
+func pcspRelayConfig() onchain.PcspRelayConfig {
+	quota, err := strconv.Atoi(os.Getenv("RELAYER_QUOTA"))
+	if err != nil || quota <= 0 {
+		quota = 20
+	}
+	globalQuota, err := strconv.Atoi(os.Getenv("RELAYER_GLOBAL_QUOTA"))
+	if err != nil || globalQuota <= 0 {
+		globalQuota = 500
+	}
+
+	var denylist []common.Address
+	for _, address := range strings.Split(os.Getenv("RELAYER_DENYLIST"), ",") {
+		if address = strings.TrimSpace(address); common.IsHexAddress(address) {
+			denylist = append(denylist, common.HexToAddress(address))
+		}
+	}
+
+	return onchain.PcspRelayConfig{
+		QuotaPerWindow:       quota,
+		GlobalQuotaPerWindow: globalQuota,
+		QuotaWindow:          time.Hour,
+		Denylist:             denylist,
+	}
+}
+
+// isProfileMismatch tells a node serving the wrong chain or contracts from an unreachable one.
+func isProfileMismatch(err error) bool {
+	return errors.Is(err, network.ErrChainMismatch) || errors.Is(err, network.ErrCodeMismatch)
+}
+
+// isScheduleMismatch tells a reward schedule that contradicts the TEE from a failure to read it.
+func isScheduleMismatch(err error) bool {
+	return errors.Is(err, rewards.ErrInvalidSchedule) || errors.Is(err, rewards.ErrInconsistentSchedule)
+}
+
+// breakerConfig opens the RPC circuit breaker after RPC_FAILURE_THRESHOLD (default 3) consecutive
+// failed requests, and probes the node after RPC_MIN_BACKOFF (default 1s), doubling up to
+// RPC_MAX_BACKOFF (default 1m) while it stays down.
+func breakerConfig() onchain.BreakerConfig {
+	threshold, err := strconv.Atoi(os.Getenv("RPC_FAILURE_THRESHOLD"))
+	if err != nil || threshold < 1 {
+		threshold = 3
+	}
+	minBackoff, err := time.ParseDuration(os.Getenv("RPC_MIN_BACKOFF"))
+	if err != nil || minBackoff <= 0 {
+		minBackoff = time.Second
+	}
+	maxBackoff, err := time.ParseDuration(os.Getenv("RPC_MAX_BACKOFF"))
+	if err != nil || maxBackoff < minBackoff {
+		maxBackoff = max(time.Minute, minBackoff)
+	}
+	return onchain.BreakerConfig{Threshold: threshold, MinBackoff: minBackoff, MaxBackoff: maxBackoff}
+}
+
+// batchConfig groups up to UPLOAD_BATCH_SIZE uploads per transaction, waiting at most
+// UPLOAD_BATCH_WINDOW for a batch to fill. Batching is off unless the size is above 1.
+func batchConfig() onchain.BatchConfig {
+	size, err := strconv.Atoi(os.Getenv("UPLOAD_BATCH_SIZE"))
+	if err != nil || size < 1 {
+		size = 1
+	}
+	window, err := time.ParseDuration(os.Getenv("UPLOAD_BATCH_WINDOW"))
+	if err != nil || window <= 0 {
+		window = 2 * time.Second
+	}
+	return onchain.BatchConfig{Size: size, Window: window}
+}
+
+// anchorConfig turns on anchoring mode when ANCHOR_WINDOW is set: content hashes are then
+// anchored on-chain once per window in a Merkle root, instead of stored with each doc.
+func anchorConfig() (time.Duration, bool) {
+	window, err := time.ParseDuration(os.Getenv("ANCHOR_WINDOW"))
+	if err != nil || window <= 0 {
+		return anchor.DefaultWindow, false
+	}
+	return window, true
+}
+
+// fingerprintKey is the TEE secret keying genome fingerprints, from the hex TEE_FINGERPRINT_KEY. It
+// must stay the same for the life of the database, or duplicates are no longer recognized.
+func fingerprintKey() []byte {
+	key, err := tee.ParseFingerprintKey(os.Getenv("TEE_FINGERPRINT_KEY"))
+	if err != nil {
+		panic("TEE_FINGERPRINT_KEY must be at least 32 bytes of hex")
+	}
+	return key
+}
+
+// gatewayID names this replica in the upload workflows it claims, from GATEWAY_ID or the host name.
+// It must be stable across restarts, so a restarted replica resumes its own uploads at once.
+func gatewayID() string {
+	if id := os.Getenv("GATEWAY_ID"); id != "" {
+		return id
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		panic(fmt.Errorf("GATEWAY_ID is not set and the host name is unknown: %v", err))
+	}
+	return host
+}
+
+// retentionPolicy reads RETENTION_POLICY, "until-withdrawn" by default or e.g. "raw-after:30d",
+// RETENTION_GRACE before soft-deleted raw data is purged (7 days by default), and RETENTION_DRY_RUN.
+func retentionPolicy() retention.Policy {
+	policy, err := retention.ParsePolicy(os.Getenv("RETENTION_POLICY"))
+	if err != nil {
+		panic(err)
+	}
+	if grace, err := time.ParseDuration(os.Getenv("RETENTION_GRACE")); err == nil && grace >= 0 {
+		policy.Grace = grace
+	}
+	if dryRun, err := strconv.ParseBool(os.Getenv("RETENTION_DRY_RUN")); err == nil {
+		policy.DryRun = dryRun
+	}
+	return policy
+}
+
+// retentionInterval is how often the retention policy is evaluated, from RETENTION_INTERVAL.
+func retentionInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("RETENTION_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return retention.DefaultInterval
+	}
+	return interval
+}
+
+// loginConfig accepts sign-in messages for SIWE_DOMAIN (localhost:8080 by default) on the chain of
+// the profile, for SIWE_SESSION_TTL (24 hours by default).
+func loginConfig(profile *network.Profile) auth.LoginConfig {
+	config := auth.LoginConfig{
+		Domain:     os.Getenv("SIWE_DOMAIN"),
+		ChainID:    profile.ChainID,
+		NonceTTL:   auth.DefaultNonceTTL,
+		SessionTTL: auth.DefaultSessionTTL,
+	}
+	if config.Domain == "" {
+		config.Domain = "localhost:8080"
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("SIWE_SESSION_TTL")); err == nil && ttl > 0 {
+		config.SessionTTL = ttl
+	}
+	return config
+}
+
+// auditSignInterval is how often the head of the audit log is signed, from AUDIT_SIGN_INTERVAL.
+func auditSignInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("AUDIT_SIGN_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return audit.DefaultSignInterval
+	}
+	return interval
+}
+
+// auditAnchor also queues every signed head of the audit log for the content anchor when
+// AUDIT_ANCHOR is true.
+func auditAnchor() bool {
+	anchor, _ := strconv.ParseBool(os.Getenv("AUDIT_ANCHOR"))
+	return anchor
+}
+
+// teeCodec is the compression the TEE applies before sealing, from TEE_COMPRESSION: "zstd" (the
+// default), "gzip" or "none". Data sealed with any of them can always be opened.
+func teeCodec() tee.Codec {
+	codec, err := tee.ParseCodec(os.Getenv("TEE_COMPRESSION"))
+	if err != nil {
+		panic(err)
+	}
+	return codec
+}
+
+// loadProfile selects the NETWORK profile (local-lifenetwork by default), optionally completed
+// by the NETWORK_PROFILES file and the legacy address variables.
+func loadProfile() *network.Profile {
+	name := os.Getenv("NETWORK")
+	if name == "" {
+		name = network.DefaultProfile
+	}
+	profile, err := network.Load(name, os.Getenv("NETWORK_PROFILES"))
+	if err != nil {
+		panic(err)
+	}
+	if err := profile.ApplyEnv(); err != nil {
+		panic(err)
+	}
+	if err := profile.Validate(); err != nil {
+		panic(err)
+	}
+	return profile
+}
+
+// feeConfig starts from the profile's fee policy, which can be overridden with GAS_LEGACY for
+// subnets without EIP-1559, GAS_MAX_FEE_GWEI and GAS_MAX_TIP_GWEI ceilings, GAS_TIP_PERCENTILE
+// of recent tips and GAS_ESTIMATE_MARGIN in percent.
+func feeConfig(profile *network.Profile) onchain.FeeConfig {
+	// Validated with the profile
+	config, _ := profile.Fees.FeeConfig()
+	if legacy, err := strconv.ParseBool(os.Getenv("GAS_LEGACY")); err == nil {
+		config.Legacy = legacy
+	}
+	if maxFee, err := onchain.ParseUnits(os.Getenv("GAS_MAX_FEE_GWEI"), 9); err == nil && maxFee.Sign() > 0 {
+		config.MaxFeeCap = maxFee
+	}
+	if maxTip, err := onchain.ParseUnits(os.Getenv("GAS_MAX_TIP_GWEI"), 9); err == nil && maxTip.Sign() > 0 {
+		config.MaxTipCap = maxTip
+	}
+	if percentile, err := strconv.ParseFloat(os.Getenv("GAS_TIP_PERCENTILE"), 64); err == nil && percentile >= 0 && percentile <= 100 {
+		config.TipPercentile = percentile
+	}
+	if margin, err := strconv.ParseUint(os.Getenv("GAS_ESTIMATE_MARGIN"), 10, 64); err == nil {
+		config.GasMargin = margin
+	}
+	return config
+}
+
+// balanceConfig reads WALLET_WARN_BALANCE and WALLET_MIN_BALANCE, in LIFE.
+func balanceConfig() onchain.BalanceConfig {
+	config := onchain.BalanceConfig{Interval: time.Minute}
+	config.Warn, _ = onchain.ParseUnits(os.Getenv("WALLET_WARN_BALANCE"), 18)
+	if config.Warn == nil {
+		config.Warn, _ = onchain.ParseUnits("1", 18)
+	}
+	config.Floor, _ = onchain.ParseUnits(os.Getenv("WALLET_MIN_BALANCE"), 18)
+	if config.Floor == nil {
+		config.Floor, _ = onchain.ParseUnits("0.1", 18)
+	}
+	return config
+}
@@ -2,6 +2,8 @@ package server_test
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,12 +11,17 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/TropicalDog17/genomic-dao-service/internal/access"
 	"github.com/TropicalDog17/genomic-dao-service/internal/auth"
+	"github.com/TropicalDog17/genomic-dao-service/internal/migrate"
 	"github.com/TropicalDog17/genomic-dao-service/internal/server"
-	"github.com/TropicalDog17/genomic-dao-service/internal/storage"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/assert"
@@ -46,6 +53,7 @@ func (s *IntegrationTestSuite) SetupSuite() {
 
 	// TODO: pre-fund the test private key
 	os.Setenv("PRIVATE_KEY", testPrivKey)
+	os.Setenv("TEE_FINGERPRINT_KEY", strings.Repeat("ab", 32))
 
 	var err error
 	s.db, err = gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
@@ -54,11 +62,7 @@ func (s *IntegrationTestSuite) SetupSuite() {
 	}
 
 	// Run migrations
-	err = s.db.AutoMigrate(&auth.User{})
-	if err != nil {
-		s.T().Fatal("Failed to run migrations:", err)
-	}
-	err = s.db.AutoMigrate(&storage.GeneData{})
+	_, err = migrate.NewMigrator(s.db).Up()
 	if err != nil {
 		s.T().Fatal("Failed to run migrations:", err)
 	}
@@ -77,14 +81,21 @@ func (s *IntegrationTestSuite) SetupSuite() {
 func (s *IntegrationTestSuite) TestCompleteUserFlow() {
 	t := s.T()
 
-	// 1. Test user registration
-	registerPayload := map[string]interface{}{
-		"address": testPubKey,
-	}
-	registerBody, _ := json.Marshal(registerPayload)
-	resp, err := http.Post("http://localhost:8080/auth/register", "application/json", bytes.NewBuffer(registerBody))
+	privKey, err := crypto.HexToECDSA(testPrivKey)
+	assert.NoError(t, err)
+
+	// 1. Test user registration and sign-in, each with a signed message and a fresh nonce
+	resp, err := http.Post("http://localhost:8080/auth/register", "application/json", bytes.NewBuffer(s.signIn(privKey)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Post("http://localhost:8080/auth/login", "application/json", bytes.NewBuffer(s.signIn(privKey)))
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var token auth.Token
+	err = json.NewDecoder(resp.Body).Decode(&token)
+	assert.NoError(t, err)
+	resp.Body.Close()
 
 	// 2. Test genomic data upload using multipart/form-data
 	var b bytes.Buffer
@@ -96,12 +107,6 @@ func (s *IntegrationTestSuite) TestCompleteUserFlow() {
 	_, err = genomicField.Write([]byte("LifeNetwork, Decentralized Science, Blockchain, Genomic Data"))
 	assert.NoError(t, err)
 
-	// Add pubkey field
-	pubkeyField, err := writer.CreateFormField("pubkey")
-	assert.NoError(t, err)
-	_, err = pubkeyField.Write([]byte(testPubKey))
-	assert.NoError(t, err)
-
 	// Close the writer
 	err = writer.Close()
 	assert.NoError(t, err)
@@ -110,6 +115,7 @@ func (s *IntegrationTestSuite) TestCompleteUserFlow() {
 	req, err := http.NewRequest("POST", "http://localhost:8080/upload", &b)
 	assert.NoError(t, err)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token.Token)
 
 	client := &http.Client{}
 	resp, err = client.Do(req)
@@ -131,9 +137,21 @@ func (s *IntegrationTestSuite) TestCompleteUserFlow() {
 	assert.NotEmpty(t, uploadResponse.FileID, "FileID should not be empty")
 	assert.NotEmpty(t, uploadResponse.SessionID, "SessionID should not be empty")
 
-	// 3. Test genomic data retrieval
+	// 3. Test genomic data retrieval, signed by the GeneNFT owner
 	resp, err = http.Get(fmt.Sprintf("http://localhost:8080/retrieve?fileID=%s", uploadResponse.FileID))
 	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	chainID, err := s.client.ChainID(context.Background())
+	assert.NoError(t, err)
+	timestamp := time.Now().Unix()
+	message := access.RetrieveMessage("localhost:8080", chainID.Uint64(), uploadResponse.FileID, "", timestamp)
+	signature, err := crypto.Sign(accounts.TextHash([]byte(message)), privKey)
+	assert.NoError(t, err)
+
+	resp, err = http.Get(fmt.Sprintf("http://localhost:8080/retrieve?fileID=%s&address=%s&timestamp=%d&signature=%s",
+		uploadResponse.FileID, testPubKey, timestamp, hexutil.Encode(signature)))
+	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	var retrieveResponse map[string]interface{}
 	err = json.NewDecoder(resp.Body).Decode(&retrieveResponse)
@@ -152,6 +170,32 @@ func (s *IntegrationTestSuite) TestCompleteUserFlow() {
 	fmt.Println("PCSP balance:", balanceResponse["balance"])
 }
 
+// signIn signs a Sign-In with Ethereum message for a new nonce, as the body of a register or login request.
+func (s *IntegrationTestSuite) signIn(privKey *ecdsa.PrivateKey) []byte {
+	t := s.T()
+	resp, err := http.Get("http://localhost:8080/auth/nonce")
+	assert.NoError(t, err)
+	var challenge auth.Challenge
+	err = json.NewDecoder(resp.Body).Decode(&challenge)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	message := (&auth.Message{
+		Domain:    challenge.Domain,
+		Address:   testPubKey,
+		Statement: "Sign in to GenomicDAO",
+		URI:       "http://" + challenge.Domain,
+		Version:   "1",
+		ChainID:   challenge.ChainID,
+		Nonce:     challenge.Nonce,
+		IssuedAt:  time.Now(),
+	}).String()
+	signature, err := crypto.Sign(accounts.TextHash([]byte(message)), privKey)
+	assert.NoError(t, err)
+	body, _ := json.Marshal(map[string]string{"message": message, "signature": hexutil.Encode(signature)})
+	return body
+}
+
 // UploadResponse struct to match the handler's response structure
 type UploadResponse struct {
 	SessionID string `json:"sessionID"`
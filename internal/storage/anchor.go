@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Content anchor statuses.
+const (
+	AnchorPending  = "pending"
+	AnchorAnchored = "anchored"
+)
+
+// ContentAnchor is a Merkle root over a batch of content hashes, anchored on-chain in place of the hashes.
+type ContentAnchor struct {
+	gorm.Model
+	Root        string `gorm:"uniqueIndex"`
+	LeafCount   int
+	Status      string `gorm:"index"`
+	TxHash      string
+	BlockNumber uint64
+}
+
+// AnchorLeaf is the content hash of a doc confirmed without one. It waits for the next anchor
+// while AnchorID is nil, and then sits at LeafIndex in that anchor's tree.
+type AnchorLeaf struct {
+	gorm.Model
+	DocID       string `gorm:"uniqueIndex"`
+	ContentHash string
+	AnchorID    *uint `gorm:"index"`
+	LeafIndex   int
+}
+
+// TableName matches the table created by the content anchor migration.
+func (AnchorLeaf) TableName() string {
+	return "anchor_leaves"
+}
+
+type AnchorRepository interface {
+	AddLeaf(leaf *AnchorLeaf) error
+	FindPendingLeaves() ([]AnchorLeaf, error)
+	CreateAnchor(anchor *ContentAnchor, leaves []AnchorLeaf) error
+	SaveAnchor(anchor *ContentAnchor) error
+	FindPendingAnchors() ([]ContentAnchor, error)
+	FindAnchor(id uint) (*ContentAnchor, error)
+	FindLeaf(docID string) (*AnchorLeaf, error)
+	FindAnchorLeaves(anchorID uint) ([]AnchorLeaf, error)
+}
+
+type anchorRepository struct {
+	db *gorm.DB
+}
+
+// NewAnchorRepository creates a new content anchor repository.
+func NewAnchorRepository(db *gorm.DB) AnchorRepository {
+	return &anchorRepository{db}
+}
+
+// AddLeaf queues a doc's content hash; a doc that is already queued keeps its first hash.
+func (r *anchorRepository) AddLeaf(leaf *AnchorLeaf) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "doc_id"}},
+		DoNothing: true,
+	}).Create(leaf).Error
+}
+
+// FindPendingLeaves returns the leaves not yet in an anchor, in the order they were queued.
+func (r *anchorRepository) FindPendingLeaves() ([]AnchorLeaf, error) {
+	var leaves []AnchorLeaf
+	err := r.db.Where("anchor_id IS NULL").Order("id").Find(&leaves).Error
+	return leaves, err
+}
+
+// CreateAnchor stores the anchor and assigns the leaves to it, in order, together.
+func (r *anchorRepository) CreateAnchor(anchor *ContentAnchor, leaves []AnchorLeaf) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(anchor).Error; err != nil {
+			return err
+		}
+
+		for i := range leaves {
+			leaves[i].AnchorID = &anchor.ID
+			leaves[i].LeafIndex = i
+			if err := tx.Model(&leaves[i]).Select("anchor_id", "leaf_index").Updates(&leaves[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *anchorRepository) SaveAnchor(anchor *ContentAnchor) error {
+	return r.db.Save(anchor).Error
+}
+
+// FindPendingAnchors returns the anchors whose root may not be on-chain yet.
+func (r *anchorRepository) FindPendingAnchors() ([]ContentAnchor, error) {
+	var anchors []ContentAnchor
+	err := r.db.Where("status = ?", AnchorPending).Order("id").Find(&anchors).Error
+	return anchors, err
+}
+
+func (r *anchorRepository) FindAnchor(id uint) (*ContentAnchor, error) {
+	var anchor ContentAnchor
+	if err := r.db.First(&anchor, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &anchor, nil
+}
+
+func (r *anchorRepository) FindLeaf(docID string) (*AnchorLeaf, error) {
+	var leaf AnchorLeaf
+	if err := r.db.Where("doc_id = ?", docID).First(&leaf).Error; err != nil {
+		return nil, err
+	}
+
+	return &leaf, nil
+}
+
+// FindAnchorLeaves returns the leaves of an anchor in tree order.
+func (r *anchorRepository) FindAnchorLeaves(anchorID uint) ([]AnchorLeaf, error) {
+	var leaves []AnchorLeaf
+	err := r.db.Where("anchor_id = ?", anchorID).Order("leaf_index").Find(&leaves).Error
+	return leaves, err
+}
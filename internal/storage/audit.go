@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"gorm.io/gorm"
+)
+
+// Audit actions
+const (
+	AuditRetrieve = "retrieve"
+	AuditAnalyze  = "analyze"
+	AuditExport   = "export"
+)
+
+// Audit outcomes
+const (
+	AuditAllowed = "allowed"
+	AuditDenied  = "denied"
+	AuditFailed  = "failed"
+)
+
+// GenesisHash is the PrevHash of the first audit event.
+const GenesisHash = "0x0000000000000000000000000000000000000000000000000000000000000000"
+
+// appendAttempts bounds the retries of an append that lost the race for its sequence number.
+const appendAttempts = 5
+
+// AuditEvent is one access to the genomic data of a user. Events are only ever appended: each one
+// is numbered by Seq and carries the hash of the one before, so an edited, removed or inserted event
+// breaks the chain. It has no soft delete.
+type AuditEvent struct {
+	ID        uint   `gorm:"primarykey"`
+	Seq       uint64 `gorm:"uniqueIndex"`
+	CreatedAt time.Time
+	// Wallet that accessed the data
+	Actor   string
+	Action  string
+	FileID  string `gorm:"index"`
+	UserID  uint32 `gorm:"index"`
+	Purpose string
+	Outcome string
+	// Hash of the previous event, GenesisHash for the first
+	PrevHash string
+	Hash     string
+}
+
+// ComputeHash hashes the event with the hash of the one before: the SHA-256 of the JSON array of its
+// fields in declaration order, its time in Unix microseconds, as a 0x-prefixed hex string.
+func (e *AuditEvent) ComputeHash() string {
+	// Only strings and integers, which always encode
+	encoded, _ := json.Marshal([]interface{}{
+		e.Seq, e.CreatedAt.UnixMicro(), e.Actor, e.Action, e.FileID, e.UserID, e.Purpose, e.Outcome, e.PrevHash,
+	})
+	sum := sha256.Sum256(encoded)
+	return hexutil.Encode(sum[:])
+}
+
+// AuditHead is the head of the audit chain at Seq, signed by the service wallet. A head queued for
+// the content anchor keeps its doc ID in AnchorDocID.
+type AuditHead struct {
+	ID          uint   `gorm:"primarykey"`
+	Seq         uint64 `gorm:"uniqueIndex"`
+	Hash        string
+	Signer      string
+	Signature   string
+	SignedAt    time.Time
+	AnchorDocID string
+}
+
+type AuditRepository interface {
+	Append(event *AuditEvent) error
+	FindLastEvent() (*AuditEvent, error)
+	FindEvents(afterSeq uint64, limit int) ([]AuditEvent, error)
+	FindUserEvents(userID uint32, beforeSeq uint64, limit int) ([]AuditEvent, error)
+	SaveHead(head *AuditHead) error
+	FindLastHead() (*AuditHead, error)
+	FindHeads(afterSeq uint64, limit int) ([]AuditHead, error)
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit log repository.
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{db}
+}
+
+// Append numbers the event after the last one, chains it to its hash and stores it. Its time is kept
+// to the microsecond, as PostgreSQL does. Replicas appending together race for the next Seq; the
+// unique index lets one win and the others retry.
+func (r *auditRepository) Append(event *AuditEvent) error {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	event.CreatedAt = event.CreatedAt.UTC().Truncate(time.Microsecond)
+
+	var err error
+	for attempt := 0; attempt < appendAttempts; attempt++ {
+		err = r.db.Transaction(func(tx *gorm.DB) error {
+			event.Seq, event.PrevHash = 1, GenesisHash
+			var last AuditEvent
+			err := tx.Order("seq DESC").Limit(1).Take(&last).Error
+			switch {
+			case err == nil:
+				event.Seq, event.PrevHash = last.Seq+1, last.Hash
+			case !errors.Is(err, gorm.ErrRecordNotFound):
+				return err
+			}
+			event.ID = 0
+			event.Hash = event.ComputeHash()
+			return tx.Create(event).Error
+		})
+		if err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to append audit event: %w", err)
+}
+
+// FindLastEvent returns the head of the chain, or gorm.ErrRecordNotFound while it is empty.
+func (r *auditRepository) FindLastEvent() (*AuditEvent, error) {
+	var event AuditEvent
+	if err := r.db.Order("seq DESC").Limit(1).Take(&event).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// FindEvents returns the events after afterSeq, in order.
+func (r *auditRepository) FindEvents(afterSeq uint64, limit int) ([]AuditEvent, error) {
+	var events []AuditEvent
+	err := r.db.Where("seq > ?", afterSeq).Order("seq").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// FindUserEvents returns the events on the data of a user, newest first, from before beforeSeq
+// unless it is zero.
+func (r *auditRepository) FindUserEvents(userID uint32, beforeSeq uint64, limit int) ([]AuditEvent, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if beforeSeq > 0 {
+		query = query.Where("seq < ?", beforeSeq)
+	}
+	var events []AuditEvent
+	err := query.Order("seq DESC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (r *auditRepository) SaveHead(head *AuditHead) error {
+	return r.db.Save(head).Error
+}
+
+// FindLastHead returns the last signed head, or gorm.ErrRecordNotFound before the first.
+func (r *auditRepository) FindLastHead() (*AuditHead, error) {
+	var head AuditHead
+	if err := r.db.Order("seq DESC").Limit(1).Take(&head).Error; err != nil {
+		return nil, err
+	}
+	return &head, nil
+}
+
+// FindHeads returns the signed heads after afterSeq, in order.
+func (r *auditRepository) FindHeads(afterSeq uint64, limit int) ([]AuditHead, error) {
+	var heads []AuditHead
+	err := r.db.Where("seq > ?", afterSeq).Order("seq").Limit(limit).Find(&heads).Error
+	return heads, err
+}
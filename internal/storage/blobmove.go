@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"gorm.io/gorm"
+)
+
+// legacyGeneData is a gene data row still holding its encrypted data in the database.
+type legacyGeneData struct {
+	ID            uint
+	EncryptedData []byte
+}
+
+// MoveBlobs moves the encrypted data kept in the legacy gene_data.encrypted_data column to the
+// blob store, batchSize rows at a time, then drops the column. Rows are committed one by one, so
+// an interrupted move can simply be run again. It returns the number of rows moved.
+func MoveBlobs(db *gorm.DB, blobs blobstore.BlobStore, batchSize int) (int, error) {
+	if !db.Migrator().HasColumn(&GeneData{}, "encrypted_data") {
+		return 0, nil
+	}
+
+	moved := 0
+	for {
+		var rows []legacyGeneData
+		err := db.Unscoped().Model(&GeneData{}).Select("id, encrypted_data").
+			Where("blob_key IS NULL OR blob_key = ''").Order("id").Limit(batchSize).
+			Find(&rows).Error
+		if err != nil {
+			return moved, fmt.Errorf("failed to read gene data: %v", err)
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			blob, err := blobs.Put(row.EncryptedData)
+			if err != nil {
+				return moved, fmt.Errorf("failed to move gene data %d: %w", row.ID, err)
+			}
+			// The legacy column is NOT NULL until it is dropped
+			err = db.Unscoped().Model(&GeneData{}).Where("id = ?", row.ID).Updates(map[string]interface{}{
+				"blob_key":       blob.Key,
+				"blob_size":      blob.Size,
+				"blob_checksum":  blob.Checksum,
+				"encrypted_data": []byte{},
+			}).Error
+			if err != nil {
+				return moved, fmt.Errorf("failed to update gene data %d: %v", row.ID, err)
+			}
+			moved++
+		}
+	}
+
+	if err := db.Exec("ALTER TABLE gene_data DROP COLUMN encrypted_data").Error; err != nil {
+		return moved, fmt.Errorf("failed to drop encrypted_data: %v", err)
+	}
+	return moved, nil
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"gorm.io/gorm"
+)
+
+func TestMoveBlobs(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		blobs, err := blobstore.NewFileStore(t.TempDir())
+		if err != nil {
+			t.Fatalf("Failed to open blob store: %v", err)
+		}
+
+		// Rows written before the blob store, one of them soft-deleted
+		blobType := "BLOB"
+		if db.Dialector.Name() == "postgres" {
+			blobType = "BYTEA"
+		}
+		db.Exec("ALTER TABLE gene_data ADD COLUMN encrypted_data " + blobType)
+		legacy := map[string]string{"f1": "one", "f2": "two", "f3": "three"}
+		for fileID, data := range legacy {
+			db.Exec("INSERT INTO gene_data (file_id, user_id, encrypted_data) VALUES (?, 1, ?)", fileID, []byte(data))
+		}
+		db.Where("file_id = ?", "f3").Delete(&GeneData{})
+		repo := NewGenDataRepository(db, blobs)
+		if _, err := repo.StoreGeneData(1, []byte("four"), nil, []byte("already stored")); err != nil {
+			t.Fatalf("StoreGeneData() error = %v", err)
+		}
+
+		moved, err := MoveBlobs(db, blobs, 2)
+		if err != nil {
+			t.Fatalf("MoveBlobs() error = %v", err)
+		}
+		if moved != len(legacy) {
+			t.Errorf("MoveBlobs() moved %d rows, want %d", moved, len(legacy))
+		}
+		if db.Migrator().HasColumn(&GeneData{}, "encrypted_data") {
+			t.Errorf("Expected encrypted_data to be dropped")
+		}
+
+		for fileID, want := range legacy {
+			var geneData GeneData
+			db.Unscoped().Where("file_id = ?", fileID).First(&geneData)
+			got, err := loadBlob(blobs, &geneData)
+			if err != nil || string(got) != want {
+				t.Errorf("Blob of %s = %q, %v, want %q", fileID, got, err, want)
+			}
+		}
+
+		// Running it again is a no-op
+		if moved, err := MoveBlobs(db, blobs, 2); err != nil || moved != 0 {
+			t.Errorf("MoveBlobs() again = %d, %v, want 0, nil", moved, err)
+		}
+	})
+}
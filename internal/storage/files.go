@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// File listing orders. Uploads are ordered by ID, which follows the upload time.
+const (
+	SortUploaded = "uploaded"
+	SortSize     = "size"
+)
+
+// MaxFilePageSize caps the files listed at once.
+const MaxFilePageSize = 100
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// FileQuery filters and orders the files of a user. The zero value lists them all, newest first.
+type FileQuery struct {
+	// Upload status; any when empty
+	Status string
+	// Data format; any when empty
+	Format    string
+	Sort      string
+	Ascending bool
+	// Cursor continues from the NextCursor of the previous page
+	Cursor string
+	Limit  int
+}
+
+// FilePage is one page of files, with the cursor of the next one when more are left.
+type FilePage struct {
+	Files      []GeneData
+	NextCursor string
+}
+
+// fileCursor points after the last file of a page, by its sort key.
+type fileCursor struct {
+	ID   uint  `json:"id"`
+	Size int64 `json:"size,omitempty"`
+}
+
+func (c fileCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeFileCursor(cursor string) (*fileCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c fileCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// ListFiles returns one page of the files uploaded by a user.
+func (r *genDataRepository) ListFiles(userID uint32, query FileQuery) (*FilePage, error) {
+	if query.Limit < 1 || query.Limit > MaxFilePageSize {
+		query.Limit = MaxFilePageSize
+	}
+
+	db := r.db.Where("user_id = ?", userID)
+	if query.Status != "" {
+		db = db.Where("upload_status = ?", query.Status)
+	}
+	if query.Format != "" {
+		db = db.Where("format = ?", query.Format)
+	}
+
+	direction, after := "DESC", "<"
+	if query.Ascending {
+		direction, after = "ASC", ">"
+	}
+	var cursor *fileCursor
+	if query.Cursor != "" {
+		var err error
+		if cursor, err = decodeFileCursor(query.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	switch query.Sort {
+	case "", SortUploaded:
+		if cursor != nil {
+			db = db.Where(fmt.Sprintf("id %s ?", after), cursor.ID)
+		}
+		db = db.Order("id " + direction)
+	case SortSize:
+		if cursor != nil {
+			db = db.Where(fmt.Sprintf("(blob_size %s ? OR (blob_size = ? AND id %s ?))", after, after), cursor.Size, cursor.Size, cursor.ID)
+		}
+		db = db.Order("blob_size " + direction).Order("id " + direction)
+	default:
+		return nil, fmt.Errorf("unknown sort order %q", query.Sort)
+	}
+
+	// One more than the page tells whether another page follows
+	var files []GeneData
+	if err := db.Limit(query.Limit + 1).Find(&files).Error; err != nil {
+		return nil, err
+	}
+
+	page := &FilePage{Files: files}
+	if len(files) > query.Limit {
+		page.Files = files[:query.Limit]
+		last := page.Files[query.Limit-1]
+		next := fileCursor{ID: last.ID}
+		if query.Sort == SortSize {
+			next.Size = last.BlobSize
+		}
+		page.NextCursor = next.encode()
+	}
+	return page, nil
+}
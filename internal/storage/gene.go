@@ -1,45 +1,110 @@
 package storage
 
 import (
-	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
 	"gorm.io/gorm"
 )
 
+// LegacyFileIDLength is the length of the file IDs given before they were content-derived.
+const LegacyFileIDLength = 16
+
+// ErrAmbiguousFileID reports a legacy file ID shared by several files.
+var ErrAmbiguousFileID = errors.New("file ID is ambiguous, use the full-length file ID")
+
+// ErrRawDataPurged reports a file whose encrypted data was deleted under the retention policy.
+var ErrRawDataPurged = errors.New("the raw data of this file was deleted under the retention policy")
+
 // GeneData represents the structure to hold gene data and associated information.
+// The encrypted data itself is kept in the blob store under BlobKey.
 type GeneData struct {
 	gorm.Model
-	FileID        string
-	UserID        uint32
-	EncryptedData []byte
+	// The blob key of the encrypted data, so the ID is derived from the content
+	FileID string `gorm:"uniqueIndex"`
+	// The 16-character ID the file had before it was re-keyed, and under which it was opened on-chain
+	LegacyFileID string `gorm:"index"`
+	// Indexed with the listing filters and sort keys, for GET /users/me/files
+	UserID        uint32 `gorm:"index:idx_gene_data_user_status,priority:1;index:idx_gene_data_user_size,priority:1"`
+	Format        string
+	BlobKey       string
+	BlobSize      int64 `gorm:"index:idx_gene_data_user_size,priority:2"`
+	BlobChecksum  string
 	DataHash      []byte
 	Signature     []byte
+	SessionID     string `gorm:"index"`
+	DocID         string `gorm:"index"`
+	TokenID       string `gorm:"index"`
+	RiskScore     int
+	ModelVersion  string
+	ShareRiskTier bool
+	UploadStatus  string `gorm:"index:idx_gene_data_user_status,priority:2"`
+	// Keyed by the TEE, so each genotype is stored once; nil for failed uploads
+	Fingerprint *string `gorm:"uniqueIndex"`
+	// When the report was delivered with the recorded upload; the retention clock starts here
+	ReportedAt *time.Time
+	// When the user asked for the raw data to be deleted
+	WithdrawnAt *time.Time
+	// When the retention policy deleted the blob; the record, report and hashes are kept
+	PurgedAt *time.Time
+}
+
+// UploadRecord holds the on-chain references and report metadata of a confirmed upload.
+type UploadRecord struct {
+	SessionID     string
+	DocID         string
+	TokenID       string
+	RiskScore     int
+	ModelVersion  string
+	ShareRiskTier bool
 }
 
 type GenDataRepository interface {
 	StoreGeneData(userID uint32, encryptedData []byte, signatureBytes []byte, hashBytes []byte) (string, error)
 	RetrieveGeneData(fileID string) ([]byte, error)
+	UpdateUploadRecord(fileID string, record UploadRecord) error
+	FindByDocID(docID string) (*GeneData, error)
+	FindByFileID(fileID string) (*GeneData, error)
+	FindBySessionID(sessionID string) (*GeneData, error)
+	ListFiles(userID uint32, query FileQuery) (*FilePage, error)
+	RestoreGeneData(geneData *GeneData, encryptedData []byte) error
+	WithdrawGeneData(fileID string, withdrawnAt time.Time) error
 }
 
 type genDataRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	blobs blobstore.BlobStore
+}
+
+// NewGenDataRepository creates a new gene data repository keeping the encrypted data in blobs.
+func NewGenDataRepository(db *gorm.DB, blobs blobstore.BlobStore) GenDataRepository {
+	return &genDataRepository{db, blobs}
 }
 
-// NewGenDataRepository creates a new gene data repository.
-func NewGenDataRepository(db *gorm.DB) GenDataRepository {
-	return &genDataRepository{db}
+func newGeneData(userID uint32, blob *blobstore.Blob, signatureBytes []byte, hashBytes []byte) GeneData {
+	return GeneData{
+		FileID:       blob.Key,
+		UserID:       userID,
+		BlobKey:      blob.Key,
+		BlobSize:     blob.Size,
+		BlobChecksum: blob.Checksum,
+		DataHash:     hashBytes,
+		Signature:    signatureBytes,
+	}
 }
 
-// StoreGeneData stores gene data in the database.
+// StoreGeneData stores the encrypted data in the blob store and its record in the database.
 func (r *genDataRepository) StoreGeneData(userID uint32, encryptedData []byte, signatureBytes []byte, hashBytes []byte) (string, error) {
-	fileID := hex.EncodeToString(hashBytes[:8])
-	geneData := GeneData{
-		FileID:        fileID,
-		UserID:        userID,
-		EncryptedData: encryptedData,
-		DataHash:      hashBytes,
-		Signature:     signatureBytes,
+	blob, err := r.blobs.Put(encryptedData)
+	if err != nil {
+		return "", err
 	}
+	geneData := newGeneData(userID, blob, signatureBytes, hashBytes)
+	geneData.UploadStatus = UploadCompleted
+	reportedAt := time.Now().UTC()
+	geneData.ReportedAt = &reportedAt
 
 	if err := r.db.Create(&geneData).Error; err != nil {
 		return "", err
@@ -48,12 +113,134 @@ func (r *genDataRepository) StoreGeneData(userID uint32, encryptedData []byte, s
 	return geneData.FileID, nil
 }
 
-// RetrieveGeneData retrieves gene data from the database.
+// RestoreGeneData stores the encrypted data of a completed upload brought from another deployment,
+// with geneData carrying its record. It fails with ErrDuplicateGeneData when a stored upload has the
+// same fingerprint.
+func (r *genDataRepository) RestoreGeneData(geneData *GeneData, encryptedData []byte) error {
+	if geneData.Fingerprint != nil {
+		taken, err := fingerprintTaken(r.db, *geneData.Fingerprint)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrDuplicateGeneData
+		}
+	}
+
+	blob, err := r.blobs.Put(encryptedData)
+	if err != nil {
+		return err
+	}
+	geneData.FileID = blob.Key
+	geneData.BlobKey = blob.Key
+	geneData.BlobSize = blob.Size
+	geneData.BlobChecksum = blob.Checksum
+	geneData.UploadStatus = UploadCompleted
+
+	err = r.db.Create(geneData).Error
+	if err != nil && geneData.Fingerprint != nil {
+		if taken, _ := fingerprintTaken(r.db, *geneData.Fingerprint); taken {
+			return ErrDuplicateGeneData
+		}
+	}
+	return err
+}
+
+// RetrieveGeneData retrieves the encrypted data of a file from the blob store.
 func (r *genDataRepository) RetrieveGeneData(fileID string) ([]byte, error) {
+	geneData, err := findByFileID(r.db, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadBlob(r.blobs, geneData)
+}
+
+// findByFileID resolves a file ID, or the legacy ID of a re-keyed file as long as no other file
+// had the same one.
+func findByFileID(db *gorm.DB, fileID string) (*GeneData, error) {
+	var geneData GeneData
+	err := db.Where("file_id = ?", fileID).First(&geneData).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) || len(fileID) != LegacyFileIDLength {
+		if err != nil {
+			return nil, err
+		}
+		return &geneData, nil
+	}
+
+	var matches []GeneData
+	if err := db.Where("legacy_file_id = ?", fileID).Limit(2).Find(&matches).Error; err != nil {
+		return nil, err
+	}
+	switch len(matches) {
+	case 0:
+		return nil, gorm.ErrRecordNotFound
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, ErrAmbiguousFileID
+	}
+}
+
+// loadBlob reads the encrypted data of a record, checked against the size and checksum recorded with it.
+func loadBlob(blobs blobstore.BlobStore, geneData *GeneData) ([]byte, error) {
+	if geneData.PurgedAt != nil {
+		return nil, fmt.Errorf("gene data %s: %w", geneData.FileID, ErrRawDataPurged)
+	}
+	if geneData.BlobKey == "" {
+		return nil, fmt.Errorf("gene data %s has not been moved to the blob store", geneData.FileID)
+	}
+
+	data, err := blobs.Get(geneData.BlobKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gene data %s: %w", geneData.FileID, err)
+	}
+	if int64(len(data)) != geneData.BlobSize || blobstore.Checksum(data) != geneData.BlobChecksum {
+		return nil, fmt.Errorf("gene data %s: %w", geneData.FileID, blobstore.ErrChecksumMismatch)
+	}
+	return data, nil
+}
+
+// UpdateUploadRecord attaches the on-chain references and report metadata to stored gene data.
+func (r *genDataRepository) UpdateUploadRecord(fileID string, record UploadRecord) error {
+	return r.db.Model(&GeneData{}).Where("file_id = ?", fileID).Updates(map[string]interface{}{
+		"session_id":      record.SessionID,
+		"doc_id":          record.DocID,
+		"token_id":        record.TokenID,
+		"risk_score":      record.RiskScore,
+		"model_version":   record.ModelVersion,
+		"share_risk_tier": record.ShareRiskTier,
+		"reported_at":     time.Now().UTC(),
+	}).Error
+}
+
+// WithdrawGeneData records that the user asked for the raw data of a file to be deleted. The first
+// withdrawal is kept.
+func (r *genDataRepository) WithdrawGeneData(fileID string, withdrawnAt time.Time) error {
+	return r.db.Model(&GeneData{}).Where("file_id = ? AND withdrawn_at IS NULL", fileID).Update("withdrawn_at", withdrawnAt).Error
+}
+
+// FindByDocID retrieves the gene data record linked to an on-chain doc.
+func (r *genDataRepository) FindByDocID(docID string) (*GeneData, error) {
+	var geneData GeneData
+	if err := r.db.Where("doc_id = ?", docID).First(&geneData).Error; err != nil {
+		return nil, err
+	}
+
+	return &geneData, nil
+}
+
+// FindByFileID retrieves the gene data record of a file, also by its legacy file ID.
+func (r *genDataRepository) FindByFileID(fileID string) (*GeneData, error) {
+	return findByFileID(r.db, fileID)
+}
+
+// FindBySessionID retrieves the gene data record uploaded in an on-chain session.
+func (r *genDataRepository) FindBySessionID(sessionID string) (*GeneData, error) {
 	var geneData GeneData
-	if err := r.db.Where("file_id = ?", fileID).First(&geneData).Error; err != nil {
+	if err := r.db.Where("session_id = ?", sessionID).First(&geneData).Error; err != nil {
 		return nil, err
 	}
 
-	return geneData.EncryptedData, nil
+	return &geneData, nil
 }
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Analysis job statuses, following the escrowed payment.
+const (
+	AnalysisLocked   = "locked"
+	AnalysisSettled  = "settled"
+	AnalysisRefunded = "refunded"
+)
+
+// ResearchConsent is a GeneNFT holder's latest decision on research use of the token's data.
+// It only applies while Owner still holds the token.
+type ResearchConsent struct {
+	gorm.Model
+	TokenID  string `gorm:"uniqueIndex"`
+	Owner    string
+	Granted  bool
+	SignedAt int64
+}
+
+// AnalysisJob tracks a researcher's paid analysis from the escrow lock to its settlement or refund.
+type AnalysisJob struct {
+	gorm.Model
+	RequestID     string `gorm:"uniqueIndex"`
+	Researcher    string `gorm:"index"`
+	Analysis      string
+	Amount        string
+	Status        string `gorm:"index"`
+	TokenIDs      string // Comma-separated contributing GeneNFTs
+	Result        string // Released aggregate, as JSON
+	Reason        string
+	RequestTxHash string
+	SettleTxHash  string
+}
+
+// ConsentedData is an upload whose GeneNFT holder consented to research use, with its encrypted
+// data once loaded.
+type ConsentedData struct {
+	FileID        string
+	UserID        uint32
+	TokenID       string
+	Owner         string
+	BlobKey       string
+	BlobSize      int64
+	BlobChecksum  string
+	EncryptedData []byte `gorm:"-"`
+}
+
+type MarketplaceRepository interface {
+	FindConsent(tokenID string) (*ResearchConsent, error)
+	SaveConsent(consent *ResearchConsent) error
+	FindConsentedData(offset int, limit int) ([]ConsentedData, error)
+	LoadConsentedData(data []ConsentedData) error
+	CreateJob(job *AnalysisJob) error
+	SaveJob(job *AnalysisJob) error
+	FindJob(requestID string) (*AnalysisJob, error)
+	FindLockedJobs() ([]AnalysisJob, error)
+}
+
+type marketplaceRepository struct {
+	db    *gorm.DB
+	blobs blobstore.BlobStore
+}
+
+// NewMarketplaceRepository creates a new research marketplace repository.
+func NewMarketplaceRepository(db *gorm.DB, blobs blobstore.BlobStore) MarketplaceRepository {
+	return &marketplaceRepository{db, blobs}
+}
+
+func (r *marketplaceRepository) FindConsent(tokenID string) (*ResearchConsent, error) {
+	var consent ResearchConsent
+	if err := r.db.Where("token_id = ?", tokenID).First(&consent).Error; err != nil {
+		return nil, err
+	}
+
+	return &consent, nil
+}
+
+// SaveConsent replaces the consent recorded for the token.
+func (r *marketplaceRepository) SaveConsent(consent *ResearchConsent) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "owner", "granted", "signed_at"}),
+	}).Create(consent).Error
+}
+
+// FindConsentedData returns a page of the completed uploads whose GeneNFT holder granted consent, and
+// whose raw data is still kept, in increasing token ID order. Their data is not loaded.
+func (r *marketplaceRepository) FindConsentedData(offset int, limit int) ([]ConsentedData, error) {
+	var data []ConsentedData
+	err := r.db.Model(&GeneData{}).
+		Select("gene_data.file_id, gene_data.user_id, gene_data.token_id, research_consents.owner, gene_data.blob_key, gene_data.blob_size, gene_data.blob_checksum").
+		Joins("JOIN research_consents ON research_consents.token_id = gene_data.token_id AND research_consents.deleted_at IS NULL").
+		Where("research_consents.granted = ? AND research_consents.owner <> '' AND gene_data.upload_status = ? AND gene_data.token_id <> ''", true, UploadCompleted).
+		// Withdrawn data is no longer used, even before the retention policy deletes it
+		Where("gene_data.withdrawn_at IS NULL AND gene_data.purged_at IS NULL").
+		// Token IDs are decimal, so shorter ones are smaller
+		Order("LENGTH(gene_data.token_id), gene_data.token_id").
+		Offset(offset).Limit(limit).Scan(&data).Error
+	return data, err
+}
+
+// LoadConsentedData reads the encrypted data of the given uploads from the blob store.
+func (r *marketplaceRepository) LoadConsentedData(data []ConsentedData) error {
+	for i := range data {
+		var err error
+		data[i].EncryptedData, err = loadBlob(r.blobs, &GeneData{
+			FileID:       data[i].FileID,
+			BlobKey:      data[i].BlobKey,
+			BlobSize:     data[i].BlobSize,
+			BlobChecksum: data[i].BlobChecksum,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *marketplaceRepository) CreateJob(job *AnalysisJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *marketplaceRepository) SaveJob(job *AnalysisJob) error {
+	return r.db.Save(job).Error
+}
+
+func (r *marketplaceRepository) FindJob(requestID string) (*AnalysisJob, error) {
+	var job AnalysisJob
+	if err := r.db.Where("request_id = ?", requestID).First(&job).Error; err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// FindLockedJobs returns the jobs whose payment is still held in escrow.
+func (r *marketplaceRepository) FindLockedJobs() ([]AnalysisJob, error) {
+	var jobs []AnalysisJob
+	err := r.db.Where("status = ?", AnalysisLocked).Order("id").Find(&jobs).Error
+	return jobs, err
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// RekeyFileIDs gives the files still under a legacy 16-character ID their content-derived ID,
+// keeping the old one as LegacyFileID, then enforces the unique file ID index. Files whose upload
+// is still pending are left for a later run, as their workflow opens the on-chain session under the
+// old ID; so are files not yet moved to the blob store. It returns the number of files re-keyed and
+// of those left.
+func RekeyFileIDs(db *gorm.DB) (int, int, error) {
+	var legacy []GeneData
+	err := db.Unscoped().Where("length(file_id) = ? AND blob_key <> '' AND upload_status <> ?", LegacyFileIDLength, UploadPending).
+		Order("id").Find(&legacy).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read gene data: %v", err)
+	}
+
+	// A workflow can only follow its file when no other file had the same ID
+	shared := make(map[string]int)
+	for _, geneData := range legacy {
+		shared[geneData.FileID]++
+	}
+
+	rekeyed := 0
+	for _, geneData := range legacy {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			err := tx.Unscoped().Model(&GeneData{}).Where("id = ?", geneData.ID).Updates(map[string]interface{}{
+				"file_id":        geneData.BlobKey,
+				"legacy_file_id": geneData.FileID,
+			}).Error
+			if err != nil || shared[geneData.FileID] > 1 {
+				return err
+			}
+			return tx.Unscoped().Model(&UploadWorkflow{}).Where("file_id = ?", geneData.FileID).Update("file_id", geneData.BlobKey).Error
+		})
+		if err != nil {
+			return rekeyed, 0, fmt.Errorf("failed to re-key gene data %d: %v", geneData.ID, err)
+		}
+		rekeyed++
+	}
+
+	var left int64
+	if err := db.Unscoped().Model(&GeneData{}).Where("length(file_id) = ?", LegacyFileIDLength).Count(&left).Error; err != nil {
+		return rekeyed, 0, fmt.Errorf("failed to count legacy file IDs: %v", err)
+	}
+	if err := db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_gene_data_file_id ON gene_data(file_id)").Error; err != nil {
+		return rekeyed, int(left), fmt.Errorf("failed to enforce unique file IDs, run again once pending uploads finish: %v", err)
+	}
+	return rekeyed, int(left), nil
+}
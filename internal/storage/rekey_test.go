@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"gorm.io/gorm"
+)
+
+func TestRekeyFileIDs(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		// Legacy databases never had the index
+		db.Exec("DROP INDEX idx_gene_data_file_id")
+
+		legacy := func(fileID string, blobKey string, status string) {
+			db.Create(&GeneData{FileID: fileID, BlobKey: blobKey, UploadStatus: status})
+		}
+		legacy("aaaaaaaaaaaaaaaa", "key-shared-1", UploadCompleted)
+		legacy("aaaaaaaaaaaaaaaa", "key-shared-2", UploadCompleted)
+		legacy("bbbbbbbbbbbbbbbb", "key-completed", UploadCompleted)
+		legacy("cccccccccccccccc", "key-pending", UploadPending)
+		db.Create(&UploadWorkflow{FileID: "bbbbbbbbbbbbbbbb", Step: WorkflowCompleted})
+
+		rekeyed, left, err := RekeyFileIDs(db)
+		if err != nil {
+			t.Fatalf("RekeyFileIDs() error = %v", err)
+		}
+		if rekeyed != 3 || left != 1 {
+			t.Errorf("RekeyFileIDs() = %d, %d, want 3, 1", rekeyed, left)
+		}
+
+		repo := NewGenDataRepository(db, nil)
+		tests := []struct {
+			name    string
+			fileID  string
+			wantKey string
+			wantErr error
+		}{
+			{name: "Content-derived ID", fileID: "key-completed", wantKey: "key-completed"},
+			{name: "Legacy ID", fileID: "bbbbbbbbbbbbbbbb", wantKey: "key-completed"},
+			{name: "Legacy ID of several files", fileID: "aaaaaaaaaaaaaaaa", wantErr: ErrAmbiguousFileID},
+			{name: "Pending upload keeps its ID", fileID: "cccccccccccccccc", wantKey: "key-pending"},
+			{name: "Unknown ID", fileID: "dddddddddddddddd", wantErr: gorm.ErrRecordNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				geneData, err := repo.FindByFileID(tt.fileID)
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Errorf("FindByFileID() error = %v, want %v", err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil || geneData.BlobKey != tt.wantKey {
+					t.Errorf("FindByFileID() = %+v, %v, want blob %s", geneData, err, tt.wantKey)
+				}
+			})
+		}
+
+		var workflow UploadWorkflow
+		db.First(&workflow)
+		if workflow.FileID != "key-completed" {
+			t.Errorf("Expected the workflow to follow its file, got %s", workflow.FileID)
+		}
+		if err := db.Create(&GeneData{FileID: "key-completed"}).Error; err == nil {
+			t.Errorf("Expected file IDs to be unique")
+		}
+	})
+}
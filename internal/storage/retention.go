@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"gorm.io/gorm"
+)
+
+// RetentionRepository deletes the raw data the retention policy expires, in two steps: a soft
+// delete, which hides the record from retrieval and research while it can still be undone, then a
+// purge of the blob, after which the record comes back without it.
+type RetentionRepository interface {
+	FindExpired(reportedBefore *time.Time, afterID uint, limit int) ([]GeneData, error)
+	SoftDelete(geneData *GeneData, deletedAt time.Time) error
+	FindPurgeable(deletedBefore time.Time, afterID uint, limit int) ([]GeneData, error)
+	Purge(geneData *GeneData, purgedAt time.Time) error
+}
+
+type retentionRepository struct {
+	db    *gorm.DB
+	blobs blobstore.BlobStore
+}
+
+// NewRetentionRepository creates a new retention repository over the gene data in db and blobs.
+func NewRetentionRepository(db *gorm.DB, blobs blobstore.BlobStore) RetentionRepository {
+	return &retentionRepository{db, blobs}
+}
+
+// FindExpired returns the files whose raw data is due for deletion: withdrawn by the user, or, when
+// reportedBefore is set, whose report was delivered before it. A failed upload never delivered one,
+// so it is due once uploaded before reportedBefore. Uploads still pending are left to finish first.
+// Files come in ID order, from after afterID.
+func (r *retentionRepository) FindExpired(reportedBefore *time.Time, afterID uint, limit int) ([]GeneData, error) {
+	query := r.db.Where("id > ? AND purged_at IS NULL AND upload_status <> ?", afterID, UploadPending)
+	if reportedBefore != nil {
+		query = query.Where("withdrawn_at IS NOT NULL OR reported_at < ? OR (reported_at IS NULL AND upload_status = ? AND created_at < ?)",
+			*reportedBefore, UploadFailed, *reportedBefore)
+	} else {
+		query = query.Where("withdrawn_at IS NOT NULL")
+	}
+
+	var expired []GeneData
+	if err := query.Order("id").Limit(limit).Find(&expired).Error; err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+// SoftDelete sets the DeletedAt of a file, which no query then returns until it is purged.
+func (r *retentionRepository) SoftDelete(geneData *GeneData, deletedAt time.Time) error {
+	if err := r.db.Model(&GeneData{}).Where("id = ?", geneData.ID).Update("deleted_at", deletedAt).Error; err != nil {
+		return err
+	}
+	geneData.DeletedAt = gorm.DeletedAt{Time: deletedAt, Valid: true}
+	return nil
+}
+
+// FindPurgeable returns the files soft-deleted before deletedBefore and not purged yet, in ID order
+// from after afterID.
+func (r *retentionRepository) FindPurgeable(deletedBefore time.Time, afterID uint, limit int) ([]GeneData, error) {
+	var purgeable []GeneData
+	err := r.db.Unscoped().
+		Where("id > ? AND deleted_at IS NOT NULL AND deleted_at < ? AND purged_at IS NULL", afterID, deletedBefore).
+		Order("id").Limit(limit).Find(&purgeable).Error
+	if err != nil {
+		return nil, err
+	}
+	return purgeable, nil
+}
+
+// Purge deletes the blob of a soft-deleted file for good, and restores its record without it. The
+// blob checksum, data hash and signature stay, so the record can still be checked against the chain.
+func (r *retentionRepository) Purge(geneData *GeneData, purgedAt time.Time) error {
+	if geneData.BlobKey != "" {
+		if err := r.blobs.Delete(geneData.BlobKey); err != nil && !errors.Is(err, blobstore.ErrNotFound) {
+			return err
+		}
+	}
+
+	err := r.db.Unscoped().Model(&GeneData{}).Where("id = ?", geneData.ID).Updates(map[string]interface{}{
+		"blob_key":   "",
+		"purged_at":  purgedAt,
+		"deleted_at": nil,
+	}).Error
+	if err != nil {
+		return err
+	}
+	geneData.BlobKey = ""
+	geneData.PurgedAt = &purgedAt
+	geneData.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
@@ -1,6 +1,11 @@
 package storage
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"gorm.io/gorm"
+)
 
 type geneDataStorageService struct {
 	geneDataRepository GenDataRepository
@@ -9,22 +14,64 @@ type geneDataStorageService struct {
 type GeneDataStorageService interface {
 	StoreGeneData(userID uint32, encryptedData []byte, signatureBytes []byte, hashBytes []byte) (string, error)
 	RetrieveGeneData(fileID string) ([]byte, error)
+	UpdateUploadRecord(fileID string, record UploadRecord) error
+	FindByDocID(docID string) (*GeneData, error)
+	FindByFileID(fileID string) (*GeneData, error)
+	FindBySessionID(sessionID string) (*GeneData, error)
+	ListFiles(userID uint32, query FileQuery) (*FilePage, error)
+	RestoreGeneData(geneData *GeneData, encryptedData []byte) error
+	WithdrawGeneData(fileID string, withdrawnAt time.Time) error
 }
 
 // NewGeneDataStorageService creates a new gene data storage service.
-func NewGeneDataStorageService(db *gorm.DB) GeneDataStorageService {
-	repo := NewGenDataRepository(db)
+func NewGeneDataStorageService(db *gorm.DB, blobs blobstore.BlobStore) GeneDataStorageService {
+	repo := NewGenDataRepository(db, blobs)
 	return &geneDataStorageService{
 		geneDataRepository: repo,
 	}
 }
 
-// StoreGeneData stores gene data in the database.
+// StoreGeneData stores gene data in the blob store and database.
 func (s *geneDataStorageService) StoreGeneData(userID uint32, encryptedData []byte, signatureBytes []byte, hashBytes []byte) (string, error) {
 	return s.geneDataRepository.StoreGeneData(userID, encryptedData, signatureBytes, hashBytes)
 }
 
-// RetrieveGeneData retrieves gene data from the database.
+// RetrieveGeneData retrieves gene data from the blob store.
 func (s *geneDataStorageService) RetrieveGeneData(fileID string) ([]byte, error) {
 	return s.geneDataRepository.RetrieveGeneData(fileID)
 }
+
+// UpdateUploadRecord attaches the on-chain references and report metadata to stored gene data.
+func (s *geneDataStorageService) UpdateUploadRecord(fileID string, record UploadRecord) error {
+	return s.geneDataRepository.UpdateUploadRecord(fileID, record)
+}
+
+// FindByDocID retrieves the gene data record linked to an on-chain doc.
+func (s *geneDataStorageService) FindByDocID(docID string) (*GeneData, error) {
+	return s.geneDataRepository.FindByDocID(docID)
+}
+
+// FindByFileID retrieves the gene data record of a file, also by its legacy file ID.
+func (s *geneDataStorageService) FindByFileID(fileID string) (*GeneData, error) {
+	return s.geneDataRepository.FindByFileID(fileID)
+}
+
+// FindBySessionID retrieves the gene data record uploaded in an on-chain session.
+func (s *geneDataStorageService) FindBySessionID(sessionID string) (*GeneData, error) {
+	return s.geneDataRepository.FindBySessionID(sessionID)
+}
+
+// ListFiles returns one page of the files uploaded by a user.
+func (s *geneDataStorageService) ListFiles(userID uint32, query FileQuery) (*FilePage, error) {
+	return s.geneDataRepository.ListFiles(userID, query)
+}
+
+// RestoreGeneData stores a completed upload brought from another deployment.
+func (s *geneDataStorageService) RestoreGeneData(geneData *GeneData, encryptedData []byte) error {
+	return s.geneDataRepository.RestoreGeneData(geneData, encryptedData)
+}
+
+// WithdrawGeneData records that the user asked for the raw data of a file to be deleted.
+func (s *geneDataStorageService) WithdrawGeneData(fileID string, withdrawnAt time.Time) error {
+	return s.geneDataRepository.WithdrawGeneData(fileID, withdrawnAt)
+}
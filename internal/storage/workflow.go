@@ -0,0 +1,232 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Upload workflow steps, in order. Each step names the last one that completed.
+const (
+	WorkflowStored        = "stored"
+	WorkflowSessionOpened = "session_opened"
+	WorkflowConfirmed     = "confirmed"
+	WorkflowRecorded      = "recorded"
+	WorkflowCompleted     = "completed"
+	WorkflowFailed        = "failed"
+)
+
+// Upload status of stored gene data.
+const (
+	UploadPending   = "pending"
+	UploadCompleted = "completed"
+	UploadFailed    = "failed"
+)
+
+// WorkflowClaimTTL is how long a gateway replica holds an upload it runs, renewed at every step. Another
+// replica only takes the upload over once the claim expires.
+const WorkflowClaimTTL = 5 * time.Minute
+
+// ErrDuplicateGeneData reports a genotype that was already uploaded, by anyone.
+var ErrDuplicateGeneData = errors.New("this genomic data has already been uploaded")
+
+// UploadWorkflow persists the progress of one upload so it can be resumed after a crash.
+type UploadWorkflow struct {
+	gorm.Model
+	FileID        string `gorm:"uniqueIndex"`
+	UserAddress   string
+	Step          string `gorm:"index"`
+	SessionID     string
+	DocID         string
+	TokenID       string
+	ContentHash   string
+	RiskScore     int
+	ShareRiskTier bool
+	Attempts      int
+	LastError     string
+	Note          string
+	ClaimedBy     string
+	ClaimedUntil  *time.Time
+}
+
+type UploadWorkflowRepository interface {
+	StartUpload(userID uint32, encryptedData []byte, signatureBytes []byte, hashBytes []byte, format string, fingerprint string, workflow *UploadWorkflow) error
+	Save(workflow *UploadWorkflow) error
+	RecordFailure(workflow *UploadWorkflow, err error) error
+	Complete(workflow *UploadWorkflow) error
+	Fail(workflow *UploadWorkflow, note string) error
+	FindIncomplete() ([]UploadWorkflow, error)
+	ClaimNext(afterID uint) (*UploadWorkflow, error)
+	Release(workflow *UploadWorkflow) error
+	ReleaseStale() error
+}
+
+type uploadWorkflowRepository struct {
+	db      *gorm.DB
+	blobs   blobstore.BlobStore
+	owner   string
+	started time.Time
+}
+
+// NewUploadWorkflowRepository creates a new upload workflow repository for the gateway replica owner,
+// which claims the workflows it starts or recovers.
+func NewUploadWorkflowRepository(db *gorm.DB, blobs blobstore.BlobStore, owner string) UploadWorkflowRepository {
+	return &uploadWorkflowRepository{db, blobs, owner, time.Now().UTC()}
+}
+
+// StartUpload stores the pending gene data and its workflow together, so no data is stored without a
+// workflow. The blob goes first: a failed transaction at worst leaves an unreferenced blob. It fails
+// with ErrDuplicateGeneData when a stored upload has the same fingerprint.
+func (r *uploadWorkflowRepository) StartUpload(userID uint32, encryptedData []byte, signatureBytes []byte, hashBytes []byte, format string, fingerprint string, workflow *UploadWorkflow) error {
+	taken, err := r.fingerprintTaken(fingerprint)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrDuplicateGeneData
+	}
+
+	blob, err := r.blobs.Put(encryptedData)
+	if err != nil {
+		return err
+	}
+	geneData := newGeneData(userID, blob, signatureBytes, hashBytes)
+	geneData.Format = format
+	geneData.UploadStatus = UploadPending
+	geneData.Fingerprint = &fingerprint
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&geneData).Error; err != nil {
+			return err
+		}
+
+		workflow.FileID = geneData.FileID
+		workflow.Step = WorkflowStored
+		r.claim(workflow)
+		return tx.Create(workflow).Error
+	})
+	// A concurrent upload of the same genotype is stopped by the unique index
+	if err != nil {
+		if taken, _ := r.fingerprintTaken(fingerprint); taken {
+			return ErrDuplicateGeneData
+		}
+	}
+	return err
+}
+
+func (r *uploadWorkflowRepository) fingerprintTaken(fingerprint string) (bool, error) {
+	return fingerprintTaken(r.db, fingerprint)
+}
+
+// fingerprintTaken reports whether an upload has the fingerprint. A failed lookup is an error rather
+// than a free fingerprint, so the duplicate check never lets an upload through by mistake.
+func fingerprintTaken(db *gorm.DB, fingerprint string) (bool, error) {
+	var count int64
+	if err := db.Unscoped().Model(&GeneData{}).Where("fingerprint = ?", fingerprint).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to look up fingerprint: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Save records the step the workflow reached and renews the claim on it.
+func (r *uploadWorkflowRepository) Save(workflow *UploadWorkflow) error {
+	r.claim(workflow)
+	return r.db.Save(workflow).Error
+}
+
+func (r *uploadWorkflowRepository) claim(workflow *UploadWorkflow) {
+	until := time.Now().UTC().Add(WorkflowClaimTTL)
+	workflow.ClaimedBy = r.owner
+	workflow.ClaimedUntil = &until
+}
+
+// RecordFailure counts a failed attempt at the workflow's next step.
+func (r *uploadWorkflowRepository) RecordFailure(workflow *UploadWorkflow, err error) error {
+	workflow.Attempts++
+	workflow.LastError = err.Error()
+	return r.db.Save(workflow).Error
+}
+
+// Complete finishes the workflow and releases the gene data.
+func (r *uploadWorkflowRepository) Complete(workflow *UploadWorkflow) error {
+	return r.finish(workflow, WorkflowCompleted, UploadCompleted, "")
+}
+
+// Fail abandons the workflow and marks its gene data failed; note records what was left behind on-chain.
+// The fingerprint is released, so the same genotype can be uploaded again.
+func (r *uploadWorkflowRepository) Fail(workflow *UploadWorkflow, note string) error {
+	return r.finish(workflow, WorkflowFailed, UploadFailed, note)
+}
+
+func (r *uploadWorkflowRepository) finish(workflow *UploadWorkflow, step string, status string, note string) error {
+	updates := map[string]interface{}{"upload_status": status}
+	if status == UploadFailed {
+		updates["fingerprint"] = nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&GeneData{}).Where("file_id = ?", workflow.FileID).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		workflow.Step = step
+		workflow.Note = note
+		return tx.Save(workflow).Error
+	})
+}
+
+// FindIncomplete lists the workflows that neither completed nor failed, oldest first.
+func (r *uploadWorkflowRepository) FindIncomplete() ([]UploadWorkflow, error) {
+	var workflows []UploadWorkflow
+	err := r.db.Where("step NOT IN ?", []string{WorkflowCompleted, WorkflowFailed}).Order("id").Find(&workflows).Error
+	return workflows, err
+}
+
+// ClaimNext claims the oldest incomplete workflow after afterID that no replica holds, or nil when
+// there is none. The row is locked while it is claimed, so replicas recovering at the same time never
+// run the same upload. Workflows this replica is still running stay claimed and are passed over too.
+func (r *uploadWorkflowRepository) ClaimNext(afterID uint) (*UploadWorkflow, error) {
+	var workflow UploadWorkflow
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED passes over a row another replica is claiming. SQLite, which has no row
+		// locks, serialises the transactions instead.
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("step NOT IN ? AND id > ?", []string{WorkflowCompleted, WorkflowFailed}, afterID).
+			Where("claimed_until IS NULL OR claimed_until < ?", time.Now().UTC()).
+			Order("id").First(&workflow).Error
+		if err != nil {
+			return err
+		}
+
+		r.claim(&workflow)
+		return tx.Model(&workflow).Select("claimed_by", "claimed_until").Updates(&workflow).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+// Release gives up the claim on a workflow this replica stopped running, so the next recovery can
+// take it at once instead of waiting for the claim to expire.
+func (r *uploadWorkflowRepository) Release(workflow *UploadWorkflow) error {
+	workflow.ClaimedUntil = nil
+	return r.db.Model(&UploadWorkflow{}).
+		Where("id = ? AND claimed_by = ?", workflow.ID, r.owner).
+		Update("claimed_until", nil).Error
+}
+
+// ReleaseStale gives up the claims a previous run of this replica still held when it stopped. Claims
+// taken since the repository was created are kept, so uploads running now are never taken twice.
+func (r *uploadWorkflowRepository) ReleaseStale() error {
+	return r.db.Model(&UploadWorkflow{}).
+		Where("claimed_by = ? AND claimed_until < ?", r.owner, r.started.Add(WorkflowClaimTTL)).
+		Update("claimed_until", nil).Error
+}
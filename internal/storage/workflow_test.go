@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/TropicalDog17/genomic-dao-service/internal/blobstore"
+	"github.com/TropicalDog17/genomic-dao-service/internal/database/databasetest"
+	"gorm.io/gorm"
+)
+
+func TestStartUpload_Fingerprint(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, db *gorm.DB) {
+		dir := t.TempDir()
+		blobs, err := blobstore.NewFileStore(dir)
+		if err != nil {
+			t.Fatalf("Failed to open blob store: %v", err)
+		}
+		repo := NewUploadWorkflowRepository(db, blobs, "replica-1")
+		if err := repo.StartUpload(1, []byte("one"), nil, []byte("hash one"), "23andme", "fp", &UploadWorkflow{}); err != nil {
+			t.Fatalf("StartUpload() error = %v", err)
+		}
+
+		if err := repo.StartUpload(2, []byte("two"), nil, []byte("hash two"), "23andme", "fp", &UploadWorkflow{}); !errors.Is(err, ErrDuplicateGeneData) {
+			t.Errorf("StartUpload() error = %v, want %v", err, ErrDuplicateGeneData)
+		}
+
+		// A failed fingerprint lookup refuses the upload instead of treating the fingerprint as free
+		lookupErr := errors.New("lookup failed")
+		db.Callback().Query().Before("gorm:query").Register("test:fail_fingerprint", func(tx *gorm.DB) {
+			if tx.Statement.Table == "gene_data" {
+				tx.AddError(lookupErr)
+			}
+		})
+		t.Cleanup(func() { db.Callback().Query().Remove("test:fail_fingerprint") })
+
+		if err := repo.StartUpload(3, []byte("three"), nil, []byte("hash three"), "23andme", "fp", &UploadWorkflow{}); !errors.Is(err, lookupErr) {
+			t.Errorf("StartUpload() error = %v, want %v", err, lookupErr)
+		}
+
+		var count int64
+		db.Session(&gorm.Session{SkipHooks: true}).Raw("SELECT COUNT(*) FROM gene_data").Scan(&count)
+		if count != 1 {
+			t.Errorf("Expected 1 stored upload, got %d", count)
+		}
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 1 {
+			t.Errorf("Expected 1 stored blob, got %d", len(entries))
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package tee
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// AnalysisRiskSummary reports the cohort's mean risk score and how its members spread over risk tiers.
+const AnalysisRiskSummary = "risk-summary"
+
+const (
+	// MinCohortSize is the smallest cohort whose aggregate may leave the TEE.
+	MinCohortSize = 5
+	// MinCellSize is the smallest count released for a single risk tier.
+	MinCellSize = 3
+)
+
+var (
+	ErrUnsupportedAnalysis = errors.New("unsupported analysis")
+	ErrCohortTooSmall      = fmt.Errorf("cohort is smaller than %d members", MinCohortSize)
+)
+
+// SupportedAnalysis reports whether the TEE can run analysis.
+func SupportedAnalysis(analysis string) bool {
+	return analysis == AnalysisRiskSummary
+}
+
+// AnalysisResult is the aggregate output released for a cohort; it never holds individual records.
+type AnalysisResult struct {
+	Analysis     string  `json:"analysis" example:"risk-summary"`
+	ModelVersion string  `json:"modelVersion" example:"g-stroke-v1"`
+	CohortSize   int     `json:"cohortSize" example:"12"`
+	MeanScore    float64 `json:"meanScore" example:"0.4213"`
+	// Members per risk tier, keyed "1" to "4"; tiers under MinCellSize are withheld
+	RiskTiers map[string]int `json:"riskTiers"`
+	// Members of the withheld tiers
+	Suppressed int `json:"suppressed" example:"2"`
+}
+
+// Analyze decrypts each record inside the TEE and aggregates those it can read. It returns the
+// released result with the indexes of the records that contributed to it.
+func (t *teeService) Analyze(analysis string, encryptedData [][]byte, privKey *ecdsa.PrivateKey) (*AnalysisResult, []int, error) {
+	if !SupportedAnalysis(analysis) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedAnalysis, analysis)
+	}
+
+	var contributors []int
+	var sum float64
+	tiers := make(map[int]int)
+	for i, record := range encryptedData {
+		data, err := t.DecryptData(record, privKey)
+		if err != nil {
+			continue
+		}
+		markers, err := bytesToMarkers(data)
+		if err != nil || len(markers) == 0 {
+			continue
+		}
+
+		score, riskLevel := scoreMarkers(markers)
+		sum += score
+		tiers[riskLevel]++
+		contributors = append(contributors, i)
+	}
+
+	if len(contributors) < MinCohortSize {
+		return nil, nil, ErrCohortTooSmall
+	}
+
+	result := &AnalysisResult{
+		Analysis:     analysis,
+		ModelVersion: ModelVersion,
+		CohortSize:   len(contributors),
+		MeanScore:    math.Round(sum/float64(len(contributors))*1e4) / 1e4,
+		RiskTiers:    make(map[string]int),
+	}
+	for riskLevel, count := range tiers {
+		if count < MinCellSize {
+			result.Suppressed += count
+			continue
+		}
+		result.RiskTiers[strconv.Itoa(riskLevel)] = count
+	}
+	return result, contributors, nil
+}
@@ -0,0 +1,103 @@
+package tee
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestTeeService_Analyze(t *testing.T) {
+	service := NewTeeService([]byte("enclave secret"), DefaultCodec)
+	privKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	encrypt := func(key *ecdsa.PrivateKey, riskFactors ...float64) [][]byte {
+		var records [][]byte
+		for _, riskFactor := range riskFactors {
+			record, _, err := service.ProcessAndEncrypt(generateTestData(5, riskFactor), &key.PublicKey)
+			if err != nil {
+				t.Fatalf("Failed to encrypt: %v", err)
+			}
+			records = append(records, record)
+		}
+		return records
+	}
+
+	tests := []struct {
+		name             string
+		analysis         string
+		records          [][]byte
+		wantErr          error
+		wantContributors []int
+		wantTiers        map[string]int
+		wantSuppressed   int
+	}{
+		{
+			name:             "Small tiers are withheld",
+			analysis:         AnalysisRiskSummary,
+			records:          encrypt(privKey, 0.1, 0.1, 0.1, 0.4, 0.8),
+			wantContributors: []int{0, 1, 2, 3, 4},
+			wantTiers:        map[string]int{"1": 3},
+			wantSuppressed:   2,
+		},
+		{
+			name:             "Unreadable records do not contribute",
+			analysis:         AnalysisRiskSummary,
+			records:          append(encrypt(otherKey, 0.1), encrypt(privKey, 0.8, 0.8, 0.8, 0.8, 0.8)...),
+			wantContributors: []int{1, 2, 3, 4, 5},
+			wantTiers:        map[string]int{"4": 5},
+		},
+		{
+			name:     "Cohort too small",
+			analysis: AnalysisRiskSummary,
+			records:  append(encrypt(otherKey, 0.1), encrypt(privKey, 0.1, 0.1, 0.1, 0.1)...),
+			wantErr:  ErrCohortTooSmall,
+		},
+		{
+			name:     "Unsupported analysis",
+			analysis: "genotype-dump",
+			records:  encrypt(privKey, 0.1, 0.1, 0.1, 0.1, 0.1),
+			wantErr:  ErrUnsupportedAnalysis,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, contributors, err := service.Analyze(tt.analysis, tt.records, privKey)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(contributors) != len(tt.wantContributors) {
+				t.Fatalf("Expected contributors %v, got %v", tt.wantContributors, contributors)
+			}
+			for i := range contributors {
+				if contributors[i] != tt.wantContributors[i] {
+					t.Errorf("Expected contributors %v, got %v", tt.wantContributors, contributors)
+				}
+			}
+			if result.CohortSize != len(tt.wantContributors) {
+				t.Errorf("Expected cohort size %d, got %d", len(tt.wantContributors), result.CohortSize)
+			}
+			if len(result.RiskTiers) != len(tt.wantTiers) {
+				t.Errorf("Expected risk tiers %v, got %v", tt.wantTiers, result.RiskTiers)
+			}
+			for tier, count := range tt.wantTiers {
+				if result.RiskTiers[tier] != count {
+					t.Errorf("Expected risk tiers %v, got %v", tt.wantTiers, result.RiskTiers)
+				}
+			}
+			if result.Suppressed != tt.wantSuppressed {
+				t.Errorf("Expected %d suppressed, got %d", tt.wantSuppressed, result.Suppressed)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package tee
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec is the compression applied to genomic data before it is sealed.
+type Codec byte
+
+const (
+	CodecNone Codec = iota
+	CodecGzip
+	CodecZstd
+)
+
+// DefaultCodec compresses genotypes, which are highly repetitive, 5 to 10 times.
+const DefaultCodec = CodecZstd
+
+// MaxDataSize bounds the genomic data the TEE seals, and so what decompressing sealed data may
+// produce, however small the compressed data is.
+const MaxDataSize = 256 << 20
+
+var ErrDataTooLarge = errors.New("genomic data exceeds the TEE size limit")
+
+var codecNames = map[Codec]string{
+	CodecNone: "none",
+	CodecGzip: "gzip",
+	CodecZstd: "zstd",
+}
+
+func (c Codec) String() string {
+	if name, ok := codecNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("codec(%d)", byte(c))
+}
+
+// ParseCodec reads a codec name: "zstd", "gzip" or "none". An empty name is DefaultCodec.
+func ParseCodec(name string) (Codec, error) {
+	if name == "" {
+		return DefaultCodec, nil
+	}
+	for codec, codecName := range codecNames {
+		if codecName == name {
+			return codec, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown compression %q", name)
+}
+
+func compress(codec Codec, data []byte) ([]byte, error) {
+	switch codec {
+	case CodecNone:
+		return data, nil
+	case CodecGzip:
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CodecZstd:
+		encoder, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		defer encoder.Close()
+		return encoder.EncodeAll(data, make([]byte, 0, len(data)/4)), nil
+	default:
+		return nil, fmt.Errorf("unknown compression %s", codec)
+	}
+}
+
+// decompress restores the size bytes that data was compressed from. It reads at most one byte past
+// size, so data expanding beyond it is rejected without being inflated.
+func decompress(codec Codec, data []byte, size uint64) ([]byte, error) {
+	if size > MaxDataSize {
+		return nil, ErrDataTooLarge
+	}
+
+	var reader io.Reader
+	switch codec {
+	case CodecNone:
+		reader = bytes.NewReader(data)
+	case CodecGzip:
+		gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("decompressing: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	case CodecZstd:
+		decoder, err := zstd.NewReader(bytes.NewReader(data), zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(MaxDataSize))
+		if err != nil {
+			return nil, fmt.Errorf("decompressing: %w", err)
+		}
+		defer decoder.Close()
+		reader = decoder
+	default:
+		return nil, fmt.Errorf("unknown compression %s", codec)
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, size))
+	n, err := io.Copy(out, io.LimitReader(reader, int64(size)+1))
+	if err != nil {
+		return nil, fmt.Errorf("decompressing: %w", err)
+	}
+	if uint64(n) != size {
+		return nil, fmt.Errorf("decompressed data is not the %d bytes sealed", size)
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,198 @@
+package tee
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	mathrand "math/rand"
+	"testing"
+)
+
+// genotypeData encodes count markers as a genotype would: each is one of three allele dosages.
+func genotypeData(count int) []byte {
+	dosages := []uint64{0, 1 << 62, 1 << 63}
+	random := mathrand.New(mathrand.NewSource(1))
+	data := make([]byte, count*8)
+	for i := 0; i < count; i++ {
+		binary.LittleEndian.PutUint64(data[i*8:], dosages[random.Intn(len(dosages))])
+	}
+	return data
+}
+
+// sealLegacy seals data as the TEE did before the header: hash, nonce and ciphertext.
+func sealLegacy(t *testing.T, pubKey *ecdsa.PublicKey, data []byte) []byte {
+	block, _ := aes.NewCipher(deriveKey(pubKey))
+	aesgcm, _ := cipher.NewGCM(block)
+	hash := sha256.Sum256(data)
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		t.Fatalf("Failed to generate nonce: %v", err)
+	}
+	sealed := append(hash[:], nonce...)
+	return aesgcm.Seal(sealed, nonce, data, hash[:])
+}
+
+func TestTeeService_Compression(t *testing.T) {
+	privKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	genotype := genotypeData(10000)
+	incompressible := make([]byte, 8000)
+	rand.Read(incompressible)
+
+	tests := []struct {
+		name      string
+		codec     Codec
+		data      []byte
+		wantCodec Codec
+		maxRatio  float64
+	}{
+		{name: "zstd", codec: CodecZstd, data: genotype, wantCodec: CodecZstd, maxRatio: 0.2},
+		{name: "gzip", codec: CodecGzip, data: genotype, wantCodec: CodecGzip, maxRatio: 0.2},
+		{name: "Uncompressed", codec: CodecNone, data: genotype, wantCodec: CodecNone, maxRatio: 1.1},
+		{name: "Incompressible data is sealed as is", codec: CodecZstd, data: incompressible, wantCodec: CodecNone, maxRatio: 1.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewTeeService([]byte("enclave secret"), tt.codec)
+			sealed, _, err := service.ProcessAndEncrypt(tt.data, &privKey.PublicKey)
+			if err != nil {
+				t.Fatalf("ProcessAndEncrypt() error = %v", err)
+			}
+			if codec := Codec(sealed[len(sealedMagic)]); codec != tt.wantCodec {
+				t.Errorf("Sealed with %s, want %s", codec, tt.wantCodec)
+			}
+			if ratio := float64(len(sealed)) / float64(len(tt.data)); ratio > tt.maxRatio {
+				t.Errorf("Sealed %d bytes into %d, ratio %.2f above %.2f", len(tt.data), len(sealed), ratio, tt.maxRatio)
+			}
+
+			// Any TEE opens it, whatever codec it seals with
+			decrypted, err := NewTeeService([]byte("enclave secret"), CodecGzip).DecryptData(sealed, privKey)
+			if err != nil {
+				t.Fatalf("DecryptData() error = %v", err)
+			}
+			if !bytes.Equal(decrypted, tt.data) {
+				t.Errorf("DecryptData() did not return the original data")
+			}
+		})
+	}
+}
+
+func TestTeeService_DecryptData_Sealed(t *testing.T) {
+	service := NewTeeService([]byte("enclave secret"), CodecZstd)
+	privKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	key := deriveKey(&privKey.PublicKey)
+	data := genotypeData(1000)
+	hash := sha256.Sum256(data)
+	compressed, _ := compress(CodecZstd, data)
+	// 1 MiB of zeros compresses to a few bytes
+	bomb, _ := compress(CodecZstd, make([]byte, 1<<20))
+
+	sealed := func(codec Codec, payload []byte, size uint64) []byte {
+		result, err := seal(key, codec, hash, payload, size)
+		if err != nil {
+			t.Fatalf("seal() error = %v", err)
+		}
+		return result
+	}
+	tampered := func(data []byte, offset int) []byte {
+		data[offset] ^= 1
+		return data
+	}
+
+	tests := []struct {
+		name      string
+		data      []byte
+		wantData  bool
+		wantError error
+	}{
+		{name: "Sealed data", data: sealed(CodecZstd, compressed, uint64(len(data))), wantData: true},
+		{name: "Legacy data", data: sealLegacy(t, &privKey.PublicKey, data), wantData: true},
+		{name: "Tampered codec", data: tampered(sealed(CodecZstd, compressed, uint64(len(data))), len(sealedMagic))},
+		{name: "Tampered size", data: tampered(sealed(CodecZstd, compressed, uint64(len(data))), len(sealedMagic)+1)},
+		{name: "Expands past its size", data: sealed(CodecZstd, bomb, uint64(len(data)))},
+		{name: "Size above the limit", data: sealed(CodecZstd, bomb, MaxDataSize+1), wantError: ErrDataTooLarge},
+		{name: "Unknown codec", data: sealed(Codec(9), compressed, uint64(len(data)))},
+		{name: "Truncated header", data: []byte(sealedMagic)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decrypted, err := service.DecryptData(tt.data, privKey)
+			if tt.wantData {
+				if err != nil || !bytes.Equal(decrypted, data) {
+					t.Errorf("DecryptData() error = %v, want the original data", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected error but got none")
+			}
+			if tt.wantError != nil && !errors.Is(err, tt.wantError) {
+				t.Errorf("DecryptData() error = %v, want %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestParseCodec(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Codec
+		wantErr bool
+	}{
+		{name: "", want: DefaultCodec},
+		{name: "zstd", want: CodecZstd},
+		{name: "gzip", want: CodecGzip},
+		{name: "none", want: CodecNone},
+		{name: "lz4", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCodec(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseCodec(%q) = %s, %v, want %s", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+// Run with go test -bench . ./internal/tee: MB/s is the throughput over the raw genotype, and
+// stored/raw the size of what is stored relative to it.
+func BenchmarkProcessAndEncrypt(b *testing.B) {
+	privKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	data := genotypeData(1 << 20)
+
+	for _, codec := range []Codec{CodecNone, CodecGzip, CodecZstd} {
+		b.Run(codec.String(), func(b *testing.B) {
+			service := NewTeeService([]byte("enclave secret"), codec)
+			b.SetBytes(int64(len(data)))
+			var sealed []byte
+			for i := 0; i < b.N; i++ {
+				sealed, _, _ = service.ProcessAndEncrypt(data, &privKey.PublicKey)
+			}
+			b.ReportMetric(float64(len(sealed))/float64(len(data)), "stored/raw")
+		})
+	}
+}
+
+func BenchmarkDecryptData(b *testing.B) {
+	privKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	data := genotypeData(1 << 20)
+
+	for _, codec := range []Codec{CodecNone, CodecGzip, CodecZstd} {
+		b.Run(codec.String(), func(b *testing.B) {
+			service := NewTeeService([]byte("enclave secret"), codec)
+			sealed, _, _ := service.ProcessAndEncrypt(data, &privKey.PublicKey)
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if _, err := service.DecryptData(sealed, privKey); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
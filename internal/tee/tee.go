@@ -1,26 +1,87 @@
 package tee
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/ecdsa"
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"strings"
 )
 
+// ModelVersion identifies the risk scoring model run inside the TEE.
+const ModelVersion = "g-stroke-v1"
+
+// DataFormat names the genotype encoding the TEE reads: markers as little-endian uint64 fractions of 2^63.
+const DataFormat = "markers-u64le"
+
+// Risk levels assigned by the model, from the lowest score to the highest. The risk level is
+// the risk score sent on-chain, where it selects the PCSP reward.
+const (
+	RiskLow           = 1
+	RiskSlightlyHigh  = 2
+	RiskHigh          = 3
+	RiskExtremelyHigh = 4
+)
+
+var riskLevelNames = map[int]string{
+	RiskLow:           "low",
+	RiskSlightlyHigh:  "slightly high",
+	RiskHigh:          "high",
+	RiskExtremelyHigh: "extremely high",
+}
+
+// RiskLevelName describes a risk level, e.g. "extremely high" for RiskExtremelyHigh.
+func RiskLevelName(level int) string {
+	return riskLevelNames[level]
+}
+
 type TeeService interface {
 	ProcessAndEncrypt(data []byte, pubKey *ecdsa.PublicKey) ([]byte, int, error)
 	DecryptData(encryptedData []byte, privKey *ecdsa.PrivateKey) ([]byte, error)
+	Analyze(analysis string, encryptedData [][]byte, privKey *ecdsa.PrivateKey) (*AnalysisResult, []int, error)
+	Fingerprint(data []byte) (string, error)
+	ExportData(encryptedData []byte, privKey *ecdsa.PrivateKey, exportKey []byte) ([]byte, error)
+	ImportData(exportedData []byte, exportKey []byte, pubKey *ecdsa.PublicKey) ([]byte, string, error)
 }
 
-type teeService struct{}
+type teeService struct {
+	fingerprintKey []byte
+	codec          Codec
+}
 
-func NewTeeService() TeeService {
-	return &teeService{}
+// NewTeeService creates the TEE; fingerprintKey is the enclave secret that keys genome
+// fingerprints and never leaves it. Data is compressed with codec before it is sealed.
+func NewTeeService(fingerprintKey []byte, codec Codec) TeeService {
+	return &teeService{fingerprintKey: fingerprintKey, codec: codec}
 }
 
+// ParseFingerprintKey reads the enclave secret keying fingerprints, at least 32 bytes of hex.
+func ParseFingerprintKey(hexKey string) ([]byte, error) {
+	key, err := hex.DecodeString(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil || len(key) < 32 {
+		return nil, fmt.Errorf("fingerprint key must be at least 32 bytes of hex")
+	}
+	return key, nil
+}
+
+// Sealed data starts with a header, authenticated together with the hash of the data:
+//
+//	"GDS1" | codec (1 byte) | data size (8 bytes, little-endian) | SHA-256 of the data | nonce | ciphertext
+//
+// Data sealed before the header was added is only the hash, nonce and ciphertext of the
+// uncompressed data.
+const (
+	sealedMagic = "GDS1"
+	headerSize  = len(sealedMagic) + 1 + 8
+	nonceSize   = 12
+)
+
 // deriveKey creates a consistent key from either public or private key
 func deriveKey(key interface{}) []byte {
 	switch k := key.(type) {
@@ -38,41 +99,100 @@ func deriveKey(key interface{}) []byte {
 	}
 }
 
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCM: %w", err)
+	}
+	return aesgcm, nil
+}
+
+// DecryptData returns the original data, decompressed, of data sealed with or without a header.
 func (t *teeService) DecryptData(encryptedData []byte, privKey *ecdsa.PrivateKey) ([]byte, error) {
-	if len(encryptedData) < sha256.Size+12 {
+	key := deriveKey(&privKey.PublicKey)
+	if !bytes.HasPrefix(encryptedData, []byte(sealedMagic)) {
+		return openLegacy(key, encryptedData)
+	}
+
+	data, err := open(key, encryptedData)
+	if err != nil {
+		// The hash a legacy ciphertext starts with may, rarely, start like the header
+		if legacy, legacyErr := openLegacy(key, encryptedData); legacyErr == nil {
+			return legacy, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func open(key []byte, encryptedData []byte) ([]byte, error) {
+	if len(encryptedData) < headerSize+sha256.Size+nonceSize {
 		return nil, fmt.Errorf("encrypted data too short")
 	}
 
-	hash := encryptedData[:sha256.Size]
-	nonce := encryptedData[sha256.Size : sha256.Size+12]
-	ciphertext := encryptedData[sha256.Size+12:]
+	codec := Codec(encryptedData[len(sealedMagic)])
+	size := binary.LittleEndian.Uint64(encryptedData[len(sealedMagic)+1 : headerSize])
+	authenticated := encryptedData[:headerSize+sha256.Size]
+	hash := encryptedData[headerSize : headerSize+sha256.Size]
+	nonce := encryptedData[headerSize+sha256.Size : headerSize+sha256.Size+nonceSize]
+	ciphertext := encryptedData[headerSize+sha256.Size+nonceSize:]
 
-	key := deriveKey(&privKey.PublicKey)
+	aesgcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
 
-	block, err := aes.NewCipher(key)
+	payload, err := aesgcm.Open(nil, nonce, ciphertext, authenticated)
 	if err != nil {
-		return nil, fmt.Errorf("creating cipher: %w", err)
+		return nil, fmt.Errorf("decrypting: %w", err)
 	}
 
-	aesgcm, err := cipher.NewGCM(block)
+	decryptedData, err := decompress(codec, payload, size)
 	if err != nil {
-		return nil, fmt.Errorf("creating GCM: %w", err)
+		return nil, err
+	}
+	return checkHash(hash, decryptedData)
+}
+
+func openLegacy(key []byte, encryptedData []byte) ([]byte, error) {
+	if len(encryptedData) < sha256.Size+nonceSize {
+		return nil, fmt.Errorf("encrypted data too short")
+	}
+
+	hash := encryptedData[:sha256.Size]
+	nonce := encryptedData[sha256.Size : sha256.Size+nonceSize]
+	ciphertext := encryptedData[sha256.Size+nonceSize:]
+
+	aesgcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
 	}
 
 	decryptedData, err := aesgcm.Open(nil, nonce, ciphertext, hash)
 	if err != nil {
 		return nil, fmt.Errorf("decrypting: %w", err)
 	}
+	return checkHash(hash, decryptedData)
+}
 
-	actualHash := sha256.Sum256(decryptedData)
-	if !compareHashes(hash[:], actualHash[:]) {
+func checkHash(hash []byte, data []byte) ([]byte, error) {
+	actualHash := sha256.Sum256(data)
+	if !compareHashes(hash, actualHash[:]) {
 		return nil, fmt.Errorf("data integrity check failed")
 	}
-
-	return decryptedData, nil
+	return data, nil
 }
 
 func (t *teeService) ProcessAndEncrypt(data []byte, pubKey *ecdsa.PublicKey) ([]byte, int, error) {
+	if len(data) > MaxDataSize {
+		return nil, 0, ErrDataTooLarge
+	}
+
 	markers, err := bytesToMarkers(data)
 	if err != nil {
 		return nil, 0, fmt.Errorf("invalid marker data: %w", err)
@@ -82,52 +202,123 @@ func (t *teeService) ProcessAndEncrypt(data []byte, pubKey *ecdsa.PublicKey) ([]
 		return nil, 0, fmt.Errorf("no markers provided")
 	}
 
-	var sum float64
-	for _, marker := range markers {
-		sum += marker
-	}
-	score := sum / float64(len(markers))
+	_, riskLevel := scoreMarkers(markers)
 
-	riskLevel := 1
-	switch {
-	case score < 0.25:
-		riskLevel = 1
-	case score < 0.50:
-		riskLevel = 2
-	case score < 0.75:
-		riskLevel = 3
-	default:
-		riskLevel = 4
+	// Use consistent key derivation
+	result, err := t.compressAndSeal(deriveKey(pubKey), data)
+	if err != nil {
+		return nil, 0, err
 	}
+	return result, riskLevel, nil
+}
 
-	hash := sha256.Sum256(data)
+// ExportData seals data sealed by this TEE again under exportKey, a 32-byte key of an export
+// bundle, so that it can be opened elsewhere without the service key.
+func (t *teeService) ExportData(encryptedData []byte, privKey *ecdsa.PrivateKey, exportKey []byte) ([]byte, error) {
+	if len(exportKey) != 32 {
+		return nil, fmt.Errorf("export key must be 32 bytes")
+	}
+	data, err := t.DecryptData(encryptedData, privKey)
+	if err != nil {
+		return nil, err
+	}
+	return t.compressAndSeal(exportKey, data)
+}
 
-	// Use consistent key derivation
-	key := deriveKey(pubKey)
+// ImportData opens data sealed under exportKey and seals it for pubKey, as ProcessAndEncrypt would.
+// It also returns the fingerprint of the data, keyed by this TEE.
+func (t *teeService) ImportData(exportedData []byte, exportKey []byte, pubKey *ecdsa.PublicKey) ([]byte, string, error) {
+	if len(exportKey) != 32 {
+		return nil, "", fmt.Errorf("export key must be 32 bytes")
+	}
+	data, err := open(exportKey, exportedData)
+	if err != nil {
+		return nil, "", err
+	}
+	fingerprint, err := t.Fingerprint(data)
+	if err != nil {
+		return nil, "", err
+	}
+	result, err := t.compressAndSeal(deriveKey(pubKey), data)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, fingerprint, nil
+}
 
-	block, err := aes.NewCipher(key)
+func (t *teeService) compressAndSeal(key []byte, data []byte) ([]byte, error) {
+	codec := t.codec
+	payload, err := compress(codec, data)
 	if err != nil {
-		return nil, 0, fmt.Errorf("creating cipher: %w", err)
+		return nil, fmt.Errorf("compressing: %w", err)
+	}
+	// Data that does not compress is sealed as is
+	if len(payload) >= len(data) {
+		codec, payload = CodecNone, data
 	}
+	return seal(key, codec, sha256.Sum256(data), payload, uint64(len(data)))
+}
 
-	aesgcm, err := cipher.NewGCM(block)
+// seal encrypts payload, the data of the given size and hash compressed with codec, behind its header.
+func seal(key []byte, codec Codec, hash [sha256.Size]byte, payload []byte, size uint64) ([]byte, error) {
+	aesgcm, err := newGCM(key)
 	if err != nil {
-		return nil, 0, fmt.Errorf("creating GCM: %w", err)
+		return nil, err
 	}
 
-	nonce := make([]byte, aesgcm.NonceSize())
+	nonce := make([]byte, nonceSize)
 	if _, err := rand.Read(nonce); err != nil {
-		return nil, 0, fmt.Errorf("generating nonce: %w", err)
+		return nil, fmt.Errorf("generating nonce: %w", err)
 	}
 
-	ciphertext := aesgcm.Seal(nil, nonce, data, hash[:])
+	result := make([]byte, headerSize+sha256.Size+nonceSize, headerSize+sha256.Size+nonceSize+len(payload)+aesgcm.Overhead())
+	copy(result, sealedMagic)
+	result[len(sealedMagic)] = byte(codec)
+	binary.LittleEndian.PutUint64(result[len(sealedMagic)+1:headerSize], size)
+	copy(result[headerSize:], hash[:])
+	copy(result[headerSize+sha256.Size:], nonce)
 
-	result := make([]byte, sha256.Size+len(nonce)+len(ciphertext))
-	copy(result[:sha256.Size], hash[:])
-	copy(result[sha256.Size:sha256.Size+len(nonce)], nonce)
-	copy(result[sha256.Size+len(nonce):], ciphertext)
+	return aesgcm.Seal(result, nonce, payload, result[:headerSize+sha256.Size]), nil
+}
 
-	return result, riskLevel, nil
+// Fingerprint identifies a genotype regardless of its encryption: the HMAC-SHA256 of its markers
+// under the enclave secret. Without the secret, a fingerprint cannot be matched against guessed
+// genotypes.
+func (t *teeService) Fingerprint(data []byte) (string, error) {
+	markers, err := bytesToMarkers(data)
+	if err != nil {
+		return "", fmt.Errorf("invalid marker data: %w", err)
+	}
+	if len(markers) == 0 {
+		return "", fmt.Errorf("no markers provided")
+	}
+
+	// Normalized to the whole markers, as bytes past the last one are never read
+	mac := hmac.New(sha256.New, t.fingerprintKey)
+	mac.Write(data[:len(markers)*8])
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// scoreMarkers returns the mean marker value and the risk level it falls in.
+func scoreMarkers(markers []float64) (float64, int) {
+	var sum float64
+	for _, marker := range markers {
+		sum += marker
+	}
+	score := sum / float64(len(markers))
+
+	riskLevel := RiskLow
+	switch {
+	case score < 0.25:
+		riskLevel = RiskLow
+	case score < 0.50:
+		riskLevel = RiskSlightlyHigh
+	case score < 0.75:
+		riskLevel = RiskHigh
+	default:
+		riskLevel = RiskExtremelyHigh
+	}
+	return score, riskLevel
 }
 
 func bytesToMarkers(data []byte) ([]float64, error) {
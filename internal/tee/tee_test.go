@@ -10,7 +10,7 @@ import (
 
 func TestTeeService_ProcessAndDecrypt(t *testing.T) {
 	// Setup
-	service := NewTeeService()
+	service := NewTeeService([]byte("enclave secret"), DefaultCodec)
 
 	// Generate key pair
 	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
@@ -100,7 +100,7 @@ func TestTeeService_ProcessAndDecrypt(t *testing.T) {
 }
 
 func TestTeeService_DecryptData_InvalidInput(t *testing.T) {
-	service := NewTeeService()
+	service := NewTeeService([]byte("enclave secret"), DefaultCodec)
 	privKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 
 	tests := []struct {
@@ -138,6 +138,70 @@ func TestTeeService_DecryptData_InvalidInput(t *testing.T) {
 	}
 }
 
+func TestTeeService_Fingerprint(t *testing.T) {
+	service := NewTeeService([]byte("enclave secret"), DefaultCodec)
+	genome := generateTestData(5, 0.2)
+	fingerprint, err := service.Fingerprint(genome)
+	if err != nil {
+		t.Fatalf("Fingerprint() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		service   TeeService
+		data      []byte
+		wantSame  bool
+		wantError bool
+	}{
+		{
+			name:     "Same genotype",
+			service:  service,
+			data:     generateTestData(5, 0.2),
+			wantSame: true,
+		},
+		{
+			name:     "Trailing bytes are not markers",
+			service:  service,
+			data:     append(generateTestData(5, 0.2), 1, 2, 3),
+			wantSame: true,
+		},
+		{
+			name:    "Different genotype",
+			service: service,
+			data:    generateTestData(5, 0.4),
+		},
+		{
+			name:    "Other enclave secret",
+			service: NewTeeService([]byte("other secret"), DefaultCodec),
+			data:    generateTestData(5, 0.2),
+		},
+		{
+			name:      "No markers",
+			service:   service,
+			data:      []byte{1, 2, 3},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.service.Fingerprint(tt.data)
+			if tt.wantError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if (got == fingerprint) != tt.wantSame {
+				t.Errorf("Fingerprint() = %s, first upload %s, want same %v", got, fingerprint, tt.wantSame)
+			}
+		})
+	}
+}
+
 // Helper function to generate test data with specific risk level
 func generateTestData(count int, riskFactor float64) []byte {
 	data := make([]byte, count*8)
@@ -149,3 +213,85 @@ func generateTestData(count int, riskFactor float64) []byte {
 
 	return data
 }
+
+func TestTeeService_ExportImport(t *testing.T) {
+	source := NewTeeService([]byte("source secret"), DefaultCodec)
+	destination := NewTeeService([]byte("destination secret"), CodecGzip)
+	sourceKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	destinationKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	exportKey := make([]byte, 32)
+	rand.Read(exportKey)
+
+	genome := generateTestData(5, 0.2)
+	sealed, _, err := source.ProcessAndEncrypt(genome, &sourceKey.PublicKey)
+	if err != nil {
+		t.Fatalf("ProcessAndEncrypt() error = %v", err)
+	}
+	exported, err := source.ExportData(sealed, sourceKey, exportKey)
+	if err != nil {
+		t.Fatalf("ExportData() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		data      []byte
+		key       []byte
+		wantError bool
+	}{
+		{
+			name: "Export key",
+			data: exported,
+			key:  exportKey,
+		},
+		{
+			name:      "Other export key",
+			data:      exported,
+			key:       make([]byte, 32),
+			wantError: true,
+		},
+		{
+			name:      "Short export key",
+			data:      exported,
+			key:       exportKey[:16],
+			wantError: true,
+		},
+		{
+			name:      "Sealed for the source",
+			data:      sealed,
+			key:       exportKey,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imported, fingerprint, err := destination.ImportData(tt.data, tt.key, &destinationKey.PublicKey)
+			if tt.wantError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportData() error = %v", err)
+			}
+
+			decrypted, err := destination.DecryptData(imported, destinationKey)
+			if err != nil {
+				t.Fatalf("DecryptData() error = %v", err)
+			}
+			if string(decrypted) != string(genome) {
+				t.Error("Imported data does not match the original")
+			}
+			if want, _ := destination.Fingerprint(genome); fingerprint != want {
+				t.Errorf("ImportData() fingerprint = %s, want %s", fingerprint, want)
+			}
+		})
+	}
+}
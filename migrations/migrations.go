@@ -0,0 +1,23 @@
+// Package migrations embeds the versioned SQL migrations of the gateway database, one directory
+// per dialect. Each file is named <version>-<name>.sql; statements after a "-- +migrate Down" line
+// revert it. A change to the schema is added to every dialect under the same version.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+)
+
+//go:embed sqlite/*.sql postgres/*.sql
+var FS embed.FS
+
+// For returns the migrations of a gorm dialect, "sqlite" or "postgres".
+func For(dialect string) (fs.FS, error) {
+	switch dialect {
+	case "sqlite", "postgres":
+		return fs.Sub(FS, dialect)
+	default:
+		return nil, fmt.Errorf("no migrations for database %q", dialect)
+	}
+}
@@ -0,0 +1,144 @@
+-- The schema of migrations/sqlite up to 1735400000-align-schema-with-models. PostgreSQL databases
+-- start here, without encrypted data in gene_data or legacy file IDs to carry over.
+CREATE TABLE users (
+    id BIGINT PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    pubkey TEXT NOT NULL UNIQUE
+);
+
+CREATE INDEX idx_users_deleted_at ON users(deleted_at);
+
+CREATE TABLE gene_data (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    file_id TEXT NOT NULL,
+    legacy_file_id TEXT,
+    user_id BIGINT NOT NULL,
+    format TEXT NOT NULL DEFAULT 'markers-u64le',
+    blob_key TEXT,
+    blob_size BIGINT NOT NULL DEFAULT 0,
+    blob_checksum TEXT,
+    data_hash BYTEA,
+    signature BYTEA,
+    session_id TEXT,
+    doc_id TEXT,
+    token_id TEXT,
+    risk_score BIGINT NOT NULL DEFAULT 0,
+    model_version TEXT,
+    share_risk_tier BOOLEAN NOT NULL DEFAULT FALSE,
+    upload_status TEXT NOT NULL DEFAULT 'completed',
+    fingerprint TEXT
+);
+
+CREATE UNIQUE INDEX idx_gene_data_file_id ON gene_data(file_id);
+CREATE INDEX idx_gene_data_legacy_file_id ON gene_data(legacy_file_id);
+CREATE INDEX idx_gene_data_user_status ON gene_data(user_id, upload_status);
+CREATE INDEX idx_gene_data_user_size ON gene_data(user_id, blob_size);
+CREATE INDEX idx_gene_data_session_id ON gene_data(session_id);
+CREATE INDEX idx_gene_data_doc_id ON gene_data(doc_id);
+CREATE INDEX idx_gene_data_token_id ON gene_data(token_id);
+CREATE UNIQUE INDEX idx_gene_data_fingerprint ON gene_data(fingerprint);
+CREATE INDEX idx_gene_data_deleted_at ON gene_data(deleted_at);
+
+CREATE TABLE upload_workflows (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    file_id TEXT NOT NULL,
+    user_address TEXT NOT NULL,
+    step TEXT NOT NULL,
+    session_id TEXT,
+    doc_id TEXT,
+    token_id TEXT,
+    content_hash TEXT,
+    risk_score BIGINT NOT NULL DEFAULT 0,
+    share_risk_tier BOOLEAN NOT NULL DEFAULT FALSE,
+    attempts BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    note TEXT
+);
+
+CREATE UNIQUE INDEX idx_upload_workflows_file_id ON upload_workflows(file_id);
+CREATE INDEX idx_upload_workflows_step ON upload_workflows(step);
+CREATE INDEX idx_upload_workflows_deleted_at ON upload_workflows(deleted_at);
+
+CREATE TABLE research_consents (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    token_id TEXT NOT NULL,
+    owner TEXT NOT NULL,
+    granted BOOLEAN NOT NULL DEFAULT FALSE,
+    signed_at BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX idx_research_consents_token_id ON research_consents(token_id);
+CREATE INDEX idx_research_consents_deleted_at ON research_consents(deleted_at);
+
+CREATE TABLE analysis_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    request_id TEXT NOT NULL,
+    researcher TEXT NOT NULL,
+    analysis TEXT NOT NULL,
+    amount TEXT NOT NULL,
+    status TEXT NOT NULL,
+    token_ids TEXT,
+    result TEXT,
+    reason TEXT,
+    request_tx_hash TEXT,
+    settle_tx_hash TEXT
+);
+
+CREATE UNIQUE INDEX idx_analysis_jobs_request_id ON analysis_jobs(request_id);
+CREATE INDEX idx_analysis_jobs_researcher ON analysis_jobs(researcher);
+CREATE INDEX idx_analysis_jobs_status ON analysis_jobs(status);
+CREATE INDEX idx_analysis_jobs_deleted_at ON analysis_jobs(deleted_at);
+
+CREATE TABLE content_anchors (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    root TEXT NOT NULL,
+    leaf_count BIGINT NOT NULL,
+    status TEXT NOT NULL,
+    tx_hash TEXT,
+    block_number BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX idx_content_anchors_root ON content_anchors(root);
+CREATE INDEX idx_content_anchors_status ON content_anchors(status);
+CREATE INDEX idx_content_anchors_deleted_at ON content_anchors(deleted_at);
+
+CREATE TABLE anchor_leaves (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    doc_id TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    anchor_id BIGINT,
+    leaf_index BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX idx_anchor_leaves_doc_id ON anchor_leaves(doc_id);
+CREATE INDEX idx_anchor_leaves_anchor_id ON anchor_leaves(anchor_id);
+CREATE INDEX idx_anchor_leaves_deleted_at ON anchor_leaves(deleted_at);
+
+-- +migrate Down
+DROP TABLE anchor_leaves;
+DROP TABLE content_anchors;
+DROP TABLE analysis_jobs;
+DROP TABLE research_consents;
+DROP TABLE upload_workflows;
+DROP TABLE gene_data;
+DROP TABLE users;
@@ -0,0 +1,7 @@
+-- When the user withdrew a file, and when the retention policy purged its raw data
+ALTER TABLE gene_data ADD COLUMN withdrawn_at TIMESTAMPTZ;
+ALTER TABLE gene_data ADD COLUMN purged_at TIMESTAMPTZ;
+
+-- +migrate Down
+ALTER TABLE gene_data DROP COLUMN purged_at;
+ALTER TABLE gene_data DROP COLUMN withdrawn_at;
@@ -0,0 +1,34 @@
+-- Append-only, hash-chained log of accesses to genomic data, and its signed heads
+CREATE TABLE audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    seq BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    file_id TEXT NOT NULL,
+    user_id BIGINT NOT NULL,
+    purpose TEXT NOT NULL DEFAULT '',
+    outcome TEXT NOT NULL,
+    prev_hash TEXT NOT NULL,
+    hash TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_audit_events_seq ON audit_events(seq);
+CREATE INDEX idx_audit_events_file_id ON audit_events(file_id);
+CREATE INDEX idx_audit_events_user_id ON audit_events(user_id);
+
+CREATE TABLE audit_heads (
+    id BIGSERIAL PRIMARY KEY,
+    seq BIGINT NOT NULL,
+    hash TEXT NOT NULL,
+    signer TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    signed_at TIMESTAMPTZ NOT NULL,
+    anchor_doc_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX idx_audit_heads_seq ON audit_heads(seq);
+
+-- +migrate Down
+DROP TABLE audit_heads;
+DROP TABLE audit_events;
@@ -0,0 +1,28 @@
+-- Sign-In with Ethereum nonces, each accepted once, and the sessions opened with them
+CREATE TABLE auth_nonces (
+    id BIGSERIAL PRIMARY KEY,
+    nonce TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX idx_auth_nonces_nonce ON auth_nonces(nonce);
+CREATE INDEX idx_auth_nonces_expires_at ON auth_nonces(expires_at);
+
+CREATE TABLE auth_sessions (
+    id BIGSERIAL PRIMARY KEY,
+    token_hash TEXT NOT NULL,
+    user_id BIGINT NOT NULL,
+    address TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_auth_sessions_token_hash ON auth_sessions(token_hash);
+CREATE INDEX idx_auth_sessions_user_id ON auth_sessions(user_id);
+CREATE INDEX idx_auth_sessions_expires_at ON auth_sessions(expires_at);
+
+-- +migrate Down
+DROP TABLE auth_sessions;
+DROP TABLE auth_nonces;
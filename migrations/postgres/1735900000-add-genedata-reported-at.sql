@@ -0,0 +1,7 @@
+-- When the report of a file was delivered, which the retention policy counts from. Files recorded
+-- before it keep their upload time, the closest time known.
+ALTER TABLE gene_data ADD COLUMN reported_at TIMESTAMPTZ;
+UPDATE gene_data SET reported_at = created_at WHERE upload_status = 'completed';
+
+-- +migrate Down
+ALTER TABLE gene_data DROP COLUMN reported_at;
@@ -19,4 +19,8 @@ CREATE TABLE IF NOT EXISTS gene_data (
     encrypted_data BLOB NOT NULL,
     data_hash BLOB NOT NULL,
     signature BLOB NOT NULL
-);
\ No newline at end of file
+);
+
+-- +migrate Down
+DROP TABLE gene_data;
+DROP TABLE users;
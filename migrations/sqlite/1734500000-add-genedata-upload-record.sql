@@ -0,0 +1,20 @@
+-- Link gene data to its on-chain upload and report metadata
+ALTER TABLE gene_data ADD COLUMN session_id TEXT;
+ALTER TABLE gene_data ADD COLUMN doc_id TEXT;
+ALTER TABLE gene_data ADD COLUMN token_id TEXT;
+ALTER TABLE gene_data ADD COLUMN risk_score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gene_data ADD COLUMN model_version TEXT;
+ALTER TABLE gene_data ADD COLUMN share_risk_tier BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_gene_data_doc_id ON gene_data(doc_id);
+CREATE INDEX IF NOT EXISTS idx_gene_data_token_id ON gene_data(token_id);
+
+-- +migrate Down
+DROP INDEX idx_gene_data_token_id;
+DROP INDEX idx_gene_data_doc_id;
+ALTER TABLE gene_data DROP COLUMN share_risk_tier;
+ALTER TABLE gene_data DROP COLUMN model_version;
+ALTER TABLE gene_data DROP COLUMN risk_score;
+ALTER TABLE gene_data DROP COLUMN token_id;
+ALTER TABLE gene_data DROP COLUMN doc_id;
+ALTER TABLE gene_data DROP COLUMN session_id;
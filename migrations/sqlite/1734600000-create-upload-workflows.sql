@@ -0,0 +1,28 @@
+-- Track upload progress so interrupted uploads can be resumed or compensated
+ALTER TABLE gene_data ADD COLUMN upload_status TEXT NOT NULL DEFAULT 'completed';
+
+CREATE TABLE IF NOT EXISTS upload_workflows (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    file_id TEXT NOT NULL,
+    user_address TEXT NOT NULL,
+    step TEXT NOT NULL,
+    session_id TEXT,
+    doc_id TEXT,
+    token_id TEXT,
+    content_hash TEXT,
+    risk_score INTEGER NOT NULL DEFAULT 0,
+    share_risk_tier BOOLEAN NOT NULL DEFAULT FALSE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    note TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_upload_workflows_file_id ON upload_workflows(file_id);
+CREATE INDEX IF NOT EXISTS idx_upload_workflows_step ON upload_workflows(step);
+
+-- +migrate Down
+DROP TABLE upload_workflows;
+ALTER TABLE gene_data DROP COLUMN upload_status;
@@ -0,0 +1,5 @@
+-- Look up gene data by its on-chain upload session
+CREATE INDEX IF NOT EXISTS idx_gene_data_session_id ON gene_data(session_id);
+
+-- +migrate Down
+DROP INDEX idx_gene_data_session_id;
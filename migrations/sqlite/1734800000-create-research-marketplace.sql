@@ -0,0 +1,38 @@
+-- Consent to research use of GeneNFT data, and the analyses researchers paid for
+CREATE TABLE IF NOT EXISTS research_consents (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    token_id TEXT NOT NULL,
+    owner TEXT NOT NULL,
+    granted BOOLEAN NOT NULL DEFAULT FALSE,
+    signed_at INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_research_consents_token_id ON research_consents(token_id);
+
+CREATE TABLE IF NOT EXISTS analysis_jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    request_id TEXT NOT NULL,
+    researcher TEXT NOT NULL,
+    analysis TEXT NOT NULL,
+    amount TEXT NOT NULL,
+    status TEXT NOT NULL,
+    token_ids TEXT,
+    result TEXT,
+    reason TEXT,
+    request_tx_hash TEXT,
+    settle_tx_hash TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_analysis_jobs_request_id ON analysis_jobs(request_id);
+CREATE INDEX IF NOT EXISTS idx_analysis_jobs_researcher ON analysis_jobs(researcher);
+CREATE INDEX IF NOT EXISTS idx_analysis_jobs_status ON analysis_jobs(status);
+
+-- +migrate Down
+DROP TABLE analysis_jobs;
+DROP TABLE research_consents;
@@ -0,0 +1,33 @@
+-- Merkle roots anchored on-chain, and the content hashes each one covers
+CREATE TABLE IF NOT EXISTS content_anchors (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    root TEXT NOT NULL,
+    leaf_count INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    tx_hash TEXT,
+    block_number INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_content_anchors_root ON content_anchors(root);
+CREATE INDEX IF NOT EXISTS idx_content_anchors_status ON content_anchors(status);
+
+CREATE TABLE IF NOT EXISTS anchor_leaves (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME DEFAULT NULL,
+    doc_id TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    anchor_id INTEGER DEFAULT NULL,
+    leaf_index INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_anchor_leaves_doc_id ON anchor_leaves(doc_id);
+CREATE INDEX IF NOT EXISTS idx_anchor_leaves_anchor_id ON anchor_leaves(anchor_id);
+
+-- +migrate Down
+DROP TABLE anchor_leaves;
+DROP TABLE content_anchors;
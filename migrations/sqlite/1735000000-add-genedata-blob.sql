@@ -0,0 +1,10 @@
+-- Reference encrypted gene data kept in the blob store. Existing rows are moved out of
+-- encrypted_data, which is then dropped, by cmd/migrate-blobs.
+ALTER TABLE gene_data ADD COLUMN blob_key TEXT;
+ALTER TABLE gene_data ADD COLUMN blob_size INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gene_data ADD COLUMN blob_checksum TEXT;
+
+-- +migrate Down
+ALTER TABLE gene_data DROP COLUMN blob_checksum;
+ALTER TABLE gene_data DROP COLUMN blob_size;
+ALTER TABLE gene_data DROP COLUMN blob_key;
@@ -0,0 +1,9 @@
+-- Reject duplicate genotypes by their TEE-keyed fingerprint. Rows uploaded before fingerprints
+-- keep NULL, which the unique index allows.
+ALTER TABLE gene_data ADD COLUMN fingerprint TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gene_data_fingerprint ON gene_data(fingerprint);
+
+-- +migrate Down
+DROP INDEX idx_gene_data_fingerprint;
+ALTER TABLE gene_data DROP COLUMN fingerprint;
@@ -0,0 +1,9 @@
+-- Keep the 16-character ID of files re-keyed to their content-derived ID. cmd/rekey-files
+-- re-keys the existing rows, then creates the unique index on file_id.
+ALTER TABLE gene_data ADD COLUMN legacy_file_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_gene_data_legacy_file_id ON gene_data(legacy_file_id);
+
+-- +migrate Down
+DROP INDEX idx_gene_data_legacy_file_id;
+ALTER TABLE gene_data DROP COLUMN legacy_file_id;
@@ -0,0 +1,10 @@
+-- List each user's files by status or size. Every file stored so far holds TEE markers.
+ALTER TABLE gene_data ADD COLUMN format TEXT NOT NULL DEFAULT 'markers-u64le';
+
+CREATE INDEX IF NOT EXISTS idx_gene_data_user_status ON gene_data(user_id, upload_status);
+CREATE INDEX IF NOT EXISTS idx_gene_data_user_size ON gene_data(user_id, blob_size);
+
+-- +migrate Down
+DROP INDEX idx_gene_data_user_size;
+DROP INDEX idx_gene_data_user_status;
+ALTER TABLE gene_data DROP COLUMN format;
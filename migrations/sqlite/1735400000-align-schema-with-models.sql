@@ -0,0 +1,78 @@
+-- Align tables created by the earlier migrations with the gorm models. SQLite cannot alter a
+-- column, so users and gene_data are rebuilt:
+-- + users.id holds the uint32 user ID, not VARCHAR(20);
+-- + gene_data no longer has encrypted_data, and data_hash and signature may be NULL, as the data
+--   is kept in the blob store;
+-- + gene_data.file_id is unique under the index name the model declares.
+-- Every soft-deleted table also gets its deleted_at index.
+--
+-- On a database with gene data, run cmd/migrate-blobs and cmd/rekey-files first. Until then this
+-- migration fails, on the check below or on the unique file ID index, and nothing is changed.
+CREATE TEMP TABLE unmoved_gene_data (count INTEGER CHECK (count = 0));
+INSERT INTO unmoved_gene_data SELECT count(*) FROM gene_data WHERE blob_key IS NULL OR blob_key = '';
+DROP TABLE unmoved_gene_data;
+
+CREATE TABLE users_aligned (
+    id INTEGER PRIMARY KEY,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    pubkey TEXT NOT NULL UNIQUE
+);
+INSERT INTO users_aligned (id, created_at, updated_at, deleted_at, pubkey)
+    SELECT CAST(id AS INTEGER), created_at, updated_at, deleted_at, pubkey FROM users;
+DROP TABLE users;
+ALTER TABLE users_aligned RENAME TO users;
+
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users(deleted_at);
+
+CREATE TABLE gene_data_aligned (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    file_id TEXT NOT NULL,
+    legacy_file_id TEXT,
+    user_id INTEGER NOT NULL,
+    format TEXT NOT NULL DEFAULT 'markers-u64le',
+    blob_key TEXT,
+    blob_size INTEGER NOT NULL DEFAULT 0,
+    blob_checksum TEXT,
+    data_hash BLOB,
+    signature BLOB,
+    session_id TEXT,
+    doc_id TEXT,
+    token_id TEXT,
+    risk_score INTEGER NOT NULL DEFAULT 0,
+    model_version TEXT,
+    share_risk_tier BOOLEAN NOT NULL DEFAULT FALSE,
+    upload_status TEXT NOT NULL DEFAULT 'completed',
+    fingerprint TEXT
+);
+INSERT INTO gene_data_aligned (
+    id, created_at, updated_at, deleted_at, file_id, legacy_file_id, user_id, format, blob_key,
+    blob_size, blob_checksum, data_hash, signature, session_id, doc_id, token_id, risk_score,
+    model_version, share_risk_tier, upload_status, fingerprint
+)
+    SELECT id, created_at, updated_at, deleted_at, file_id, legacy_file_id, user_id, format, blob_key,
+        blob_size, blob_checksum, data_hash, signature, session_id, doc_id, token_id, risk_score,
+        model_version, share_risk_tier, upload_status, fingerprint
+    FROM gene_data;
+DROP TABLE gene_data;
+ALTER TABLE gene_data_aligned RENAME TO gene_data;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gene_data_file_id ON gene_data(file_id);
+CREATE INDEX IF NOT EXISTS idx_gene_data_legacy_file_id ON gene_data(legacy_file_id);
+CREATE INDEX IF NOT EXISTS idx_gene_data_user_status ON gene_data(user_id, upload_status);
+CREATE INDEX IF NOT EXISTS idx_gene_data_user_size ON gene_data(user_id, blob_size);
+CREATE INDEX IF NOT EXISTS idx_gene_data_session_id ON gene_data(session_id);
+CREATE INDEX IF NOT EXISTS idx_gene_data_doc_id ON gene_data(doc_id);
+CREATE INDEX IF NOT EXISTS idx_gene_data_token_id ON gene_data(token_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gene_data_fingerprint ON gene_data(fingerprint);
+CREATE INDEX IF NOT EXISTS idx_gene_data_deleted_at ON gene_data(deleted_at);
+
+CREATE INDEX IF NOT EXISTS idx_upload_workflows_deleted_at ON upload_workflows(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_research_consents_deleted_at ON research_consents(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_analysis_jobs_deleted_at ON analysis_jobs(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_content_anchors_deleted_at ON content_anchors(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_anchor_leaves_deleted_at ON anchor_leaves(deleted_at);
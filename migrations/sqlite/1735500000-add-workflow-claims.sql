@@ -0,0 +1,7 @@
+-- Lease each upload workflow to the gateway replica running it
+ALTER TABLE upload_workflows ADD COLUMN claimed_by TEXT;
+ALTER TABLE upload_workflows ADD COLUMN claimed_until DATETIME;
+
+-- +migrate Down
+ALTER TABLE upload_workflows DROP COLUMN claimed_until;
+ALTER TABLE upload_workflows DROP COLUMN claimed_by;
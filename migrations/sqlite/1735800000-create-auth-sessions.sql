@@ -0,0 +1,28 @@
+-- Sign-In with Ethereum nonces, each accepted once, and the sessions opened with them
+CREATE TABLE IF NOT EXISTS auth_nonces (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    nonce TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_auth_nonces_nonce ON auth_nonces(nonce);
+CREATE INDEX IF NOT EXISTS idx_auth_nonces_expires_at ON auth_nonces(expires_at);
+
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    token_hash TEXT NOT NULL,
+    user_id INTEGER NOT NULL,
+    address TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_auth_sessions_token_hash ON auth_sessions(token_hash);
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_user_id ON auth_sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_expires_at ON auth_sessions(expires_at);
+
+-- +migrate Down
+DROP TABLE auth_sessions;
+DROP TABLE auth_nonces;
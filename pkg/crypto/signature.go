@@ -0,0 +1,41 @@
+package crypto
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// RecoverPersonalSignAddress recovers the address that signed message with EIP-191 personal_sign.
+func RecoverPersonalSignAddress(message []byte, signature string) (common.Address, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return common.Address{}, ErrInvalidSignature
+	}
+
+	// Wallets produce V as 27/28, go-ethereum expects 0/1
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(accounts.TextHash(message), sig)
+	if err != nil {
+		return common.Address{}, ErrInvalidSignature
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// VerifyPersonalSign reports whether signature is a personal_sign of message by address.
+func VerifyPersonalSign(address common.Address, message []byte, signature string) bool {
+	signer, err := RecoverPersonalSignAddress(message, signature)
+	if err != nil {
+		return false
+	}
+	return signer == address
+}
@@ -0,0 +1,87 @@
+// Package merkle builds keccak256 Merkle trees whose proofs verify with OpenZeppelin's MerkleProof.
+// Pairs are hashed in sorted order, so a proof is only the list of sibling hashes.
+package merkle
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrNoLeaves         = errors.New("merkle tree needs at least one leaf")
+	ErrIndexOutOfBounds = errors.New("leaf index out of bounds")
+)
+
+// Tree keeps every level, from the leaves up to the root.
+type Tree struct {
+	levels [][]common.Hash
+}
+
+// New builds a tree over the leaves, in order. A node without a sibling moves up unchanged.
+func New(leaves []common.Hash) (*Tree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrNoLeaves
+	}
+
+	level := append([]common.Hash(nil), leaves...)
+	levels := [][]common.Hash{level}
+	for len(level) > 1 {
+		next := make([]common.Hash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, HashPair(level[i], level[i+1]))
+		}
+		levels = append(levels, next)
+		level = next
+	}
+
+	return &Tree{levels: levels}, nil
+}
+
+func (t *Tree) Root() common.Hash {
+	return t.levels[len(t.levels)-1][0]
+}
+
+func (t *Tree) Len() int {
+	return len(t.levels[0])
+}
+
+// Proof lists the siblings on the path from the leaf at index to the root.
+func (t *Tree) Proof(index int) ([]common.Hash, error) {
+	if index < 0 || index >= t.Len() {
+		return nil, ErrIndexOutOfBounds
+	}
+
+	proof := []common.Hash{}
+	for _, level := range t.levels[:len(t.levels)-1] {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, level[sibling])
+		}
+		index /= 2
+	}
+	return proof, nil
+}
+
+// Verify checks that leaf is in the tree with the given root.
+func Verify(root common.Hash, leaf common.Hash, proof []common.Hash) bool {
+	computed := leaf
+	for _, sibling := range proof {
+		computed = HashPair(computed, sibling)
+	}
+	return computed == root
+}
+
+// HashPair hashes two nodes in sorted order, like OpenZeppelin's MerkleProof.
+func HashPair(a, b common.Hash) common.Hash {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return crypto.Keccak256Hash(a[:], b[:])
+}
@@ -0,0 +1,82 @@
+package merkle
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func leaves(n int) []common.Hash {
+	hashes := make([]common.Hash, n)
+	for i := range hashes {
+		hashes[i] = crypto.Keccak256Hash([]byte(fmt.Sprintf("leaf%d", i)))
+	}
+	return hashes
+}
+
+func TestTree(t *testing.T) {
+	tests := []struct {
+		name      string
+		leafCount int
+		wantErr   error
+	}{
+		{name: "No leaves", leafCount: 0, wantErr: ErrNoLeaves},
+		{name: "Single leaf", leafCount: 1},
+		{name: "Two leaves", leafCount: 2},
+		{name: "Odd number of leaves", leafCount: 5},
+		{name: "Full tree", leafCount: 8},
+		{name: "Large tree", leafCount: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashes := leaves(tt.leafCount)
+			tree, err := New(hashes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("New() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			for i, leaf := range hashes {
+				proof, err := tree.Proof(i)
+				if err != nil {
+					t.Fatalf("Proof(%d) error = %v", i, err)
+				}
+				if !Verify(tree.Root(), leaf, proof) {
+					t.Errorf("Verify() = false for leaf %d", i)
+				}
+			}
+
+			other := crypto.Keccak256Hash([]byte("other"))
+			proof, _ := tree.Proof(0)
+			if Verify(tree.Root(), other, proof) {
+				t.Errorf("Verify() = true for a leaf outside the tree")
+			}
+			if _, err := tree.Proof(tt.leafCount); !errors.Is(err, ErrIndexOutOfBounds) {
+				t.Errorf("Proof() error = %v, want %v", err, ErrIndexOutOfBounds)
+			}
+		})
+	}
+}
+
+func TestTreeRoot(t *testing.T) {
+	hashes := leaves(3)
+	tree, err := New(hashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The third leaf has no sibling and moves up unchanged
+	want := HashPair(HashPair(hashes[0], hashes[1]), hashes[2])
+	if tree.Root() != want {
+		t.Errorf("Root() = %s, want %s", tree.Root(), want)
+	}
+	if HashPair(hashes[0], hashes[1]) != HashPair(hashes[1], hashes[0]) {
+		t.Errorf("HashPair() depends on the order of its arguments")
+	}
+}
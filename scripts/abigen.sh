@@ -42,11 +42,38 @@ else
     exit 1
 fi
 
+if [ -f "genomicdao/artifacts/contracts/Forwarder.sol/Forwarder.json" ]; then
+    jq .abi "genomicdao/artifacts/contracts/Forwarder.sol/Forwarder.json" > build/Forwarder.abi
+    echo "Extracted Forwarder ABI"
+else
+    echo "Error: Forwarder artifact not found"
+    exit 1
+fi
+
+if [ -f "genomicdao/artifacts/contracts/Governor.sol/PCSPGovernor.json" ]; then
+    jq .abi "genomicdao/artifacts/contracts/Governor.sol/PCSPGovernor.json" > build/Governor.abi
+    echo "Extracted Governor ABI"
+else
+    echo "Error: Governor artifact not found"
+    exit 1
+fi
+
+if [ -f "genomicdao/artifacts/contracts/Escrow.sol/ResearchEscrow.json" ]; then
+    jq .abi "genomicdao/artifacts/contracts/Escrow.sol/ResearchEscrow.json" > build/Escrow.abi
+    echo "Extracted Escrow ABI"
+else
+    echo "Error: Escrow artifact not found"
+    exit 1
+fi
+
 echo "Generating Go bindings..."
 # Generate bindings
 abigen --abi build/GeneNFT.abi --pkg contracts --type GeneNFT --out internal/onchain/bindings/gene_nft.go
 abigen --abi build/PCSP.abi --pkg contracts --type PCSPToken --out internal/onchain/bindings/pcsp_token.go
 abigen --abi build/Controller.abi --pkg contracts --type Controller --out internal/onchain/bindings/controller.go
+abigen --abi build/Forwarder.abi --pkg contracts --type Forwarder --out internal/onchain/bindings/forwarder.go
+abigen --abi build/Governor.abi --pkg contracts --type PCSPGovernor --out internal/onchain/bindings/governor.go
+abigen --abi build/Escrow.abi --pkg contracts --type ResearchEscrow --out internal/onchain/bindings/escrow.go
 
 # Clean up
 rm -rf build